@@ -50,8 +50,12 @@ fi
 # Create ipset with CIDR support
 ipset create allowed-domains hash:net
 
+# maestro writes the firewall's config files here, on /run so they can be
+# written in containers with a read-only root filesystem
+FIREWALL_DIR="/run/maestro-firewall"
+
 # Read allowed domains from config file if it exists
-DOMAINS_FILE="/etc/allowed-domains.txt"
+DOMAINS_FILE="$FIREWALL_DIR/allowed-domains.txt"
 if [ -f "$DOMAINS_FILE" ]; then
     echo "Reading allowed domains from $DOMAINS_FILE"
     ALLOWED_DOMAINS=$(cat "$DOMAINS_FILE")
@@ -128,8 +132,8 @@ echo "ipset=/.anthropic.com/allowed-domains" >> "$DNSMASQ_CONF"
 echo "server=/.anthropic.com/8.8.8.8" >> "$DNSMASQ_CONF"
 
 # Add wildcard entries for AWS (only if AWS/Bedrock is enabled)
-# This is controlled by /etc/aws-enabled.txt which is written by maestro when aws.enabled or bedrock.enabled is true
-AWS_ENABLED_FILE="/etc/aws-enabled.txt"
+# This is controlled by $FIREWALL_DIR/aws-enabled.txt which is written by maestro when aws.enabled or bedrock.enabled is true
+AWS_ENABLED_FILE="$FIREWALL_DIR/aws-enabled.txt"
 if [ -f "$AWS_ENABLED_FILE" ]; then
     echo "AWS/Bedrock enabled - adding AWS domain rules"
     echo "ipset=/.amazonaws.com/allowed-domains" >> "$DNSMASQ_CONF"
@@ -141,8 +145,8 @@ else
 fi
 
 # Configure internal DNS for corporate networks (Zscaler, VPN, etc.)
-INTERNAL_DNS_FILE="/etc/internal-dns.txt"
-INTERNAL_DOMAINS_FILE="/etc/internal-domains.txt"
+INTERNAL_DNS_FILE="$FIREWALL_DIR/internal-dns.txt"
+INTERNAL_DOMAINS_FILE="$FIREWALL_DIR/internal-domains.txt"
 if [ -f "$INTERNAL_DNS_FILE" ] && [ -f "$INTERNAL_DOMAINS_FILE" ]; then
     INTERNAL_DNS=$(cat "$INTERNAL_DNS_FILE")
     if [ -n "$INTERNAL_DNS" ]; then
@@ -165,8 +169,14 @@ fi
 echo "Starting dnsmasq..."
 dnsmasq --conf-file="$DNSMASQ_CONF"
 
-# Update /etc/resolv.conf to use local dnsmasq
-echo "nameserver 127.0.0.1" | tee /etc/resolv.conf > /dev/null
+# Update /etc/resolv.conf to use local dnsmasq. With a read-only root
+# filesystem it can't be written, and maestro starts the container with
+# --dns 127.0.0.1 instead
+if [ -w /etc/resolv.conf ]; then
+    echo "nameserver 127.0.0.1" | tee /etc/resolv.conf > /dev/null
+else
+    echo "/etc/resolv.conf is read-only, leaving it as is"
+fi
 
 # Process GitHub API ranges and add them directly to ipset
 # We do this because GitHub has many IPs and we want to ensure we catch them all
@@ -250,6 +260,11 @@ iptables -P INPUT DROP
 iptables -P FORWARD DROP
 iptables -P OUTPUT DROP
 
+# Log rejected connections to the kernel log (rate limited), so blocked
+# domains can be found with the TUI's firewall log ('L'). The host kernel
+# drops these unless it sets net.netfilter.nf_log_all_netns=1
+iptables -A OUTPUT -m limit --limit 30/min --limit-burst 10 -j LOG --log-prefix "MAESTRO_DROP " --log-level 4
+
 # Explicitly REJECT all other outbound traffic for immediate feedback
 iptables -A OUTPUT -j REJECT --reject-with icmp-admin-prohibited
 
@@ -17,13 +17,13 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/settings"
 	"gopkg.in/yaml.v3"
 )
 
@@ -55,7 +55,7 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 	containerName := resolveContainerName(shortName)
 
 	// Check if container is running
-	checkCmd := exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
+	checkCmd := logging.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
 	output, err := checkCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to check container status: %w", err)
@@ -73,16 +73,16 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 	dnsmasqConf := "/tmp/dnsmasq-firewall.conf"
 
 	// Check if domain already in config (grep arg is safe — not through shell)
-	checkConfCmd := exec.Command("docker", "exec", containerName, "grep", "-qF",
+	checkConfCmd := logging.Command("docker", "exec", containerName, "grep", "-qF",
 		fmt.Sprintf("ipset=/%s/", domain), dnsmasqConf)
-	if checkConfCmd.Run() == nil {
+	if logging.Run(checkConfCmd) == nil {
 		fmt.Printf("  Domain %s already in dnsmasq config\n", domain)
 	} else {
 		// Append domain to dnsmasq config using positional parameters (no interpolation)
-		appendCmd := exec.Command("docker", "exec", "-u", "root", containerName,
+		appendCmd := logging.Command("docker", "exec", "-u", "root", containerName,
 			"sh", "-c", `printf '%s\n' "ipset=/$1/allowed-domains" "server=/$1/8.8.8.8" >> "$2"`,
 			"_", domain, dnsmasqConf)
-		if err := appendCmd.Run(); err != nil {
+		if err := logging.Run(appendCmd); err != nil {
 			return fmt.Errorf("failed to update dnsmasq config: %w", err)
 		}
 		fmt.Println("  Updated dnsmasq config")
@@ -90,9 +90,9 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 
 	// Restart dnsmasq to pick up new config
 	fmt.Println("  Restarting dnsmasq...")
-	restartCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	restartCmd := logging.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
 		"pkill -9 dnsmasq 2>/dev/null || true; sleep 0.2; dnsmasq --conf-file=/tmp/dnsmasq-firewall.conf")
-	if err := restartCmd.Run(); err != nil {
+	if err := logging.Run(restartCmd); err != nil {
 		return fmt.Errorf("failed to restart dnsmasq: %w", err)
 	}
 
@@ -101,7 +101,7 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 
 	// Now do an initial resolution to populate the ipset
 	fmt.Println("  Performing initial DNS resolution...")
-	resolveCmd := exec.Command("docker", "exec", containerName,
+	resolveCmd := logging.Command("docker", "exec", containerName,
 		"sh", "-c", `dig +short "$1" | head -5`, "_", domain)
 	output, err = resolveCmd.Output()
 	if err != nil {
@@ -168,8 +168,13 @@ func updateConfigWithDomain(domain string) error {
 			domains = append(domains, d.(string))
 		}
 	} else {
-		// Use defaults from viper
-		domains = viper.GetStringSlice("firewall.allowed_domains")
+		// Use the defaults, not the running config, which may include a
+		// project .maestro.yml's domains
+		defaults, err := settings.GlobalStringSlice("firewall.allowed_domains")
+		if err != nil {
+			return err
+		}
+		domains = defaults
 	}
 
 	// Check if domain already exists
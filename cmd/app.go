@@ -19,42 +19,55 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/settings"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var (
 	appSyncNow bool
 	appCleanup bool
 	appAll     bool
-	appQuiet   bool
 )
 
 var appCmd = &cobra.Command{
-	Use:   "app",
-	Short: "Manage custom binaries synced to containers",
+	Use:     "app",
+	Aliases: []string{"apps"},
+	Short:   "Manage custom binaries synced to containers",
 	Long: `Manage custom binaries that are automatically copied to all containers.
 
 Apps are configured in ~/.maestro/config.yml and copied to /usr/local/bin in each container.
+A source is a host path or an http(s) URL; URLs are downloaded once and cached in
+~/.maestro/apps. Append "#sha256:<hex>" to a URL to verify the download. For
+per-architecture binaries use a map keyed by the container image architecture:
+
+  apps:
+    mytool:
+      url_amd64: https://example.com/mytool-linux-amd64#sha256:...
+      url_arm64: https://example.com/mytool-linux-arm64#sha256:...
+
 Use 'app update' to sync changes to running containers.`,
 }
 
 var appListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List configured apps",
+	Short: "List configured apps and how each resolves",
 	RunE:  runAppList,
 }
 
 var appAddCmd = &cobra.Command{
-	Use:   "add <name> <source-path>",
+	Use:   "add <name> <source-path-or-url>",
 	Short: "Add an app to configuration",
 	Long: `Add an app to the configuration file.
 
+The source is a host path or an http(s) URL (optionally ending in
+"#sha256:<hex>"). URLs are downloaded immediately to verify them.
 The app will be copied to /usr/local/bin/<name> in all new containers.
 Use --sync to immediately update all running containers.`,
 	Args: cobra.ExactArgs(2),
@@ -91,9 +104,7 @@ func init() {
 
 	appAddCmd.Flags().BoolVarP(&appSyncNow, "sync", "s", false, "Sync to running containers immediately")
 	appUpdateCmd.Flags().BoolVarP(&appAll, "all", "a", false, "Update all configured apps")
-	appUpdateCmd.Flags().BoolVarP(&appQuiet, "quiet", "q", false, "Suppress output (for Makefile integration)")
 	appRemoveCmd.Flags().BoolVar(&appCleanup, "cleanup", false, "Remove from running containers")
-	appRemoveCmd.Flags().BoolVarP(&appQuiet, "quiet", "q", false, "Suppress output")
 }
 
 func runAppList(cmd *cobra.Command, args []string) error {
@@ -103,14 +114,34 @@ func runAppList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Println("Configured apps:")
-	for name, source := range config.Apps {
-		expandedPath := expandPath(source)
-		if info, err := os.Stat(expandedPath); err == nil {
-			size := formatFileSize(info.Size())
-			fmt.Printf("  %-20s → %s (%s)\n", name, source, size)
-		} else {
-			fmt.Printf("  %-20s → %s (⚠ not found)\n", name, source)
+	arch, detected := imageArch()
+	if detected {
+		fmt.Printf("Configured apps (image architecture: %s):\n", arch)
+	} else {
+		fmt.Printf("Configured apps (image not pulled, assuming %s):\n", arch)
+	}
+
+	names := make([]string, 0, len(config.Apps))
+	for name := range config.Apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		app, err := resolveApp(config.Apps[name], arch, false)
+		switch {
+		case err != nil && app.Location == "":
+			fmt.Printf("  %-20s (%s %v)\n", name, style.Warning(), err)
+		case err != nil:
+			fmt.Printf("  %-20s → %s (%s %v)\n", name, app.Location, style.Warning(), err)
+		case app.File == "":
+			fmt.Printf("  %-20s → %s (%s)\n", name, app.Location, app.Status)
+		default:
+			size := ""
+			if info, err := os.Stat(app.File); err == nil {
+				size = ", " + formatFileSize(info.Size())
+			}
+			fmt.Printf("  %-20s → %s (%s %s%s)\n", name, app.Location, style.Check(), app.Status, size)
 		}
 	}
 
@@ -131,27 +162,31 @@ func runAppAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	source := args[1]
 
-	// Expand and validate source path
-	expandedPath := expandPath(source)
-	info, err := os.Stat(expandedPath)
+	// Validate the source: local paths must exist, URLs must download
+	arch, _ := imageArch()
+	app, err := resolveApp(source, arch, true)
 	if err != nil {
-		return fmt.Errorf("source file not found: %s", expandedPath)
+		return fmt.Errorf("invalid source: %w", err)
+	}
+	info, err := os.Stat(app.File)
+	if err != nil {
+		return fmt.Errorf("source file not found: %s", app.File)
 	}
 
-	if !appQuiet {
-		fmt.Printf("✓ Verified source exists (%s)\n", formatFileSize(info.Size()))
+	if !flagQuiet {
+		fmt.Printf("%s Verified source %s (%s)\n", style.Check(), app.Status, formatFileSize(info.Size()))
 	}
 
 	// Check if already exists
 	if _, exists := config.Apps[name]; exists {
-		if !appQuiet {
-			fmt.Printf("⚠  App '%s' already configured, updating path\n", name)
+		if !flagQuiet {
+			fmt.Printf("%s  App '%s' already configured, updating path\n", style.Warning(), name)
 		}
 	}
 
 	// Add to config
 	if config.Apps == nil {
-		config.Apps = make(map[string]string)
+		config.Apps = make(map[string]any)
 	}
 	config.Apps[name] = source
 
@@ -160,13 +195,13 @@ func runAppAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
-	if !appQuiet {
-		fmt.Printf("✓ Added %s to configuration\n", name)
+	if !flagQuiet {
+		fmt.Printf("%s Added %s to configuration\n", style.Check(), name)
 	}
 
 	// Sync to running containers if requested
 	if appSyncNow {
-		if err := updateSingleApp(name, appQuiet); err != nil {
+		if err := updateSingleApp(name, flagQuiet); err != nil {
 			return err
 		}
 	}
@@ -184,7 +219,7 @@ func runAppUpdate(cmd *cobra.Command, args []string) error {
 			appsToUpdate = append(appsToUpdate, name)
 		}
 		if len(appsToUpdate) == 0 {
-			if !appQuiet {
+			if !flagQuiet {
 				fmt.Println("No apps configured to update")
 			}
 			return nil
@@ -202,9 +237,9 @@ func runAppUpdate(cmd *cobra.Command, args []string) error {
 
 	// Update each app
 	for _, name := range appsToUpdate {
-		if err := updateSingleApp(name, appQuiet); err != nil {
-			if !appQuiet {
-				fmt.Printf("⚠  Failed to update %s: %v\n", name, err)
+		if err := updateSingleApp(name, flagQuiet); err != nil {
+			if !flagQuiet {
+				fmt.Printf("%s  Failed to update %s: %v\n", style.Warning(), name, err)
 			}
 			continue
 		}
@@ -229,8 +264,8 @@ func runAppRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
-	if !appQuiet {
-		fmt.Printf("✓ Removed %s from configuration\n", name)
+	if !flagQuiet {
+		fmt.Printf("%s Removed %s from configuration\n", style.Check(), name)
 	}
 
 	// Cleanup from containers if requested
@@ -240,16 +275,16 @@ func runAppRemove(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to list containers: %w", err)
 		}
 
-		if !appQuiet {
+		if !flagQuiet {
 			fmt.Printf("Removing from %d container(s)...\n", len(containers))
 		}
 
 		for _, c := range containers {
 			destPath := fmt.Sprintf("/usr/local/bin/%s", name)
-			rmCmd := exec.Command("docker", "exec", "-u", "root", c.Name, "rm", "-f", destPath)
-			rmCmd.Run() // Ignore errors (file might not exist)
-			if !appQuiet {
-				fmt.Printf("  ✓ %s\n", c.ShortName)
+			rmCmd := logging.Command("docker", "exec", "-u", "root", c.Name, "rm", "-f", destPath)
+			logging.Run(rmCmd) // Ignore errors (file might not exist)
+			if !flagQuiet {
+				fmt.Printf("  %s %s\n", style.Check(), c.ShortName)
 			}
 		}
 	}
@@ -259,30 +294,11 @@ func runAppRemove(cmd *cobra.Command, args []string) error {
 
 // updateSingleApp updates a single app in all running containers
 func updateSingleApp(appName string, quiet bool) error {
-	sourcePath, exists := config.Apps[appName]
+	source, exists := config.Apps[appName]
 	if !exists {
 		return fmt.Errorf("app '%s' not configured", appName)
 	}
 
-	expandedPath := expandPath(sourcePath)
-
-	// Check for Linux-specific variant first (for cross-platform binaries)
-	linuxPath := expandedPath + ".linux_aarch64"
-	actualPath := expandedPath
-	if _, err := os.Stat(linuxPath); err == nil {
-		actualPath = linuxPath
-	}
-
-	if _, err := os.Stat(actualPath); err != nil {
-		return fmt.Errorf("source file not found: %s", actualPath)
-	}
-
-	// Calculate source checksum once
-	sourceChecksum, err := calculateChecksum(actualPath)
-	if err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
-	}
-
 	// Get running containers
 	containers, err := container.GetRunningContainers(config.Containers.Prefix)
 	if err != nil {
@@ -296,6 +312,28 @@ func updateSingleApp(appName string, quiet bool) error {
 		return nil
 	}
 
+	// Resolve the source and its checksum once per image architecture
+	type archSource struct {
+		path     string
+		checksum string
+		err      error
+	}
+	sources := make(map[string]archSource)
+	containerSources := make(map[string]archSource, len(containers))
+	for _, c := range containers {
+		arch := containerArch(c.Name)
+		src, ok := sources[arch]
+		if !ok {
+			app, err := resolveApp(source, arch, true)
+			src = archSource{path: app.File, err: err}
+			if err == nil {
+				src.checksum, src.err = calculateChecksum(app.File)
+			}
+			sources[arch] = src
+		}
+		containerSources[c.Name] = src
+	}
+
 	if !quiet {
 		fmt.Printf("Updating %s in %d container(s)...\n", appName, len(containers))
 	}
@@ -309,36 +347,42 @@ func updateSingleApp(appName string, quiet bool) error {
 		go func(container container.Info) {
 			defer wg.Done()
 
+			src := containerSources[container.Name]
+			if src.err != nil {
+				results <- fmt.Sprintf("  %s %s: %v", style.Cross(), container.ShortName, src.err)
+				return
+			}
+
 			destPath := fmt.Sprintf("/usr/local/bin/%s", appName)
 			containerPath := fmt.Sprintf("%s:%s", container.Name, destPath)
 
 			// Check if file exists and compare checksums
-			checkCmd := exec.Command("docker", "exec", container.Name, "sh", "-c",
+			checkCmd := logging.Command("docker", "exec", container.Name, "sh", "-c",
 				fmt.Sprintf("sha256sum %s 2>/dev/null | awk '{print $1}'", destPath))
 			if output, err := checkCmd.Output(); err == nil {
 				existingChecksum := strings.TrimSpace(string(output))
-				if existingChecksum == sourceChecksum {
-					results <- fmt.Sprintf("  ✓ %s (already up to date)", container.ShortName)
+				if existingChecksum == src.checksum {
+					results <- fmt.Sprintf("  %s %s (already up to date)", style.Check(), container.ShortName)
 					return
 				}
 			}
 
 			// Copy file
-			cpCmd := exec.Command("docker", "cp", actualPath, containerPath)
-			if err := cpCmd.Run(); err != nil {
-				results <- fmt.Sprintf("  ✗ %s: %v", container.ShortName, err)
+			cpCmd := logging.Command("docker", "cp", src.path, containerPath)
+			if err := logging.Run(cpCmd); err != nil {
+				results <- fmt.Sprintf("  %s %s: %v", style.Cross(), container.ShortName, err)
 				return
 			}
 
 			// Make executable and set ownership
-			chmodCmd := exec.Command("docker", "exec", "-u", "root", container.Name,
+			chmodCmd := logging.Command("docker", "exec", "-u", "root", container.Name,
 				"sh", "-c", fmt.Sprintf("chmod +x %s && chown node:node %s", destPath, destPath))
-			if err := chmodCmd.Run(); err != nil {
-				results <- fmt.Sprintf("  ⚠ %s: copied but failed to set permissions", container.ShortName)
+			if err := logging.Run(chmodCmd); err != nil {
+				results <- fmt.Sprintf("  %s %s: copied but failed to set permissions", style.Warning(), container.ShortName)
 				return
 			}
 
-			results <- fmt.Sprintf("  ✓ %s", container.ShortName)
+			results <- fmt.Sprintf("  %s %s", style.Check(), container.ShortName)
 		}(c)
 	}
 
@@ -353,7 +397,7 @@ func updateSingleApp(appName string, quiet bool) error {
 	if !quiet {
 		for result := range results {
 			fmt.Println(result)
-			if strings.Contains(result, "✓") {
+			if strings.HasPrefix(strings.TrimSpace(result), style.Check()) {
 				successCount++
 			}
 		}
@@ -361,7 +405,7 @@ func updateSingleApp(appName string, quiet bool) error {
 	} else {
 		// In quiet mode, just count successes
 		for result := range results {
-			if strings.Contains(result, "✓") {
+			if strings.HasPrefix(strings.TrimSpace(result), style.Check()) {
 				successCount++
 			}
 		}
@@ -388,10 +432,7 @@ func calculateChecksum(filePath string) (string, error) {
 
 // writeConfigFile writes the current config to the config file
 func writeConfigFile() error {
-	// Write all settings back to viper
-	viper.Set("apps", config.Apps)
-
-	return viper.WriteConfig()
+	return settings.Save(map[string]any{"apps": config.Apps})
 }
 
 // formatFileSize formats bytes to human-readable format
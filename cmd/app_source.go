@@ -0,0 +1,294 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// appSource is one entry of the apps config. An entry is either a plain
+// string (a host path or an http(s) URL, optionally suffixed with
+// "#sha256:<hex>") or a map with per-architecture variants:
+//
+//	mytool:
+//	  url_amd64: https://example.com/mytool-linux-amd64
+//	  url_arm64: https://example.com/mytool-linux-arm64
+//	  sha256_amd64: ...
+//	  sha256_arm64: ...
+//
+// A plain sha256 key checks every variant without its own sha256_<arch>.
+type appSource struct {
+	Location   string            // path or URL used for any architecture
+	ArchURLs   map[string]string // architecture -> URL
+	SHA256     string            // checksum for Location and variants without their own
+	ArchSHA256 map[string]string // architecture -> checksum
+}
+
+// appArchitectures are the architecture keys accepted in the apps config.
+var appArchitectures = []string{"amd64", "arm64"}
+
+// appDownloadTimeout bounds a single app download.
+const appDownloadTimeout = 5 * time.Minute
+
+// parseAppSource interprets a raw apps config value.
+func parseAppSource(value any) (appSource, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return appSource{}, fmt.Errorf("empty source")
+		}
+		return appSource{Location: strings.TrimSpace(v)}, nil
+	case map[string]any:
+		src := appSource{ArchURLs: map[string]string{}, ArchSHA256: map[string]string{}}
+		for key, raw := range v {
+			s, ok := raw.(string)
+			if !ok {
+				return appSource{}, fmt.Errorf("%s must be a string", key)
+			}
+			s = strings.TrimSpace(s)
+			switch key {
+			case "path", "url":
+				if src.Location != "" {
+					return appSource{}, fmt.Errorf("set only one of path and url")
+				}
+				src.Location = s
+			case "sha256":
+				src.SHA256 = s
+			default:
+				if arch, ok := strings.CutPrefix(key, "url_"); ok && isAppArchitecture(arch) {
+					src.ArchURLs[arch] = s
+				} else if arch, ok := strings.CutPrefix(key, "sha256_"); ok && isAppArchitecture(arch) {
+					src.ArchSHA256[arch] = s
+				} else {
+					return appSource{}, fmt.Errorf("unknown key %q", key)
+				}
+			}
+		}
+		if src.Location == "" && len(src.ArchURLs) == 0 {
+			return appSource{}, fmt.Errorf("no path or url set")
+		}
+		return src, nil
+	default:
+		return appSource{}, fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+// isAppArchitecture reports whether arch is an accepted architecture key.
+func isAppArchitecture(arch string) bool {
+	for _, a := range appArchitectures {
+		if a == arch {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeArch maps uname-style names to Docker's architecture names.
+func normalizeArch(arch string) string {
+	switch arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	}
+	return arch
+}
+
+// forArch picks the location and expected checksum for arch. An inline
+// "#sha256:<hex>" suffix on the location takes precedence over config keys.
+func (s appSource) forArch(arch string) (location, checksum string, err error) {
+	location = s.Location
+	checksum = s.SHA256
+	if u, ok := s.ArchURLs[arch]; ok {
+		location = u
+		if sum, ok := s.ArchSHA256[arch]; ok {
+			checksum = sum
+		}
+	}
+	if location == "" {
+		return "", "", fmt.Errorf("no variant for %s", arch)
+	}
+	if loc, sum, ok := strings.Cut(location, "#sha256:"); ok {
+		location, checksum = loc, sum
+	}
+	return location, strings.ToLower(strings.TrimPrefix(checksum, "sha256:")), nil
+}
+
+// isAppURL reports whether location should be downloaded rather than read
+// from the host filesystem.
+func isAppURL(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
+
+// resolvedApp is where an app's binary comes from for one architecture.
+type resolvedApp struct {
+	Location string // configured path or URL
+	File     string // local file to copy, empty if unavailable
+	Status   string // human-readable resolution status
+}
+
+// resolveApp finds the local file for an app on arch. URLs are served from
+// the cache under ~/.maestro/apps and downloaded on a miss when fetch is set.
+// Local paths keep the legacy ".linux_<arch>" suffix convention.
+func resolveApp(value any, arch string, fetch bool) (resolvedApp, error) {
+	src, err := parseAppSource(value)
+	if err != nil {
+		return resolvedApp{}, err
+	}
+	location, checksum, err := src.forArch(arch)
+	if err != nil {
+		return resolvedApp{}, err
+	}
+	res := resolvedApp{Location: location}
+
+	if isAppURL(location) {
+		cached := appCachePath(location)
+		if _, err := os.Stat(cached); err == nil {
+			if err := verifyAppChecksum(cached, checksum); err == nil {
+				res.File, res.Status = cached, "cached"
+				return res, nil
+			}
+			os.Remove(cached) // stale or corrupt; fetch again
+		}
+		if !fetch {
+			res.Status = "not downloaded yet"
+			return res, nil
+		}
+		if err := downloadApp(location, checksum, cached); err != nil {
+			return res, err
+		}
+		res.File, res.Status = cached, "downloaded"
+		return res, nil
+	}
+
+	expanded := expandPath(location)
+	for _, candidate := range localAppVariants(expanded, arch) {
+		if _, err := os.Stat(candidate); err == nil {
+			if err := verifyAppChecksum(candidate, checksum); err != nil {
+				return res, err
+			}
+			res.File, res.Status = candidate, "found"
+			return res, nil
+		}
+	}
+	return res, fmt.Errorf("source not found: %s", location)
+}
+
+// localAppVariants lists host paths to try for arch, most specific first.
+func localAppVariants(path, arch string) []string {
+	switch arch {
+	case "arm64":
+		return []string{path + ".linux_aarch64", path + ".linux_arm64", path}
+	case "amd64":
+		return []string{path + ".linux_x86_64", path + ".linux_amd64", path}
+	}
+	return []string{path}
+}
+
+// appCachePath returns the cache file for a download URL. Files are keyed by
+// URL, so changing the URL (e.g. a new version) fetches a fresh copy.
+func appCachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	base := filepath.Base(strings.SplitN(url, "?", 2)[0])
+	return filepath.Join(paths.AppsCacheDir(), hex.EncodeToString(sum[:6])+"-"+base)
+}
+
+// downloadApp fetches url into dest, verifying checksum (if set) before the
+// file is moved into place.
+func downloadApp(url, checksum, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create app cache: %w", err)
+	}
+
+	client := &http.Client{Timeout: appDownloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".download-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("download failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if checksum != "" {
+		if got := hex.EncodeToString(hash.Sum(nil)); got != checksum {
+			return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", checksum, got)
+		}
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// verifyAppChecksum checks file against an expected sha256 (no-op if empty).
+func verifyAppChecksum(file, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	got, err := calculateChecksum(file)
+	if err != nil {
+		return fmt.Errorf("failed to calculate checksum: %w", err)
+	}
+	if got != checksum {
+		return fmt.Errorf("checksum mismatch for %s: expected sha256 %s, got %s", file, checksum, got)
+	}
+	return nil
+}
+
+// containerArch returns the architecture apps should be resolved for in a
+// container, falling back to the host architecture if it cannot be detected.
+func containerArch(containerName string) string {
+	arch, err := container.ContainerArchitecture(containerName)
+	if err != nil || arch == "" {
+		return normalizeArch(runtime.GOARCH)
+	}
+	return normalizeArch(arch)
+}
+
+// imageArch returns the architecture of the configured container image,
+// falling back to the host architecture if the image is not present locally.
+// The second result reports whether the value was detected.
+func imageArch() (string, bool) {
+	arch, err := container.ImageArchitecture(getDockerImage())
+	if err != nil || arch == "" {
+		return normalizeArch(runtime.GOARCH), false
+	}
+	return normalizeArch(arch), true
+}
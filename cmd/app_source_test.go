@@ -0,0 +1,148 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppSourceForArch(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        any
+		arch         string
+		wantLocation string
+		wantChecksum string
+		wantErr      bool
+	}{
+		{"plain path", "~/bin/tool", "arm64", "~/bin/tool", "", false},
+		{"url with checksum suffix", "https://example.com/tool#sha256:ABCD", "amd64", "https://example.com/tool", "abcd", false},
+		{"per-arch map", map[string]any{
+			"url_amd64": "https://example.com/tool-amd64", "sha256_amd64": "aa",
+			"url_arm64": "https://example.com/tool-arm64", "sha256_arm64": "bb",
+		}, "arm64", "https://example.com/tool-arm64", "bb", false},
+		{"map falls back to url", map[string]any{
+			"url": "https://example.com/tool", "sha256": "sha256:cc",
+			"url_arm64": "https://example.com/tool-arm64",
+		}, "amd64", "https://example.com/tool", "cc", false},
+		{"shared checksum for per-arch urls", map[string]any{
+			"url_amd64": "https://example.com/tool-amd64",
+			"url_arm64": "https://example.com/tool-arm64", "sha256_arm64": "bb",
+			"sha256": "cc",
+		}, "amd64", "https://example.com/tool-amd64", "cc", false},
+		{"missing arch", map[string]any{"url_arm64": "https://example.com/tool-arm64"}, "amd64", "", "", true},
+		{"unknown key", map[string]any{"url_riscv": "https://example.com/tool"}, "amd64", "", "", true},
+		{"empty", "", "amd64", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := parseAppSource(tt.value)
+			if err == nil {
+				var location, checksum string
+				location, checksum, err = src.forArch(tt.arch)
+				if err == nil && (location != tt.wantLocation || checksum != tt.wantChecksum) {
+					t.Errorf("forArch(%s) = %q, %q; want %q, %q", tt.arch, location, checksum, tt.wantLocation, tt.wantChecksum)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveApp_Download(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	body := []byte("#!/bin/sh\necho hi\n")
+	sum := sha256.Sum256(body)
+	goodSum := hex.EncodeToString(sum[:])
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	// Not fetched yet: listing must not download
+	app, err := resolveApp(srv.URL+"/tool", "amd64", false)
+	if err != nil || app.File != "" || requests != 0 {
+		t.Fatalf("resolve without fetch = %+v, %v (requests %d)", app, err, requests)
+	}
+
+	app, err = resolveApp(srv.URL+"/tool#sha256:"+goodSum, "amd64", true)
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if app.Status != "downloaded" {
+		t.Errorf("Status = %q, want downloaded", app.Status)
+	}
+	if got, _ := os.ReadFile(app.File); string(got) != string(body) {
+		t.Errorf("cached file content = %q", got)
+	}
+
+	// Second resolution is served from the cache
+	app, err = resolveApp(srv.URL+"/tool#sha256:"+goodSum, "amd64", true)
+	if err != nil || app.Status != "cached" || requests != 1 {
+		t.Errorf("second resolve = %+v, %v (requests %d), want cached with 1 request", app, err, requests)
+	}
+
+	// A checksum mismatch fails and leaves nothing in the cache
+	bad := srv.URL + "/other#sha256:" + strings.Repeat("0", 64)
+	if _, err := resolveApp(bad, "amd64", true); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+	if _, err := os.Stat(appCachePath(srv.URL + "/other")); !os.IsNotExist(err) {
+		t.Errorf("mismatched download should not be cached")
+	}
+
+	if _, err := resolveApp(srv.URL+"/missing", "amd64", true); err == nil {
+		t.Error("expected error for 404 download")
+	}
+}
+
+func TestResolveApp_LocalArchVariant(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "tool")
+	for _, p := range []string{base, base + ".linux_aarch64"} {
+		if err := os.WriteFile(p, []byte("x"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app, err := resolveApp(base, "arm64", true)
+	if err != nil || app.File != base+".linux_aarch64" {
+		t.Errorf("arm64 resolve = %+v, %v; want the .linux_aarch64 variant", app, err)
+	}
+	app, err = resolveApp(base, "amd64", true)
+	if err != nil || app.File != base {
+		t.Errorf("amd64 resolve = %+v, %v; want the plain path", app, err)
+	}
+	if _, err := resolveApp(filepath.Join(dir, "nope"), "amd64", true); err == nil {
+		t.Error("expected error for missing local app")
+	}
+}
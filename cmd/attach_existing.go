@@ -0,0 +1,98 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// containersForBase returns the containers named baseName-<n>, the names
+// getNextContainerName hands out for the same branch.
+func containersForBase(containers []container.Info, baseName string) []container.Info {
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(baseName) + `-\d+$`)
+	var matches []container.Info
+	for _, c := range containers {
+		if pattern.MatchString(c.Name) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// parseExistingChoice interprets the answer to the attach-existing prompt:
+// the index of the chosen container, or -1 to create a new one. An empty
+// answer picks the first container.
+func parseExistingChoice(input string, count int) (int, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	switch input {
+	case "":
+		return 0, nil
+	case "n", "new":
+		return -1, nil
+	}
+	choice, err := strconv.Atoi(input)
+	if err != nil || choice < 1 || choice > count {
+		return 0, fmt.Errorf("invalid selection: %s", input)
+	}
+	return choice - 1, nil
+}
+
+// chooseExistingContainer implements 'maestro new --attach-existing': if
+// containers for baseName are already running, it lists them and asks which
+// to connect to instead of creating another. Returns "" to create a new one.
+func chooseExistingContainer(ctx context.Context, baseName string) (string, error) {
+	svc := newContainerService()
+	defer svc.Close()
+
+	running, err := svc.ListRunning(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get running containers: %w", err)
+	}
+	candidates := containersForBase(running, baseName)
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	fmt.Println("\nRunning containers already exist for this branch:")
+	fmt.Println()
+	sorted := container.Display(candidates, container.DisplayOptions{
+		ShowNumbers: true,
+		ShowTable:   true,
+	})
+	fmt.Println()
+	fmt.Printf("Connect to (1-%d), or n to create a new container [1]: ", len(sorted))
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	idx, err := parseExistingChoice(input, len(sorted))
+	if err != nil {
+		return "", err
+	}
+	if idx < 0 {
+		return "", nil
+	}
+	return sorted[idx].Name, nil
+}
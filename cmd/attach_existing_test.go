@@ -0,0 +1,71 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestContainersForBase(t *testing.T) {
+	running := []container.Info{
+		{Name: "maestro-feat-auth-1"},
+		{Name: "maestro-feat-auth-3"},
+		{Name: "maestro-feat-auth-flow-1"},
+		{Name: "maestro-feat-auth"},
+		{Name: "maestro-fix-auth-1"},
+	}
+	got := containersForBase(running, "maestro-feat-auth")
+	if len(got) != 2 || got[0].Name != "maestro-feat-auth-1" || got[1].Name != "maestro-feat-auth-3" {
+		t.Errorf("got %v, want feat-auth-1 and feat-auth-3", got)
+	}
+}
+
+func TestContainerBaseName(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+	config = &Config{}
+	config.Containers.Prefix = "maestro-"
+
+	if got := containerBaseName("feat/auth"); got != "maestro-feat-auth" {
+		t.Errorf("got %q", got)
+	}
+	if got := containerBaseName("feat/auth", "Web App"); got != "maestro-web-app-feat-auth" {
+		t.Errorf("with project: got %q", got)
+	}
+}
+
+func TestParseExistingChoice(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"\n", 0, false},
+		{"2\n", 1, false},
+		{"n\n", -1, false},
+		{"New", -1, false},
+		{"3", 0, true},
+		{"0", 0, true},
+		{"x", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseExistingChoice(tt.input, 2)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("parseExistingChoice(%q) = %d, %v; want %d, err=%v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -16,12 +16,16 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var authCmd = &cobra.Command{
@@ -42,15 +46,32 @@ This command will:
 3. By default, sync new credentials to all running containers
    - Use --no-sync to skip this step
 
-All authentication data is stored in ~/.maestro/ and shared (read-only) with containers.`,
+GitHub CLI auth can be set up on its own and without a browser, for CI and
+scripted provisioning: --gh-token reads a token from a file (- for stdin),
+and --gh-only uses $GH_TOKEN when it is set. A full 'maestro auth' asks
+before setting up GitHub, from $GH_TOKEN if it is set, since that replaces
+the existing login.
+
+All authentication data is stored in ~/.maestro/ and shared (read-only) with containers.
+
+Examples:
+  maestro auth
+  maestro auth --gh-token ~/.secrets/gh-token
+  GH_TOKEN=ghp_... maestro auth --gh-only`,
 	RunE: runAuth,
 }
 
-var noSync bool
+var (
+	noSync      bool
+	ghTokenFile string
+	ghOnly      bool
+)
 
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.Flags().BoolVar(&noSync, "no-sync", false, "Skip syncing credentials to running containers")
+	authCmd.Flags().StringVar(&ghTokenFile, "gh-token", "", "Set up only GitHub CLI auth, non-interactively, from a token file (- for stdin)")
+	authCmd.Flags().BoolVar(&ghOnly, "gh-only", false, "Set up only GitHub CLI auth (with $GH_TOKEN if set, otherwise interactively)")
 }
 
 // runBedrockAuth handles authentication for AWS Bedrock users
@@ -73,7 +94,7 @@ func runBedrockAuth() error {
 
 	// Check if source ~/.claude exists
 	if _, err := os.Stat(sourceClaudeDir); os.IsNotExist(err) {
-		fmt.Printf("Warning: ~/.claude directory not found\n")
+		logging.Warnf("~/.claude directory not found")
 		fmt.Println("You may need to run 'claude' once on the host to create initial config")
 	} else {
 		// Copy .credentials.json if exists
@@ -81,9 +102,9 @@ func runBedrockAuth() error {
 		if _, err := os.Stat(srcCreds); err == nil {
 			destCreds := filepath.Join(destAuthPath, ".credentials.json")
 			if err := copyFile(srcCreds, destCreds); err != nil {
-				fmt.Printf("Warning: Failed to copy credentials: %v\n", err)
+				logging.Warnf("Failed to copy credentials: %v", err)
 			} else {
-				fmt.Printf("✓ Copied credentials from %s\n", srcCreds)
+				fmt.Printf("%s Copied credentials from %s\n", style.Check(), srcCreds)
 			}
 		}
 
@@ -92,9 +113,9 @@ func runBedrockAuth() error {
 		if _, err := os.Stat(srcSettings); err == nil {
 			destSettings := filepath.Join(destAuthPath, "settings.json")
 			if err := copyFile(srcSettings, destSettings); err != nil {
-				fmt.Printf("Warning: Failed to copy settings: %v\n", err)
+				logging.Warnf("Failed to copy settings: %v", err)
 			} else {
-				fmt.Printf("✓ Copied settings from %s\n", srcSettings)
+				fmt.Printf("%s Copied settings from %s\n", style.Check(), srcSettings)
 			}
 		}
 	}
@@ -104,9 +125,9 @@ func runBedrockAuth() error {
 	if _, err := os.Stat(srcClaudeJson); err == nil {
 		destClaudeJson := filepath.Join(destAuthPath, ".claude.json")
 		if err := copyFile(srcClaudeJson, destClaudeJson); err != nil {
-			fmt.Printf("Warning: Failed to copy .claude.json: %v\n", err)
+			logging.Warnf("Failed to copy .claude.json: %v", err)
 		} else {
-			fmt.Printf("✓ Copied config from %s\n", srcClaudeJson)
+			fmt.Printf("%s Copied config from %s\n", style.Check(), srcClaudeJson)
 		}
 	}
 
@@ -123,7 +144,7 @@ func runBedrockAuth() error {
 		if err := ssoCmd.Run(); err != nil {
 			return fmt.Errorf("AWS SSO login failed: %w", err)
 		}
-		fmt.Println("✓ AWS SSO login successful")
+		fmt.Println(style.Check() + " AWS SSO login successful")
 	}
 
 	fmt.Println("\n✅ Bedrock authentication setup complete!")
@@ -143,7 +164,7 @@ func runBedrockAuth() error {
 
 	if response == "y" || response == "Y" || response == "yes" || response == "Yes" {
 		if err := setupGitHubAuth(); err != nil {
-			fmt.Printf("\n⚠️  GitHub CLI setup failed: %v\n", err)
+			fmt.Printf("\n%s  GitHub CLI setup failed: %v\n", style.Warning(), err)
 			fmt.Println("You can skip this and run 'gh auth login' manually later.")
 		}
 	} else {
@@ -167,6 +188,21 @@ func copyFile(src, dst string) error {
 }
 
 func runAuth(cmd *cobra.Command, cmdArgs []string) error {
+	// GitHub CLI only, leaving Claude's auth alone
+	if ghTokenFile != "" {
+		token, err := readGitHubToken(ghTokenFile)
+		if err != nil {
+			return err
+		}
+		return setupGitHubAuthWithToken(token)
+	}
+	if ghOnly {
+		if token := strings.TrimSpace(os.Getenv("GH_TOKEN")); token != "" {
+			return setupGitHubAuthWithToken(token)
+		}
+		return setupGitHubAuth()
+	}
+
 	// If Bedrock is enabled, use different auth flow
 	if config.Bedrock.Enabled {
 		return runBedrockAuth()
@@ -192,22 +228,22 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 			return fmt.Errorf("failed to remove %s: %w", entryPath, err)
 		}
 	}
-	fmt.Println("✓ Cleared existing authentication data")
+	fmt.Println(style.Check() + " Cleared existing authentication data")
 
 	// Ensure Docker image exists
-	if err := ensureDockerImage(getDockerImage()); err != nil {
+	if err := ensureDockerImage(getDockerImage(), false); err != nil {
 		return fmt.Errorf("failed to ensure Docker image: %w", err)
 	}
 
 	authContainerName := config.Containers.Prefix + "auth"
 
 	// Check if auth container already exists
-	checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", authContainerName), "--format", "{{.Names}}")
+	checkCmd := logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", authContainerName), "--format", "{{.Names}}")
 	output, _ := checkCmd.Output()
 	if len(output) > 0 {
 		// Remove existing auth container
 		fmt.Println("Removing existing auth container...")
-		exec.Command("docker", "rm", "-f", authContainerName).Run()
+		logging.Run(logging.Command("docker", "rm", "-f", authContainerName))
 	}
 
 	fmt.Println("\nStarting authentication container...")
@@ -227,100 +263,112 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 		"-w", "/workspace",
 	}
 
-	// Mount host SSL certificates for corporate proxies (Zscaler, etc.)
-	// This allows the container to use the same CA trust store as the host
-	if _, err := os.Stat("/etc/ssl/certs/ca-certificates.crt"); err == nil {
-		args = append(args,
-			"-v", "/etc/ssl/certs:/etc/ssl/certs:ro",
-			"-e", "NODE_EXTRA_CA_CERTS=/etc/ssl/certs/ca-certificates.crt",
-			"-e", "NODE_OPTIONS=--use-openssl-ca",
-			"-e", "SSL_CERT_FILE=/etc/ssl/certs/ca-certificates.crt",
-			"-e", "CURL_CA_BUNDLE=/etc/ssl/certs/ca-certificates.crt",
-			"-e", "REQUESTS_CA_BUNDLE=/etc/ssl/certs/ca-certificates.crt",
-		)
+	// Mount SSL certificates for corporate proxies (Zscaler, etc.)
+	certOpts, certWrap := caCertArgs("NODE_EXTRA_CA_CERTS", "SSL_CERT_FILE", "CURL_CA_BUNDLE", "REQUESTS_CA_BUNDLE")
+	if len(certOpts) > 0 {
+		args = append(args, certOpts...)
+		args = append(args, "-e", "NODE_OPTIONS=--use-openssl-ca")
 	}
 
-	args = append(args,
-		config.Containers.Image,
-		"claude", "--dangerously-skip-permissions",
-	)
+	args = append(args, config.Containers.Image)
+	args = append(args, certWrap...)
+	args = append(args, "claude", "--dangerously-skip-permissions")
 
-	authCmd := exec.Command("docker", args...)
+	authCmd := logging.Command("docker", args...)
 	authCmd.Stdin = os.Stdin
 	authCmd.Stdout = os.Stdout
 	authCmd.Stderr = os.Stderr
 
-	if err := authCmd.Run(); err != nil {
+	if err := logging.Run(authCmd); err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 
 	fmt.Println("\nCopying configuration from auth container...")
 
+	credPath := filepath.Join(authPath, ".credentials.json")
+	configPath := filepath.Join(authPath, ".claude.json")
+
 	// Copy .claude.json from container's home directory to host
-	// This file contains onboarding state, permissions, and account info
-	copyConfigCmd := exec.Command("docker", "cp",
-		fmt.Sprintf("%s:/home/node/.claude.json", authContainerName),
-		filepath.Join(authPath, ".claude.json"))
-	if err := copyConfigCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to copy .claude.json from container: %v\n", err)
-	}
+	// This file contains onboarding state, permissions, and account info.
+	// Some Claude versions don't write it, so fall back to the copies they
+	// do leave.
+	configSource := copyAuthConfig(authContainerName, authPath, configPath)
 
 	// Clean up auth container now that we've copied the files
 	fmt.Println("Cleaning up auth container...")
-	exec.Command("docker", "rm", "-f", authContainerName).Run()
-
-	// Check if both credentials and config were created
-	credPath := filepath.Join(authPath, ".credentials.json")
-	configPath := filepath.Join(authPath, ".claude.json")
+	logging.Run(logging.Command("docker", "rm", "-f", authContainerName))
 
 	credExists := false
-	configExists := false
-
 	if _, err := os.Stat(credPath); err == nil {
 		credExists = true
 	}
-	if _, err := os.Stat(configPath); err == nil {
-		configExists = true
+
+	if !credExists {
+		fmt.Println("\n" + style.Warning() + "  Warning: Setup incomplete.")
+		fmt.Println("  - Missing .credentials.json: the OAuth login did not finish.")
+		fmt.Println("    Complete the login in your browser and wait for Claude to show")
+		fmt.Println("    its prompt before exiting.")
+		if configSource == "" {
+			fmt.Println("  - Missing .claude.json: Claude did not save its configuration either.")
+		} else {
+			fmt.Printf("  - Configuration was saved (from %s).\n", configSource)
+		}
+		fmt.Println("\nRun 'maestro auth' again to retry.")
+		return fmt.Errorf("authentication incomplete: no credentials in %s", authPath)
 	}
 
-	if credExists && configExists {
-		fmt.Println("\n✅ Authentication and configuration successful!")
-		fmt.Printf("Credentials saved to: %s\n", credPath)
+	fmt.Println("\n✅ Authentication successful!")
+	fmt.Printf("Credentials saved to: %s\n", credPath)
+	switch configSource {
+	case "/home/node/.claude.json":
 		fmt.Printf("Configuration saved to: %s\n", configPath)
-		fmt.Println("\nYou can now create maestro containers with: maestro new <description>")
-	} else {
-		fmt.Println("\n⚠️  Warning: Setup incomplete.")
-		if !credExists {
-			fmt.Println("  - Missing .credentials.json (authentication)")
+	case "":
+		// Logged in, but this Claude version wrote no configuration we
+		// could find. A minimal one keeps new containers from re-running
+		// onboarding.
+		fmt.Println(style.Warning() + "  Claude did not write .claude.json, so a minimal configuration was created.")
+		fmt.Println("   Theme and other first-run choices will use Claude's defaults.")
+		if err := writeMinimalClaudeConfig(configPath); err != nil {
+			return err
 		}
-		if !configExists {
-			fmt.Println("  - Missing .claude.json (configuration)")
-		}
-		fmt.Println("\nAuthentication may not have completed successfully.")
-		fmt.Println("You can try running 'maestro auth' again.")
-		return fmt.Errorf("authentication incomplete")
+		fmt.Printf("Configuration saved to: %s\n", configPath)
+	default:
+		fmt.Printf("Configuration saved to: %s (recovered from %s)\n", configPath, configSource)
 	}
+	fmt.Println("\nYou can now create maestro containers with: maestro new <description>")
 
 	// Sync credentials to running containers unless --no-sync is set
 	if !noSync {
 		if err := syncCredentialsToContainers(); err != nil {
-			fmt.Printf("\n⚠️  Warning: Failed to sync credentials to containers: %v\n", err)
+			fmt.Printf("\n%s  Warning: Failed to sync credentials to containers: %v\n", style.Warning(), err)
 			fmt.Println("You can manually restart containers or try syncing again later.")
 		}
 	}
 
-	// Ask user if they want to set up GitHub CLI
+	// Ask user if they want to set up GitHub CLI. Setting it up replaces any
+	// existing login, so even a token in the environment needs a yes
+	token := strings.TrimSpace(os.Getenv("GH_TOKEN"))
 	fmt.Println("\n========================================================================")
-	fmt.Print("\nWould you like to set up GitHub CLI (gh) authentication? (y/N): ")
+	if token != "" {
+		fmt.Print("\nWould you like to set up GitHub CLI (gh) authentication from $GH_TOKEN, replacing any existing login? (y/N): ")
+	} else {
+		fmt.Print("\nWould you like to set up GitHub CLI (gh) authentication? (y/N): ")
+	}
 	var response string
 	fmt.Scanln(&response)
+	yes := response == "y" || response == "Y" || response == "yes" || response == "Yes"
 
-	if response == "y" || response == "Y" || response == "yes" || response == "Yes" {
+	switch {
+	case yes && token != "":
+		if err := setupGitHubAuthWithToken(token); err != nil {
+			fmt.Printf("\n%s  GitHub CLI setup failed: %v\n", style.Warning(), err)
+		}
+	case yes:
 		if err := setupGitHubAuth(); err != nil {
-			fmt.Printf("\n⚠️  GitHub CLI setup failed: %v\n", err)
+			fmt.Printf("\n%s  GitHub CLI setup failed: %v\n", style.Warning(), err)
 			fmt.Println("You can skip this and run 'gh auth login' manually later.")
 		}
-	} else {
+	default:
 		fmt.Println("\nSkipping GitHub CLI setup.")
 		fmt.Println("You can set it up later by running 'gh auth login' in a container,")
 		fmt.Println("or enable github.enabled in your config file and authenticate on the host.")
@@ -329,11 +377,14 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 	return nil
 }
 
-func setupGitHubAuth() error {
+// prepareGitHubConfigDir creates the GitHub CLI config directory shared with
+// containers and clears what a previous login left there. It returns the
+// directory and the GitHub hostname to log in to.
+func prepareGitHubConfigDir() (string, string, error) {
 	// Ensure GitHub auth directory exists
 	ghPath := expandPath(config.GitHub.ConfigPath)
 	if err := os.MkdirAll(ghPath, 0755); err != nil {
-		return fmt.Errorf("failed to create GitHub auth directory: %w", err)
+		return "", "", fmt.Errorf("failed to create GitHub auth directory: %w", err)
 	}
 
 	// Determine hostname (default to github.com)
@@ -354,17 +405,31 @@ func setupGitHubAuth() error {
 			os.RemoveAll(entryPath)
 		}
 	}
-	fmt.Println("✓ Cleared existing GitHub authentication data")
+	fmt.Println(style.Check() + " Cleared existing GitHub authentication data")
+	return ghPath, hostname, nil
+}
+
+// ghCertArgs gives a gh container the CA certificates for corporate proxies
+// (Zscaler, etc.); see caCertArgs.
+func ghCertArgs() (opts, wrap []string) {
+	return caCertArgs("SSL_CERT_FILE", "CURL_CA_BUNDLE")
+}
+
+func setupGitHubAuth() error {
+	ghPath, hostname, err := prepareGitHubConfigDir()
+	if err != nil {
+		return err
+	}
 
 	ghAuthContainerName := config.Containers.Prefix + "gh-auth"
 
 	// Check if gh auth container already exists
-	checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", ghAuthContainerName), "--format", "{{.Names}}")
+	checkCmd := logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", ghAuthContainerName), "--format", "{{.Names}}")
 	output, _ := checkCmd.Output()
 	if len(output) > 0 {
 		// Remove existing gh auth container
 		fmt.Println("Removing existing gh auth container...")
-		exec.Command("docker", "rm", "-f", ghAuthContainerName).Run()
+		logging.Run(logging.Command("docker", "rm", "-f", ghAuthContainerName))
 	}
 
 	fmt.Println("\nStarting GitHub CLI authentication container...")
@@ -383,34 +448,30 @@ func setupGitHubAuth() error {
 		"-w", "/workspace",
 	}
 
-	// Mount host SSL certificates for corporate proxies (Zscaler, etc.)
-	if _, err := os.Stat("/etc/ssl/certs/ca-certificates.crt"); err == nil {
-		args = append(args,
-			"-v", "/etc/ssl/certs:/etc/ssl/certs:ro",
-			"-e", "SSL_CERT_FILE=/etc/ssl/certs/ca-certificates.crt",
-			"-e", "CURL_CA_BUNDLE=/etc/ssl/certs/ca-certificates.crt",
-		)
-	}
+	// Mount SSL certificates for corporate proxies (Zscaler, etc.)
+	certOpts, certWrap := ghCertArgs()
+	args = append(args, certOpts...)
 
 	// Build gh auth login command with hostname
 	ghAuthArgs := []string{"gh", "auth", "login", "--hostname", hostname}
 	args = append(args, config.Containers.Image)
+	args = append(args, certWrap...)
 	args = append(args, ghAuthArgs...)
 
-	ghAuthCmd := exec.Command("docker", args...)
+	ghAuthCmd := logging.Command("docker", args...)
 	ghAuthCmd.Stdin = os.Stdin
 	ghAuthCmd.Stdout = os.Stdout
 	ghAuthCmd.Stderr = os.Stderr
 
-	if err := ghAuthCmd.Run(); err != nil {
+	if err := logging.Run(ghAuthCmd); err != nil {
 		// Clean up container even on error
-		exec.Command("docker", "rm", "-f", ghAuthContainerName).Run()
+		logging.Run(logging.Command("docker", "rm", "-f", ghAuthContainerName))
 		return fmt.Errorf("GitHub authentication failed: %w", err)
 	}
 
 	// Clean up gh auth container
 	fmt.Println("\nCleaning up GitHub auth container...")
-	exec.Command("docker", "rm", "-f", ghAuthContainerName).Run()
+	logging.Run(logging.Command("docker", "rm", "-f", ghAuthContainerName))
 
 	// Check if authentication was successful
 	hostsPath := filepath.Join(ghPath, "hosts.yml")
@@ -426,12 +487,74 @@ func setupGitHubAuth() error {
 	return nil
 }
 
+// readGitHubToken reads a GitHub token from a file, or from stdin for "-".
+func readGitHubToken(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(expandPath(path))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read GitHub token: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" || strings.ContainsAny(token, " \t\n") {
+		return "", fmt.Errorf("failed to read GitHub token: %s should hold just the token", path)
+	}
+	return token, nil
+}
+
+// setupGitHubAuthWithToken logs GitHub CLI in with a token, without a
+// browser or prompts ('gh auth login --with-token' in a temporary
+// container), then checks the login with 'gh auth status'.
+func setupGitHubAuthWithToken(token string) error {
+	ghPath, hostname, err := prepareGitHubConfigDir()
+	if err != nil {
+		return err
+	}
+	image := getDockerImage()
+	if err := ensureDockerImage(image, false); err != nil {
+		return fmt.Errorf("failed to ensure Docker image: %w", err)
+	}
+
+	ghRun := func(ghArgs ...string) *exec.Cmd {
+		args := []string{"run", "--rm", "-i", "-v", fmt.Sprintf("%s:/home/node/.config/gh", ghPath)}
+		certOpts, certWrap := ghCertArgs()
+		args = append(args, certOpts...)
+		args = append(args, image)
+		args = append(args, certWrap...)
+		args = append(args, "gh")
+		return logging.Command("docker", append(args, ghArgs...)...)
+	}
+
+	login := ghRun("auth", "login", "--hostname", hostname, "--with-token")
+	login.Stdin = strings.NewReader(token + "\n")
+	if output, err := login.CombinedOutput(); err != nil {
+		return fmt.Errorf("gh auth login failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	if output, err := ghRun("auth", "status", "--hostname", hostname).CombinedOutput(); err != nil {
+		return fmt.Errorf("the token was saved but gh auth status failed: %s", strings.TrimSpace(string(output)))
+	}
+	if _, err := os.Stat(filepath.Join(ghPath, "hosts.yml")); err != nil {
+		return fmt.Errorf("hosts.yml not found in %s after logging in", ghPath)
+	}
+
+	fmt.Println("\n✅ GitHub CLI authentication successful!")
+	fmt.Printf("Hostname: %s\n", hostname)
+	fmt.Printf("Configuration saved to: %s\n", ghPath)
+	fmt.Println("\nGitHub CLI will be available in all Maestro containers when github.enabled is true.")
+	return nil
+}
+
 func syncCredentialsToContainers() error {
 	fmt.Println("\n========================================================================")
 	fmt.Println("Syncing credentials to running containers...")
 
 	// Get all running containers
-	dockerCmd := exec.Command("docker", "ps", "--format", "{{.Names}}\t{{.State}}")
+	dockerCmd := logging.Command("docker", "ps", "--format", "{{.Names}}\t{{.State}}")
 	output, err := dockerCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
@@ -488,29 +611,29 @@ func syncCredentialsToContainers() error {
 		fmt.Printf("  Updating %s... ", containerName)
 
 		// Copy credentials to container
-		copyCmd := exec.Command("docker", "cp",
+		copyCmd := logging.Command("docker", "cp",
 			credPath,
 			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
-		if err := copyCmd.Run(); err != nil {
+		if err := logging.Run(copyCmd); err != nil {
 			fmt.Printf("FAILED: %v\n", err)
 			continue
 		}
 
 		// Fix ownership (run as root)
-		chownCmd := exec.Command("docker", "exec", "-u", "root", containerName,
+		chownCmd := logging.Command("docker", "exec", "-u", "root", containerName,
 			"chown", "node:node", "/home/node/.claude/.credentials.json")
-		if err := chownCmd.Run(); err != nil {
+		if err := logging.Run(chownCmd); err != nil {
 			fmt.Printf("WARNING: ownership fix failed: %v\n", err)
 		}
 
-		fmt.Println("✓")
+		fmt.Println(style.Check())
 		successCount++
 	}
 
 	if successCount == len(runningContainers) {
 		fmt.Printf("\n✅ Successfully synced credentials to %d container(s)\n", successCount)
 	} else {
-		fmt.Printf("\n⚠️  Synced credentials to %d/%d container(s)\n", successCount, len(runningContainers))
+		fmt.Printf("\n%s  Synced credentials to %d/%d container(s)\n", style.Warning(), successCount, len(runningContainers))
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// minimalClaudeConfig stands in for .claude.json when Claude authenticated
+// but never wrote one. New containers patch in the remaining onboarding and
+// trust flags when they start.
+const minimalClaudeConfig = "{\n  \"hasCompletedOnboarding\": true\n}\n"
+
+// findAuthConfigFallback looks in the auth directory (the container's
+// ~/.claude) for a configuration Claude left behind instead of
+// ~/.claude.json: .config.json from versions that keep it in the config
+// dir, or the newest backups/.claude.json.backup.* copy. It returns "" when
+// there is none.
+func findAuthConfigFallback(authPath string) string {
+	candidate := filepath.Join(authPath, ".config.json")
+	if info, err := os.Stat(candidate); err == nil && info.Mode().IsRegular() {
+		return candidate
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(authPath, "backups", ".claude.json.backup*"))
+	if len(backups) == 0 {
+		return ""
+	}
+	// Backups are suffixed with a millisecond timestamp, so the last one
+	// in lexical order is the newest.
+	sort.Strings(backups)
+	return backups[len(backups)-1]
+}
+
+// copyAuthConfig puts Claude's configuration from the finished auth
+// container at configPath. It tries ~/.claude.json, then the backup Claude
+// keeps beside it, then whatever findAuthConfigFallback finds in the auth
+// directory, and returns a description of where the file came from. It
+// returns "" when none of them exist.
+func copyAuthConfig(authContainerName, authPath, configPath string) string {
+	for _, src := range []string{"/home/node/.claude.json", "/home/node/.claude.json.backup"} {
+		cpCmd := logging.Command("docker", "cp", fmt.Sprintf("%s:%s", authContainerName, src), configPath)
+		if err := logging.Run(cpCmd); err != nil {
+			logging.Debugf("No %s in auth container: %v", src, err)
+			continue
+		}
+		return src
+	}
+
+	if src := findAuthConfigFallback(authPath); src != "" {
+		if err := copyFile(src, configPath); err != nil {
+			logging.Warnf("Failed to copy %s: %v", src, err)
+			return ""
+		}
+		return src
+	}
+	return ""
+}
+
+// writeMinimalClaudeConfig writes minimalClaudeConfig to configPath.
+func writeMinimalClaudeConfig(configPath string) error {
+	if err := os.WriteFile(configPath, []byte(minimalClaudeConfig), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", configPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindAuthConfigFallback(t *testing.T) {
+	dir := t.TempDir()
+	if got := findAuthConfigFallback(dir); got != "" {
+		t.Errorf("empty auth dir: got %q, want none", got)
+	}
+
+	backups := filepath.Join(dir, "backups")
+	if err := os.MkdirAll(backups, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{".claude.json.backup.1760000000000", ".claude.json.backup.1760000999000"} {
+		if err := os.WriteFile(filepath.Join(backups, name), []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := findAuthConfigFallback(dir), filepath.Join(backups, ".claude.json.backup.1760000999000"); got != want {
+		t.Errorf("with backups: got %q, want the newest %q", got, want)
+	}
+
+	configJSON := filepath.Join(dir, ".config.json")
+	if err := os.WriteFile(configJSON, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := findAuthConfigFallback(dir); got != configJSON {
+		t.Errorf("with .config.json: got %q, want %q", got, configJSON)
+	}
+}
+
+func TestWriteMinimalClaudeConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".claude.json")
+	if err := writeMinimalClaudeConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg map[string]any
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("minimal config is not valid JSON: %v", err)
+	}
+	if cfg["hasCompletedOnboarding"] != true {
+		t.Errorf("hasCompletedOnboarding = %v, want true", cfg["hasCompletedOnboarding"])
+	}
+}
+
+func TestReadGitHubToken(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gh-token")
+	if err := os.WriteFile(path, []byte("ghp_abc123\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := readGitHubToken(path); err != nil || token != "ghp_abc123" {
+		t.Errorf("readGitHubToken() = %q, %v; want ghp_abc123", token, err)
+	}
+
+	for name, content := range map[string]string{"empty": " \n", "two-lines": "ghp_a\nghp_b\n"} {
+		bad := filepath.Join(dir, name)
+		if err := os.WriteFile(bad, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readGitHubToken(bad); err == nil {
+			t.Errorf("readGitHubToken(%s) should fail", name)
+		}
+	}
+	if _, err := readGitHubToken(filepath.Join(dir, "missing")); err == nil {
+		t.Error("readGitHubToken of a missing file should fail")
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// containerCredPath is where containers keep their Claude credentials.
+const containerCredPath = "/home/node/.claude/.credentials.json"
+
+var authRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Get a fresh OAuth token without re-running the full auth flow",
+	Long: `Exchange the stored refresh token for a new access token, save it, and sync
+it to all running containers.
+
+With --container, only that container's credentials are rotated and written
+back to it. The token endpoint may issue a new refresh token, which replaces
+the old one, so a container still holding the host's credentials (synced in
+by 'maestro auth') rotates the host's instead and syncs them everywhere.
+
+If the refresh token has also expired, run 'maestro auth' to log in again.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthRotate,
+}
+
+var rotateContainer string
+
+func init() {
+	authCmd.AddCommand(authRotateCmd)
+	authRotateCmd.Flags().StringVar(&rotateContainer, "container", "", "Rotate only this container's token")
+}
+
+func runAuthRotate(cmd *cobra.Command, args []string) error {
+	if rotateContainer != "" {
+		containerName, ok := getNicknameStore().Get(rotateContainer)
+		if !ok {
+			containerName = resolveContainerName(rotateContainer)
+		}
+		return rotateContainerToken(containerName)
+	}
+
+	return rotateHostToken()
+}
+
+// hostCredentialsPath is where the host keeps the credentials synced into
+// containers.
+func hostCredentialsPath() string {
+	return filepath.Join(expandPath(config.Claude.AuthPath), ".credentials.json")
+}
+
+// rotateHostToken rotates the host's credentials and syncs them to all
+// running containers.
+func rotateHostToken() error {
+	credPath := hostCredentialsPath()
+	creds, err := rotateCredentials(credPath)
+	if err != nil {
+		return err
+	}
+	if err := container.WriteCredentials(credPath, creds); err != nil {
+		return err
+	}
+	printRotated(creds)
+	return syncCredentialsToContainers()
+}
+
+// sharesHostRefreshToken reports whether creds hold the same refresh token
+// as the host credentials at hostPath.
+func sharesHostRefreshToken(hostPath string, creds *container.Credentials) bool {
+	host, err := container.ReadCredentials(hostPath)
+	if err != nil {
+		return false
+	}
+	token := host.ClaudeAiOauth.RefreshToken
+	return token != "" && token == creds.ClaudeAiOauth.RefreshToken
+}
+
+// rotateContainerToken rotates the credentials of a single container,
+// leaving the host and other containers alone. A container holding the
+// host's refresh token rotates the host's credentials instead: rotating only
+// its copy would invalidate the token the host and other containers share.
+func rotateContainerToken(containerName string) error {
+	tmpDir, err := os.MkdirTemp("", "maestro-rotate-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpFile := filepath.Join(tmpDir, ".credentials.json")
+	src := fmt.Sprintf("%s:%s", containerName, containerCredPath)
+	if err := logging.Run(logging.Command("docker", "cp", src, tmpFile)); err != nil {
+		return fmt.Errorf("failed to read credentials from %s: %w", containerName, err)
+	}
+	if current, err := container.ReadCredentials(tmpFile); err == nil && sharesHostRefreshToken(hostCredentialsPath(), current) {
+		fmt.Printf("%s uses the host's credentials; rotating those and syncing them to all containers\n", containerName)
+		return rotateHostToken()
+	}
+	creds, err := rotateCredentials(tmpFile)
+	if err != nil {
+		return err
+	}
+	if err := container.WriteCredentials(tmpFile, creds); err != nil {
+		return err
+	}
+	if err := logging.Run(logging.Command("docker", "cp", tmpFile, src)); err != nil {
+		return fmt.Errorf("failed to write credentials to %s: %w", containerName, err)
+	}
+	chownCmd := logging.Command("docker", "exec", "-u", "root", containerName,
+		"chown", "node:node", containerCredPath)
+	if err := logging.Run(chownCmd); err != nil {
+		logging.Warnf("Failed to fix credentials ownership in %s: %v", containerName, err)
+	}
+	printRotated(creds)
+	fmt.Printf("Updated %s\n", containerName)
+	return nil
+}
+
+// rotateCredentials reads the credentials at path and exchanges their
+// refresh token for a new access token.
+func rotateCredentials(path string) (*container.Credentials, error) {
+	creds, err := container.ReadCredentials(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials (run 'maestro auth' first?): %w", err)
+	}
+	fmt.Println("Requesting a new access token...")
+	rotated, err := container.RefreshOAuthToken(creds, container.OAuthTokenURL)
+	if errors.Is(err, container.ErrRefreshTokenExpired) {
+		return nil, fmt.Errorf("%w; run 'maestro auth' to log in again", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate token: %w", err)
+	}
+	return rotated, nil
+}
+
+func printRotated(creds *container.Credentials) {
+	expiresAt := time.UnixMilli(creds.ClaudeAiOauth.ExpiresAt)
+	fmt.Printf("✅ Token rotated, expires %s (%s)\n", expiresAt.Format(time.RFC1123), container.FormatExpiration(creds))
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestSharesHostRefreshToken(t *testing.T) {
+	hostPath := filepath.Join(t.TempDir(), ".credentials.json")
+	creds := func(refresh string) *container.Credentials {
+		c := &container.Credentials{}
+		c.ClaudeAiOauth.RefreshToken = refresh
+		return c
+	}
+
+	if sharesHostRefreshToken(hostPath, creds("rt-host")) {
+		t.Error("without host credentials nothing is shared")
+	}
+	if err := container.WriteCredentials(hostPath, creds("rt-host")); err != nil {
+		t.Fatal(err)
+	}
+	if !sharesHostRefreshToken(hostPath, creds("rt-host")) {
+		t.Error("a synced copy of the host's credentials should be recognized")
+	}
+	if sharesHostRefreshToken(hostPath, creds("rt-own")) {
+		t.Error("a container with its own refresh token does not share the host's")
+	}
+	if sharesHostRefreshToken(hostPath, creds("")) {
+		t.Error("an empty refresh token should never match")
+	}
+}
@@ -26,6 +26,10 @@ import (
 	"sync"
 
 	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/branchname"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var (
@@ -234,11 +238,11 @@ func promptTaskSelection(tasks []Task) ([]Task, error) {
 	for _, part := range strings.Split(input, ",") {
 		num, err := strconv.Atoi(strings.TrimSpace(part))
 		if err != nil {
-			fmt.Printf("Warning: '%s' is not a valid number, skipping\n", part)
+			logging.Warnf("'%s' is not a valid number, skipping", part)
 			continue
 		}
 		if num < 1 || num > len(tasks) {
-			fmt.Printf("Warning: %d is out of range, skipping\n", num)
+			logging.Warnf("%d is out of range, skipping", num)
 			continue
 		}
 		selected = append(selected, tasks[num-1])
@@ -311,8 +315,8 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 			return fmt.Errorf("failed to generate branch for task %d: %w", task.Number, err)
 		}
 
-		if !isValidBranchName(branchName) {
-			branchName = generateSimpleBranch(task.Title)
+		if !branchname.IsValid(branchName) {
+			branchName = branchname.Simple(task.Title)
 		}
 
 		containerName, err := getNextContainerName(branchName)
@@ -332,7 +336,7 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 	}
 
 	// Start progress display
-	fmt.Println("\nCopying source code to containers:")
+	logging.Infof("\nCopying source code to containers:")
 	mp.Start()
 
 	// Start container creation in parallel
@@ -347,7 +351,7 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 			}
 
 			// Create the container
-			if err := createBatchContainer(info.containerName, info.branchName, info.fullPrompt); err != nil {
+			if err := createBatchContainer(info.containerName, info.branchName, info.fullPrompt, info.task.Title+"\n\n"+info.task.Description); err != nil {
 				result.Success = false
 				result.Message = fmt.Sprintf("failed to create container: %v", err)
 				results <- result
@@ -384,10 +388,10 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 	successCount := 0
 	for _, result := range resultsList {
 		if result.Success {
-			fmt.Printf("  [%d] ✓ %s\n", result.TaskNumber, result.Message)
+			fmt.Printf("  [%d] %s %s\n", result.TaskNumber, style.Check(), result.Message)
 			successCount++
 		} else {
-			fmt.Printf("  [%d] ✗ %s\n", result.TaskNumber, result.Message)
+			fmt.Printf("  [%d] %s %s\n", result.TaskNumber, style.Cross(), result.Message)
 		}
 	}
 
@@ -396,11 +400,13 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 }
 
 // createBatchContainer creates a single container without connecting
-func createBatchContainer(containerName, branchName, prompt string) error {
+func createBatchContainer(containerName, branchName, prompt, task string) error {
 	return setupContainer(ContainerSetupOptions{
 		ContainerName: containerName,
 		BranchName:    branchName,
+		Task:          task,
 		Prompt:        prompt,
 		ExactPrompt:   true,
+		NoFirewall:    config.Containers.DefaultNoFirewall,
 	})
 }
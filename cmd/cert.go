@@ -0,0 +1,275 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var certCmd = &cobra.Command{
+	Use:     "cert",
+	Aliases: []string{"certs"},
+	Short:   "Manage CA certificates containers trust",
+	Long: `Manage the CA certificates maestro installs in new containers, for
+corporate proxies and internal CAs that aren't in the host's bundle.
+
+Certificates are kept in ssl.certificates_path (~/.maestro/certificates by
+default) and added to each new container's system trust store, the bundle
+SSL_CERT_FILE and friends point at, and the Java keystore. Recreate running
+containers to pick up changes.`,
+}
+
+var certAddCmd = &cobra.Command{
+	Use:   "add <file>",
+	Short: "Add a PEM certificate",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCertAdd,
+}
+
+var certListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the certificates with their subject and expiry",
+	Args:  cobra.NoArgs,
+	RunE:  runCertList,
+}
+
+var certRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a certificate (the extension may be left out)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCertRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(certCmd)
+	certCmd.AddCommand(certAddCmd)
+	certCmd.AddCommand(certListCmd)
+	certCmd.AddCommand(certRemoveCmd)
+}
+
+// hostCABundle is the host's CA bundle. With ssl.mount_host_certs, the
+// host's /etc/ssl/certs replaces the container's when the host has one.
+const hostCABundle = "/etc/ssl/certs/ca-certificates.crt"
+
+// mountHostCerts reports whether containers get the host's /etc/ssl/certs.
+func mountHostCerts() bool {
+	if !config.SSL.MountHostCerts {
+		return false
+	}
+	_, err := os.Stat(hostCABundle)
+	return err == nil
+}
+
+// oneOffCertDir and oneOffCABundle are where a container started by
+// caCertArgs finds ssl.certificates_path and builds its CA bundle.
+const (
+	oneOffCertDir  = "/etc/ssl/maestro/certs"
+	oneOffCABundle = "/tmp/maestro-ca-certificates.crt"
+)
+
+// caCertArgs returns the docker run options giving a container that runs a
+// single command (the auth flows) the host's CA certificates, with
+// ssl.mount_host_certs, and those in ssl.certificates_path, pointing envs at
+// the bundle. There is no setup step to install custom certificates in, so
+// wrap, when set, goes before the command: it builds the bundle and execs it.
+func caCertArgs(envs ...string) (opts, wrap []string) {
+	bundle := ""
+	if mountHostCerts() {
+		opts = append(opts, "-v", "/etc/ssl/certs:/etc/ssl/certs:ro")
+		bundle = hostCABundle
+	}
+	certsPath := expandPath(config.SSL.CertificatesPath)
+	if certs, _ := listCertFiles(certsPath); len(certs) > 0 {
+		opts = append(opts, "-v", certsPath+":"+oneOffCertDir+":ro")
+		bundle = oneOffCABundle
+		script := fmt.Sprintf(`{ cat %s; for f in %s/*; do case "$f" in *.[cC][rR][tT]|*.[pP][eE][mM]) cat "$f"; echo;; esac; done; } > %s 2>/dev/null; exec "$@"`,
+			hostCABundle, oneOffCertDir, oneOffCABundle)
+		wrap = []string{"sh", "-c", script, "sh"}
+	}
+	if bundle == "" {
+		return nil, nil
+	}
+	for _, env := range envs {
+		opts = append(opts, "-e", env+"="+bundle)
+	}
+	return opts, wrap
+}
+
+// isCertFile reports whether a file name has an extension maestro installs.
+func isCertFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".crt" || ext == ".pem"
+}
+
+// listCertFiles returns the names of the certificate files in dir, sorted.
+// A missing directory has none.
+func listCertFiles(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read certificates directory: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isCertFile(entry.Name()) {
+			files = append(files, entry.Name())
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// parseCertificate returns the first certificate in PEM data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate: %w", err)
+			}
+			return cert, nil
+		}
+	}
+}
+
+// certSummary describes a certificate as "<subject>, expires <date>".
+func certSummary(cert *x509.Certificate) string {
+	subject := cert.Subject.CommonName
+	if subject == "" {
+		subject = cert.Subject.String()
+	}
+	return fmt.Sprintf("%s, expires %s", subject, cert.NotAfter.Format("2006-01-02"))
+}
+
+// addCert copies the PEM certificate at src into dir, keeping its file name
+// (with .crt added unless it ends in .crt or .pem), and returns the new name.
+func addCert(dir, src string) (string, *x509.Certificate, error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+	cert, err := parseCertificate(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", src, err)
+	}
+
+	name := filepath.Base(src)
+	if !isCertFile(name) {
+		name += ".crt"
+	}
+	dest := filepath.Join(dir, name)
+	if _, err := os.Stat(dest); err == nil {
+		return "", nil, fmt.Errorf("%s already exists; remove it first with 'maestro cert remove %s'", name, name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("failed to create certificates directory: %w", err)
+	}
+	if err := os.WriteFile(dest, data, 0644); err != nil {
+		return "", nil, fmt.Errorf("failed to write certificate: %w", err)
+	}
+	return name, cert, nil
+}
+
+// resolveCertName finds the certificate file in dir called name, with or
+// without its extension.
+func resolveCertName(dir, name string) (string, error) {
+	files, err := listCertFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if f == name || strings.TrimSuffix(f, filepath.Ext(f)) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("no certificate %q in %s", name, dir)
+}
+
+func runCertAdd(cmd *cobra.Command, args []string) error {
+	dir := expandPath(config.SSL.CertificatesPath)
+	name, cert, err := addCert(dir, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Added %s (%s)\n", style.Check(), name, certSummary(cert))
+	fmt.Println("New containers trust it; recreate running containers to pick it up.")
+	return nil
+}
+
+func runCertList(cmd *cobra.Command, args []string) error {
+	dir := expandPath(config.SSL.CertificatesPath)
+	files, err := listCertFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Printf("No certificates in %s.\n", dir)
+		fmt.Println("\nAdd one with: maestro cert add <file>")
+		return nil
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			fmt.Printf("%s  %s: %v\n", style.Cross(), f, err)
+			continue
+		}
+		cert, err := parseCertificate(data)
+		if err != nil {
+			fmt.Printf("%s  %s: %v\n", style.Cross(), f, err)
+			continue
+		}
+		mark := style.Check()
+		if time.Now().After(cert.NotAfter) {
+			mark = style.Warning() + " expired"
+		}
+		fmt.Printf("%s  %s (%s)\n", f, certSummary(cert), mark)
+	}
+	return nil
+}
+
+func runCertRemove(cmd *cobra.Command, args []string) error {
+	dir := expandPath(config.SSL.CertificatesPath)
+	name, err := resolveCertName(dir, args[0])
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to remove certificate: %w", err)
+	}
+	fmt.Printf("%s Removed %s\n", style.Check(), name)
+	fmt.Println("Containers created from now on no longer trust it; existing ones keep it until recreated.")
+	return nil
+}
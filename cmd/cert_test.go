@@ -0,0 +1,134 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed PEM certificate for cn to path.
+func writeTestCert(t *testing.T, path, cn string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddCert(t *testing.T) {
+	src := t.TempDir()
+	dir := filepath.Join(t.TempDir(), "certificates")
+
+	writeTestCert(t, filepath.Join(src, "corp-ca"), "Corp Root CA")
+	name, cert, err := addCert(dir, filepath.Join(src, "corp-ca"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "corp-ca.crt" || cert.Subject.CommonName != "Corp Root CA" {
+		t.Errorf("addCert = %q, %q; want corp-ca.crt, Corp Root CA", name, cert.Subject.CommonName)
+	}
+	if _, _, err := addCert(dir, filepath.Join(src, "corp-ca")); err == nil {
+		t.Error("adding the same certificate twice should fail")
+	}
+
+	notCert := filepath.Join(src, "notes.pem")
+	if err := os.WriteFile(notCert, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := addCert(dir, notCert); err == nil {
+		t.Error("a file without a PEM certificate should be rejected")
+	}
+
+	writeTestCert(t, filepath.Join(src, "proxy.pem"), "Proxy CA")
+	if _, _, err := addCert(dir, filepath.Join(src, "proxy.pem")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files, err := listCertFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0] != "corp-ca.crt" || files[1] != "proxy.pem" {
+		t.Errorf("listCertFiles = %v, want [corp-ca.crt proxy.pem]", files)
+	}
+
+	if got, err := resolveCertName(dir, "proxy"); err != nil || got != "proxy.pem" {
+		t.Errorf("resolveCertName(proxy) = %q, %v", got, err)
+	}
+	if _, err := resolveCertName(dir, "missing"); err == nil {
+		t.Error("resolveCertName should fail for an unknown certificate")
+	}
+}
+
+func TestListCertFiles_MissingDir(t *testing.T) {
+	files, err := listCertFiles(filepath.Join(t.TempDir(), "none"))
+	if err != nil || len(files) != 0 {
+		t.Errorf("listCertFiles of a missing dir = %v, %v; want none", files, err)
+	}
+}
+
+func TestCACertArgs(t *testing.T) {
+	saved := config
+	defer func() { config = saved }()
+	dir := t.TempDir()
+	config = &Config{}
+	config.SSL.CertificatesPath = dir
+
+	if opts, wrap := caCertArgs("SSL_CERT_FILE"); opts != nil || wrap != nil {
+		t.Errorf("no host certs and no custom certs should add nothing, got %v %v", opts, wrap)
+	}
+
+	writeTestCert(t, filepath.Join(dir, "corp.pem"), "Corp Root CA")
+	opts, wrap := caCertArgs("SSL_CERT_FILE", "CURL_CA_BUNDLE")
+	want := []string{
+		"-v", dir + ":" + oneOffCertDir + ":ro",
+		"-e", "SSL_CERT_FILE=" + oneOffCABundle,
+		"-e", "CURL_CA_BUNDLE=" + oneOffCABundle,
+	}
+	if !slices.Equal(opts, want) {
+		t.Errorf("opts = %v, want %v", opts, want)
+	}
+	if len(wrap) != 4 || wrap[0] != "sh" || !strings.Contains(wrap[2], oneOffCABundle) {
+		t.Errorf("wrap = %v, want a sh -c script building %s", wrap, oneOffCABundle)
+	}
+}
@@ -18,10 +18,12 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
 var forceVolumeCleanup bool
@@ -40,7 +42,7 @@ func init() {
 
 func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	// Get all Maestro volumes
-	volumeCmd := exec.Command("docker", "volume", "ls", "--format", "{{.Name}}")
+	volumeCmd := logging.Command("docker", "volume", "ls", "--format", "{{.Name}}")
 	volumeOutput, err := volumeCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list volumes: %w", err)
@@ -49,6 +51,10 @@ func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	var matchingVolumes []string
 	prefix := config.Containers.Prefix
 	for _, line := range strings.Split(string(volumeOutput), "\n") {
+		// Shared volumes belong to no container
+		if strings.HasPrefix(line, container.SharedVolumePrefix) {
+			continue
+		}
 		if strings.HasPrefix(line, prefix) {
 			matchingVolumes = append(matchingVolumes, line)
 		}
@@ -60,7 +66,7 @@ func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get all Maestro containers (including stopped)
-	containerCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", prefix), "--format", "{{.Names}}")
+	containerCmd := logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", prefix), "--format", "{{.Names}}")
 	containerOutput, err := containerCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
@@ -119,9 +125,9 @@ func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	// Remove orphaned volumes
 	removed := 0
 	for _, vol := range orphaned {
-		volCmd := exec.Command("docker", "volume", "rm", vol)
-		if err := volCmd.Run(); err != nil {
-			fmt.Printf("Warning: failed to remove %s: %v\n", vol, err)
+		volCmd := logging.Command("docker", "volume", "rm", vol)
+		if err := logging.Run(volCmd); err != nil {
+			logging.Warnf("failed to remove %s: %v", vol, err)
 		} else {
 			removed++
 		}
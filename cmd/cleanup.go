@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -31,13 +32,19 @@ var (
 	forceCleanup   bool
 	cleanupAll     bool
 	cleanupTimeout int
+	cleanupVolumes bool
 )
 
 var cleanupCmd = &cobra.Command{
 	Use:   "cleanup",
 	Short: "Remove stopped containers",
-	Long:  `Remove stopped Maestro containers and their associated volumes.`,
-	RunE:  runCleanup,
+	Long: `Remove stopped Maestro containers.
+
+Each container's npm, uv and shell history cache volumes are kept so the next
+container on the same branch starts with warm caches. Pass --volumes to remove
+them too, or run 'maestro cleanup-volumes' later to remove the volumes of
+containers that no longer exist.`,
+	RunE: runCleanup,
 }
 
 func init() {
@@ -45,6 +52,7 @@ func init() {
 	cleanupCmd.Flags().BoolVarP(&forceCleanup, "force", "f", false, "Skip confirmation")
 	cleanupCmd.Flags().BoolVarP(&cleanupAll, "all", "a", false, "Remove all containers (including running)")
 	cleanupCmd.Flags().IntVar(&cleanupTimeout, "timeout", 0, "Per-container timeout in seconds (0 = no timeout)")
+	cleanupCmd.Flags().BoolVar(&cleanupVolumes, "volumes", false, "Also remove the containers' npm/uv/history cache volumes")
 }
 
 func runCleanup(cmd *cobra.Command, args []string) error {
@@ -109,42 +117,36 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 	total := len(toRemove)
 	fmt.Printf("\nRemoving %d container(s)...\n", total)
 
-	var removed []string
-	var errors []string
-	totalVolumes := 0
-
-	for i, name := range toRemove {
-		fmt.Printf("  [%d/%d] Removing %s...", i+1, total, name)
-
-		// Use state hash only for the first container to detect stale state,
-		// then skip validation for the rest (we've already confirmed the list).
-		hash := ""
-		if i == 0 {
-			hash = stateHash
-		}
-
-		ctx := cmd.Context()
-		var cancel context.CancelFunc
+	var (
+		mu           sync.Mutex
+		done         int
+		removed      []string
+		errors       []string
+		totalVolumes int
+	)
+
+	// removeOne removes a container and reports it on one line, so
+	// containers removed in parallel don't interleave their output
+	removeOne := func(ctx context.Context, name, hash string) error {
 		if cleanupTimeout > 0 {
+			var cancel context.CancelFunc
 			ctx, cancel = context.WithTimeout(ctx, time.Duration(cleanupTimeout)*time.Second)
+			defer cancel()
 		}
 
 		result, err := svc.CleanupContainers(ctx, []string{name}, hash, &containerservice.CleanupOptions{
-			SkipRefresh: true, // refresh once at end, not per container
+			SkipRefresh:   true, // refresh once at end, not per container
+			RemoveVolumes: cleanupVolumes,
 		})
 
-		if cancel != nil {
-			cancel()
-		}
-
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		fmt.Printf("  [%d/%d] Removing %s...", done, total, name)
 		if err != nil {
-			if i == 0 && isStateHashMismatch(err) {
-				fmt.Println(" failed")
-				return fmt.Errorf("container state changed since listing — please re-run 'maestro cleanup'")
-			}
 			fmt.Printf(" error: %v\n", err)
 			errors = append(errors, fmt.Sprintf("failed to remove %s: %v", name, err))
-			continue
+			return err
 		}
 
 		if len(result.Removed) > 0 {
@@ -162,7 +164,18 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 			fmt.Printf("    warning: %s\n", e)
 			errors = append(errors, e)
 		}
+		return nil
+	}
+
+	// Use the state hash only for the first container to detect stale state,
+	// then skip validation for the rest (we've already confirmed the list),
+	// removing them a few at a time.
+	if err := removeOne(cmd.Context(), toRemove[0], stateHash); err != nil && isStateHashMismatch(err) {
+		return fmt.Errorf("container state changed since listing — please re-run 'maestro cleanup'")
 	}
+	container.Bulk(cmd.Context(), toRemove[1:], container.DefaultBulkConcurrency, func(ctx context.Context, name string) error {
+		return removeOne(ctx, name, "")
+	})
 
 	// Remove any expose sidecars associated with the cleaned-up containers
 	if len(removed) > 0 {
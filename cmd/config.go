@@ -0,0 +1,255 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/settings"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+	"github.com/uprockcom/maestro/pkg/tui/views"
+)
+
+var configInitForce bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the maestro config file",
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a fully commented default config file",
+	Long: `Write a config.yml listing every supported key with its default value and
+a comment explaining it. Optional keys without a default are included
+commented out.
+
+The file goes to ~/.maestro/config.yml (or the path given with --config).
+An existing file is left alone unless --force is passed.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigInit,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for problems",
+	Long: `Check the loaded config for invalid values and risky settings.
+
+It first lists the config files that were loaded: the global
+~/.maestro/config.yml and, if the working directory has one, a project
+.maestro.yml merged over it.
+
+Errors are values maestro can't use; it exits non-zero when there are any.
+Warnings are valid settings worth knowing about, such as host networking
+bypassing the firewall.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "Overwrite an existing config file")
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	path := paths.ConfigFile()
+	if cfgFile != "" {
+		path = cfgFile
+	}
+
+	if err := settings.WriteDefault(path, configInitForce); err != nil {
+		if errors.Is(err, settings.ErrConfigExists) {
+			return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+		}
+		return err
+	}
+	fmt.Printf("%s Wrote default config to %s\n", style.Check(), path)
+	return nil
+}
+
+// configProblem is something 'maestro config validate' reports about a key.
+type configProblem struct {
+	key     string
+	message string
+	warning bool // Valid but worth knowing about; errors are unusable values
+}
+
+// validateConfig checks the settings maestro would otherwise only complain
+// about (or silently replace) when it uses them.
+func validateConfig(c *Config) []configProblem {
+	var problems []configProblem
+	if prefix := c.Containers.Prefix; prefix != "" {
+		if err := container.ValidatePrefix(prefix); err != nil {
+			problems = append(problems, configProblem{key: "containers.prefix", message: err.Error()})
+		}
+	}
+	mode := c.Containers.NetworkMode
+	if err := container.ValidateNetworkMode(mode); err != nil {
+		problems = append(problems, configProblem{key: "containers.network_mode", message: err.Error()})
+	} else if mode == container.NetworkHost {
+		problems = append(problems, configProblem{
+			key:     "containers.network_mode",
+			message: "host networking bypasses the firewall; containers get the host's unrestricted network access",
+			warning: true,
+		})
+	}
+	if err := container.ValidateVolumeDriver(c.Containers.VolumeDriver); err != nil {
+		problems = append(problems, configProblem{key: "containers.volume_driver", message: err.Error()})
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.Containers.VolumeOpts)) {
+		if key == "" || strings.ContainsAny(key, "=,") {
+			problems = append(problems, configProblem{key: "containers.volume_opts", message: fmt.Sprintf("invalid option name %q", key)})
+		}
+	}
+	for _, spec := range c.Containers.SharedVolumes {
+		if _, err := container.ParseSharedVolume(expandPath(spec)); err != nil {
+			problems = append(problems, configProblem{key: "containers.shared_volumes", message: err.Error()})
+		}
+	}
+	for _, spec := range c.Containers.Ulimits {
+		name, err := container.ParseUlimit(spec)
+		if err != nil {
+			problems = append(problems, configProblem{key: "containers.ulimits", message: err.Error() + " (it is skipped)"})
+		} else if name == "memlock" {
+			problems = append(problems, configProblem{key: "containers.ulimits", message: memlockWarning, warning: true})
+		}
+	}
+	for _, opt := range c.Containers.SecurityOpts {
+		if _, err := resolveSecurityOpt(opt); err != nil {
+			problems = append(problems, configProblem{key: "containers.security_opts", message: err.Error()})
+		}
+	}
+	if c.Containers.MaxContextTokens < 0 {
+		problems = append(problems, configProblem{key: "containers.max_context_tokens", message: fmt.Sprintf("must be 0 (no limit) or more, got %d", c.Containers.MaxContextTokens)})
+	}
+	if limit := c.Daemon.Notifications.RateLimit; limit != "" {
+		if _, err := time.ParseDuration(limit); err != nil {
+			problems = append(problems, configProblem{key: "daemon.notifications.rate_limit", message: fmt.Sprintf("invalid duration %q; 30m is used instead", limit)})
+		}
+	}
+	for _, notifyType := range slices.Sorted(maps.Keys(c.Daemon.Notifications.RateLimits)) {
+		limit := c.Daemon.Notifications.RateLimits[notifyType]
+		if _, err := time.ParseDuration(limit); err != nil {
+			problems = append(problems, configProblem{key: "daemon.notifications.rate_limits." + notifyType, message: fmt.Sprintf("invalid duration %q; rate_limit is used instead", limit)})
+		}
+	}
+	problems = append(problems, fallbackProblems(c)...)
+	switch c.TUI.Color {
+	case "", "auto", "always", "never":
+	default:
+		problems = append(problems, configProblem{key: "tui.color", message: fmt.Sprintf("invalid value %q; use auto, always or never (auto is used instead)", c.TUI.Color)})
+	}
+	switch c.TUI.HomeOrder {
+	case "", "default", "recent", "git":
+	default:
+		problems = append(problems, configProblem{key: "tui.home_order", message: fmt.Sprintf("invalid value %q; use default, recent or git (default is used instead)", c.TUI.HomeOrder)})
+	}
+	if ttl := c.TUI.CacheTTL; ttl != "" {
+		if d, err := time.ParseDuration(ttl); err != nil || d < 0 {
+			problems = append(problems, configProblem{key: "tui.cache_ttl", message: fmt.Sprintf("invalid duration %q; 24h is used instead", ttl)})
+		}
+	}
+	if _, err := container.TmuxWindowIndex(c.TUI.ConnectWindow); err != nil {
+		problems = append(problems, configProblem{key: "tui.connect_window", message: err.Error() + " (the last active window is used instead)"})
+	}
+	for _, col := range c.TUI.Columns {
+		if !slices.Contains(views.ColumnNames(), strings.ToLower(strings.TrimSpace(col))) {
+			problems = append(problems, configProblem{key: "tui.columns", message: fmt.Sprintf("unknown column %q; use %s (it is left out)", col, strings.Join(views.ColumnNames(), ", "))})
+		}
+	}
+	return problems
+}
+
+// fallbackProblems reports the values workspaceDir, containerShell and
+// tmuxSessionName replace with a default. initConfig warns about them once,
+// so the getters can stay quiet.
+func fallbackProblems(c *Config) []configProblem {
+	var problems []configProblem
+	if ws := c.Containers.Workspace; ws != "" && !container.ValidWorkspacePath(ws) {
+		problems = append(problems, configProblem{key: "containers.workspace", message: fmt.Sprintf("invalid path %q; %s is used instead", ws, container.DefaultWorkspace)})
+	}
+	if shell := c.Containers.Shell; shell != "" && !container.SupportedShell(shell) {
+		problems = append(problems, configProblem{key: "containers.shell", message: fmt.Sprintf("unsupported shell %q; sh is used instead", shell)})
+	}
+	if session := c.Tmux.DefaultSession; session != "" && !container.ValidTmuxSessionName(session) {
+		problems = append(problems, configProblem{key: "tmux.default_session", message: fmt.Sprintf("invalid session name %q; %s is used instead", session, container.DefaultTmuxSession)})
+	}
+	return problems
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	printLoadedConfigFiles()
+	problems := append(validateConfig(config), projectConfigProblems(loadedProjectConfig)...)
+	if len(problems) == 0 {
+		fmt.Println(style.Check() + " No problems found")
+		return nil
+	}
+	errCount := 0
+	for _, p := range problems {
+		if p.warning {
+			fmt.Printf("%s  %s: %s\n", style.Warning(), p.key, p.message)
+		} else {
+			errCount++
+			fmt.Printf("%s %s: %s\n", style.Cross(), p.key, p.message)
+		}
+	}
+	if errCount > 0 {
+		return fmt.Errorf("%d config error(s)", errCount)
+	}
+	return nil
+}
+
+// printLoadedConfigFiles lists the config files the running config came from.
+func printLoadedConfigFiles() {
+	global := "none (defaults)"
+	if file := viper.ConfigFileUsed(); file != "" {
+		if _, err := os.Stat(file); err == nil {
+			global = file
+		}
+	}
+	fmt.Printf("Global config:  %s\n", global)
+	if loadedProjectConfig.Path != "" {
+		fmt.Printf("Project config: %s (%d setting(s) applied)\n", loadedProjectConfig.Path, len(loadedProjectConfig.Keys))
+	} else {
+		fmt.Printf("Project config: none\n")
+	}
+	fmt.Println()
+}
+
+// projectConfigProblems warns about settings a project config file tried to
+// set that only the global config may.
+func projectConfigProblems(overlay projectOverlay) []configProblem {
+	var problems []configProblem
+	for _, key := range overlay.Ignored {
+		problems = append(problems, configProblem{
+			key:     projectConfigFile + ": " + key,
+			message: "ignored; only ~/.maestro/config.yml can set this",
+			warning: true,
+		})
+	}
+	return problems
+}
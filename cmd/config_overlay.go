@@ -0,0 +1,146 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+// projectConfigFile is the per-project config file read from the working
+// directory and merged over the global config.
+const projectConfigFile = ".maestro.yml"
+
+// projectConfigKeys are the settings a project config file may override.
+// Anything else, like credentials, the image, host folders to sync or host
+// networking, could let a cloned repository reach the host or weaken every
+// container created from it, so only the global config can set it. The
+// firewall's DNS servers and internal domains stay global for the same
+// reason; the domains a project adds are printed by 'maestro new'.
+var projectConfigKeys = []string{
+	"containers.resources",
+	"containers.ulimits",
+	"containers.default_model",
+	"containers.init_commands",
+	"containers.shell",
+	"containers.workspace",
+	"firewall.allowed_domains",
+	"sync.compress",
+	"tmux",
+	"git",
+	"web.enabled",
+	"web.shm_size",
+}
+
+// projectOverlay describes the project config file merged over the global
+// config.
+type projectOverlay struct {
+	Path    string   // File that was merged, "" if there was none
+	Keys    []string // Keys it sets
+	Ignored []string // Keys it sets that only the global config may
+	Domains []string // Firewall domains it allows that the global config doesn't
+}
+
+// loadedProjectConfig is the overlay initConfig merged, for 'maestro config
+// validate'.
+var loadedProjectConfig projectOverlay
+
+// projectConfigAllowed reports whether a project config file may set key.
+func projectConfigAllowed(key string) bool {
+	return slices.ContainsFunc(projectConfigKeys, func(prefix string) bool {
+		return key == prefix || strings.HasPrefix(key, prefix+".")
+	})
+}
+
+// mergeProjectConfig merges dir/.maestro.yml, if there is one, over the
+// config already read into v. Values from the project file win; keys it may
+// not set are left out and reported in Ignored.
+func mergeProjectConfig(v *viper.Viper, dir string) (projectOverlay, error) {
+	var overlay projectOverlay
+	path := filepath.Join(dir, projectConfigFile)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return overlay, nil
+		}
+		return overlay, err
+	}
+
+	pv := viper.New()
+	pv.SetConfigFile(path)
+	pv.SetConfigType("yaml")
+	if err := pv.ReadInConfig(); err != nil {
+		return overlay, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	values := map[string]any{}
+	for _, key := range pv.AllKeys() {
+		if !projectConfigAllowed(key) {
+			overlay.Ignored = append(overlay.Ignored, key)
+			continue
+		}
+		overlay.Keys = append(overlay.Keys, key)
+		setNested(values, strings.Split(key, "."), pv.Get(key))
+	}
+	slices.Sort(overlay.Keys)
+	slices.Sort(overlay.Ignored)
+
+	// Merging replaces lists, but project domains add to the global ones
+	if pv.IsSet("firewall.allowed_domains") {
+		global := v.GetStringSlice("firewall.allowed_domains")
+		for _, domain := range pv.GetStringSlice("firewall.allowed_domains") {
+			if !slices.Contains(global, domain) && !slices.Contains(overlay.Domains, domain) {
+				overlay.Domains = append(overlay.Domains, domain)
+			}
+		}
+		setNested(values, []string{"firewall", "allowed_domains"}, append(slices.Clone(global), overlay.Domains...))
+	}
+
+	if err := v.MergeConfigMap(values); err != nil {
+		return overlay, fmt.Errorf("failed to merge %s: %w", path, err)
+	}
+	overlay.Path = path
+	return overlay, nil
+}
+
+// printProjectDomains lists the firewall domains the project config file
+// allows on top of the global config, so a cloned repository can't widen the
+// firewall unnoticed.
+func printProjectDomains() {
+	if len(loadedProjectConfig.Domains) == 0 {
+		return
+	}
+	fmt.Printf("%s  %s allows extra firewall domains: %s\n", style.Warning(), loadedProjectConfig.Path, strings.Join(loadedProjectConfig.Domains, ", "))
+}
+
+// setNested sets m[path[0]][path[1]]... = value, creating maps as needed.
+func setNested(m map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[key] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
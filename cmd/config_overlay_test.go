@@ -0,0 +1,129 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMergeProjectConfig(t *testing.T) {
+	global := `containers:
+  image: maestro:global
+  default_model: sonnet
+  resources:
+    memory: 4g
+    cpus: "2"
+claude:
+  auth_path: ~/.maestro/claude
+`
+	project := `containers:
+  image: evil:latest
+  default_model: opus
+  resources:
+    memory: 8g
+claude:
+  auth_path: /tmp/stolen
+`
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(global)); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, projectConfigFile), []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	overlay, err := mergeProjectConfig(v, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overlay.Path != filepath.Join(dir, projectConfigFile) {
+		t.Errorf("Path = %q", overlay.Path)
+	}
+	if want := []string{"containers.default_model", "containers.resources.memory"}; !slices.Equal(overlay.Keys, want) {
+		t.Errorf("Keys = %v, want %v", overlay.Keys, want)
+	}
+	if want := []string{"claude.auth_path", "containers.image"}; !slices.Equal(overlay.Ignored, want) {
+		t.Errorf("Ignored = %v, want %v", overlay.Ignored, want)
+	}
+
+	for key, want := range map[string]string{
+		"containers.default_model":    "opus",
+		"containers.resources.memory": "8g",
+		"containers.resources.cpus":   "2",
+		"containers.image":            "maestro:global",
+		"claude.auth_path":            "~/.maestro/claude",
+	} {
+		if got := v.GetString(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestMergeProjectConfig_NoFile(t *testing.T) {
+	v := viper.New()
+	v.Set("containers.default_model", "sonnet")
+	overlay, err := mergeProjectConfig(v, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overlay.Path != "" || len(overlay.Keys) != 0 {
+		t.Errorf("overlay = %+v, want empty", overlay)
+	}
+	if got := v.GetString("containers.default_model"); got != "sonnet" {
+		t.Errorf("default_model = %q", got)
+	}
+}
+
+func TestMergeProjectConfig_FirewallDomainsOnly(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader("firewall:\n  allowed_domains: [github.com, pypi.org]\n")); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	project := `firewall:
+  allowed_domains: [github.com, pastebin.com]
+  internal_dns: 203.0.113.5
+  internal_domains: [corp.example]
+`
+	if err := os.WriteFile(filepath.Join(dir, projectConfigFile), []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	overlay, err := mergeProjectConfig(v, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"firewall.internal_dns", "firewall.internal_domains"}; !slices.Equal(overlay.Ignored, want) {
+		t.Errorf("Ignored = %v, want %v", overlay.Ignored, want)
+	}
+	if want := []string{"pastebin.com"}; !slices.Equal(overlay.Domains, want) {
+		t.Errorf("Domains = %v, want the ones the global config lacks %v", overlay.Domains, want)
+	}
+	if want := []string{"github.com", "pypi.org", "pastebin.com"}; !slices.Equal(v.GetStringSlice("firewall.allowed_domains"), want) {
+		t.Errorf("allowed_domains = %v, want the project's added to the global list %v", v.GetStringSlice("firewall.allowed_domains"), want)
+	}
+	if v.IsSet("firewall.internal_dns") {
+		t.Error("a project file should not set the firewall's DNS server")
+	}
+}
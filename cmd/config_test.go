@@ -0,0 +1,109 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	var c Config
+	if problems := validateConfig(&c); len(problems) != 0 {
+		t.Errorf("defaults should be valid, got %v", problems)
+	}
+
+	c.Containers.Prefix = "my box-"
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "containers.prefix" {
+		t.Errorf("want a containers.prefix error, got %v", problems)
+	}
+	c.Containers.Prefix = ""
+
+	c.Containers.NetworkMode = "host"
+	problems := validateConfig(&c)
+	if len(problems) != 1 || !problems[0].warning || problems[0].key != "containers.network_mode" {
+		t.Errorf("host networking should be a warning, got %v", problems)
+	}
+
+	c.Containers.NetworkMode = "my net"
+	c.Containers.Shell = "fish"
+	problems = validateConfig(&c)
+	if len(problems) != 2 || problems[0].warning || problems[1].warning {
+		t.Errorf("want network_mode and shell errors, got %v", problems)
+	}
+
+	c = Config{}
+	c.Containers.VolumeDriver = "nfs driver"
+	c.Containers.VolumeOpts = map[string]string{"type": "nfs", "o=addr": "10.0.0.1"}
+	problems = validateConfig(&c)
+	if len(problems) != 2 || problems[0].key != "containers.volume_driver" || problems[1].key != "containers.volume_opts" {
+		t.Errorf("want volume_driver and volume_opts errors, got %v", problems)
+	}
+
+	c = Config{}
+	c.Containers.SharedVolumes = []string{"~/.cargo:/home/node/.cargo", "~/.gradle:gradle"}
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "containers.shared_volumes" {
+		t.Errorf("want one containers.shared_volumes error, got %v", problems)
+	}
+
+	c = Config{}
+	c.Containers.Ulimits = []string{"nofile=65536:65536", "nofile=lots", "memlock=-1"}
+	problems = validateConfig(&c)
+	if len(problems) != 2 || problems[0].warning || !problems[1].warning || problems[1].key != "containers.ulimits" {
+		t.Errorf("want a containers.ulimits error and a memlock warning, got %v", problems)
+	}
+
+	profile := filepath.Join(t.TempDir(), "seccomp.json")
+	if err := os.WriteFile(profile, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c = Config{}
+	c.Containers.SecurityOpts = []string{"seccomp=" + profile, "no-new-privileges", "seccomp=/nonexistent/profile.json", "privileged"}
+	problems = validateConfig(&c)
+	if len(problems) != 2 || problems[0].key != "containers.security_opts" || problems[1].key != "containers.security_opts" {
+		t.Errorf("want errors for the missing profile and the unknown option, got %v", problems)
+	}
+
+	c = Config{}
+	c.Containers.MaxContextTokens = -1
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "containers.max_context_tokens" {
+		t.Errorf("want a containers.max_context_tokens error, got %v", problems)
+	}
+
+	c = Config{}
+	c.TUI.Color = "sometimes"
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "tui.color" {
+		t.Errorf("want a tui.color error, got %v", problems)
+	}
+
+	c = Config{}
+	c.TUI.HomeOrder = "newest"
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "tui.home_order" {
+		t.Errorf("want a tui.home_order error, got %v", problems)
+	}
+
+	c = Config{}
+	c.TUI.ConnectWindow = "editor"
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "tui.connect_window" {
+		t.Errorf("want a tui.connect_window error, got %v", problems)
+	}
+
+	c = Config{}
+	c.TUI.Columns = []string{"name", "Uptime", "size"}
+	if problems := validateConfig(&c); len(problems) != 1 || problems[0].key != "tui.columns" {
+		t.Errorf("want one tui.columns error, got %v", problems)
+	}
+}
@@ -18,12 +18,14 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/tui"
 )
 
 var connectCmd = &cobra.Command{
@@ -33,18 +35,49 @@ var connectCmd = &cobra.Command{
 
 If no name is provided:
   - Auto-connects if only one container is running
-  - Shows interactive selection if multiple containers are running`,
+  - Shows a fuzzy picker if multiple containers are running (type to
+    filter, arrows to select, Enter to connect), or a numbered prompt when
+    not run from a terminal
+
+tmux attaches to whichever window was active last. --window picks one:
+claude (window 0), shell (window 1) or a window number; --shell is short for
+--window shell.
+
+If the container's tmux session has died (the tmux server was killed or ran
+out of memory), connect offers to recreate it: Claude in window 0, optionally
+re-prompted with the container's task, and a shell in window 1. --repair
+recreates it with Claude without asking.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConnect,
 }
 
+var (
+	connectWindow string
+	connectShell  bool
+	connectRepair bool
+)
+
 func init() {
 	rootCmd.AddCommand(connectCmd)
+	connectCmd.Flags().StringVar(&connectWindow, "window", "", "tmux window to open: claude, shell or a window number")
+	connectCmd.Flags().BoolVar(&connectShell, "shell", false, "Open the shell window (--window shell)")
+	connectCmd.Flags().BoolVar(&connectRepair, "repair", false, "Recreate a missing tmux session with Claude without asking")
 }
 
 func runConnect(cmd *cobra.Command, args []string) error {
 	var containerName string
 
+	window := connectWindow
+	if connectShell {
+		if window != "" && window != "shell" {
+			return fmt.Errorf("--shell and --window %s disagree", window)
+		}
+		window = "shell"
+	}
+	if _, err := container.TmuxWindowIndex(window); err != nil {
+		return err
+	}
+
 	// If no argument provided, show interactive selection
 	if len(args) == 0 {
 		svc := newContainerService()
@@ -70,8 +103,18 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			// Auto-connect to the only container
 			containerName = containers[0].Name
 			fmt.Printf("Auto-connecting to %s\n", containers[0].ShortName)
+		} else if term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd()) {
+			// Multiple containers - fuzzy picker
+			selected, ok, err := tui.PickContainer("Connect to a container", container.SortByPriority(containers))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("cancelled - no container selected")
+			}
+			containerName = selected.Name
 		} else {
-			// Multiple containers - show selection
+			// Multiple containers, no terminal - numbered prompt
 			selected, err := selectContainer(containers)
 			if err != nil {
 				return err
@@ -89,7 +132,7 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		}
 
 		// Check if container exists (include stopped containers with -a)
-		checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", containerName), "--format", "{{.State}}")
+		checkCmd := logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", containerName), "--format", "{{.State}}")
 		output, err := checkCmd.Output()
 		if err != nil {
 			return fmt.Errorf("failed to check container status: %w", err)
@@ -120,25 +163,134 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	return syncAndConnect(containerName, window)
+}
+
+// syncAndConnect refreshes the container's credentials and connects to it,
+// on the given tmux window ("" for the last active one).
+func syncAndConnect(containerName, window string) error {
 	// Ensure container has fresh token before connecting
 	fmt.Printf("Syncing credentials for %s...\n", containerName)
 	if err := container.EnsureFreshToken(containerName, config.Containers.Prefix); err != nil {
 		// Warn but don't fail - user might want to connect anyway
-		fmt.Printf("Warning: Token sync: %v\n", err)
+		logging.Warnf("Token sync: %v", err)
 		fmt.Println("   You may need to run 'maestro auth' if authentication fails.")
 	}
 
 	fmt.Printf("Connecting to %s...\n", containerName)
-	fmt.Println("Detach with: Ctrl+b d")
-	fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
+	return connectContainer(containerName, window)
+}
+
+// connectContainer attaches the terminal to Claude in the container: through
+// tmux normally, on window if one is given, or by running Claude directly
+// for --no-tmux containers.
+func connectContainer(containerName, window string) error {
+	if err := container.RecordConnect(containerName); err != nil {
+		logging.Debugf("Failed to record connect: %v", err)
+	}
+	if !container.UsesTmux(containerName) {
+		if window != "" {
+			return fmt.Errorf("%s runs Claude without tmux, so it has no windows to choose from", containerName)
+		}
+		fmt.Println("Claude runs without tmux in this container; exiting Claude disconnects.")
+		return runDirectClaude(containerName)
+	}
+	printTmuxHints()
+	return attachTmuxSession(containerName, window)
+}
+
+// runDirectClaude runs Claude interactively via docker exec using the
+// launcher installed by 'maestro new --no-tmux'.
+func runDirectClaude(containerName string) error {
+	claudeCmd := logging.Command("docker", "exec", "-it", "-u", "node", containerName, container.DirectClaudeScript)
+	claudeCmd.Stdin = os.Stdin
+	claudeCmd.Stdout = os.Stdout
+	claudeCmd.Stderr = os.Stderr
+	return logging.Run(claudeCmd)
+}
+
+// attachTmuxSession attaches to the container's main tmux session, first
+// switching to window if one is given. If the session is gone (Claude
+// crashed, the container restarted or ran out of memory), explains why and
+// offers to recreate it before attaching.
+func attachTmuxSession(containerName, window string) error {
+	if exists, reason := container.CheckTmuxSession(containerName); !exists {
+		if err := recoverTmuxSession(containerName, reason); err != nil {
+			return err
+		}
+	}
+	if err := container.SelectTmuxWindow(containerName, window); err != nil {
+		return err
+	}
+
+	err := runTmuxAttach(containerName)
+	if err == nil {
+		return nil
+	}
+
+	// The session may have died between the check and the attach
+	exists, reason := container.CheckTmuxSession(containerName)
+	if exists {
+		return err
+	}
+	if err := recoverTmuxSession(containerName, reason); err != nil {
+		return err
+	}
+	return runTmuxAttach(containerName)
+}
 
-	// Connect to tmux session
-	connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
+// runTmuxAttach runs an interactive tmux attach to the main session.
+func runTmuxAttach(containerName string) error {
+	connectCmd := logging.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", container.TmuxSession(containerName))
 	connectCmd.Stdin = os.Stdin
 	connectCmd.Stdout = os.Stdout
 	connectCmd.Stderr = os.Stderr
+	return logging.Run(connectCmd)
+}
+
+// recoverTmuxSession tells the user the tmux session is gone and offers to
+// recreate it with Claude, optionally re-sending the container's task, or
+// with a bare shell. With --repair it relaunches Claude without asking.
+func recoverTmuxSession(containerName, reason string) error {
+	fmt.Printf("\nCannot attach to %s: %s.\n", containerName, reason)
+	fmt.Println("The container's files and git state are intact.")
+
+	opts := container.TmuxSessionOptions{LaunchClaude: true}
+	if !connectRepair {
+		task := container.GetLabel(containerName, container.TaskLabel)
+		fmt.Println()
+		fmt.Println("  [c] Recreate session and relaunch Claude (default)")
+		if task != "" {
+			fmt.Printf("  [t] Recreate session and re-prompt Claude with the task: %s\n", truncateString(container.TaskDescriptionLine(task), 50))
+		}
+		fmt.Println("  [s] Recreate session with a shell only")
+		fmt.Println("  [n] Cancel")
+		if task != "" {
+			fmt.Print("Choice (C/t/s/n): ")
+		} else {
+			fmt.Print("Choice (C/s/n): ")
+		}
 
-	return connectCmd.Run()
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		switch {
+		case response == "" || response == "c" || response == "claude":
+		case task != "" && (response == "t" || response == "task"):
+			opts.Prompt = container.ResumePrompt(task)
+		case response == "s" || response == "shell":
+			opts.LaunchClaude = false
+		default:
+			return fmt.Errorf("cancelled - tmux session not recreated")
+		}
+	}
+
+	if err := container.CreateTmuxSession(containerName, opts); err != nil {
+		return err
+	}
+	fmt.Println("tmux session recreated")
+	return nil
 }
 
 // selectContainer shows an interactive menu to select a container
@@ -0,0 +1,81 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContainerBaseName_LongBranches(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+
+	shared := "feat/" + strings.Repeat("implement-the-new-billing-pipeline-", 3)
+	for _, prefix := range []string{"maestro-", "mcl-", "a-very-long-container-prefix-for-this-team-"} {
+		config = &Config{}
+		config.Containers.Prefix = prefix
+
+		a, truncatedA := containerBaseNameInfo(shared + "for-invoices")
+		b, truncatedB := containerBaseNameInfo(shared + "for-refunds")
+		if !truncatedA || !truncatedB {
+			t.Fatalf("prefix %q: long branches were not truncated", prefix)
+		}
+		if a == b {
+			t.Errorf("prefix %q: branches sharing a long prefix collide as %q", prefix, a)
+		}
+		for _, name := range []string{a, b} {
+			if !strings.HasPrefix(name, prefix) {
+				t.Errorf("%q lost the prefix %q", name, prefix)
+			}
+			if got := len(name + "-999"); got > maxContainerNameLength {
+				t.Errorf("%q with a three-digit suffix is %d characters, over %d", name, got, maxContainerNameLength)
+			}
+			if strings.Contains(name, "--") {
+				t.Errorf("%q has a doubled dash", name)
+			}
+		}
+
+		// The same branch always gets the same name, so numbering and
+		// --attach-existing keep finding its containers
+		if again := containerBaseName(shared + "for-invoices"); again != a {
+			t.Errorf("prefix %q: name not stable: %q then %q", prefix, a, again)
+		}
+	}
+}
+
+func TestContainerBaseName_ShortBranchUnchanged(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+	config = &Config{}
+	config.Containers.Prefix = "maestro-"
+
+	// Exactly at the budget: 63 - len("maestro-") - len("-999")
+	branch := strings.Repeat("a", 51)
+	name, truncated := containerBaseNameInfo(branch)
+	if truncated || name != "maestro-"+branch {
+		t.Errorf("got %q (truncated=%v), want the branch unchanged", name, truncated)
+	}
+	if _, truncated := containerBaseNameInfo(branch + "b"); !truncated {
+		t.Error("one character over the budget should truncate")
+	}
+}
+
+func TestTruncateContainerBaseName_TinyBudget(t *testing.T) {
+	got, truncated := truncateContainerBaseName("feat-something-long", 3)
+	if !truncated || len(got) != containerNameHashLength {
+		t.Errorf("got %q, want just the hash", got)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+// tokensPerWord is the rough words-to-tokens ratio used to estimate prompt
+// size without a tokenizer.
+const tokensPerWord = 1.33
+
+// estimateTokens approximates how many tokens Claude will count in s.
+func estimateTokens(s string) int {
+	words := 0
+	inWord := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			words++
+		}
+	}
+	return int(math.Ceil(float64(words) * tokensPerWord))
+}
+
+// truncateToTokens cuts s so its estimate fits within limit, keeping whole
+// words and the original whitespace, and marks the cut with "...". It
+// reports whether anything was removed. A limit of 0 or less keeps s as is.
+func truncateToTokens(s string, limit int) (string, bool) {
+	if limit <= 0 || estimateTokens(s) <= limit {
+		return s, false
+	}
+	maxWords := int(float64(limit) / tokensPerWord)
+	words := 0
+	inWord := false
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		if !inWord {
+			inWord = true
+			if words == maxWords {
+				return strings.TrimRightFunc(s[:i], unicode.IsSpace) + "...", true
+			}
+			words++
+		}
+	}
+	return s, false
+}
+
+// contextLimit is the planning prompt budget from
+// containers.max_context_tokens, which --context-limit overrides.
+func contextLimit() int {
+	if config == nil {
+		return container.DefaultMaxContextTokens
+	}
+	return config.Containers.MaxContextTokens
+}
+
+// applyContextLimit truncates a planning prompt that is over the context
+// limit and says so, so a large spec file can't run up the bill unnoticed.
+func applyContextLimit(prompt string) string {
+	limit := contextLimit()
+	truncated, cut := truncateToTokens(prompt, limit)
+	if cut {
+		fmt.Printf("%s  Warning: prompt is about %d tokens, over the %d token limit; truncated (raise it with --context-limit or containers.max_context_tokens)\n",
+			style.Warning(), estimateTokens(prompt), limit)
+	}
+	return truncated
+}
+
+// continuePromptWithinLimit prepends a previous session's summary to prompt
+// like continuePrompt, cutting the summary, never the task, to fit the context
+// limit. A task that leaves no room gets no summary at all.
+func continuePromptWithinLimit(summary, prompt string) string {
+	limit := contextLimit()
+	full := continuePrompt(summary, prompt)
+	if limit <= 0 || estimateTokens(full) <= limit {
+		return full
+	}
+	budget := limit - estimateTokens(continuePrompt("", prompt))
+	fmt.Printf("%s  Warning: previous session context is about %d tokens, over the %d token limit with the task; truncated (raise it with --context-limit or containers.max_context_tokens)\n",
+		style.Warning(), estimateTokens(summary), limit)
+	if budget <= 0 {
+		return prompt
+	}
+	summary, _ = truncateToTokens(summary, budget)
+	return continuePrompt(summary, prompt)
+}
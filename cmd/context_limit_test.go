@@ -0,0 +1,90 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"one", 2},
+		{"  add   user\n\tauth ", 4},
+		{strings.Repeat("word ", 300), 399},
+	} {
+		if got := estimateTokens(tt.in); got != tt.want {
+			t.Errorf("estimateTokens(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateToTokens(t *testing.T) {
+	short := "fix the login bug"
+	if got, cut := truncateToTokens(short, 100); cut || got != short {
+		t.Errorf("short prompt changed: %q, %v", got, cut)
+	}
+
+	long := "Implement:\n" + strings.Repeat("step ", 100)
+	if got, cut := truncateToTokens(long, 0); cut || got != long {
+		t.Error("a limit of 0 should keep the prompt")
+	}
+
+	got, cut := truncateToTokens(long, 20)
+	if !cut {
+		t.Fatal("long prompt should be truncated")
+	}
+	if !strings.HasPrefix(got, "Implement:\nstep ") || !strings.HasSuffix(got, "step...") {
+		t.Errorf("truncated prompt should keep whitespace and end in ...: %q", got)
+	}
+	if n := estimateTokens(got); n > 20 {
+		t.Errorf("truncated prompt is %d tokens, want at most 20", n)
+	}
+}
+
+func TestContinuePromptWithinLimit(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+	config = &Config{}
+	config.Containers.MaxContextTokens = 60
+
+	task := "add a logout button to the header"
+	summary := "Previous work:\n" + strings.Repeat("diff line ", 200)
+	got := continuePromptWithinLimit(summary, task)
+	if !strings.HasSuffix(got, "New task: "+task) {
+		t.Errorf("the task should be kept whole at the end: %q", got)
+	}
+	if !strings.HasPrefix(got, "Context from previous session:\nPrevious work:") {
+		t.Errorf("the start of the summary should be kept: %q", got)
+	}
+	if n := estimateTokens(got); n > 60 {
+		t.Errorf("prompt is %d tokens, want at most 60", n)
+	}
+
+	// A task over the limit on its own is left to applyContextLimit
+	long := strings.Repeat("word ", 100)
+	if got := continuePromptWithinLimit(summary, long); got != long {
+		t.Errorf("a task with no room left should be kept without context: %q", got)
+	}
+
+	config.Containers.MaxContextTokens = 0
+	if got := continuePromptWithinLimit(summary, task); got != continuePrompt(summary, task) {
+		t.Error("a limit of 0 should keep the whole summary")
+	}
+}
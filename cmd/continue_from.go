@@ -0,0 +1,139 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// continuedFromLabel records the container a --continue-from container
+// picks up from.
+const continuedFromLabel = "maestro.continued_from"
+
+const (
+	// continuePaneLines is how much of the previous Claude window is passed on
+	continuePaneLines = 200
+
+	// continueLogCommits is how many recent commits are passed on, and how far
+	// back the diff goes
+	continueLogCommits = 10
+
+	// continueDiffMaxLines caps the diff so a large change doesn't crowd out
+	// the task
+	continueDiffMaxLines = 500
+)
+
+// continueFrom resolves the --continue-from container (name or nickname) and
+// returns its full name with a summary of its session.
+func continueFrom(nameOrNick string) (string, string, error) {
+	source, ok := getNicknameStore().Get(nameOrNick)
+	if !ok {
+		source = resolveContainerName(nameOrNick)
+	}
+	sc, err := gatherSessionContext(source)
+	if err != nil {
+		return "", "", fmt.Errorf("--continue-from: %w", err)
+	}
+	return source, sc.summary(container.GetShortName(source, config.Containers.Prefix)), nil
+}
+
+// sessionContext is what a previous container passes to its follow-up.
+type sessionContext struct {
+	pane string // End of Claude's window
+	log  string // git log --oneline
+	diff string // git diff against continueLogCommits commits back
+}
+
+// gatherSessionContext reads the end of a container's Claude window and its
+// recent git history. The container must be running; parts that can't be read
+// are skipped with a warning.
+func gatherSessionContext(containerName string) (sessionContext, error) {
+	out, err := logging.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName).Output()
+	if err != nil {
+		return sessionContext{}, fmt.Errorf("container %s not found", containerName)
+	}
+	if status := strings.TrimSpace(string(out)); status != "running" {
+		shortName := container.GetShortName(containerName, config.Containers.Prefix)
+		return sessionContext{}, fmt.Errorf("container %s is %s; start it with 'maestro restart %s' so its session can be read", shortName, status, shortName)
+	}
+
+	var sc sessionContext
+	if sc.pane, err = container.CaptureClaudePane(containerName, continuePaneLines); err != nil {
+		logging.Warnf("Skipping Claude's window: %v", err)
+	}
+
+	dir := container.GitWorkspace(containerName)
+	git := func(args ...string) (string, error) {
+		args = append([]string{"exec", "-u", "node", containerName, "git", "-C", dir}, args...)
+		out, err := logging.Command("docker", args...).Output()
+		return strings.TrimRight(string(out), "\n"), err
+	}
+	if sc.log, err = git("log", "--oneline", fmt.Sprintf("-%d", continueLogCommits)); err != nil {
+		logging.Warnf("Skipping git history: %v", err)
+		return sc, nil
+	}
+
+	// Histories shorter than continueLogCommits are diffed from the root commit
+	base := fmt.Sprintf("HEAD~%d", continueLogCommits)
+	if _, err := git("rev-parse", "--verify", "-q", base); err != nil {
+		roots, err := git("rev-list", "--max-parents=0", "HEAD")
+		if err != nil {
+			logging.Warnf("Skipping git diff: %v", err)
+			return sc, nil
+		}
+		base = strings.Fields(roots)[0]
+	}
+	diff, err := git("diff", base)
+	if err != nil {
+		logging.Warnf("Skipping git diff: %v", err)
+		return sc, nil
+	}
+	sc.diff = truncateLines(diff, continueDiffMaxLines)
+	return sc, nil
+}
+
+// truncateLines keeps the first max lines of s, noting how many were dropped.
+func truncateLines(s string, max int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= max {
+		return s
+	}
+	return strings.Join(lines[:max], "\n") + fmt.Sprintf("\n... (%d more lines)", len(lines)-max)
+}
+
+// summary formats the context for the new container's prompt.
+func (c sessionContext) summary(source string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This task continues the work of container %s.\n", source)
+	if c.pane != "" {
+		fmt.Fprintf(&b, "\nEnd of Claude's window:\n```\n%s\n```\n", c.pane)
+	}
+	if c.log != "" {
+		fmt.Fprintf(&b, "\nRecent commits:\n```\n%s\n```\n", c.log)
+	}
+	if c.diff != "" {
+		fmt.Fprintf(&b, "\nChanges over those commits:\n```diff\n%s\n```\n", c.diff)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// continuePrompt prepends the previous session's context to a prompt.
+func continuePrompt(summary, prompt string) string {
+	return fmt.Sprintf("Context from previous session:\n%s\n\nNew task: %s", summary, prompt)
+}
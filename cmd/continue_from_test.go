@@ -0,0 +1,56 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateLines(t *testing.T) {
+	if got := truncateLines("a\nb", 2); got != "a\nb" {
+		t.Errorf("short input changed: %q", got)
+	}
+	if got := truncateLines("a\nb\nc\nd", 2); got != "a\nb\n... (2 more lines)" {
+		t.Errorf("truncateLines = %q", got)
+	}
+}
+
+func TestSessionContextSummary(t *testing.T) {
+	sc := sessionContext{
+		pane: "> All tests pass",
+		log:  "abc123 Add login form",
+		diff: "+func Login() {}",
+	}
+	prompt := continuePrompt(sc.summary("feat-login-1"), "add logout")
+	for _, want := range []string{
+		"Context from previous session:\nThis task continues the work of container feat-login-1.",
+		"> All tests pass",
+		"abc123 Add login form",
+		"```diff\n+func Login() {}\n```",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if !strings.HasSuffix(prompt, "\n\nNew task: add logout") {
+		t.Errorf("prompt should end with the new task:\n%s", prompt)
+	}
+
+	// Missing parts are left out
+	if s := (sessionContext{log: "abc123 x"}).summary("x-1"); strings.Contains(s, "Claude's window") || strings.Contains(s, "diff") {
+		t.Errorf("summary should skip empty parts:\n%s", s)
+	}
+}
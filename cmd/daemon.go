@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"os"
@@ -33,8 +34,10 @@ import (
 	"github.com/uprockcom/maestro/pkg/api"
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/daemon"
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/notify"
 	"github.com/uprockcom/maestro/pkg/notify/signal"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 // newDaemonClient creates an api.Client from DaemonIPCInfo.
@@ -64,8 +67,12 @@ The daemon monitors running containers and:
 Commands:
   maestro daemon start   - Start the daemon
   maestro daemon stop    - Stop the daemon
+  maestro daemon restart - Restart the daemon (reloads config)
   maestro daemon status  - Show daemon status
-  maestro daemon logs    - View daemon logs`,
+  maestro daemon logs    - View daemon logs
+  maestro daemon test-notification - Send a sample desktop notification
+  maestro daemon install - Start the daemon when you log in
+  maestro daemon uninstall - Remove the login service`,
 }
 
 var daemonStartCmd = &cobra.Command{
@@ -80,6 +87,19 @@ var daemonStopCmd = &cobra.Command{
 	RunE:  runDaemonStop,
 }
 
+var daemonRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart the Maestro daemon",
+	Long: `Stop the daemon, wait for the process to exit, and start it again.
+
+The daemon reads config.yml only at startup, so restart it after changing
+daemon settings such as quiet hours or notification providers. If the daemon
+is not running it is simply started.`,
+	RunE: runDaemonRestart,
+}
+
+var flagDaemonRestartForce bool
+
 var daemonStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show daemon status",
@@ -92,12 +112,29 @@ var daemonLogsCmd = &cobra.Command{
 	RunE:  runDaemonLogs,
 }
 
+var daemonTestNotificationCmd = &cobra.Command{
+	Use:   "test-notification",
+	Short: "Send a sample desktop notification",
+	Long: `Send a sample desktop notification the way the daemon does, with the same
+backend choice (terminal-notifier or osascript on macOS, notify-send on Linux)
+and icon, and report which backend showed it.
+
+Use it to check notifications work without waiting for a real event. The
+daemon does not need to be running.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonTestNotification,
+}
+
 func init() {
 	rootCmd.AddCommand(daemonCmd)
 	daemonCmd.AddCommand(daemonStartCmd)
 	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonRestartCmd)
 	daemonCmd.AddCommand(daemonStatusCmd)
 	daemonCmd.AddCommand(daemonLogsCmd)
+	daemonCmd.AddCommand(daemonTestNotificationCmd)
+	daemonRestartCmd.Flags().BoolVar(&flagDaemonRestartForce, "force", false,
+		fmt.Sprintf("Kill the daemon if it has not stopped within %s", daemonStopGrace))
 }
 
 // readDaemonIPCInfo reads daemon-ipc.json and returns the parsed info, or nil if not found.
@@ -153,7 +190,7 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 	// Check notification support if notifications are enabled
 	if config.Daemon.Notifications.Enabled {
 		if err := checkNotificationSupport(); err != nil {
-			fmt.Printf("\n⚠️  Warning: %v\n", err)
+			fmt.Printf("\n%s  Warning: %v\n", style.Warning(), err)
 			fmt.Println("   Daemon will run but notifications will be disabled.")
 			fmt.Println("   Consider setting notifications.enabled: false in config")
 		} else if runtime.GOOS == "darwin" {
@@ -208,18 +245,35 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 	return fmt.Errorf("daemon failed to start - check logs")
 }
 
+// daemonStopGrace is how long a graceful shutdown may take before the
+// daemon is killed (always for 'daemon stop', with --force for restart).
+const daemonStopGrace = 5 * time.Second
+
+// daemonRestartTimeout bounds a graceful shutdown during 'daemon restart'
+// without --force.
+const daemonRestartTimeout = 30 * time.Second
+
 func runDaemonStop(cmd *cobra.Command, args []string) error {
+	_, err := stopDaemon(daemonStopGrace, true)
+	return err
+}
+
+// stopDaemon asks a running daemon to shut down and waits up to timeout for
+// its process to exit. If it is still running after that, it is killed when
+// kill is set and an error is returned otherwise. The first result reports
+// whether a daemon was running.
+func stopDaemon(timeout time.Duration, kill bool) (bool, error) {
 	info := readDaemonIPCInfo()
 	if info == nil {
 		fmt.Println("Daemon is not running")
-		return nil
+		return false, nil
 	}
 
 	// Verify it's actually running
 	running, _ := isDaemonRunning()
 	if !running {
 		fmt.Println("Daemon is not running")
-		return nil
+		return false, nil
 	}
 
 	fmt.Printf("Stopping daemon (PID %d)...\n", info.PID)
@@ -234,34 +288,81 @@ func runDaemonStop(cmd *cobra.Command, args []string) error {
 		// If we can't connect, daemon may have already stopped
 		fmt.Println("Daemon stopped")
 		os.Remove(daemonIPCFilePath())
-		return nil
+		return true, nil
 	}
 
-	// Poll until daemon is no longer responding (up to 5 seconds)
-	for i := 0; i < 50; i++ {
+	// Poll until the daemon no longer responds and its process has exited,
+	// so a following start does not race its cleanup
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
 		time.Sleep(100 * time.Millisecond)
-		if running, _ := isDaemonRunning(); !running {
+		if running, _ := isDaemonRunning(); !running && (info.PID <= 0 || !daemon.ProcessAlive(info.PID)) {
 			fmt.Println("Daemon stopped")
-			return nil
+			return true, nil
 		}
 	}
 
+	if !kill {
+		return true, fmt.Errorf("daemon (PID %d) did not stop within %s; retry with --force to kill it", info.PID, timeout)
+	}
+
 	// Fallback: if HTTP shutdown didn't work and we have PID, kill the process
 	if info.PID > 0 {
-		// Re-check if daemon is still running to avoid killing an unrelated process
-		// that may have reused the PID
-		if stillRunning, _ := isDaemonRunning(); stillRunning {
-			process, err := os.FindProcess(info.PID)
-			if err == nil {
-				process.Kill()
+		// Only while the daemon still answers as that PID: once it stops
+		// responding, the PID may belong to an unrelated process
+		stillRunning, current := isDaemonRunning()
+		if !stillRunning || current.PID != info.PID {
+			fmt.Println("Daemon stopped")
+			return true, nil
+		}
+		process, err := os.FindProcess(info.PID)
+		if err == nil {
+			process.Kill()
+			for i := 0; i < 20 && daemon.ProcessAlive(info.PID); i++ {
+				time.Sleep(100 * time.Millisecond)
 			}
 		}
 		os.Remove(daemonIPCFilePath())
 		fmt.Println("Daemon stopped (forced)")
-		return nil
+		return true, nil
+	}
+
+	return true, fmt.Errorf("daemon did not stop gracefully")
+}
+
+func runDaemonRestart(cmd *cobra.Command, args []string) error {
+	timeout := daemonRestartTimeout
+	if flagDaemonRestartForce {
+		timeout = daemonStopGrace
+	}
+
+	wasRunning, err := stopDaemon(timeout, flagDaemonRestartForce)
+	if err != nil {
+		return err
+	}
+	if wasRunning {
+		appendDaemonLog("Daemon restart requested from the CLI")
+	} else {
+		fmt.Println("Starting a fresh daemon")
+		appendDaemonLog("Daemon restart requested from the CLI (daemon was not running)")
 	}
 
-	return fmt.Errorf("daemon did not stop gracefully")
+	return runDaemonStart(cmd, args)
+}
+
+// appendDaemonLog records a CLI-side event in daemon.log, in the daemon's
+// own log format, so restarts show up alongside daemon activity.
+func appendDaemonLog(format string, args ...any) {
+	authDir := expandPath(config.Claude.AuthPath)
+	if err := os.MkdirAll(authDir, 0755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(authDir, "daemon.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	log.New(f, "", log.LstdFlags).Printf("[INFO] %s\n", fmt.Sprintf(format, args...))
 }
 
 func runDaemonStatus(cmd *cobra.Command, args []string) error {
@@ -288,8 +389,8 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 		// Show update status
 		if status.Update != nil {
 			if status.Update.Available {
-				fmt.Printf("\n⚠️  Update available: %s → %s\n", status.Update.CurrentVersion, status.Update.LatestVersion)
-				fmt.Println("   Run: brew upgrade maestro  (or download from GitHub)")
+				fmt.Printf("\n%s  Update available: %s → %s\n", style.Warning(), status.Update.CurrentVersion, status.Update.LatestVersion)
+				fmt.Println("   Run: maestro self-update  (or brew upgrade maestro)")
 				if status.Update.ReleaseURL != "" {
 					fmt.Printf("   %s\n", status.Update.ReleaseURL)
 				}
@@ -305,6 +406,9 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Notifications: %v\n", config.Daemon.Notifications.Enabled)
 		if config.Daemon.Notifications.Enabled {
 			fmt.Printf("  Attention threshold: %s\n", config.Daemon.Notifications.AttentionThreshold)
+			if config.Daemon.IgnoreIdleAfter != "" {
+				fmt.Printf("  Ignore idle after: %s\n", config.Daemon.IgnoreIdleAfter)
+			}
 		}
 		fmt.Printf("  Update check: %v\n", config.Daemon.UpdateCheck)
 	} else {
@@ -369,6 +473,13 @@ func runDaemonBackground(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// A daemon killed without a clean shutdown leaves daemon-ipc.json behind,
+	// pointing clients at a port nothing is listening on.
+	staleIPCRemoved, err := daemon.CleanupStaleIPCFile(authDir)
+	if err != nil {
+		return err
+	}
+
 	// Parse config
 	daemonConfig := daemon.Config{
 		CheckInterval:       parseDuration(config.Daemon.CheckInterval, 30*time.Minute),
@@ -378,10 +489,16 @@ func runDaemonBackground(cmd *cobra.Command, args []string) error {
 		NotifyOn:            config.Daemon.Notifications.NotifyOn,
 		QuietHoursStart:     config.Daemon.Notifications.QuietHours.Start,
 		QuietHoursEnd:       config.Daemon.Notifications.QuietHours.End,
+		QuietHoursAllow:     config.Daemon.Notifications.QuietHours.Allow,
+		NotificationIcon:    expandPath(config.Daemon.Notifications.Icon),
+		RateLimit:           parseDuration(config.Daemon.Notifications.RateLimit, 30*time.Minute),
+		RateLimits:          parseRateLimits(config.Daemon.Notifications.RateLimits),
 		ContainerPrefix:     config.Containers.Prefix,
 		CreateContainer:     createContainerFromDaemonOpts,
 		UpdateCheckEnabled:  config.Daemon.UpdateCheck,
 		UpdateCheckInterval: parseDuration(config.Daemon.UpdateCheckInterval, 6*time.Hour),
+		MaxContainers:       config.Daemon.MaxContainers,
+		IgnoreIdleAfter:     parseDuration(config.Daemon.IgnoreIdleAfter, 0),
 	}
 
 	// Create and start daemon with embedded icon
@@ -389,6 +506,9 @@ func runDaemonBackground(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create daemon: %w", err)
 	}
+	if staleIPCRemoved {
+		d.LogInfo("Removed stale daemon-ipc.json left by a previous daemon")
+	}
 
 	// Build notification providers
 	var providers []notify.Provider
@@ -511,17 +631,17 @@ func runDaemonBackground(cmd *cobra.Command, args []string) error {
 }
 
 // EnsureDaemonRunning starts the daemon if it's not already running.
-// This is called automatically when the TUI starts.
-func EnsureDaemonRunning() {
+// This is called automatically when the TUI starts, which ignores errors.
+func EnsureDaemonRunning() error {
 	// Check if already running via HTTP
 	if running, _ := isDaemonRunning(); running {
-		return // Already running, nothing to do
+		return nil // Already running, nothing to do
 	}
 
 	// Start daemon silently in background
 	binary, err := os.Executable()
 	if err != nil {
-		return // Fail silently
+		return fmt.Errorf("failed to get executable path: %w", err)
 	}
 
 	daemonProc := exec.Command(binary, "daemon", "_run")
@@ -533,8 +653,9 @@ func EnsureDaemonRunning() {
 	setDaemonProcessAttr(daemonProc)
 
 	if err := daemonProc.Start(); err != nil {
-		return // Fail silently
+		return fmt.Errorf("failed to start daemon: %w", err)
 	}
+	return nil
 }
 
 // Helper functions
@@ -550,6 +671,56 @@ func parseDuration(s string, defaultDur time.Duration) time.Duration {
 	return d
 }
 
+// parseRateLimits parses daemon.notifications.rate_limits, skipping invalid
+// durations ('maestro config validate' reports them).
+func parseRateLimits(limits map[string]string) map[string]time.Duration {
+	parsed := make(map[string]time.Duration, len(limits))
+	for notifyType, s := range limits {
+		if d, err := time.ParseDuration(s); err == nil {
+			parsed[notifyType] = d
+		}
+	}
+	return parsed
+}
+
+func runDaemonTestNotification(cmd *cobra.Command, args []string) error {
+	if err := checkNotificationSupport(); err != nil {
+		return err
+	}
+	if !config.Daemon.Notifications.Enabled {
+		logging.Warnf("daemon.notifications.enabled is false, so the daemon won't send notifications")
+	} else if !config.Daemon.Notifications.Providers.Desktop.Enabled {
+		logging.Warnf("daemon.notifications.providers.desktop.enabled is false, so the daemon won't send desktop notifications")
+	}
+
+	authDir := expandPath(config.Claude.AuthPath)
+	if err := os.MkdirAll(authDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	icon, iconSource := daemon.NotificationIcon(authDir, assets.NotificationIcon, expandPath(config.Daemon.Notifications.Icon))
+	provider := notify.NewDesktopProvider(icon, daemon.TerminalNotifierAvailable())
+
+	backend, err := provider.Deliver(notify.Event{
+		ShortName: "test",
+		Title:     "Test Notification",
+		Message:   "Notifications from Maestro are working",
+		Type:      notify.EventContainerNotification,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s failed to send the notification: %w", backend, err)
+	}
+	fmt.Printf("%s Sent a test notification with %s\n", style.Check(), backend)
+	switch {
+	case backend == "osascript":
+		fmt.Println("  No custom icon (install terminal-notifier for one: brew install terminal-notifier)")
+	case icon != "":
+		fmt.Printf("  Icon: %s (%s)\n", icon, iconSource)
+	}
+	fmt.Println("If nothing appeared, check your system's notification settings.")
+	return nil
+}
+
 // checkNotificationSupport verifies notification system is available
 func checkNotificationSupport() error {
 	switch runtime.GOOS {
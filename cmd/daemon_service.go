@@ -0,0 +1,138 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+// daemonServiceName identifies the login service in launchd and systemd.
+const daemonServiceName = "com.uprock.maestro.daemon"
+
+var daemonInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Start the daemon automatically when you log in",
+	Long: `Register the daemon as a login service so it starts when you log in:
+a launchd agent on macOS or a systemd user unit on Linux. It takes effect at
+your next login; run 'maestro daemon start' to start it now.
+
+Run it again after moving the maestro binary. Windows is not supported.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonInstall,
+}
+
+var daemonUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Stop starting the daemon when you log in",
+	Long: `Remove the login service added by 'maestro daemon install'. A running
+daemon keeps running; use 'maestro daemon stop' to stop it.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonUninstall,
+}
+
+func init() {
+	daemonCmd.AddCommand(daemonInstallCmd)
+	daemonCmd.AddCommand(daemonUninstallCmd)
+}
+
+func runDaemonInstall(cmd *cobra.Command, args []string) error {
+	path, err := InstallDaemonService()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Installed login service: %s\n", style.Check(), path)
+	fmt.Println("  The daemon will start when you log in. Start it now with: maestro daemon start")
+	return nil
+}
+
+func runDaemonUninstall(cmd *cobra.Command, args []string) error {
+	path, err := uninstallDaemonService()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		fmt.Println("No login service installed")
+		return nil
+	}
+	fmt.Printf("%s Removed login service: %s\n", style.Check(), path)
+	return nil
+}
+
+// InstallDaemonService registers the daemon to start at login and returns
+// the service file it wrote.
+func InstallDaemonService() (string, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
+	}
+	return installDaemonService(binary)
+}
+
+// launchdPlist renders the launchd agent that runs binary's daemon at login.
+// KeepAlive restarts it after a crash but not after 'maestro daemon stop'.
+func launchdPlist(binary string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>daemon</string>
+		<string>_run</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+</dict>
+</plist>
+`, daemonServiceName, html.EscapeString(binary))
+}
+
+// systemdUnit renders the systemd user unit that runs binary's daemon at
+// login, restarting it after a crash but not after 'maestro daemon stop'.
+func systemdUnit(binary string) string {
+	return fmt.Sprintf(`[Unit]
+Description=Maestro background daemon
+
+[Service]
+ExecStart=%s daemon _run
+Restart=on-failure
+RestartSec=30
+
+[Install]
+WantedBy=default.target
+`, systemdQuote(binary))
+}
+
+// systemdQuote quotes a path for an ExecStart line if it needs it.
+func systemdQuote(path string) string {
+	if !strings.ContainsAny(path, " \t\"\\") {
+		return path
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(path) + `"`
+}
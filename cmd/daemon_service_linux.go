@@ -0,0 +1,89 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const daemonServiceSupported = true
+
+// daemonServicePath is the systemd user unit, under $XDG_CONFIG_HOME.
+func daemonServicePath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "systemd", "user", daemonServiceName+".service"), nil
+}
+
+func installDaemonService(binary string) (string, error) {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return "", fmt.Errorf("systemctl not found; login services need systemd")
+	}
+	path, err := daemonServicePath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(systemdUnit(binary)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := systemctlUser("daemon-reload"); err != nil {
+		return "", err
+	}
+	if err := systemctlUser("enable", filepath.Base(path)); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func uninstallDaemonService() (string, error) {
+	path, err := daemonServicePath()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	// Disabling only removes the login hook; a running daemon keeps running
+	if err := systemctlUser("disable", filepath.Base(path)); err != nil {
+		return "", err
+	}
+	if err := os.Remove(path); err != nil {
+		return "", fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return path, systemctlUser("daemon-reload")
+}
+
+// systemctlUser runs systemctl --user with args.
+func systemctlUser(args ...string) error {
+	out, err := exec.Command("systemctl", append([]string{"--user"}, args...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl --user %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDaemonServiceFiles(t *testing.T) {
+	unit := systemdUnit("/home/me/my bin/maestro")
+	if !strings.Contains(unit, `ExecStart="/home/me/my bin/maestro" daemon _run`) {
+		t.Errorf("ExecStart should quote the path:\n%s", unit)
+	}
+	if !strings.Contains(unit, "Restart=on-failure") {
+		t.Errorf("unit should restart only after a crash:\n%s", unit)
+	}
+
+	plist := launchdPlist("/opt/a&b/maestro")
+	if !strings.Contains(plist, "<string>/opt/a&amp;b/maestro</string>") {
+		t.Errorf("plist should escape the path:\n%s", plist)
+	}
+	if !strings.Contains(plist, "<string>"+daemonServiceName+"</string>") {
+		t.Errorf("plist should carry the label:\n%s", plist)
+	}
+}
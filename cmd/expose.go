@@ -17,15 +17,17 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
-const exposePrefix = "maestro-expose-"
+const exposePrefix = container.ExposeSidecarPrefix
 
 var (
 	exposeHostPort int
@@ -123,7 +125,7 @@ func runUnexpose(cmd *cobra.Command, args []string) error {
 
 // listExposures prints a table of all running expose sidecars.
 func listExposures() error {
-	out, err := exec.Command("docker", "ps", "-a",
+	out, err := logging.Command("docker", "ps", "-a",
 		"--filter", "name="+exposePrefix,
 		"--format", "{{.Names}}\t{{.State}}\t{{.Ports}}").Output()
 	if err != nil {
@@ -147,7 +149,7 @@ func listExposures() error {
 
 // discoverPorts runs ss inside the container and prints LISTEN ports.
 func discoverPorts(containerName string) error {
-	out, err := exec.Command("docker", "exec", containerName, "ss", "-tlnp").Output()
+	out, err := logging.Command("docker", "exec", containerName, "ss", "-tlnp").Output()
 	if err != nil {
 		return fmt.Errorf("failed to run ss in container: %w", err)
 	}
@@ -199,7 +201,7 @@ func launchSidecar(containerName string, port, hostPort int) error {
 	sidecarName := sidecarName(containerName, port)
 
 	// Check if sidecar already exists
-	stateOut, err := exec.Command("docker", "ps", "-a",
+	stateOut, err := logging.Command("docker", "ps", "-a",
 		"--filter", "name=^"+sidecarName+"$",
 		"--format", "{{.State}}").Output()
 	if err != nil {
@@ -214,11 +216,20 @@ func launchSidecar(containerName string, port, hostPort int) error {
 	if existing != "" {
 		// Exists but not running — remove the stale container first
 		fmt.Printf("Removing stale sidecar (%s)...\n", existing)
-		if err := exec.Command("docker", "rm", "-f", sidecarName).Run(); err != nil {
+		if err := logging.Run(logging.Command("docker", "rm", "-f", sidecarName)); err != nil {
 			return fmt.Errorf("failed to remove stale sidecar: %w", err)
 		}
 	}
 
+	// Host networked containers listen on the host already
+	networkMode, err := container.NetworkMode(containerName)
+	if err != nil {
+		return err
+	}
+	if networkMode == container.NetworkHost {
+		return fmt.Errorf("%s uses host networking; port %d is already on localhost", containerName, port)
+	}
+
 	// Get the target container's IP on its Docker network so the sidecar can
 	// reach it. Try all attached networks and use the first non-empty IP.
 	targetIP, err := getContainerIP(containerName)
@@ -233,17 +244,19 @@ func launchSidecar(containerName string, port, hostPort int) error {
 		"run", "-d",
 		"--name", sidecarName,
 		"-p", fmt.Sprintf("%d:%d", hostPort, port),
+		// Join the target's network, which the default bridge can't reach
+		"--network", networkMode,
 		"alpine/socat",
 		socatArg,
 		connectArg,
 	}
 
-	if out, err := exec.Command("docker", runArgs...).CombinedOutput(); err != nil {
+	if out, err := logging.Command("docker", runArgs...).CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to start sidecar: %w\n%s", err, strings.TrimSpace(string(out)))
 	}
 
 	// Verify the sidecar actually started (it might exit immediately if port is not open)
-	verifyOut, err := exec.Command("docker", "ps", "-a",
+	verifyOut, err := logging.Command("docker", "ps", "-a",
 		"--filter", "name=^"+sidecarName+"$",
 		"--format", "{{.State}}").Output()
 	if err != nil {
@@ -253,8 +266,8 @@ func launchSidecar(containerName string, port, hostPort int) error {
 	state := strings.TrimSpace(string(verifyOut))
 	if state != "running" {
 		// Fetch logs to give a useful error message
-		logs, _ := exec.Command("docker", "logs", sidecarName).CombinedOutput()
-		_ = exec.Command("docker", "rm", "-f", sidecarName).Run()
+		logs, _ := logging.Command("docker", "logs", sidecarName).CombinedOutput()
+		_ = logging.Run(logging.Command("docker", "rm", "-f", sidecarName))
 		return fmt.Errorf("sidecar exited immediately (state: %s)\n%s", state, strings.TrimSpace(string(logs)))
 	}
 
@@ -264,7 +277,7 @@ func launchSidecar(containerName string, port, hostPort int) error {
 
 // getContainerIP returns the container's IP address on its Docker network.
 func getContainerIP(containerName string) (string, error) {
-	out, err := exec.Command("docker", "inspect",
+	out, err := logging.Command("docker", "inspect",
 		"--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}",
 		containerName).Output()
 	if err != nil {
@@ -284,7 +297,7 @@ func removeSidecar(containerName string, port int) error {
 	name := sidecarName(containerName, port)
 
 	// Check it exists
-	stateOut, err := exec.Command("docker", "ps", "-a",
+	stateOut, err := logging.Command("docker", "ps", "-a",
 		"--filter", "name=^"+name+"$",
 		"--format", "{{.State}}").Output()
 	if err != nil {
@@ -295,7 +308,7 @@ func removeSidecar(containerName string, port int) error {
 		return fmt.Errorf("no port forward found for %s:%d", containerName, port)
 	}
 
-	if out, err := exec.Command("docker", "rm", "-f", name).CombinedOutput(); err != nil {
+	if out, err := logging.Command("docker", "rm", "-f", name).CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to remove sidecar: %w\n%s", err, strings.TrimSpace(string(out)))
 	}
 
@@ -311,7 +324,7 @@ func removeExposeSidecarsForContainers(containerNames []string) {
 	}
 
 	// List all expose sidecars at once
-	out, err := exec.Command("docker", "ps", "-a",
+	out, err := logging.Command("docker", "ps", "-a",
 		"--filter", "name="+exposePrefix,
 		"--format", "{{.Names}}").Output()
 	if err != nil {
@@ -323,7 +336,7 @@ func removeExposeSidecarsForContainers(containerNames []string) {
 			// Sidecar name is maestro-expose-<containerName>-<port>
 			prefix := exposePrefix + target + "-"
 			if strings.HasPrefix(sidecar, prefix) {
-				if err := exec.Command("docker", "rm", "-f", sidecar).Run(); err != nil {
+				if err := logging.Run(logging.Command("docker", "rm", "-f", sidecar)); err != nil {
 					fmt.Printf("  Warning: failed to remove sidecar %s: %v\n", sidecar, err)
 				} else {
 					fmt.Printf("  Removed expose sidecar: %s\n", sidecar)
@@ -341,7 +354,7 @@ func sidecarName(containerName string, port int) string {
 
 // requireRunning returns an error if the named container is not in the running state.
 func requireRunning(containerName, shortName string) error {
-	out, err := exec.Command("docker", "ps",
+	out, err := logging.Command("docker", "ps",
 		"--filter", "name=^"+containerName+"$",
 		"--format", "{{.State}}").Output()
 	if err != nil {
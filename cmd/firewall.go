@@ -0,0 +1,139 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var firewallCmd = &cobra.Command{
+	Use:   "firewall",
+	Short: "Inspect container firewalls",
+}
+
+var firewallStatusCmd = &cobra.Command{
+	Use:   "status [container]",
+	Short: "Check that container firewalls are active",
+	Long: `Check that the outbound firewall is actually in place in running containers.
+
+A container's firewall is active when its iptables OUTPUT policy is DROP and
+the allowlist rule populated by dnsmasq is present. With no argument, every
+running container is checked. Exits non-zero if any firewall is inactive.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFirewallStatus,
+}
+
+var firewallEnableCmd = &cobra.Command{
+	Use:   "enable <container>",
+	Short: "Enable the firewall in a container created with --no-firewall",
+	Long: `Install and start the outbound firewall in a running container that was
+created with --no-firewall (or containers.default_no_firewall), restricting it
+to firewall.allowed_domains like any other container.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return enableFirewall(resolveContainerName(args[0]))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(firewallCmd)
+	firewallCmd.AddCommand(firewallStatusCmd)
+	firewallCmd.AddCommand(firewallEnableCmd)
+}
+
+func runFirewallStatus(cmd *cobra.Command, args []string) error {
+	var names []string
+	if len(args) == 1 {
+		names = []string{resolveContainerName(args[0])}
+	} else {
+		var err error
+		names, err = container.RunningContainerNames(config.Containers.Prefix)
+		if err != nil {
+			return fmt.Errorf("failed to list containers: %w", err)
+		}
+		if len(names) == 0 {
+			fmt.Println("No running containers")
+			return nil
+		}
+	}
+
+	var inactive []string
+	for _, name := range names {
+		shortName := strings.TrimPrefix(name, config.Containers.Prefix)
+		if container.IsFirewallDisabled(name) {
+			fmt.Printf("%s %s: disabled (created with --no-firewall)\n", style.Cross(), shortName)
+			inactive = append(inactive, shortName)
+			continue
+		}
+		status, err := container.CheckFirewall(name)
+		switch {
+		case err != nil:
+			fmt.Printf("%s %s: %v\n", style.Cross(), shortName, err)
+			inactive = append(inactive, shortName)
+		case status.Active:
+			fmt.Printf("%s %s: active (%d outbound rules)\n", style.Check(), shortName, status.Rules)
+		default:
+			fmt.Printf("%s %s: NOT active: %s\n", style.Cross(), shortName, status.Reason)
+			if log := container.FirewallLogTail(name, 5); log != "" {
+				for _, line := range strings.Split(log, "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+			inactive = append(inactive, shortName)
+		}
+	}
+
+	if len(inactive) > 0 {
+		fmt.Println("\nContainers without an active firewall have unrestricted outbound network access.")
+		fmt.Printf("Re-run the firewall script with: docker exec -u root -d <container> bash %s\n", container.FirewallScriptPath)
+		return fmt.Errorf("%d container(s) without an active firewall: %s", len(inactive), strings.Join(inactive, ", "))
+	}
+	return nil
+}
+
+// enableFirewall initializes the firewall in a running container, typically
+// one created with --no-firewall.
+func enableFirewall(containerName string) error {
+	shortName := strings.TrimPrefix(containerName, config.Containers.Prefix)
+	if err := requireRunning(containerName, shortName); err != nil {
+		return err
+	}
+	if !container.IsFirewallDisabled(containerName) {
+		if status, err := container.CheckFirewall(containerName); err == nil && status.Active {
+			fmt.Printf("Firewall already active in %s (%d outbound rules)\n", shortName, status.Rules)
+			return nil
+		}
+	}
+
+	// The firewall's iptables and resolv.conf changes would apply to the host
+	if mode, err := container.NetworkMode(containerName); err != nil {
+		return err
+	} else if mode == container.NetworkHost {
+		return fmt.Errorf("%s uses host networking, where the firewall would change the host's own network rules; recreate it on another network", shortName)
+	}
+	if ready, err := container.FirewallDNSReady(containerName); err != nil {
+		return err
+	} else if !ready {
+		return fmt.Errorf("%s has a read-only root filesystem and was started without the firewall's DNS; recreate it without --no-firewall", shortName)
+	}
+
+	fmt.Printf("Enabling firewall in %s...\n", shortName)
+	return initializeFirewall(containerName, container.ProjectDomains(containerName))
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/settings"
+	"github.com/uprockcom/maestro/pkg/tui"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+// fontCheckHintFile records in the config directory that the non-Unicode
+// locale warning was shown, so it appears only once.
+const fontCheckHintFile = "font-check-hint"
+
+// runFontCheck prints the characters the TUI uses and, unless the user says
+// they all render, turns on tui.ascii_fallback.
+func runFontCheck(in io.Reader, out io.Writer) error {
+	fmt.Fprintln(out, tui.FontCheckSample())
+	fmt.Fprint(out, "Do all characters render correctly? (y/N): ")
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response == "y" || response == "yes" {
+		if style.IsASCIIMode() {
+			fmt.Fprintln(out, "tui.ascii_fallback is on; set it to false in config.yml to use the Unicode display.")
+		} else {
+			fmt.Fprintln(out, "No changes needed.")
+		}
+		return nil
+	}
+
+	if err := settings.Save(map[string]any{"tui.ascii_fallback": true}); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, style.Check()+" Saved tui.ascii_fallback: true; maestro will use ASCII characters from the next launch.")
+	return nil
+}
+
+// unicodeTerminal guesses from the environment whether the terminal can show
+// Unicode: a UTF-8 locale (LC_ALL, then LC_CTYPE, then LANG, as the C
+// library picks them) on a TERM other than the bare Linux console or dumb.
+func unicodeTerminal(getenv func(string) string) bool {
+	switch getenv("TERM") {
+	case "dumb", "linux":
+		return false
+	}
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale = getenv(name); locale != "" {
+			break
+		}
+	}
+	locale = strings.ToLower(locale)
+	return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+}
+
+// warnNonUnicodeLocale suggests --font-check, once, when the locale suggests
+// the TUI's Unicode characters won't render. It stays quiet in CI and when
+// ASCII mode is already on.
+func warnNonUnicodeLocale() {
+	if os.Getenv("CI") == "true" || style.IsASCIIMode() || unicodeTerminal(os.Getenv) {
+		return
+	}
+	marker := filepath.Join(paths.GetConfigDir(), fontCheckHintFile)
+	if _, err := os.Stat(marker); err == nil {
+		return
+	}
+	logging.Warnf("your locale may not support Unicode; if the display looks garbled, run 'maestro --font-check'")
+	_ = os.WriteFile(marker, nil, 0644)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestUnicodeTerminal(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want bool
+	}{
+		{map[string]string{"LANG": "en_US.UTF-8", "TERM": "xterm-256color"}, true},
+		{map[string]string{"LANG": "de_DE.utf8"}, true},
+		{map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, false},
+		{map[string]string{"LC_CTYPE": "en_US.UTF-8", "LANG": "C"}, true},
+		{map[string]string{"LANG": "en_US.UTF-8", "TERM": "linux"}, false},
+		{map[string]string{}, false},
+	}
+	for _, tt := range tests {
+		getenv := func(name string) string { return tt.env[name] }
+		if got := unicodeTerminal(getenv); got != tt.want {
+			t.Errorf("unicodeTerminal(%v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestRunFontCheck(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	viper.SetConfigFile(path)
+
+	var out bytes.Buffer
+	if err := runFontCheck(strings.NewReader("y\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Indicators:") || viper.GetBool("tui.ascii_fallback") {
+		t.Errorf("yes should print the sample and change nothing:\n%s", out.String())
+	}
+
+	// Enter alone takes the default, no
+	if err := runFontCheck(strings.NewReader("\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if !v.GetBool("tui.ascii_fallback") {
+		t.Error("no should save tui.ascii_fallback: true")
+	}
+}
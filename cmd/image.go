@@ -0,0 +1,270 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// customImageRepo is the local repository images built from
+	// containers.dockerfile are tagged into
+	customImageRepo = "maestro-custom"
+
+	// imageBuildHashLabel records the inputs a locally built image came from,
+	// so it is rebuilt when they change
+	imageBuildHashLabel = "maestro.build_hash"
+
+	// imageBuildContextLabel records the build context directory a locally
+	// built image came from, so a different directory's Dockerfile, like a
+	// project's own docker/, is never compared with it
+	imageBuildContextLabel = "maestro.build_context"
+
+	// baseImageArg names the build arg carrying the image a custom
+	// Dockerfile extends
+	baseImageArg = "BASE_IMAGE"
+)
+
+// imageBuild describes a local docker build.
+type imageBuild struct {
+	Dockerfile string            // path to the Dockerfile
+	Context    string            // build context directory
+	BuildArgs  map[string]string // passed as --build-arg
+}
+
+// hash identifies the build inputs: the Dockerfile contents and build args.
+// Changes to other files in the context are not detected.
+func (b imageBuild) hash() (string, error) {
+	content, err := os.ReadFile(b.Dockerfile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+	h := sha256.New()
+	h.Write(content)
+	for _, arg := range b.buildArgList() {
+		fmt.Fprintf(h, "\x00%s", arg)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12], nil
+}
+
+// buildArgList returns the build args as sorted NAME=value pairs.
+func (b imageBuild) buildArgList() []string {
+	args := make([]string, 0, len(b.BuildArgs))
+	for k, v := range b.BuildArgs {
+		args = append(args, k+"="+v)
+	}
+	sort.Strings(args)
+	return args
+}
+
+// dockerArgs returns the arguments for 'docker build'.
+func (b imageBuild) dockerArgs(tag, hash string) []string {
+	args := []string{"build", "-t", tag, "-f", b.Dockerfile,
+		"--label", fmt.Sprintf("%s=%s", imageBuildHashLabel, hash),
+		"--label", fmt.Sprintf("%s=%s", imageBuildContextLabel, b.Context)}
+	for _, arg := range b.buildArgList() {
+		args = append(args, "--build-arg", arg)
+	}
+	return append(args, b.Context)
+}
+
+// run builds the image as tag, streaming docker's output.
+func (b imageBuild) run(tag string) error {
+	hash, err := b.hash()
+	if err != nil {
+		return err
+	}
+	buildCmd := logging.Command("docker", b.dockerArgs(tag, hash)...)
+	buildCmd.Stdout = os.Stdout
+	buildCmd.Stderr = os.Stderr
+	if err := logging.Run(buildCmd); err != nil {
+		return fmt.Errorf("docker build failed: %w", err)
+	}
+	return nil
+}
+
+// configBuildArgs returns containers.build_args. Viper lower-cases map keys
+// while build args are case-sensitive, so the map is read from the config file
+// directly when possible.
+func configBuildArgs() map[string]string {
+	if args := buildArgsFromFile(viper.ConfigFileUsed()); args != nil {
+		return args
+	}
+	return config.Containers.BuildArgs
+}
+
+// buildArgsFromFile reads containers.build_args from a config file with the
+// keys' case intact, or returns nil if the file has none.
+func buildArgsFromFile(file string) map[string]string {
+	if file == "" {
+		return nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var raw struct {
+		Containers struct {
+			BuildArgs map[string]string `yaml:"build_args"`
+		} `yaml:"containers"`
+	}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil
+	}
+	return raw.Containers.BuildArgs
+}
+
+// customImageBuild returns the build for containers.dockerfile on top of
+// baseImage, or false if no custom Dockerfile is configured. The base image is
+// passed as BASE_IMAGE unless build_args sets it.
+func customImageBuild(baseImage string) (imageBuild, bool) {
+	if config.Containers.Dockerfile == "" {
+		return imageBuild{}, false
+	}
+	dockerfile, _ := filepath.Abs(expandPath(config.Containers.Dockerfile))
+	args := map[string]string{baseImageArg: baseImage}
+	for k, v := range configBuildArgs() {
+		args[k] = v
+	}
+	return imageBuild{
+		Dockerfile: dockerfile,
+		Context:    filepath.Dir(dockerfile),
+		BuildArgs:  args,
+	}, true
+}
+
+// containerImage returns the image new containers run: the maestro image, or
+// the custom build layered on it when containers.dockerfile is set.
+func containerImage(web bool) (string, error) {
+	base := getDockerImage()
+	if web {
+		base = getDockerWebImage()
+	}
+	build, ok := customImageBuild(base)
+	if !ok {
+		return base, nil
+	}
+	hash, err := build.hash()
+	if err != nil {
+		return "", fmt.Errorf("containers.dockerfile: %w", err)
+	}
+	return customImageRepo + ":" + hash, nil
+}
+
+// currentImages lists the images this binary creates containers from: the
+// maestro and web images and, with containers.dockerfile, the custom images
+// built on top of them.
+func currentImages() []string {
+	images := []string{getDockerImage(), getDockerWebImage()}
+	for _, web := range []bool{false, true} {
+		if image, err := containerImage(web); err == nil && !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// ensureContainerImage makes the image for new containers available, pulling
+// or building the maestro image and then building the custom image on top of
+// it if needed. The custom image is tagged by the hash of its inputs, so
+// changing the Dockerfile or build args triggers a rebuild.
+func ensureContainerImage(web, forceReuseImage bool) (string, error) {
+	base := getDockerImage()
+	if web {
+		base = getDockerWebImage()
+	}
+	if err := ensureDockerImage(base, forceReuseImage); err != nil {
+		return "", err
+	}
+
+	image, err := containerImage(web)
+	if err != nil || image == base {
+		return image, err
+	}
+	if imageExists(image) {
+		return image, nil
+	}
+
+	build, _ := customImageBuild(base)
+	logging.Infof("Building custom image %s from %s...", image, build.Dockerfile)
+	if err := build.run(image); err != nil {
+		return "", err
+	}
+	return image, nil
+}
+
+// Values of containers.image_pull_policy
+const (
+	imagePullIfNotPresent = "if-not-present" // Pull only images missing locally (default)
+	imagePullAlways       = "always"         // Pull registry images before every new container
+	imagePullNever        = "never"          // Only use local images
+)
+
+// imagePullPolicy returns the effective image pull policy.
+// containers.offline_mode means never, even with --reuse-image, which
+// otherwise means if-not-present.
+func imagePullPolicy(forceReuseImage bool) string {
+	if config.Containers.OfflineMode {
+		return imagePullNever
+	}
+	if forceReuseImage {
+		return imagePullIfNotPresent
+	}
+	switch policy := strings.ToLower(config.Containers.ImagePullPolicy); policy {
+	case "", imagePullIfNotPresent:
+		return imagePullIfNotPresent
+	case imagePullAlways, imagePullNever:
+		return policy
+	default:
+		logging.Warnf("invalid containers.image_pull_policy %q, using %s", config.Containers.ImagePullPolicy, imagePullIfNotPresent)
+		return imagePullIfNotPresent
+	}
+}
+
+// imageExists reports whether image is present locally.
+func imageExists(image string) bool {
+	output, err := logging.Command("docker", "images", "-q", image).Output()
+	return err == nil && len(strings.TrimSpace(string(output))) > 0
+}
+
+// staleLocalBuild reports whether image was built locally by maestro from
+// the same build context as build but different inputs. Pulled images carry
+// no hash, and images built before the context was recorded no context, so
+// neither is ever considered stale.
+func staleLocalBuild(image string, build imageBuild) bool {
+	return staleBuild(container.ImageLabel(image, imageBuildHashLabel),
+		container.ImageLabel(image, imageBuildContextLabel), build)
+}
+
+// staleBuild is staleLocalBuild given the image's recorded hash and context.
+func staleBuild(recordedHash, recordedContext string, build imageBuild) bool {
+	if recordedHash == "" || recordedContext == "" || recordedContext != build.Context {
+		return false
+	}
+	hash, err := build.hash()
+	return err == nil && hash != recordedHash
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestDockerfile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Dockerfile")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImageBuildHash(t *testing.T) {
+	dockerfile := writeTestDockerfile(t, "ARG BASE_IMAGE\nFROM ${BASE_IMAGE}\n")
+	build := imageBuild{Dockerfile: dockerfile, BuildArgs: map[string]string{"A": "1", "B": "2"}}
+
+	h1, err := build.hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := imageBuild{Dockerfile: dockerfile, BuildArgs: map[string]string{"B": "2", "A": "1"}}
+	if h2, _ := same.hash(); h2 != h1 {
+		t.Errorf("hash depends on map order: %s != %s", h1, h2)
+	}
+
+	changed := imageBuild{Dockerfile: dockerfile, BuildArgs: map[string]string{"A": "1", "B": "3"}}
+	if h3, _ := changed.hash(); h3 == h1 {
+		t.Error("changing a build arg should change the hash")
+	}
+
+	if err := os.WriteFile(dockerfile, []byte("FROM scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if h4, _ := build.hash(); h4 == h1 {
+		t.Error("changing the Dockerfile should change the hash")
+	}
+
+	if _, err := (imageBuild{Dockerfile: filepath.Join(t.TempDir(), "missing")}).hash(); err == nil {
+		t.Error("expected error for a missing Dockerfile")
+	}
+}
+
+func TestImageBuildDockerArgs(t *testing.T) {
+	build := imageBuild{Dockerfile: "/x/Dockerfile", Context: "/x", BuildArgs: map[string]string{"Z": "1", "A": "b c"}}
+	got := strings.Join(build.dockerArgs("maestro-custom:abc", "abc"), " ")
+	want := "build -t maestro-custom:abc -f /x/Dockerfile --label maestro.build_hash=abc --label maestro.build_context=/x --build-arg A=b c --build-arg Z=1 /x"
+	if got != want {
+		t.Errorf("dockerArgs =\n  %s\nwant\n  %s", got, want)
+	}
+}
+
+func TestStaleBuild(t *testing.T) {
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	if err := os.WriteFile(dockerfile, []byte("FROM node:20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	build := imageBuild{Dockerfile: dockerfile, Context: dir}
+	hash, err := build.hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		hash, context string
+		want          bool
+	}{
+		{"unchanged", hash, dir, false},
+		{"Dockerfile changed", "0123456789ab", dir, true},
+		{"pulled image", "", "", false},
+		{"built before the context was recorded", "0123456789ab", "", false},
+		{"built from another directory", "0123456789ab", "/opt/maestro", false},
+	}
+	for _, tt := range tests {
+		if got := staleBuild(tt.hash, tt.context, build); got != tt.want {
+			t.Errorf("%s: staleBuild() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCustomImageBuild(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+
+	config = &Config{}
+	if _, ok := customImageBuild("maestro:latest"); ok {
+		t.Error("no custom build expected without containers.dockerfile")
+	}
+
+	dockerfile := writeTestDockerfile(t, "ARG BASE_IMAGE\nFROM ${BASE_IMAGE}\n")
+	config.Containers.Dockerfile = dockerfile
+	build, ok := customImageBuild("maestro:latest")
+	if !ok {
+		t.Fatal("expected a custom build")
+	}
+	if build.BuildArgs[baseImageArg] != "maestro:latest" {
+		t.Errorf("BASE_IMAGE = %q, want maestro:latest", build.BuildArgs[baseImageArg])
+	}
+	if build.Context != filepath.Dir(dockerfile) {
+		t.Errorf("context = %q, want the Dockerfile's directory", build.Context)
+	}
+}
+
+func TestBuildArgsFromFile_KeepsCase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "containers:\n  build_args:\n    NODE_VERSION: \"22\"\n    pip_index: https://pypi.example.com\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	args := buildArgsFromFile(path)
+	if args["NODE_VERSION"] != "22" || args["pip_index"] != "https://pypi.example.com" {
+		t.Errorf("unexpected build args: %v", args)
+	}
+	if buildArgsFromFile("") != nil || buildArgsFromFile(filepath.Join(t.TempDir(), "missing.yml")) != nil {
+		t.Error("expected nil without a config file")
+	}
+}
+
+func TestImagePullPolicy(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+
+	tests := []struct {
+		policy  string
+		offline bool
+		reuse   bool
+		want    string
+	}{
+		{"", false, false, imagePullIfNotPresent},
+		{"always", false, false, imagePullAlways},
+		{"Never", false, false, imagePullNever},
+		{"sometimes", false, false, imagePullIfNotPresent},
+		{"always", false, true, imagePullIfNotPresent},
+		{"never", false, true, imagePullIfNotPresent},
+		{"always", true, false, imagePullNever},
+		{"always", true, true, imagePullNever},
+	}
+	for _, tt := range tests {
+		config = &Config{}
+		config.Containers.ImagePullPolicy = tt.policy
+		config.Containers.OfflineMode = tt.offline
+		if got := imagePullPolicy(tt.reuse); got != tt.want {
+			t.Errorf("policy=%q offline=%v reuse=%v: got %s, want %s", tt.policy, tt.offline, tt.reuse, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// initCommandData is the template data for containers.init_commands.
+type initCommandData struct {
+	ContainerName string
+	BranchName    string
+}
+
+// renderInitCommand expands {{.ContainerName}} and {{.BranchName}} in an
+// init command.
+func renderInitCommand(command string, data initCommandData) (string, error) {
+	tmpl, err := template.New("init_command").Option("missingkey=error").Parse(command)
+	if err != nil {
+		return "", fmt.Errorf("invalid init command %q: %w", command, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid init command %q: %w", command, err)
+	}
+	return b.String(), nil
+}
+
+// runInitCommands runs containers.init_commands in the container as node
+// from the workspace root, printing each command's output. A failing command is warned about and the
+// rest still run.
+func runInitCommands(containerName, branchName string, commands []string) {
+	if len(commands) == 0 {
+		return
+	}
+	logging.Infof("Running %d init command(s)...", len(commands))
+	data := initCommandData{ContainerName: containerName, BranchName: branchName}
+	for _, command := range commands {
+		rendered, err := renderInitCommand(command, data)
+		if err != nil {
+			logging.Warnf("Skipping init command: %v", err)
+			continue
+		}
+		fmt.Printf("$ %s\n", rendered)
+		output, err := logging.Command("docker", "exec", "-u", "node", "-w", workspaceDir(), containerName, "sh", "-c", rendered).CombinedOutput()
+		if len(output) > 0 {
+			fmt.Print(string(output))
+			if !strings.HasSuffix(string(output), "\n") {
+				fmt.Println()
+			}
+		}
+		if err != nil {
+			logging.Warnf("Init command failed: %s: %v", rendered, err)
+		}
+	}
+}
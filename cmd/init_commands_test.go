@@ -0,0 +1,42 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "testing"
+
+func TestRenderInitCommand(t *testing.T) {
+	data := initCommandData{ContainerName: "maestro-feat-auth-1", BranchName: "feat/auth"}
+	tests := []struct {
+		command string
+		want    string
+		wantErr bool
+	}{
+		{"git -C /workspace config pull.rebase false", "git -C /workspace config pull.rebase false", false},
+		{"echo {{.BranchName}} > /tmp/{{.ContainerName}}", "echo feat/auth > /tmp/maestro-feat-auth-1", false},
+		{"awk '{print $1}' file", "awk '{print $1}' file", false},
+		{"echo {{.Branch}}", "", true},
+		{"echo {{.BranchName", "", true},
+	}
+	for _, tt := range tests {
+		got, err := renderInitCommand(tt.command, data)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("renderInitCommand(%q) error = %v, wantErr %v", tt.command, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("renderInitCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
@@ -17,26 +17,127 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/api"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls", "ps"},
 	Short:   "List all maestro containers",
-	Long:    `List all maestro containers with their status and attention indicators.`,
-	RunE:    runList,
+	Long: `List all maestro containers with their state, branch, git status, auth
+expiry, last activity and uptime.
+
+Columns are dropped to fit narrow terminals. When output is not a terminal,
+every column is printed tab-separated without decoration. Use --names to print
+only container names, e.g. for xargs:
+
+  maestro list --project webapp --names | xargs -n1 maestro stop`,
+	RunE: runList,
 }
 
+var (
+	flagListRunning        bool
+	flagListStopped        bool
+	flagListNeedsAttention bool
+	flagListProject        string
+	flagListNames          bool
+	flagListLong           bool
+)
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().BoolVar(&flagListRunning, "running", false, "Only list running containers")
+	listCmd.Flags().BoolVar(&flagListStopped, "stopped", false, "Only list stopped containers")
+	listCmd.Flags().BoolVar(&flagListNeedsAttention, "needs-attention", false, "Only list containers waiting on you (idle, waiting or asking a question)")
+	listCmd.Flags().StringVar(&flagListProject, "project", "", "Only list containers of a project (name or directory)")
+	listCmd.Flags().BoolVar(&flagListNames, "names", false, "Print container names only")
+	listCmd.Flags().BoolVarP(&flagListLong, "long", "l", false, "Add a DESCRIPTION column with the task each container was created for")
+	listCmd.MarkFlagsMutuallyExclusive("running", "stopped")
+	listCmd.MarkFlagsMutuallyExclusive("stopped", "needs-attention")
+}
+
+// listFilter selects which containers 'maestro list' prints.
+type listFilter struct {
+	Running        bool
+	Stopped        bool
+	NeedsAttention bool
+	Project        string // maestro.project label value, "" for any
+}
+
+// match reports whether c passes every filter that is set.
+func (f listFilter) match(c container.Info) bool {
+	if f.Running && c.Status != "running" {
+		return false
+	}
+	if f.Stopped && c.Status == "running" {
+		return false
+	}
+	if f.NeedsAttention && !container.NeedsAttention(c) {
+		return false
+	}
+	if f.Project != "" && c.Project != f.Project {
+		return false
+	}
+	return true
+}
+
+// active reports whether any filter is set.
+func (f listFilter) active() bool {
+	return f.Running || f.Stopped || f.NeedsAttention || f.Project != ""
+}
+
+// resolveListProject maps a --project value to a maestro.project label. A
+// configured project name is used as is; a directory selects the configured
+// project containing it. Anything else is taken as a literal label value, so
+// containers of projects since removed from the config can still be listed.
+func resolveListProject(value string) (string, error) {
+	if _, ok := config.Projects[value]; ok {
+		return value, nil
+	}
+	dir := expandPath(value)
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", value, err)
+		}
+		_, name, err := matchProject(abs, "", false, config.Projects)
+		if err != nil {
+			return "", err
+		}
+		if name == "" {
+			return "", fmt.Errorf("no configured project contains %s", abs)
+		}
+		return name, nil
+	}
+	return value, nil
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	filter := listFilter{
+		Running:        flagListRunning,
+		Stopped:        flagListStopped,
+		NeedsAttention: flagListNeedsAttention,
+	}
+	if flagListProject != "" {
+		project, err := resolveListProject(flagListProject)
+		if err != nil {
+			return err
+		}
+		filter.Project = project
+	}
+
 	svc := newContainerService()
 	defer svc.Close()
 
@@ -44,6 +145,9 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		// Fall back to Docker responsive check for better error messages
 		if !container.IsDockerResponsive() {
+			if flagListNames {
+				return fmt.Errorf("failed to list containers: is Docker running?")
+			}
 			fmt.Println("No maestro containers found.")
 			fmt.Println("\nHint: Is Docker running?")
 			return nil
@@ -51,17 +155,47 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	if len(containers) == 0 {
+	container.MarkOutdatedImages(containers, currentImages())
+
+	var matched []container.Info
+	for _, c := range containers {
+		if filter.match(c) {
+			matched = append(matched, c)
+		}
+	}
+	matched = container.SortByPriority(matched)
+
+	if flagListNames {
+		for _, c := range matched {
+			fmt.Println(c.ShortName)
+		}
+		return nil
+	}
+
+	fd := os.Stdout.Fd()
+	if !term.IsTerminal(fd) {
+		writeListPlain(os.Stdout, matched, flagListLong)
+		return nil
+	}
+
+	if len(matched) == 0 {
+		if filter.active() {
+			fmt.Println("No containers match.")
+			return nil
+		}
 		fmt.Println("No maestro containers found.")
 		fmt.Println("Create one with: maestro new \"your task description\"")
 		return nil
 	}
 
-	// Display using unified display function
-	container.Display(containers, container.DisplayOptions{
-		ShowNumbers: false,
-		ShowTable:   true,
-	})
+	width, _, err := term.GetSize(fd)
+	if err != nil || width <= 0 {
+		width = 120
+	}
+	writeListTable(os.Stdout, matched, width, flagListLong)
+	if slices.ContainsFunc(matched, func(c container.Info) bool { return c.ImageOutdated }) {
+		fmt.Println("\n" + style.Glyph("⬆", "^") + " Created from an older image. Recreate with: maestro recreate <name>")
+	}
 
 	// Show quick help
 	fmt.Println("\nCommands:")
@@ -88,3 +222,257 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// listColumn is one column of the 'maestro list' table.
+type listColumn struct {
+	Header string
+	Value  func(c container.Info, tty bool) string
+}
+
+// listColumns are the table columns, in display order.
+var listColumns = []listColumn{
+	{"NAME", listName},
+	{"STATE", listState},
+	{"BRANCH", func(c container.Info, _ bool) string { return orDash(c.Branch) }},
+	{"GIT", func(c container.Info, _ bool) string { return orDash(strings.TrimSpace(c.GitStatus)) }},
+	{"AUTH", func(c container.Info, _ bool) string { return orDash(c.AuthStatus) }},
+	{"ACTIVITY", func(c container.Info, _ bool) string { return orDash(c.LastActivity) }},
+	{"UPTIME", func(c container.Info, _ bool) string { return orDash(container.Uptime(c)) }},
+	{"TASK", func(c container.Info, _ bool) string { return formatListTask(c) }},
+}
+
+// listDescriptionColumn is added by --long: the task description the
+// container was created for, on one line.
+var listDescriptionColumn = listColumn{"DESCRIPTION", func(c container.Info, _ bool) string {
+	return container.TaskDescriptionLine(c.Task)
+}}
+
+// listColumnsFor returns the columns to print, with DESCRIPTION last if long.
+func listColumnsFor(long bool) []listColumn {
+	cols := append([]listColumn(nil), listColumns...)
+	if long {
+		cols = append(cols, listDescriptionColumn)
+	}
+	return cols
+}
+
+// listDropOrder lists the columns removed, in order, until the table fits the
+// terminal. NAME, STATE and BRANCH are always shown.
+var listDropOrder = []string{"TASK", "UPTIME", "ACTIVITY", "AUTH", "GIT"}
+
+// listMinBranchWidth is the narrowest the BRANCH column is truncated to.
+const listMinBranchWidth = 12
+
+// listDescriptionWidth caps the DESCRIPTION column, which is truncated rather
+// than dropped to fit the terminal.
+const listDescriptionWidth = 60
+
+// listColumnGap separates table columns.
+const listColumnGap = "  "
+
+// listName returns the container name, flagging containers without a
+// firewall or on an outdated image the way the TUI does.
+func listName(c container.Info, tty bool) string {
+	if !tty {
+		return c.ShortName
+	}
+	name := c.ShortName
+	if c.NoFirewall {
+		name = "🔓 " + name
+	}
+	if c.ImageOutdated {
+		name += " " + style.Glyph("⬆", "^")
+	}
+	return name
+}
+
+// listState mirrors the TUI's status column. Glyphs are only used on a
+// terminal.
+func listState(c container.Info, tty bool) string {
+	glyph, word := containerState(c)
+	if c.HasWeb {
+		word += "/web"
+	}
+	if !tty {
+		return word
+	}
+	return glyph + " " + word
+}
+
+// containerState returns the TUI's glyph (ASCII in ASCII mode) and word for a container's state.
+func containerState(c container.Info) (glyph, word string) {
+	switch {
+	case c.Status == "exited":
+		return style.Glyph("○", "o"), "stopped"
+	case c.Status != "running":
+		return "?", c.Status
+	case c.IsDormant:
+		return style.Glyph("◌", "-"), "dormant"
+	}
+	switch c.AgentState {
+	case "question":
+		return "?", "question"
+	case "waiting":
+		return style.Glyph("◷", "~"), "waiting"
+	case "idle":
+		return style.Warning(), "idle"
+	case "clearing":
+		return style.Glyph("↻", "@"), "clearing"
+	case "starting":
+		return style.Glyph("⋯", "."), "starting"
+	case "active":
+		return style.Glyph("●", "*"), "working"
+	default:
+		return style.Glyph("●", "*"), "running"
+	}
+}
+
+// formatListTask returns the agent's current task, like the TUI's task column.
+func formatListTask(c container.Info) string {
+	if c.Status != "running" {
+		return "-"
+	}
+	if c.CurrentTask != "" {
+		if c.TaskProgress != "" {
+			return c.CurrentTask + " (" + c.TaskProgress + ")"
+		}
+		return c.CurrentTask
+	}
+	if c.TaskProgress != "" {
+		return c.TaskProgress + " done"
+	}
+	return "-"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// writeListPlain prints every column tab-separated, for pipes and files.
+func writeListPlain(w io.Writer, containers []container.Info, long bool) {
+	cols := listColumnsFor(long)
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		headers[i] = col.Header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, c := range containers {
+		fields := make([]string, len(cols))
+		for i, col := range cols {
+			fields[i] = strings.ReplaceAll(col.Value(c, false), "\t", " ")
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+}
+
+// writeListTable prints an aligned table no wider than width, dropping
+// low-priority columns and then truncating branch, task and description as
+// needed.
+func writeListTable(w io.Writer, containers []container.Info, width int, long bool) {
+	cols := listColumnsFor(long)
+	cells := make([][]string, len(containers))
+	for i, c := range containers {
+		cells[i] = make([]string, len(cols))
+		for j, col := range cols {
+			cells[i][j] = col.Value(c, true)
+		}
+	}
+
+	widths := func() []int {
+		ws := make([]int, len(cols))
+		for j, col := range cols {
+			ws[j] = lipgloss.Width(col.Header)
+			for i := range cells {
+				ws[j] = max(ws[j], lipgloss.Width(cells[i][j]))
+			}
+		}
+		return ws
+	}
+	total := func(ws []int) int {
+		sum := len(listColumnGap) * (len(ws) - 1)
+		for _, n := range ws {
+			sum += n
+		}
+		return sum
+	}
+	drop := func(header string) {
+		for j, col := range cols {
+			if col.Header == header {
+				cols = append(cols[:j], cols[j+1:]...)
+				for i := range cells {
+					cells[i] = append(cells[i][:j], cells[i][j+1:]...)
+				}
+				return
+			}
+		}
+	}
+
+	ws := widths()
+	// Long task descriptions are shortened before any column is dropped
+	const maxTaskWidth = 40
+	capWidths := func() {
+		for j, col := range cols {
+			switch col.Header {
+			case "TASK":
+				ws[j] = min(ws[j], maxTaskWidth)
+			case "DESCRIPTION":
+				ws[j] = min(ws[j], listDescriptionWidth)
+			}
+		}
+	}
+	capWidths()
+	for _, header := range listDropOrder {
+		if total(ws) <= width {
+			break
+		}
+		drop(header)
+		ws = widths()
+		capWidths()
+	}
+	// The description was asked for, so it is shortened instead of dropped
+	for j, col := range cols {
+		if col.Header == "DESCRIPTION" {
+			if over := total(ws) - width; over > 0 {
+				ws[j] = max(ws[j]-over, len(col.Header))
+			}
+		}
+	}
+	for j, col := range cols {
+		if col.Header == "BRANCH" {
+			if over := total(ws) - width; over > 0 {
+				ws[j] = max(ws[j]-over, listMinBranchWidth)
+			}
+		}
+	}
+
+	line := make([]string, len(cols))
+	for j, col := range cols {
+		line[j] = padCell(col.Header, ws[j], j == len(cols)-1)
+	}
+	fmt.Fprintln(w, strings.Join(line, listColumnGap))
+	for i := range cells {
+		for j := range cols {
+			line[j] = padCell(cells[i][j], ws[j], j == len(cols)-1)
+		}
+		fmt.Fprintln(w, strings.Join(line, listColumnGap))
+	}
+}
+
+// padCell truncates s to width display cells and pads it unless it is the
+// last column.
+func padCell(s string, width int, last bool) string {
+	if lipgloss.Width(s) > width {
+		runes := []rune(s)
+		for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+			runes = runes[:len(runes)-1]
+		}
+		s = string(runes) + "…"
+	}
+	if last {
+		return s
+	}
+	return s + strings.Repeat(" ", width-lipgloss.Width(s))
+}
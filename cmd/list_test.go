@@ -0,0 +1,158 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var listTestContainers = []container.Info{
+	{ShortName: "feat-auth-1", Status: "running", AgentState: "question", Branch: "feat/auth", Project: "webapp",
+		StatusDetails: "Up 2 hours", GitStatus: "Δ3 ↑1     ", AuthStatus: "5h", LastActivity: "3m", CurrentTask: "Write login tests"},
+	{ShortName: "feat-db-1", Status: "running", AgentState: "active", Branch: "feat/db", Project: "backend",
+		StatusDetails: "Up 10 minutes"},
+	{ShortName: "fix-idle-1", Status: "running", AgentState: "idle", IsDormant: true, Branch: "fix/idle"},
+	{ShortName: "old-1", Status: "exited", Branch: "old", Project: "webapp", StatusDetails: "Exited (0) 2 days ago"},
+}
+
+func listNames(containers []container.Info, f listFilter) []string {
+	var names []string
+	for _, c := range containers {
+		if f.match(c) {
+			names = append(names, c.ShortName)
+		}
+	}
+	return names
+}
+
+func TestListFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter listFilter
+		want   string
+	}{
+		{"none", listFilter{}, "feat-auth-1 feat-db-1 fix-idle-1 old-1"},
+		{"running", listFilter{Running: true}, "feat-auth-1 feat-db-1 fix-idle-1"},
+		{"stopped", listFilter{Stopped: true}, "old-1"},
+		{"needs attention skips dormant", listFilter{NeedsAttention: true}, "feat-auth-1"},
+		{"project", listFilter{Project: "webapp"}, "feat-auth-1 old-1"},
+		{"combined", listFilter{Running: true, Project: "webapp"}, "feat-auth-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(listNames(listTestContainers, tt.filter), " ")
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveListProject(t *testing.T) {
+	origConfig := config
+	defer func() { config = origConfig }()
+
+	dir := t.TempDir()
+	config = &Config{Projects: map[string]ProjectConfig{"webapp": {Path: dir}}}
+
+	if got, err := resolveListProject("webapp"); err != nil || got != "webapp" {
+		t.Errorf("name: got %q, %v", got, err)
+	}
+	if got, err := resolveListProject(dir); err != nil || got != "webapp" {
+		t.Errorf("directory: got %q, %v", got, err)
+	}
+	if got, err := resolveListProject("removed-project"); err != nil || got != "removed-project" {
+		t.Errorf("literal label: got %q, %v", got, err)
+	}
+	if _, err := resolveListProject(t.TempDir()); err == nil {
+		t.Error("expected error for a directory outside every project")
+	}
+}
+
+func TestWriteListPlain(t *testing.T) {
+	var buf bytes.Buffer
+	writeListPlain(&buf, listTestContainers[:1], false)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", buf.String())
+	}
+	want := "feat-auth-1\tquestion\tfeat/auth\tΔ3 ↑1\t5h\t3m\t2 hours\tWrite login tests"
+	if lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+}
+
+func TestWriteListTable_FitsWidth(t *testing.T) {
+	for _, width := range []int{200, 80, 60, 40} {
+		var buf bytes.Buffer
+		writeListTable(&buf, listTestContainers, width, false)
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		for _, line := range lines {
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("width %d: line is %d cells: %q", width, w, line)
+			}
+		}
+		header := lines[0]
+		for _, col := range []string{"NAME", "STATE", "BRANCH"} {
+			if !strings.Contains(header, col) {
+				t.Errorf("width %d: %s column dropped: %q", width, col, header)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	writeListTable(&buf, listTestContainers, 200, false)
+	if header := strings.SplitN(buf.String(), "\n", 2)[0]; !strings.Contains(header, "TASK") {
+		t.Errorf("wide terminal should show every column: %q", header)
+	}
+}
+
+func TestWriteListTable_LongDescription(t *testing.T) {
+	containers := []container.Info{
+		{ShortName: "feat-auth-1", Status: "running", Branch: "feat/auth",
+			Task: "Add OAuth login\n\nUse the existing session store and add tests for the callback handler"},
+		{ShortName: "old-1", Status: "exited", Branch: "old"},
+	}
+	for _, width := range []int{200, 80, 50} {
+		var buf bytes.Buffer
+		writeListTable(&buf, containers, width, true)
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		if !strings.Contains(lines[0], "DESCRIPTION") {
+			t.Errorf("width %d: DESCRIPTION column dropped: %q", width, lines[0])
+		}
+		if len(lines) != 3 {
+			t.Fatalf("width %d: multi-line task broke rows: %q", width, lines)
+		}
+		if !strings.Contains(lines[1], "Add OAuth") || !strings.HasSuffix(lines[2], container.UnknownTask) {
+			t.Errorf("width %d: unexpected rows %q", width, lines[1:])
+		}
+		for _, line := range lines {
+			if w := lipgloss.Width(line); w > width {
+				t.Errorf("width %d: line is %d cells: %q", width, w, line)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	writeListTable(&buf, containers, 200, false)
+	if strings.Contains(buf.String(), "DESCRIPTION") {
+		t.Error("DESCRIPTION should only be shown with --long")
+	}
+}
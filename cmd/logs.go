@@ -0,0 +1,206 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var (
+	logsContainers string
+	logsNoColor    bool
+	logsTail       string
+)
+
+// logTagColors are cycled through to tell containers apart in multiplexed
+// logs.
+var logTagColors = []lipgloss.Color{
+	style.OceanTide,
+	style.SunsetGlow,
+	style.HotPink,
+	style.NeonGreen,
+	style.CrimsonPulse,
+	style.SilverMist,
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [name]",
+	Short: "Follow a container's logs, or several containers' at once",
+	Long: `Follow the docker logs of a container: its startup and background output,
+not the Claude session (connect for that).
+
+With --containers, the logs of several containers are followed together, each
+line prefixed with [<short-name>] in its own color. When a container's log
+stream ends, [<short-name> exited] is printed and the others carry on.
+
+Examples:
+  maestro logs feat-auth-1
+  maestro logs --containers feat-auth-1,fix-login-2
+  maestro logs --containers feat-auth-1,fix-login-2 --no-color > all.log`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLogs,
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+	logsCmd.Flags().StringVar(&logsContainers, "containers", "", "Comma-separated containers (short names or nicknames) to follow together")
+	logsCmd.Flags().BoolVar(&logsNoColor, "no-color", false, "Don't color the container prefixes")
+	logsCmd.Flags().StringVar(&logsTail, "tail", "100", "Lines to show from the end of each log before following (\"all\" for everything)")
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	names := parseContainerList(logsContainers)
+	switch {
+	case len(args) == 1 && len(names) > 0:
+		return fmt.Errorf("give either a container name or --containers, not both")
+	case len(args) == 1:
+		names = args
+	case len(names) == 0:
+		return fmt.Errorf("name a container, or several with --containers")
+	}
+
+	if err := checkDockerRunning(); err != nil {
+		return err
+	}
+
+	store := getNicknameStore()
+	containerNames := make([]string, len(names))
+	for i, name := range names {
+		if resolved, ok := store.Get(name); ok {
+			containerNames[i] = resolved
+		} else {
+			containerNames[i] = resolveContainerName(name)
+		}
+	}
+
+	// A single container's logs go straight through
+	if len(containerNames) == 1 {
+		dockerLogs := logging.Command("docker", "logs", "--follow", "--tail", logsTail, containerNames[0])
+		dockerLogs.Stdout = os.Stdout
+		dockerLogs.Stderr = os.Stderr
+		return logging.Run(dockerLogs)
+	}
+
+	// Several: one docker logs per container, fanned in line by line
+	out := &syncWriter{w: os.Stdout}
+	var wg sync.WaitGroup
+	for i, name := range containerNames {
+		shortName := container.GetShortName(name, config.Containers.Prefix)
+		color := logTagColors[i%len(logTagColors)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			followLogs(name, shortName, color, out)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// parseContainerList splits a --containers value, dropping blanks and
+// repeats.
+func parseContainerList(value string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// logTag renders "[text]" in color, or plain with --no-color.
+func logTag(text string, color lipgloss.Color) string {
+	tag := "[" + text + "]"
+	if logsNoColor {
+		return tag
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(tag)
+}
+
+// followLogs follows one container's logs into out with each line prefixed
+// by its tag, then reports that the stream ended.
+func followLogs(containerName, shortName string, color lipgloss.Color, out io.Writer) {
+	lines := &prefixWriter{prefix: logTag(shortName, color) + " ", out: out}
+	dockerLogs := logging.Command("docker", "logs", "--follow", "--tail", logsTail, containerName)
+	// The same writer for both streams, so exec copies them in one goroutine
+	dockerLogs.Stdout = lines
+	dockerLogs.Stderr = lines
+	if err := dockerLogs.Run(); err != nil {
+		logging.Debugf("docker logs %s: %v", containerName, err)
+	}
+	lines.Flush()
+	fmt.Fprintln(out, logTag(shortName+" exited", color))
+}
+
+// syncWriter serializes writes from several goroutines.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// prefixWriter writes whole lines to out, each starting with prefix, so
+// lines from several writers sharing out never interleave. A final line
+// without a newline is held until Flush.
+type prefixWriter struct {
+	prefix string
+	out    io.Writer
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := io.WriteString(p.out, p.prefix+string(p.buf[:i+1])); err != nil {
+			return len(data), err
+		}
+		p.buf = p.buf[i+1:]
+	}
+	return len(data), nil
+}
+
+// Flush writes a pending partial line.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) > 0 {
+		io.WriteString(p.out, p.prefix+string(p.buf)+"\n")
+		p.buf = nil
+	}
+}
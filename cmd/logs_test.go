@@ -0,0 +1,53 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseContainerList(t *testing.T) {
+	got := parseContainerList(" feat-a-1, fix-b-2,,feat-a-1 ")
+	if want := []string{"feat-a-1", "fix-b-2"}; !slices.Equal(got, want) {
+		t.Errorf("parseContainerList() = %v, want %v", got, want)
+	}
+	if got := parseContainerList(""); len(got) != 0 {
+		t.Errorf("parseContainerList(\"\") = %v, want none", got)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out strings.Builder
+	w := &prefixWriter{prefix: "[a-1] ", out: &out}
+	w.Write([]byte("first\nsec"))
+	if got := out.String(); got != "[a-1] first\n" {
+		t.Errorf("a partial line should be held back, got %q", got)
+	}
+	w.Write([]byte("ond\nthird"))
+	w.Flush()
+	if got, want := out.String(), "[a-1] first\n[a-1] second\n[a-1] third\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLogTag_NoColor(t *testing.T) {
+	logsNoColor = true
+	defer func() { logsNoColor = false }()
+	if got := logTag("a-1 exited", logTagColors[0]); got != "[a-1 exited]" {
+		t.Errorf("logTag() = %q, want plain [a-1 exited]", got)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var migrateConfigDryRun bool
+
+var migrateConfigCmd = &cobra.Command{
+	Use:   "migrate-config",
+	Short: "Move a pre-1.0 ~/.mcl configuration to ~/.maestro",
+	Long: `Move the old ~/.mcl.yml config file and the contents of ~/.mcl (Claude
+auth, gh config, certificates, ...) to the ~/.maestro layout.
+
+Paths in the config that point into ~/.mcl are rewritten to ~/.maestro.
+Nothing that already exists in ~/.maestro is overwritten; those items are
+reported and left in place. Once migrated, ~/.mcl.yml is kept as
+~/.mcl.yml.bak.
+
+Examples:
+  maestro migrate-config --dry-run   # Show what would move
+  maestro migrate-config`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateConfigCmd)
+	migrateConfigCmd.Flags().BoolVar(&migrateConfigDryRun, "dry-run", false, "Show what would be moved without changing anything")
+}
+
+// migrationStep is one file or directory 'maestro migrate-config' moves.
+// Steps with a skip reason are reported but not moved.
+type migrationStep struct {
+	from    string
+	to      string
+	rewrite bool // rewrite legacy paths in the file's contents (the config file)
+	skip    string
+}
+
+// planConfigMigration lists the moves from the legacy config file and
+// directory into configDir. Missing legacy paths contribute nothing.
+func planConfigMigration(legacyFile, legacyDir, configDir string) ([]migrationStep, error) {
+	var steps []migrationStep
+
+	if legacyFile != "" {
+		if _, err := os.Stat(legacyFile); err == nil {
+			step := migrationStep{from: legacyFile, to: filepath.Join(configDir, "config.yml"), rewrite: true}
+			if _, err := os.Stat(step.to); err == nil {
+				step.skip = "already exists"
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	if legacyDir != "" {
+		entries, err := os.ReadDir(legacyDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", legacyDir, err)
+		}
+		for _, e := range entries {
+			step := migrationStep{from: filepath.Join(legacyDir, e.Name()), to: filepath.Join(configDir, e.Name())}
+			if _, err := os.Stat(step.to); err == nil {
+				step.skip = "already exists"
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	return steps, nil
+}
+
+// rewriteLegacyPaths points paths under legacyDir at configDir instead, in
+// both absolute and ~ form. Other text, including comments, is kept as is.
+func rewriteLegacyPaths(content, legacyDir, configDir string) string {
+	replacements := [][2]string{{legacyDir, configDir}}
+	if home, err := os.UserHomeDir(); err == nil {
+		if oldRel, err := filepath.Rel(home, legacyDir); err == nil && !strings.HasPrefix(oldRel, "..") {
+			newPath := configDir
+			if newRel, err := filepath.Rel(home, configDir); err == nil && !strings.HasPrefix(newRel, "..") {
+				newPath = "~/" + filepath.ToSlash(newRel)
+			}
+			replacements = append(replacements, [2]string{"~/" + filepath.ToSlash(oldRel), newPath})
+		}
+	}
+	for _, r := range replacements {
+		// Only whole path components, so ~/.mcl.yml or ~/.mcl-old are left alone
+		re := regexp.MustCompile(regexp.QuoteMeta(r[0]) + `([/"'\s]|$)`)
+		content = re.ReplaceAllString(content, strings.ReplaceAll(r[1], "$", "$$")+"$1")
+	}
+	return content
+}
+
+// applyConfigMigration performs the steps that aren't skipped and retires the
+// legacy config file and directory once emptied, so the startup warning stops.
+func applyConfigMigration(steps []migrationStep, legacyDir, configDir string) error {
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", configDir, err)
+	}
+
+	for _, step := range steps {
+		if step.skip != "" {
+			continue
+		}
+		if step.rewrite {
+			data, err := os.ReadFile(step.from)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", step.from, err)
+			}
+			content := rewriteLegacyPaths(string(data), legacyDir, configDir)
+			if err := os.WriteFile(step.to, []byte(content), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", step.to, err)
+			}
+			// Keep the old config, under a name that no longer counts as legacy
+			if err := os.Rename(step.from, step.from+".bak"); err != nil {
+				return fmt.Errorf("failed to rename %s: %w", step.from, err)
+			}
+			continue
+		}
+		if err := os.Rename(step.from, step.to); err != nil {
+			return fmt.Errorf("failed to move %s: %w", step.from, err)
+		}
+	}
+
+	// Only removed once empty; anything skipped stays for the user to sort out
+	if legacyDir != "" {
+		if err := os.Remove(legacyDir); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("%s Left %s in place: it still has files that were not moved\n", style.Warning(), legacyDir)
+		}
+	}
+	return nil
+}
+
+func runMigrateConfig(cmd *cobra.Command, args []string) error {
+	legacyFile := paths.LegacyConfigFile()
+	legacyDir := paths.LegacyConfigDir()
+	configDir := paths.GetConfigDir()
+
+	steps, err := planConfigMigration(legacyFile, legacyDir, configDir)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		fmt.Println("No legacy configuration found; nothing to migrate.")
+		return nil
+	}
+
+	for _, step := range steps {
+		if step.skip != "" {
+			fmt.Printf("  skip  %s (%s %s)\n", step.from, step.to, step.skip)
+			continue
+		}
+		fmt.Printf("  move  %s -> %s\n", step.from, step.to)
+	}
+
+	if migrateConfigDryRun {
+		fmt.Println("\nDry run: nothing was changed.")
+		return nil
+	}
+
+	if err := applyConfigMigration(steps, legacyDir, configDir); err != nil {
+		return err
+	}
+	fmt.Printf("\n%s Migrated to %s\n", style.Check(), configDir)
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRewriteLegacyPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	legacyDir := filepath.Join(home, ".mcl")
+	configDir := filepath.Join(home, ".maestro")
+
+	in := "claude:\n  auth_path: ~/.mcl/.claude\n" +
+		"github:\n  config_path: \"" + legacyDir + "/gh\"\n" +
+		"# copied from ~/.mcl.yml\n" +
+		"ssl:\n  certificates_path: ~/.mcl\n"
+	want := "claude:\n  auth_path: ~/.maestro/.claude\n" +
+		"github:\n  config_path: \"" + configDir + "/gh\"\n" +
+		"# copied from ~/.mcl.yml\n" +
+		"ssl:\n  certificates_path: ~/.maestro\n"
+
+	if got := rewriteLegacyPaths(in, legacyDir, configDir); got != want {
+		t.Errorf("rewriteLegacyPaths:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestConfigMigration(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	legacyFile := filepath.Join(home, ".mcl.yml")
+	legacyDir := filepath.Join(home, ".mcl")
+	configDir := filepath.Join(home, ".maestro")
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(legacyFile, "claude:\n  auth_path: ~/.mcl/.claude\n")
+	write(filepath.Join(legacyDir, ".claude", ".credentials.json"), "{}")
+	write(filepath.Join(legacyDir, "gh", "hosts.yml"), "old")
+	// Already migrated by hand; must not be overwritten
+	write(filepath.Join(configDir, "gh", "hosts.yml"), "new")
+
+	steps, err := planConfigMigration(legacyFile, legacyDir, configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 3 {
+		t.Fatalf("got %d steps, want 3: %+v", len(steps), steps)
+	}
+	for _, step := range steps {
+		wantSkip := filepath.Base(step.from) == "gh"
+		if (step.skip != "") != wantSkip {
+			t.Errorf("step %s skip = %q, want skipped %v", step.from, step.skip, wantSkip)
+		}
+	}
+
+	if err := applyConfigMigration(steps, legacyDir, configDir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "auth_path: ~/.maestro/.claude") {
+		t.Errorf("config not rewritten: %s", data)
+	}
+	if _, err := os.Stat(legacyFile + ".bak"); err != nil {
+		t.Errorf("legacy config should be kept as .bak: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(configDir, ".claude", ".credentials.json")); err != nil {
+		t.Errorf("auth dir not moved: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(configDir, "gh", "hosts.yml")); string(data) != "new" {
+		t.Errorf("existing gh config was overwritten: %q", data)
+	}
+	// gh was skipped, so the legacy dir stays
+	if _, err := os.Stat(filepath.Join(legacyDir, "gh")); err != nil {
+		t.Errorf("skipped gh dir should remain: %v", err)
+	}
+}
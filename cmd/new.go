@@ -18,14 +18,20 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"math/big"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -33,24 +39,53 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/uprockcom/maestro/assets"
+	"github.com/uprockcom/maestro/pkg/branchname"
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/daemon"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/system"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 	"github.com/uprockcom/maestro/pkg/version"
 )
 
 var (
-	specFile        string
-	noConnect       bool
-	exactPrompt     bool
-	flagProject     string
-	flagNoProject   bool
-	flagNick        string
-	flagModel       string
-	flagContacts    string // raw JSON contacts override
-	flagContactProf string // named contact profile from config
-	webMode         bool
+	specFile           string
+	specURL            string
+	flagPRTemplate     string
+	noConnect          bool
+	exactPrompt        bool
+	flagNoAI           bool
+	flagProject        string
+	flagNoProject      bool
+	flagNick           string
+	flagModel          string
+	flagContacts       string // raw JSON contacts override
+	flagContactProf    string // named contact profile from config
+	webMode            bool
+	flagPlanOnly       bool
+	flagContextLimit   int
+	flagForce          bool
+	flagNoTmux         bool
+	flagNoFirewall     bool
+	flagWorkspace      string
+	flagTaskWatch      string
+	flagLoop           bool
+	flagAttachExisting bool
+	flagReuseImage     bool
+	flagContinueFrom   string
+	flagRetry          int
+	flagWait           bool
+	flagWaitPattern    string
+	flagWaitTimeout    time.Duration
 )
 
+// retryDelay is how long 'maestro new --retry' waits between attempts.
+var retryDelay = 5 * time.Second
+
+// branchPromptModel is the Claude model used to generate branch names and
+// planning prompts.
+var branchPromptModel = branchname.DefaultModel
+
 var newCmd = &cobra.Command{
 	Use:   "new [description]",
 	Short: "Create a new development container",
@@ -60,17 +95,34 @@ Examples:
   maestro new "implement user authentication"
   maestro new --file specs/auth-design.md
   maestro new -f requirements.txt
+  maestro new --spec-url https://github.com/org/repo/issues/42  # Issue or PR via gh
+  maestro new -f https://example.com/spec.md   # URLs passed to --file are fetched too
+  maestro new --pr-template .github/pull_request_template.md "add caching"
   maestro new "add tests" --no-connect
   maestro new -e "/pr_review 123"     # Use exact prompt (no AI transformation)
-  maestro new -en "/help"              # Combine flags: exact + no-connect`,
+  maestro new -en "/help"              # Combine flags: exact + no-connect
+  maestro new --no-ai "fix login bug"  # No host-side AI calls (air-gapped hosts)
+  maestro new --plan-only "add caching" # Preview branch and prompt, no container
+  maestro new --no-firewall "explore"   # Unrestricted network access (use with care)
+  maestro new --workspace-dir /src "x"  # Project root other than containers.workspace
+  maestro new --attach-existing "x"     # Reuse a running container for the same branch
+  maestro new --reuse-image "x"         # Don't pull the image if it is present (CI)
+  maestro new --continue-from feat-x-1 "finish the tests"  # Follow up on a session
+  maestro new --retry 2 "x"             # Retry Docker setup up to twice on failure
+  maestro new -n --wait "x"             # Exit once Claude has finished (scripts)
+  maestro new --task-file-watch TASK.md # Create a container when TASK.md is written
+  maestro new --task-file-watch TASK.md --loop  # ...every time it is written`,
 	RunE: runNew,
 }
 
 func init() {
 	rootCmd.AddCommand(newCmd)
-	newCmd.Flags().StringVarP(&specFile, "file", "f", "", "Read task specification from file")
+	newCmd.Flags().StringVarP(&specFile, "file", "f", "", "Read task specification from file (http(s) URLs are fetched)")
+	newCmd.Flags().StringVar(&specURL, "spec-url", "", "Fetch the task specification from a URL; GitHub issue and PR links are read with gh")
 	newCmd.Flags().BoolVarP(&noConnect, "no-connect", "n", false, "Don't automatically connect after creation")
 	newCmd.Flags().BoolVarP(&exactPrompt, "exact", "e", false, "Use exact prompt without AI transformation")
+	newCmd.Flags().BoolVar(&flagNoAI, "no-ai", false, "Make no Claude calls on the host: derive the branch name from the description and use it as the prompt (Claude still runs in the container)")
+	newCmd.Flags().StringVar(&flagPRTemplate, "pr-template", "", "PR template (path or URL) to start the task description with; HTML comments are removed and the description is appended")
 	newCmd.Flags().StringVarP(&flagProject, "project", "p", "", "Use a named project from config")
 	newCmd.Flags().BoolVar(&flagNoProject, "no-project", false, "Force ad-hoc mode even inside a project directory")
 	newCmd.Flags().StringVar(&flagNick, "nick", "", "Assign a nickname to the new container")
@@ -78,12 +130,105 @@ func init() {
 	newCmd.Flags().StringVar(&flagContacts, "contacts", "", "Raw JSON contacts override (e.g. '{\"signal\":{\"recipient\":\"+1555\"}}')")
 	newCmd.Flags().StringVar(&flagContactProf, "contact-profile", "", "Named contact profile from config")
 	newCmd.Flags().BoolVarP(&webMode, "web", "w", false, "Enable browser support (Playwright + headless Chromium)")
+	newCmd.Flags().BoolVar(&flagForce, "force", false, "Skip safety prompts: create past daemon.max_containers and copy large projects without asking")
+	newCmd.Flags().BoolVar(&flagNoTmux, "no-tmux", false, "Run Claude directly via docker exec instead of inside tmux")
+	newCmd.Flags().BoolVar(&flagNoFirewall, "no-firewall", false, "Skip the outbound firewall, giving the container unrestricted network access (default from containers.default_no_firewall)")
+	newCmd.Flags().StringVar(&flagWorkspace, "workspace-dir", "", "Project root inside the container (default from containers.workspace)")
+	newCmd.Flags().BoolVar(&flagAttachExisting, "attach-existing", false, "If containers for the same branch are running, choose one to connect to instead of creating another")
+	newCmd.Flags().StringVar(&flagTaskWatch, "task-file-watch", "", "Wait for the file to be written, then create a container from its contents without connecting")
+	newCmd.Flags().BoolVar(&flagLoop, "loop", false, "With --task-file-watch, keep watching and create a container on every write")
+	newCmd.Flags().BoolVar(&flagReuseImage, "reuse-image", false, "Use the local image if present, without pulling it (overrides containers.image_pull_policy)")
+	newCmd.Flags().StringVar(&flagContinueFrom, "continue-from", "", "Start with context from a running container's session: the end of its Claude window, recent commits and their diff")
+	newCmd.Flags().IntVar(&flagContextLimit, "context-limit", container.DefaultMaxContextTokens, "Truncate the planning prompt to about this many tokens (0 disables; default from containers.max_context_tokens)")
+	newCmd.Flags().IntVar(&flagRetry, "retry", 0, "Retry container setup up to this many times if it fails, removing the partial container first")
+	newCmd.Flags().BoolVar(&flagWait, "wait", false, "After creating (and connecting to) the container, wait for Claude to finish the task and print the end of its window; exits 1 on --wait-timeout")
+	newCmd.Flags().StringVar(&flagWaitPattern, "wait-pattern", "", "With --wait, also treat Claude's window matching this regular expression as finished (the window includes the task prompt)")
+	newCmd.Flags().DurationVar(&flagWaitTimeout, "wait-timeout", defaultWaitTimeout, "With --wait, how long to wait for Claude to finish")
+	newCmd.Flags().BoolVar(&flagPlanOnly, "plan-only", false, "Print the generated branch name and planning prompt without creating a container (--model selects the generating model)")
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
+	// --workspace-dir overrides containers.workspace for this container only;
+	// later commands read the root back from the container's label
+	if cmd.Flags().Changed("workspace-dir") {
+		if !container.ValidWorkspacePath(flagWorkspace) {
+			return fmt.Errorf("invalid --workspace-dir %q: must be a clean absolute path using letters, digits, '/', '-', '_' and '.'", flagWorkspace)
+		}
+		config.Containers.Workspace = flagWorkspace
+	}
+	if cmd.Flags().Changed("context-limit") {
+		if flagContextLimit < 0 {
+			return fmt.Errorf("invalid --context-limit %d: must be 0 (no limit) or more", flagContextLimit)
+		}
+		config.Containers.MaxContextTokens = flagContextLimit
+	}
+	if flagRetry < 0 {
+		return fmt.Errorf("invalid --retry %d: must be 0 or more", flagRetry)
+	}
+
+	var waitFor *regexp.Regexp
+	if flagWait {
+		if flagNoTmux {
+			return fmt.Errorf("--wait watches Claude's tmux window and cannot be combined with --no-tmux")
+		}
+		if flagPlanOnly {
+			return fmt.Errorf("--wait cannot be combined with --plan-only")
+		}
+		if flagWaitTimeout <= 0 {
+			return fmt.Errorf("invalid --wait-timeout %s: must be more than 0", flagWaitTimeout)
+		}
+		var err error
+		if waitFor, err = waitPattern(flagWaitPattern); err != nil {
+			return err
+		}
+	} else if flagWaitPattern != "" || cmd.Flags().Changed("wait-timeout") {
+		return fmt.Errorf("--wait-pattern and --wait-timeout require --wait")
+	}
+
+	if flagLoop && flagTaskWatch == "" {
+		return fmt.Errorf("--loop requires --task-file-watch")
+	}
+	if flagTaskWatch != "" {
+		if specFile != "" || specURL != "" || flagPRTemplate != "" || len(args) > 0 || flagPlanOnly {
+			return fmt.Errorf("--task-file-watch reads the task from the file and cannot be combined with a description, --file, --spec-url, --pr-template or --plan-only")
+		}
+		if flagAttachExisting {
+			return fmt.Errorf("--attach-existing is interactive and cannot be combined with --task-file-watch")
+		}
+		if flagContinueFrom != "" {
+			return fmt.Errorf("--continue-from cannot be combined with --task-file-watch")
+		}
+		if flagWait {
+			return fmt.Errorf("--wait cannot be combined with --task-file-watch")
+		}
+		return runTaskFileWatch(cmd, flagTaskWatch, flagLoop)
+	}
+
+	if specFile != "" && specURL != "" {
+		return fmt.Errorf("--file and --spec-url cannot be combined")
+	}
+	if isSpecURL(specFile) {
+		specURL, specFile = specFile, ""
+	}
+
+	var prTemplate string
+	if flagPRTemplate != "" {
+		content, err := readPRTemplate(flagPRTemplate)
+		if err != nil {
+			return err
+		}
+		prTemplate = content
+	}
+
 	// Get task description
 	var taskDescription string
-	if specFile != "" {
+	if specURL != "" {
+		content, err := readSpecURL(specURL)
+		if err != nil {
+			return err
+		}
+		taskDescription = content
+	} else if specFile != "" {
 		content, err := os.ReadFile(specFile)
 		if err != nil {
 			return fmt.Errorf("failed to read spec file: %w", err)
@@ -92,17 +237,104 @@ func runNew(cmd *cobra.Command, args []string) error {
 	} else if len(args) > 0 {
 		taskDescription = strings.Join(args, " ")
 	} else {
-		fmt.Print("Enter task description: ")
+		if prTemplate != "" {
+			fmt.Print("Enter task description (added after the PR template): ")
+		} else {
+			fmt.Print("Enter task description: ")
+		}
 		reader := bufio.NewReader(os.Stdin)
 		desc, _ := reader.ReadString('\n')
 		taskDescription = strings.TrimSpace(desc)
 	}
+	taskDescription = withPRTemplate(prTemplate, taskDescription)
 
 	if taskDescription == "" {
 		return fmt.Errorf("task description is required")
 	}
 
-	fmt.Printf("Creating container for: %s\n", truncateString(taskDescription, 80))
+	if flagPlanOnly {
+		return runPlanOnly(taskDescription)
+	}
+
+	containerName, existing, err := createNewContainer(cmd, taskDescription)
+	if err != nil {
+		return err
+	}
+	if existing {
+		shortName := container.GetShortName(containerName, config.Containers.Prefix)
+		if noConnect {
+			fmt.Printf("Using existing container %s\n", shortName)
+			fmt.Printf("Connect with: maestro connect %s\n", shortName)
+			return nil
+		}
+		return syncAndConnect(containerName, "")
+	}
+
+	fmt.Printf("\n✅ Container %s is ready!\n", containerName)
+
+	// Without tmux, Claude only starts once someone connects
+	if flagNoTmux {
+		shortName := container.GetShortName(containerName, config.Containers.Prefix)
+		if noConnect {
+			fmt.Printf("Connect with: maestro connect %s\n", shortName)
+			fmt.Println("Claude starts with the task prompt on first connect.")
+			return nil
+		}
+		fmt.Println("\nStarting Claude...")
+		if err := runDirectClaude(containerName); err != nil {
+			fmt.Printf("\nWarning: Claude exited with an error: %v\n", err)
+		}
+		fmt.Printf("Reconnect with: maestro connect %s\n", shortName)
+		return nil
+	}
+
+	// Auto-connect unless --no-connect flag is set
+	if !noConnect {
+		fmt.Println("\nConnecting to container...")
+		printTmuxHints()
+
+		// Connect to tmux session
+		connectCmd := logging.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", tmuxSessionName())
+		connectCmd.Stdin = os.Stdin
+		connectCmd.Stdout = os.Stdout
+		connectCmd.Stderr = os.Stderr
+
+		if err := logging.Run(connectCmd); err != nil {
+			fmt.Printf("\nWarning: Failed to connect: %v\n", err)
+			fmt.Printf("You can connect later with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
+		}
+	} else {
+		fmt.Printf("Connect with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
+		fmt.Printf("Detach with: %s d\n", container.FormatTmuxKey(tmuxPrefix()))
+	}
+
+	if flagWait {
+		return waitForClaude(containerName, waitFor, flagWaitTimeout)
+	}
+	return nil
+}
+
+// createNewContainer runs the creation steps of 'maestro new' for a task,
+// from naming the branch through setup, and returns the container name. With
+// --attach-existing the user may pick a running container for the same branch
+// instead, reported by existing.
+func createNewContainer(cmd *cobra.Command, taskDescription string) (containerName string, existing bool, err error) {
+	if !flagForce {
+		if err := checkContainerLimit(); err != nil {
+			return "", false, err
+		}
+	}
+
+	logging.Infof("Creating container for: %s", truncateString(taskDescription, 80))
+
+	// Read the previous session first, so a stopped source fails fast
+	var continuedFrom, sessionSummary string
+	if flagContinueFrom != "" {
+		if continuedFrom, sessionSummary, err = continueFrom(flagContinueFrom); err != nil {
+			return "", false, err
+		}
+		logging.Infof("Continuing from: %s", container.GetShortName(continuedFrom, config.Containers.Prefix))
+	}
 
 	// Resolve model selection (flag > config > default "opus")
 	model := resolveModel(flagModel)
@@ -110,120 +342,308 @@ func runNew(cmd *cobra.Command, args []string) error {
 	// Resolve project
 	project, projectName, err := resolveProject(flagProject, flagNoProject)
 	if err != nil {
-		return fmt.Errorf("project resolution failed: %w", err)
+		return "", false, fmt.Errorf("project resolution failed: %w", err)
 	}
 	if projectName != "" {
-		fmt.Printf("Project: %s\n", projectName)
+		logging.Infof("Project: %s", projectName)
 	}
 
 	// Step 1: Generate branch name and planning prompt using Claude
 	branchName, planningPrompt, err := generateBranchAndPrompt(taskDescription, exactPrompt)
 	if err != nil {
-		return fmt.Errorf("failed to generate branch name: %w", err)
+		return "", false, fmt.Errorf("failed to generate branch name: %w", err)
 	}
 
 	// Validate the branch name and prompt user if invalid
-	if !isValidBranchName(branchName) {
+	if !branchname.IsValid(branchName) {
 		fmt.Printf("Generated branch name '%s' is invalid.\n", branchName)
 		branchName, err = promptUserForBranchName(taskDescription)
 		if err != nil {
-			return fmt.Errorf("failed to get branch name: %w", err)
+			return "", false, fmt.Errorf("failed to get branch name: %w", err)
+		}
+	}
+
+	// Offer running containers for the same branch before creating another
+	if flagAttachExisting {
+		name, err := chooseExistingContainer(cmd.Context(), containerBaseName(branchName, projectName))
+		if err != nil {
+			return "", false, err
+		}
+		if name != "" {
+			return name, true, nil
 		}
 	}
 
 	// Step 2: Get next container number
-	containerName, err := getNextContainerName(branchName, projectName)
+	containerName, err = getNextContainerName(branchName, projectName)
 	if err != nil {
-		return fmt.Errorf("failed to generate container name: %w", err)
+		return "", false, fmt.Errorf("failed to generate container name: %w", err)
 	}
 
-	fmt.Printf("Container name: %s\n", containerName)
-	fmt.Printf("Branch name: %s\n", branchName)
+	logging.Infof("Container name: %s", containerName)
+	logging.Infof("Branch name: %s", branchName)
+
+	// The branch name comes from the new task alone; the context only
+	// goes to Claude, cut to whatever the limit leaves after the task
+	planningPrompt = applyContextLimit(planningPrompt)
+	if continuedFrom != "" {
+		planningPrompt = continuePromptWithinLimit(sessionSummary, planningPrompt)
+	}
 
 	// Build labels
 	labels := map[string]string{}
+	if continuedFrom != "" {
+		labels[continuedFromLabel] = continuedFrom
+	}
 	if projectName != "" {
 		labels["maestro.project"] = projectName
 	}
 	if project != nil && !project.IsSinglePath() {
-		labels["maestro.workspace"] = "/workspace/" + filepath.Base(project.PrimaryPath())
+		labels["maestro.workspace"] = path.Join(workspaceDir(), filepath.Base(project.PrimaryPath()))
 	}
 
 	// Resolve contacts
 	contactsJSON, err := resolveContacts(flagContacts, flagContactProf)
 	if err != nil {
-		return fmt.Errorf("contacts: %w", err)
+		return "", false, fmt.Errorf("contacts: %w", err)
 	}
 	if contactsJSON != "" {
 		labels["maestro.contacts"] = contactsJSON
 	}
+	if flagNoTmux {
+		labels[container.NoTmuxLabel] = "true"
+	}
 
 	useWeb := webMode || config.Web.Enabled
 
-	// Run the shared container setup pipeline
-	if err := setupContainer(ContainerSetupOptions{
-		ContainerName: containerName,
-		BranchName:    branchName,
-		Prompt:        planningPrompt,
-		ExactPrompt:   exactPrompt,
-		Labels:        labels,
-		Project:       project,
-		ProjectName:   projectName,
-		Model:         model,
-		WebEnabled:    useWeb,
+	noFirewall := config.Containers.DefaultNoFirewall
+	if cmd.Flags().Changed("no-firewall") {
+		noFirewall = flagNoFirewall
+	}
+
+	// Estimate the copy up front so large projects can be confirmed before
+	// any Docker work starts
+	copySize := estimateCopySize(project)
+	if copySize > container.LargeCopyThreshold && !flagForce {
+		fmt.Printf("Project is ~%s. This is large. Continue? (y/N): ", formatBytes(copySize))
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return "", false, fmt.Errorf("cancelled - add large paths to .maestroignore or pass --force")
+		}
+	}
+
+	// Run the shared container setup pipeline. The steps above ran once and
+	// are kept; only the Docker work is retried with --retry.
+	if err := setupContainerWithRetry(flagRetry, ContainerSetupOptions{
+		ContainerName:     containerName,
+		BranchName:        branchName,
+		Task:              taskDescription,
+		Prompt:            planningPrompt,
+		ExactPrompt:       exactPrompt,
+		Labels:            labels,
+		Project:           project,
+		ProjectName:       projectName,
+		Model:             model,
+		WebEnabled:        useWeb,
+		EstimatedCopySize: copySize,
+		NoTmux:            flagNoTmux,
+		NoFirewall:        noFirewall,
+		ReuseImage:        flagReuseImage,
 	}); err != nil {
-		return err
+		return "", false, err
 	}
 
 	// Assign nickname if requested
 	if flagNick != "" {
 		store := getNicknameStore()
 		if err := store.Set(flagNick, containerName); err != nil {
-			fmt.Printf("Warning: Failed to save nickname: %v\n", err)
+			logging.Warnf("Failed to save nickname: %v", err)
 		} else {
-			fmt.Printf("Nickname: %s\n", flagNick)
+			logging.Infof("Nickname: %s", flagNick)
 		}
 	}
 
-	fmt.Printf("\n✅ Container %s is ready!\n", containerName)
+	return containerName, false, nil
+}
 
-	// Auto-connect unless --no-connect flag is set
-	if !noConnect {
-		fmt.Println("\nConnecting to container...")
-		fmt.Println("Detach with: Ctrl+b d")
-		fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
+// setupAttemptLabel marks the container a setupContainerWithRetry attempt
+// created, so a retry only removes that one.
+const setupAttemptLabel = "maestro.setup_attempt"
 
-		// Connect to tmux session
-		connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
-		connectCmd.Stdin = os.Stdin
-		connectCmd.Stdout = os.Stdout
-		connectCmd.Stderr = os.Stderr
+// setupContainerWithRetry runs setupContainer, retrying up to retries more
+// times on failure. The container the failed attempt created, if any, is
+// removed before each retry; one with the same name that the attempt didn't
+// create, like another maestro's, is left alone.
+func setupContainerWithRetry(retries int, opts ContainerSetupOptions) error {
+	var attempt string
+	return retryStep(retries, retryDelay, func() error {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err != nil {
+			return fmt.Errorf("failed to generate setup attempt ID: %w", err)
+		}
+		attempt = hex.EncodeToString(id)
+		attemptOpts := opts
+		attemptOpts.Labels = maps.Clone(opts.Labels)
+		if attemptOpts.Labels == nil {
+			attemptOpts.Labels = map[string]string{}
+		}
+		attemptOpts.Labels[setupAttemptLabel] = attempt
+		return setupContainer(attemptOpts)
+	}, func() {
+		removeSetupAttempt(attempt)
+	})
+}
 
-		if err := connectCmd.Run(); err != nil {
-			fmt.Printf("\nWarning: Failed to connect: %v\n", err)
-			fmt.Printf("You can connect later with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
+// removeSetupAttempt removes the container labelled with a setup attempt ID.
+func removeSetupAttempt(attempt string) {
+	if attempt == "" {
+		return
+	}
+	output, err := logging.Command("docker", "ps", "-aq", "--filter", "label="+setupAttemptLabel+"="+attempt).Output()
+	if err != nil {
+		logging.Debugf("Failed to find the container of setup attempt %s: %v", attempt, err)
+		return
+	}
+	for _, id := range strings.Fields(string(output)) {
+		if err := logging.Run(logging.Command("docker", "rm", "-f", "-v", id)); err != nil {
+			logging.Warnf("Failed to remove container %s before retrying: %v", id, err)
+		}
+	}
+}
+
+// retryStep calls step until it succeeds or has failed retries+1 times,
+// calling cleanup and waiting delay before each retry. It returns the last
+// error.
+func retryStep(retries int, delay time.Duration, step func() error, cleanup func()) error {
+	attempts := retries + 1
+	for attempt := 1; ; attempt++ {
+		err := step()
+		if err == nil || attempt >= attempts {
+			return err
+		}
+		logging.Warnf("Attempt %d of %d failed: %v", attempt, attempts, err)
+		cleanup()
+		time.Sleep(delay)
+		logging.Infof("Retrying (attempt %d of %d)...", attempt+1, attempts)
+	}
+}
+
+// estimateCopySize estimates how much copying the project (or the current
+// directory) into a container will transfer, using the same excludes as the
+// copy. .git is copied separately but still counted. Returns 0 when the
+// estimate fails or times out.
+func estimateCopySize(project *ProjectConfig) int64 {
+	var dirs []string
+	if project != nil && project.IsSinglePath() {
+		dirs = []string{project.ExpandedPath()}
+	} else if project != nil {
+		dirs = project.ExpandedPaths()
+	} else if cwd, err := os.Getwd(); err == nil {
+		dirs = []string{cwd}
+	}
+
+	var total int64
+	for _, dir := range dirs {
+		excludes := append([]string{"node_modules"}, readMaestroIgnore(dir)...)
+		size, err := container.EstimateProjectCopySize(dir, excludes)
+		if err != nil {
+			return 0
 		}
+		total += size
+	}
+	return total
+}
+
+// checkContainerLimit refuses creation when daemon.max_containers running
+// containers already exist, guarding against scripts calling new in a loop.
+func checkContainerLimit() error {
+	max := config.Daemon.MaxContainers
+	if max <= 0 {
+		return nil
+	}
+	count, err := container.CountRunningContainers(config.Containers.Prefix)
+	if err != nil {
+		// Docker errors surface later with better context
+		return nil
+	}
+	if container.AtContainerLimit(count, max) {
+		return fmt.Errorf("%d containers are already running (daemon.max_containers: %d).\n"+
+			"Stop some with 'maestro stop', raise the limit in config, or pass --force to create anyway", count, max)
+	}
+	if container.NearContainerLimit(count+1, max) {
+		logging.Warnf("%d of %d allowed containers will be running (daemon.max_containers)", count+1, max)
+	}
+	return nil
+}
+
+// runPlanOnly generates the branch name and planning prompt for a task and
+// prints them without touching Docker, so the prompt can be reviewed first.
+func runPlanOnly(taskDescription string) error {
+	if flagModel != "" {
+		branchPromptModel = resolveModel(flagModel)
+	}
+
+	if flagNoAI {
+		logging.Infof("Planning without AI: %s", truncateString(taskDescription, 80))
 	} else {
-		fmt.Printf("Connect with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
-		fmt.Printf("Detach with: Ctrl+b d\n")
+		logging.Infof("Generating plan for: %s (model: %s)", truncateString(taskDescription, 80), branchPromptModel)
+	}
+
+	branchName, planningPrompt, err := generateBranchAndPrompt(taskDescription, exactPrompt)
+	if err != nil {
+		return fmt.Errorf("failed to generate branch name: %w", err)
 	}
 
+	planningPrompt = applyContextLimit(planningPrompt)
+	if flagContinueFrom != "" {
+		_, summary, err := continueFrom(flagContinueFrom)
+		if err != nil {
+			return err
+		}
+		planningPrompt = continuePromptWithinLimit(summary, planningPrompt)
+	}
+
+	fmt.Printf("\nBranch name: %s\n", branchName)
+	fmt.Printf("Estimated tokens: %d (limit %d)\n", estimateTokens(planningPrompt), contextLimit())
+	fmt.Println("\nPlanning prompt:")
+	fmt.Println("```")
+	fmt.Println(planningPrompt)
+	fmt.Println("```")
+
 	return nil
 }
 
 // ContainerSetupOptions holds all parameters for the shared container setup pipeline.
 type ContainerSetupOptions struct {
-	ContainerName   string
-	BranchName      string
-	Prompt          string            // Task prompt sent to Claude
-	ExactPrompt     bool              // If true, prompt passed to Claude as-is (no planning wrapper)
-	Labels          map[string]string // Docker labels (e.g., maestro.parent)
-	ParentContainer string            // If set: copy workspace from this container instead of host cwd
-	SourceBranch    string            // If set (with ParentContainer): checkout this branch after copy
-	Project         *ProjectConfig    // If set: use project paths instead of cwd
-	ProjectName     string            // For Docker label and container name prefix
-	Model           string            // Claude model alias: opus, sonnet, haiku (default: opus)
-	WebEnabled      bool              // Use web-enabled image with Playwright/Chromium
+	ContainerName     string
+	BranchName        string
+	Task              string            // Task description as given, recorded in the maestro.task label
+	Prompt            string            // Task prompt sent to Claude
+	ExactPrompt       bool              // If true, prompt passed to Claude as-is (no planning wrapper)
+	Labels            map[string]string // Docker labels (e.g., maestro.parent)
+	ParentContainer   string            // If set: copy workspace from this container instead of host cwd
+	RecreateFrom      string            // If set: copy workspace from this container, which the new one replaces rather than being its child
+	SourceDir         string            // Host directory the project came from, when copied from RecreateFrom
+	SourceBranch      string            // If set (with ParentContainer or RecreateFrom): checkout this branch after copy
+	Project           *ProjectConfig    // If set: use project paths instead of cwd
+	ProjectName       string            // For Docker label and container name prefix
+	Model             string            // Claude model alias: opus, sonnet, haiku (default: opus)
+	WebEnabled        bool              // Use web-enabled image with Playwright/Chromium
+	EstimatedCopySize int64             // Expected project copy size in bytes (0 if unknown)
+	NoTmux            bool              // Skip tmux; Claude starts on connect via DirectClaudeScript
+	NoFirewall        bool              // Skip the outbound firewall (unrestricted network access)
+	ReuseImage        bool              // Use a local image without pulling or rebuilding it (--reuse-image)
+}
+
+// workspaceContainer returns the container the workspace is copied from
+// instead of the host, or "".
+func (opts ContainerSetupOptions) workspaceContainer() string {
+	if opts.RecreateFrom != "" {
+		return opts.RecreateFrom
+	}
+	return opts.ParentContainer
 }
 
 // validModels is the set of accepted Claude model aliases.
@@ -246,14 +666,14 @@ func resolveModel(flagValue string) string {
 		if isValidModel(m) {
 			return m
 		}
-		fmt.Printf("Warning: unknown model %q, falling back to config default\n", flagValue)
+		logging.Warnf("unknown model %q, falling back to config default", flagValue)
 	}
 	m := strings.ToLower(viper.GetString("containers.default_model"))
 	if isValidModel(m) {
 		return m
 	}
 	if m != "" {
-		fmt.Printf("Warning: unknown model %q in config, falling back to default\n", m)
+		logging.Warnf("unknown model %q in config, falling back to default", m)
 	}
 	return "opus"
 }
@@ -270,48 +690,105 @@ func setupContainer(opts ContainerSetupOptions) error {
 		return fmt.Errorf("invalid model %q: must be opus, sonnet, or haiku", opts.Model)
 	}
 
-	imageName := getDockerImage()
-	if opts.WebEnabled {
-		imageName = getDockerWebImage()
-	}
-
 	// 1. Ensure Docker image is available
-	if err := ensureDockerImage(imageName); err != nil {
+	if _, err := ensureContainerImage(opts.WebEnabled, opts.ReuseImage); err != nil {
 		return fmt.Errorf("failed to ensure Docker image: %w", err)
 	}
 
+	// Detect the project type so its package registries get through the firewall
+	projectDomains := detectProjectDomains(opts)
+	printProjectDomains()
+
+	// Resolve additional folders up front so the container records where they land
+	// (skipped if project is set — project IS the complete set)
+	var syncFolders []syncFolder
+	if opts.Project == nil {
+		syncFolders = resolveSyncFolders()
+		if label := container.SyncedFoldersLabelValue(syncedFolderLabels(syncFolders)); label != "" {
+			if opts.Labels == nil {
+				opts.Labels = map[string]string{}
+			}
+			opts.Labels[container.SyncedFoldersLabel] = label
+		}
+	}
+
+	// Record the task so list and details views can show what the container is for
+	if task := container.TaskLabelValue(opts.Task); task != "" {
+		if opts.Labels == nil {
+			opts.Labels = map[string]string{}
+		}
+		opts.Labels[container.TaskLabel] = task
+	}
+
+	// Record the host repository the project came from, so the TUI can pull
+	// the container's branch back to it before deleting the container
+	if source := projectSourceDir(opts); source != "" {
+		if opts.Project == nil && opts.workspaceContainer() == "" && !isGitRepo(source) {
+			// Nothing to pull back into; see initFreshRepo
+			logging.Infof("%s is not the root of a git repository; the container starts a new one on %s with the files as its first commit", source, opts.BranchName)
+		} else {
+			if opts.Labels == nil {
+				opts.Labels = map[string]string{}
+			}
+			opts.Labels[container.SourceDirLabel] = source
+		}
+		if err := container.RecordProjectDir(source); err != nil {
+			logging.Debugf("Failed to record project dir: %v", err)
+		}
+	}
+
+	// Record the project's domains for enabling the firewall later
+	if len(projectDomains) > 0 {
+		if opts.Labels == nil {
+			opts.Labels = map[string]string{}
+		}
+		opts.Labels[container.ProjectDomainsLabel] = container.ProjectDomainsLabelValue(projectDomains)
+	}
+
+	// Containers without a firewall are labelled so the TUI can flag them
+	if opts.NoFirewall {
+		if opts.Labels == nil {
+			opts.Labels = map[string]string{}
+		}
+		opts.Labels[container.FirewallLabel] = container.FirewallDisabled
+	}
+
 	// 2. Start container (with optional labels)
-	if err := startContainerWithLabels(opts.ContainerName, opts.Labels, opts.WebEnabled); err != nil {
+	if err := startContainerWithLabels(opts.ContainerName, opts.Labels, opts.WebEnabled, projectDomains); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// 3. Copy project files
-	if opts.Project != nil {
+	if opts.EstimatedCopySize > 0 {
+		logging.Infof("Copying ~%s to container...", formatBytes(opts.EstimatedCopySize))
+	}
+	if from := opts.workspaceContainer(); from != "" {
+		// Copy workspace from a parent container (daemon/child path) or the
+		// container being recreated
+		logging.Infof("Copying workspace from container %s...", from)
+		if err := copyProjectFromContainer(from, opts.ContainerName); err != nil {
+			return fmt.Errorf("failed to copy project from %s: %w", from, err)
+		}
+		// Optionally checkout a specific branch in the copied workspace
+		if opts.SourceBranch != "" {
+			checkoutCmd := logging.Command("docker", "exec", opts.ContainerName, "sh", "-c",
+				container.InWorkspace(workspaceDir(), fmt.Sprintf("git checkout %s 2>/dev/null || git checkout -b %s", opts.SourceBranch, opts.SourceBranch)))
+			if err := logging.Run(checkoutCmd); err != nil {
+				logging.Warnf("Failed to checkout branch %s: %v", opts.SourceBranch, err)
+			}
+		}
+	} else if opts.Project != nil {
 		if !opts.Project.IsSinglePath() {
-			// Multi-path project: copy each repo to /workspace/<basename>/
+			// Multi-path project: copy each repo to <workspace>/<basename>/
 			if err := copyMultiPathProject(opts.ContainerName, opts.Project.ExpandedPaths()); err != nil {
 				return fmt.Errorf("failed to copy multi-path project: %w", err)
 			}
 		} else {
-			// Single-path project: copy from specified path to /workspace/
+			// Single-path project: copy from specified path to the workspace root
 			if err := copyProjectToContainerFrom(opts.ContainerName, opts.Project.ExpandedPath()); err != nil {
 				return fmt.Errorf("failed to copy project from path: %w", err)
 			}
 		}
-	} else if opts.ParentContainer != "" {
-		// Copy workspace from parent container (daemon/child path)
-		fmt.Printf("Copying workspace from parent container %s...\n", opts.ParentContainer)
-		if err := copyProjectFromContainer(opts.ParentContainer, opts.ContainerName); err != nil {
-			return fmt.Errorf("failed to copy project from parent: %w", err)
-		}
-		// Optionally checkout a specific branch in the copied workspace
-		if opts.SourceBranch != "" {
-			checkoutCmd := exec.Command("docker", "exec", opts.ContainerName, "sh", "-c",
-				fmt.Sprintf("cd /workspace && git checkout %s 2>/dev/null || git checkout -b %s", opts.SourceBranch, opts.SourceBranch))
-			if err := checkoutCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to checkout branch %s: %v\n", opts.SourceBranch, err)
-			}
-		}
 	} else {
 		// Copy from host working directory (CLI, TUI, batch paths)
 		if err := copyProjectToContainer(opts.ContainerName); err != nil {
@@ -319,9 +796,9 @@ func setupContainer(opts ContainerSetupOptions) error {
 		}
 	}
 
-	// 4. Copy additional folders from host (skip if project is set — project IS the complete set)
-	if opts.Project == nil {
-		if err := copyAdditionalFolders(opts.ContainerName); err != nil {
+	// 4. Copy additional folders from host
+	if len(syncFolders) > 0 {
+		if err := copyAdditionalFolders(opts.ContainerName, syncFolders); err != nil {
 			return fmt.Errorf("failed to copy additional folders: %w", err)
 		}
 	}
@@ -329,17 +806,21 @@ func setupContainer(opts ContainerSetupOptions) error {
 	// 4b. For multi-path projects, symlink primary repo's skills to workspace root
 	if opts.Project != nil && !opts.Project.IsSinglePath() {
 		if err := linkPrimarySkills(opts.ContainerName, opts.Project); err != nil {
-			fmt.Printf("Warning: Failed to link primary skills: %v\n", err)
+			logging.Warnf("Failed to link primary skills: %v", err)
 		}
 	}
 
+	// 4c. Run containers.post_copy_script against the copied files, before
+	// the branch is created so its changes land on it as uncommitted work
+	runPostCopyScript(opts.ContainerName, opts.BranchName, config.Containers.PostCopyScript)
+
 	// 5. Initialize git branch
 	if opts.Project != nil && !opts.Project.IsSinglePath() {
 		// Multi-path: create branch in each repo
 		for _, p := range opts.Project.ExpandedPaths() {
-			dir := "/workspace/" + filepath.Base(p)
+			dir := path.Join(workspaceDir(), filepath.Base(p))
 			if err := initializeGitBranchInDir(opts.ContainerName, opts.BranchName, dir); err != nil {
-				fmt.Printf("Warning: Failed to init git branch in %s: %v\n", dir, err)
+				logging.Warnf("Failed to init git branch in %s: %v", dir, err)
 			}
 		}
 	} else {
@@ -350,39 +831,52 @@ func setupContainer(opts ContainerSetupOptions) error {
 
 	// 6. Configure git user
 	if err := configureGitUser(opts.ContainerName); err != nil {
-		fmt.Printf("Warning: Failed to configure git user: %v\n", err)
+		logging.Warnf("Failed to configure git user: %v", err)
 	}
 
 	// 7. Setup GitHub remote (SSH → HTTPS conversion)
 	if opts.Project != nil && !opts.Project.IsSinglePath() {
 		for _, p := range opts.Project.ExpandedPaths() {
-			dir := "/workspace/" + filepath.Base(p)
+			dir := path.Join(workspaceDir(), filepath.Base(p))
 			if err := setupGitHubRemoteInDir(opts.ContainerName, dir); err != nil {
-				fmt.Printf("Warning: Failed to setup GitHub remote in %s: %v\n", dir, err)
+				logging.Warnf("Failed to setup GitHub remote in %s: %v", dir, err)
 			}
 		}
 	} else {
 		if err := setupGitHubRemote(opts.ContainerName); err != nil {
-			fmt.Printf("Warning: Failed to setup GitHub remote: %v\n", err)
+			logging.Warnf("Failed to setup GitHub remote: %v", err)
 		}
 	}
 
+	// 7b. Run containers.init_commands. They run here rather than straight
+	// after startup so they can use the project and branch.
+	runInitCommands(opts.ContainerName, opts.BranchName, config.Containers.InitCommands)
+
 	// 8. Write MAESTRO.md agent documentation
 	if err := writeMaestroMD(opts.ContainerName, opts.BranchName, opts.ParentContainer, opts.Project, opts.WebEnabled); err != nil {
-		fmt.Printf("Warning: Failed to write MAESTRO.md: %v\n", err)
+		logging.Warnf("Failed to write MAESTRO.md: %v", err)
 	}
 
 	// 8b. Write hooks guide documentation
 	if err := writeHooksGuide(opts.ContainerName); err != nil {
-		fmt.Printf("Warning: Failed to write hooks guide: %v\n", err)
+		logging.Warnf("Failed to write hooks guide: %v", err)
 	}
 
 	// 9. Write Claude Code hooks for idle detection
 	if err := writeClaudeSettings(opts.ContainerName, opts.Model, opts.WebEnabled); err != nil {
-		fmt.Printf("Warning: Failed to write Claude settings: %v\n", err)
+		logging.Warnf("Failed to write Claude settings: %v", err)
 	}
 
-	// 10. Start tmux session with Claude
+	// 10. Start tmux session with Claude, or stage the direct launcher
+	if opts.NoTmux {
+		if err := writeBootstrapPrompt(opts.ContainerName, opts.Prompt, opts.ExactPrompt); err != nil {
+			return err
+		}
+		if err := writeDirectClaudeScript(opts.ContainerName, opts.Model); err != nil {
+			return fmt.Errorf("failed to write Claude launcher: %w", err)
+		}
+		return nil
+	}
 	if err := startTmuxSession(opts.ContainerName, opts.BranchName, opts.Prompt, opts.ExactPrompt, opts.Model); err != nil {
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
@@ -390,13 +884,58 @@ func setupContainer(opts ContainerSetupOptions) error {
 	return nil
 }
 
+// detectProjectDomains detects the type of the project being copied into the
+// container and returns the firewall domains it needs. Containers copied from
+// a parent container inherit nothing, since there is no host directory to
+// inspect; recreated ones keep the domains recorded on the original, or use
+// the host directory it came from.
+func detectProjectDomains(opts ContainerSetupOptions) []string {
+	var dir string
+	switch {
+	case opts.Project != nil:
+		dir = opts.Project.PrimaryPath()
+	case opts.RecreateFrom != "":
+		if domains := container.ProjectDomains(opts.RecreateFrom); domains != nil {
+			return domains
+		}
+		if opts.SourceDir == "" {
+			return nil
+		}
+		dir = opts.SourceDir
+	case opts.ParentContainer != "":
+		return nil
+	default:
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil
+		}
+		dir = cwd
+	}
+
+	projectType, domains, err := system.DetectProjectType(dir)
+	if err != nil {
+		logging.Warnf("Failed to detect project type: %v", err)
+		return nil
+	}
+	if projectType != system.ProjectUnknown {
+		logging.Infof("Detected project type: %s", projectType)
+	}
+	return domains
+}
+
 func generateBranchAndPrompt(taskDescription string, exact bool) (string, string, error) {
+	// --no-ai: no Claude calls on the host at all, for hosts that can't
+	// reach Anthropic
+	if flagNoAI {
+		return branchname.Simple(taskDescription), taskDescription, nil
+	}
+
 	// In exact mode, still generate branch name via AI but use literal prompt
 	if exact {
-		branchName, err := generateBranchNameOnly(taskDescription)
+		branchName, err := branchname.Generate(taskDescription, branchPromptModel)
 		if err != nil {
 			// Fallback to simple branch name generation
-			branchName = generateSimpleBranch(taskDescription)
+			branchName = branchname.Simple(taskDescription)
 		}
 		// Return the exact task description as the prompt
 		return branchName, taskDescription, nil
@@ -448,8 +987,8 @@ PROMPT: your planning prompt here
 Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/`, taskDescription)
 		}
 
-		// Call Claude CLI in --print mode to generate branch and prompt (using haiku for speed/cost)
-		cmd := exec.Command("claude", "--print", "Generate branch name and prompt", "--model", "haiku", "--dangerously-skip-permissions")
+		// Call Claude CLI in --print mode to generate branch and prompt (haiku by default for speed/cost)
+		cmd := exec.Command("claude", "--print", "Generate branch name and prompt", "--model", branchPromptModel, "--dangerously-skip-permissions")
 		cmd.Stdin = strings.NewReader(claudePrompt)
 		output, err := cmd.Output()
 		if err != nil {
@@ -475,26 +1014,26 @@ Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/`, taskDescription)
 			branchName = strings.ToLower(branchName)
 			branchName = strings.Trim(branchName, "\"'`")
 
-			// Enforce max length (40 chars) in case AI ignored the instruction
-			if len(branchName) > 40 {
-				branchName = branchName[:40]
+			// Enforce max length in case AI ignored the instruction
+			if len(branchName) > branchname.MaxLength {
+				branchName = branchName[:branchname.MaxLength]
 				branchName = strings.TrimRight(branchName, "-/")
 			}
 
 			// Validate the branch name format
-			if isValidBranchName(branchName) {
+			if branchname.IsValid(branchName) {
 				return branchName, strings.TrimSpace(promptMatch[1]), nil
 			}
 		}
 
 		// Log retry if not last attempt
 		if attempt < maxRetries {
-			fmt.Printf("Branch generation attempt %d failed validation, retrying...\n", attempt)
+			logging.Infof("Branch generation attempt %d failed validation, retrying...", attempt)
 		}
 	}
 
 	// Fallback to simple branch name generation
-	simpleBranch := generateSimpleBranch(taskDescription)
+	simpleBranch := branchname.Simple(taskDescription)
 	planningPrompt := fmt.Sprintf(`Please plan the implementation for the following task:
 
 %s
@@ -503,110 +1042,9 @@ Break down the implementation into clear steps and identify key components that
 	return simpleBranch, planningPrompt, nil
 }
 
-// generateBranchNameOnly generates just a branch name via AI, without a planning prompt
-// Includes retry logic and validation to handle cases where the AI returns invalid output
-func generateBranchNameOnly(taskDescription string) (string, error) {
-	const maxRetries = 3
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		var claudePrompt string
-		if attempt == 1 {
-			claudePrompt = fmt.Sprintf(`Extract the CORE TASK from this description and create a git branch name.
-
-Description: %s
-
-Instructions:
-1. Identify what is actually being built/fixed/reviewed (ignore instructions like "read file X" or "switch to branch")
-2. Extract key identifiers (PR numbers, ticket IDs, feature names)
-3. Create a branch name: prefix/2-4-word-summary
-
-Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/
-
-Examples:
-- "Please read requirements.txt and implement user login" -> feat/user-login
-- "Review PR #42 for the authentication module" -> review/pr-42
-- "Fix the bug in issue #123 where payments fail" -> fix/issue-123-payments
-- "After reading the spec, add dark mode support" -> feat/dark-mode
-- "Refactor the database queries in the user service" -> refactor/user-db-queries
-
-Output ONLY the branch name (lowercase, max 40 chars):`, taskDescription)
-		} else {
-			// More explicit prompt on retry
-			claudePrompt = fmt.Sprintf(`What is the MAIN GOAL of this task? Create a branch name for it.
-
-Task: %s
-
-DO NOT include filler words from the description. Extract the semantic meaning.
-BAD: feat/please-read-file-and-do-thing (too literal)
-GOOD: feat/thing (captures the actual goal)
-
-Format: prefix/short-name (lowercase, letters/numbers/hyphens only)
-Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/
-
-Output ONLY the branch name:`, taskDescription)
-		}
-
-		// Call Claude CLI in --print mode to generate just the branch name (using haiku for speed/cost)
-		cmd := exec.Command("claude", "--print", "Generate branch name", "--model", "haiku", "--dangerously-skip-permissions")
-		cmd.Stdin = strings.NewReader(claudePrompt)
-		output, err := cmd.Output()
-		if err != nil {
-			if attempt == maxRetries {
-				return "", fmt.Errorf("AI unavailable after %d attempts: %w", maxRetries, err)
-			}
-			continue
-		}
-
-		// Parse output - just take the first line and trim it
-		branchName := strings.TrimSpace(strings.Split(string(output), "\n")[0])
-
-		// Skip empty results
-		if branchName == "" {
-			if attempt == maxRetries {
-				return "", fmt.Errorf("empty branch name from AI after %d attempts", maxRetries)
-			}
-			continue
-		}
-
-		// Normalize: convert to lowercase and remove any surrounding quotes
-		branchName = strings.ToLower(branchName)
-		branchName = strings.Trim(branchName, "\"'`")
-
-		// Enforce max length (40 chars) in case AI ignored the instruction
-		if len(branchName) > 40 {
-			branchName = branchName[:40]
-			branchName = strings.TrimRight(branchName, "-/")
-		}
-
-		// Validate the branch name format
-		if isValidBranchName(branchName) {
-			return branchName, nil
-		}
-
-		// If invalid, log and retry
-		if attempt < maxRetries {
-			fmt.Printf("Branch name attempt %d returned invalid format, retrying...\n", attempt)
-		}
-	}
-
-	return "", fmt.Errorf("failed to generate valid branch name after %d attempts", maxRetries)
-}
-
-// isValidBranchName checks if a string looks like a valid git branch name
-// (lowercase with optional prefix like feat/, fix/, etc. containing only alphanumeric and hyphens)
-func isValidBranchName(name string) bool {
-	if name == "" {
-		return false
-	}
-	// Must match pattern: optional prefix (feat/, fix/, etc.) followed by lowercase alphanumeric and hyphens
-	// Valid examples: feat/add-auth, fix/bug-123, refactor/db-pool, add-new-feature
-	validPattern := regexp.MustCompile(`^[a-z][a-z0-9-]*(/[a-z0-9][a-z0-9-]*)?$`)
-	return validPattern.MatchString(name)
-}
-
 // promptUserForBranchName asks the user to provide a branch name when automated generation fails
 func promptUserForBranchName(taskDescription string) (string, error) {
-	fmt.Println("\n⚠️  Automated branch name generation failed.")
+	fmt.Println("\n" + style.Warning() + "  Automated branch name generation failed.")
 	fmt.Println("Please enter a branch name manually.")
 	fmt.Println("(Use lowercase letters, numbers, and hyphens. e.g., feat/add-auth or fix/bug-123)")
 	fmt.Printf("Task: %s\n", truncateString(taskDescription, 60))
@@ -629,55 +1067,72 @@ func promptUserForBranchName(taskDescription string) (string, error) {
 	branchName = strings.Trim(branchName, "-")
 
 	// Enforce max length
-	if len(branchName) > 40 {
-		branchName = branchName[:40]
+	if len(branchName) > branchname.MaxLength {
+		branchName = branchName[:branchname.MaxLength]
 		branchName = strings.TrimRight(branchName, "-/")
 	}
 
 	return branchName, nil
 }
 
-func generateSimpleBranch(description string) string {
-	// Simple branch name generation from description
-	desc := strings.ToLower(description)
+// getNextContainerName returns the next free {base}-{n} container name for
+// a branch.
+func getNextContainerName(branchName string, projectName ...string) (string, error) {
+	baseName, truncated := containerBaseNameInfo(branchName, projectName...)
+	if truncated {
+		logging.Warnf("Branch name is too long for a container name; using %s", baseName)
+	}
 
-	// Remove common filler words to keep it concise
-	fillerWords := []string{"the", "a", "an", "and", "or", "but", "in", "on", "at", "to", "for"}
-	words := strings.Fields(desc)
-	var filtered []string
-	for _, word := range words {
-		isFillerWord := false
-		for _, filler := range fillerWords {
-			if word == filler {
-				isFillerWord = true
-				break
+	// Check existing containers
+	cmd := logging.Command("docker", "ps", "-a", "--format", "{{.Names}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	// Find highest number for this base name. Match the whole name so that a
+	// longer branch sharing this prefix does not count.
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(baseName) + `-(\d+)$`)
+	maxNum := 0
+	for _, name := range strings.Split(string(output), "\n") {
+		if m := pattern.FindStringSubmatch(strings.TrimSpace(name)); m != nil {
+			if num, err := strconv.Atoi(m[1]); err == nil && num > maxNum {
+				maxNum = num
 			}
 		}
-		if !isFillerWord {
-			filtered = append(filtered, word)
-		}
 	}
-	desc = strings.Join(filtered, " ")
-
-	// Convert to branch-safe format
-	desc = regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(desc, "-")
-	desc = strings.Trim(desc, "-")
 
-	// Keep it short (max 35 chars for the description part)
-	if len(desc) > 35 {
-		desc = desc[:35]
+	name := fmt.Sprintf("%s-%d", baseName, maxNum+1)
+	if len(name) > maxContainerNameLength {
+		return "", fmt.Errorf("container name %s is longer than %d characters; remove old containers for this branch or use a shorter containers.prefix", name, maxContainerNameLength)
 	}
-	desc = strings.TrimRight(desc, "-")
+	return name, nil
+}
 
-	// Handle edge case where description has no usable characters
-	if desc == "" {
-		desc = fmt.Sprintf("task-%d", time.Now().Unix()%100000)
-	}
+const (
+	// maxContainerNameLength keeps container names usable as hostnames,
+	// which Linux limits to 64 characters (63 for a DNS label).
+	maxContainerNameLength = 63
+
+	// containerSuffixReserve is the room kept for the numeric suffix
+	// ("-" and up to three digits).
+	containerSuffixReserve = 4
 
-	return fmt.Sprintf("feat/%s", desc)
+	// containerNameHashLength is the length of the hash appended to
+	// truncated names.
+	containerNameHashLength = 6
+)
+
+// containerBaseName returns the full container name for a branch (and
+// optional project) without the numeric suffix.
+func containerBaseName(branchName string, projectName ...string) string {
+	baseName, _ := containerBaseNameInfo(branchName, projectName...)
+	return baseName
 }
 
-func getNextContainerName(branchName string, projectName ...string) (string, error) {
+// containerBaseNameInfo is containerBaseName, also reporting whether the name
+// had to be truncated.
+func containerBaseNameInfo(branchName string, projectName ...string) (string, bool) {
 	// Convert branch to container-friendly name
 	baseName := strings.ReplaceAll(branchName, "/", "-")
 	baseName = regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(baseName, "-")
@@ -688,41 +1143,26 @@ func getNextContainerName(branchName string, projectName ...string) (string, err
 		baseName = projPrefix + "-" + baseName
 	}
 
-	// CRITICAL: Limit total length to avoid hostname errors
-	// Linux hostname limit is 64 chars. We need room for prefix + base + suffix
-	// Format: {prefix}{basename}-{num}
-	// Example: maestro-insight-feat-auth-1 (prefix + project + suffix, leaves room for basename)
-	maxBaseLength := 50 // Conservative limit leaving room for prefix/suffix
-	if len(baseName) > maxBaseLength {
-		baseName = baseName[:maxBaseLength]
-		baseName = strings.TrimRight(baseName, "-") // Remove trailing dash if truncated mid-word
-	}
+	baseName, truncated := truncateContainerBaseName(baseName, maxContainerNameLength-len(config.Containers.Prefix)-containerSuffixReserve)
+	return config.Containers.Prefix + baseName, truncated
+}
 
-	// Check existing containers
-	cmd := exec.Command("docker", "ps", "-a", "--format", "{{.Names}}")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+// truncateContainerBaseName shortens baseName to at most budget characters.
+// Two branches that only differ after the cut would otherwise share a name,
+// so a truncated name ends with a short hash of the full one. If the budget
+// leaves no room beside the hash, the hash alone is returned.
+func truncateContainerBaseName(baseName string, budget int) (string, bool) {
+	if len(baseName) <= budget {
+		return baseName, false
 	}
-
-	// Find highest number for this base name
-	containerPrefix := config.Containers.Prefix + baseName
-	maxNum := 0
-	for _, name := range strings.Split(string(output), "\n") {
-		if strings.HasPrefix(name, containerPrefix+"-") {
-			parts := strings.Split(name, "-")
-			if len(parts) > 0 {
-				var num int
-				if _, err := fmt.Sscanf(parts[len(parts)-1], "%d", &num); err == nil {
-					if num > maxNum {
-						maxNum = num
-					}
-				}
-			}
-		}
+	sum := sha256.Sum256([]byte(baseName))
+	hash := hex.EncodeToString(sum[:])[:containerNameHashLength]
+	keep := budget - containerNameHashLength - 1
+	if keep <= 0 {
+		return hash, true
 	}
-
-	return fmt.Sprintf("%s-%d", containerPrefix, maxNum+1), nil
+	// Remove trailing dash if truncated mid-word
+	return strings.TrimRight(baseName[:keep], "-") + "-" + hash, true
 }
 
 // getDockerImage returns the container image to use, prioritizing embedded version.
@@ -752,61 +1192,131 @@ func getDockerWebImage() string {
 	return config.Web.Image
 }
 
-func ensureDockerImage(imageName string) error {
-	cmd := exec.Command("docker", "images", "-q", imageName)
+// ensureDockerImage makes imageName available locally according to the
+// image pull policy. forceReuseImage (--reuse-image) uses a local image as is,
+// whatever containers.image_pull_policy says.
+func ensureDockerImage(imageName string, forceReuseImage bool) error {
+	policy := imagePullPolicy(forceReuseImage)
+
+	cmd := logging.Command("docker", "images", "-q", imageName)
 	output, err := cmd.Output()
 	if err != nil {
 		return err
 	}
 
-	if len(output) == 0 {
-		// Image doesn't exist - try to pull from registry first
-		if strings.Contains(imageName, "ghcr.io") || strings.Contains(imageName, "docker.io") {
-			fmt.Printf("Pulling Docker image from registry: %s\n", imageName)
-			pullCmd := exec.Command("docker", "pull", imageName)
-			pullCmd.Stdout = os.Stdout
-			pullCmd.Stderr = os.Stderr
-			if err := pullCmd.Run(); err == nil {
-				fmt.Println("✓ Image pulled successfully")
-				return nil
+	if len(output) > 0 {
+		if policy == imagePullAlways && isRegistryImage(imageName) {
+			if err := pullDockerImage(imageName); err != nil {
+				logging.Warnf("Failed to pull %s, using the local image: %v", imageName, err)
 			}
-			fmt.Println("Warning: Failed to pull from registry, will try to build locally...")
+			return nil
 		}
-
-		// Fall back to building locally (for development)
-		fmt.Println("Building Docker image locally...")
-		dockerDir := "docker"
-		if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
-			// Try relative to binary location
-			binDir := filepath.Dir(os.Args[0])
-			dockerDir = filepath.Join(binDir, "docker")
+		if forceReuseImage || policy == imagePullNever {
+			return nil
 		}
-
-		// Check if docker directory exists
-		if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
-			return fmt.Errorf("docker image not found and cannot build (no docker/ directory found)\nTry: docker pull %s", imageName)
+		// Images maestro built locally are rebuilt when their Dockerfile or
+		// containers.build_args change; pulled images are left alone
+		if build, err := bundledImageBuild(imageName); err == nil && staleLocalBuild(imageName, build) {
+			logging.Infof("Dockerfile or build args changed, rebuilding %s...", imageName)
+			return build.run(imageName)
 		}
+		return nil
+	}
 
-		projectDir := filepath.Dir(dockerDir)
-		dockerFile := filepath.Join(dockerDir, "Dockerfile")
-		if strings.Contains(imageName, "maestro-web") {
-			dockerFile = filepath.Join(dockerDir, "Dockerfile.web")
+	if policy == imagePullNever {
+		return fmt.Errorf("image %s is not available locally and image pulls are disabled (containers.offline_mode or image_pull_policy: never)\nLoad it first with: docker pull %s", imageName, imageName)
+	}
+
+	// Image doesn't exist - try to pull from registry first
+	if isRegistryImage(imageName) {
+		if err := pullDockerImage(imageName); err == nil {
+			return nil
 		}
+		logging.Warnf("Failed to pull from registry, will try to build locally...")
+	}
 
-		buildCmd := exec.Command("docker", "build", "-t", imageName, "-f", dockerFile, projectDir)
-		buildCmd.Stdout = os.Stdout
-		buildCmd.Stderr = os.Stderr
-		return buildCmd.Run()
+	// Fall back to building locally (for development)
+	build, err := bundledImageBuild(imageName)
+	if err != nil {
+		return err
 	}
+	logging.Infof("Building Docker image locally...")
+	return build.run(imageName)
+}
+
+// isRegistryImage reports whether imageName comes from a registry maestro
+// pulls from, rather than being built locally.
+func isRegistryImage(imageName string) bool {
+	return strings.Contains(imageName, "ghcr.io") || strings.Contains(imageName, "docker.io")
+}
 
+// pullDockerImage pulls imageName, showing docker's progress.
+func pullDockerImage(imageName string) error {
+	logging.Infof("Pulling Docker image from registry: %s", imageName)
+	pullCmd := logging.Command("docker", "pull", imageName)
+	pullCmd.Stdout = os.Stdout
+	pullCmd.Stderr = os.Stderr
+	if err := logging.Run(pullCmd); err != nil {
+		return err
+	}
+	logging.Infof("%s Image pulled successfully", style.Check())
 	return nil
 }
 
+// bundledImageBuild returns the build of imageName from the repository's
+// docker/ directory, with containers.build_args applied.
+func bundledImageBuild(imageName string) (imageBuild, error) {
+	dockerDir := "docker"
+	if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
+		// Try relative to binary location
+		binDir := filepath.Dir(os.Args[0])
+		dockerDir = filepath.Join(binDir, "docker")
+	}
+
+	// Check if docker directory exists
+	if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
+		return imageBuild{}, fmt.Errorf("docker image not found and cannot build (no docker/ directory found)\nTry: docker pull %s", imageName)
+	}
+
+	// Absolute, as the context is recorded in the image to match builds by
+	if abs, err := filepath.Abs(dockerDir); err == nil {
+		dockerDir = abs
+	}
+	dockerFile := filepath.Join(dockerDir, "Dockerfile")
+	if strings.Contains(imageName, "maestro-web") {
+		dockerFile = filepath.Join(dockerDir, "Dockerfile.web")
+	}
+	return imageBuild{
+		Dockerfile: dockerFile,
+		Context:    filepath.Dir(dockerDir),
+		BuildArgs:  configBuildArgs(),
+	}, nil
+}
+
 func startContainer(containerName string) error {
-	return startContainerWithLabels(containerName, nil, false)
+	return startContainerWithLabels(containerName, nil, false, nil)
 }
 
-func startContainerWithLabels(containerName string, labels map[string]string, webEnabled bool) error {
+// startContainerWithLabels creates and initializes the container. projectDomains
+// are allowed through the firewall in addition to firewall.allowed_domains.
+// The firewall is skipped for containers labelled with container.FirewallLabel
+// and with host networking (containers.network_mode: host).
+func startContainerWithLabels(containerName string, labels map[string]string, webEnabled bool, projectDomains []string) error {
+	networkArgs, err := container.NetworkArgs(config.Containers.NetworkMode)
+	if err != nil {
+		return err
+	}
+	hostNetwork := config.Containers.NetworkMode == container.NetworkHost
+	if hostNetwork {
+		// The firewall's iptables rules would apply to the host, so host
+		// networked containers are labelled like --no-firewall ones
+		labels = maps.Clone(labels)
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[container.FirewallLabel] = container.FirewallDisabled
+	}
+
 	// Ensure Claude auth directory exists
 	authPath := expandPath(config.Claude.AuthPath)
 	if err := os.MkdirAll(authPath, 0755); err != nil {
@@ -830,7 +1340,7 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 	// Skip credential checks when using Bedrock (uses AWS auth instead)
 	if config.Bedrock.Enabled {
 		if !configExists {
-			fmt.Println("⚠️  Warning: Missing .claude.json configuration.")
+			fmt.Println(style.Warning() + "  Warning: Missing .claude.json configuration.")
 			fmt.Println("Run 'maestro auth' to copy config from ~/.claude")
 		}
 	} else {
@@ -844,7 +1354,7 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 
 		if tokenErr != nil {
 			// No valid token found anywhere
-			fmt.Println("⚠️  Warning: No valid Claude authentication found.")
+			fmt.Println(style.Warning() + "  Warning: No valid Claude authentication found.")
 			if !credExists {
 				fmt.Println("  - No credentials on host")
 			} else {
@@ -858,12 +1368,13 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 		} else {
 			// Found a valid token
 			if freshestToken.Source != "host" {
-				fmt.Printf("Using fresh token from container %s\n", freshestToken.Source)
+				logging.Infof("Using fresh token from container %s", freshestToken.Source)
 			}
 
 			timeLeft := time.Until(freshestToken.ExpiresAt)
 			if timeLeft < 24*time.Hour {
-				fmt.Printf("⚠️  Token expires in %.1f hours. Consider running 'maestro auth' soon.\n",
+				fmt.Printf("%s  Token expires in %.1f hours. Consider running 'maestro auth' soon.\n",
+					style.Warning(),
 					timeLeft.Hours())
 			}
 
@@ -873,7 +1384,7 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 		}
 
 		if !configExists {
-			fmt.Println("⚠️  Warning: Missing .claude.json - run 'maestro auth' to complete setup.")
+			fmt.Println(style.Warning() + "  Warning: Missing .claude.json - run 'maestro auth' to complete setup.")
 		}
 	}
 
@@ -881,10 +1392,23 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 		"run", "-d",
 		"--name", containerName,
 		"--hostname", containerName,
-		"--cap-add", "NET_ADMIN", // For iptables
 		"--memory", config.Containers.Resources.Memory,
 		"--cpus", config.Containers.Resources.CPUs,
 	}
+	if !hostNetwork {
+		// For iptables; with host networking this would be the host's
+		args = append(args, "--cap-add", "NET_ADMIN")
+	}
+	args = append(args, networkArgs...)
+	args = append(args, ulimitArgs(config.Containers.Ulimits)...)
+	securityArgs, err := securityOptArgs(config.Containers.SecurityOpts)
+	if err != nil {
+		return err
+	}
+	args = append(args, securityArgs...)
+	if config.Containers.ReadOnlyRootfs {
+		args = append(args, readOnlyRootfsArgs(labels[container.FirewallLabel] != container.FirewallDisabled)...)
+	}
 
 	if webEnabled {
 		args = append(args, "--label", "maestro.web=true", "--init")
@@ -900,13 +1424,28 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 		args = append(args, "--label", fmt.Sprintf("%s=%s", k, v))
 	}
 
-	// Add cache volumes for persistence
+	// Record the tmux session name so connect/inject find it even if the
+	// config changes later, and tell maestro-agent which session to drive
+	session := tmuxSessionName()
+	args = append(args,
+		"--label", fmt.Sprintf("%s=%s", container.TmuxSessionLabel, session),
+		"-e", fmt.Sprintf("%s=%s", container.TmuxSessionEnv, session),
+	)
+
+	// Record the workspace root and shell so later operations (restart,
+	// connect recovery, copy) use the ones the container was created with,
+	// and tell maestro-agent where the project lives
+	workspace := workspaceDir()
 	args = append(args,
-		"-v", fmt.Sprintf("%s-npm:/home/node/.npm", containerName),
-		"-v", fmt.Sprintf("%s-uv:/home/node/.cache/uv", containerName),
-		"-v", fmt.Sprintf("%s-history:/commandhistory", containerName),
+		"-w", workspace,
+		"--label", fmt.Sprintf("%s=%s", container.WorkspaceRootLabel, workspace),
+		"-e", fmt.Sprintf("%s=%s", container.WorkspaceEnv, workspace),
+		"--label", fmt.Sprintf("%s=%s", container.ShellLabel, containerShell()),
 	)
 
+	// Add cache volumes for persistence
+	args = append(args, container.CacheVolumeArgs(containerName, config.Containers.VolumeDriver, config.Containers.VolumeOpts)...)
+
 	// Mount daemon IPC directory so containers can read fresh connection info (survives daemon restarts)
 	authDir := expandPath(config.Claude.AuthPath)
 	args = append(args,
@@ -919,14 +1458,25 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 
 	// Mount host SSL certificates for corporate proxies (Zscaler, etc.)
 	// This allows the container to use the same CA trust store as the host
-	if _, err := os.Stat("/etc/ssl/certs/ca-certificates.crt"); err == nil {
+	caBundle := hostCABundle
+	hostCerts := mountHostCerts()
+	if hostCerts {
+		args = append(args, "-v", "/etc/ssl/certs:/etc/ssl/certs:ro")
+	}
+	// Certificates added with 'maestro cert' go into a bundle of their own,
+	// built by copySSLCertificates once the container is up
+	customCerts, _ := listCertFiles(expandPath(config.SSL.CertificatesPath))
+	if len(customCerts) > 0 {
+		caBundle = containerCABundle
+		args = append(args, "-e", "GIT_SSL_CAINFO="+caBundle)
+	}
+	if hostCerts || len(customCerts) > 0 {
 		args = append(args,
-			"-v", "/etc/ssl/certs:/etc/ssl/certs:ro",
-			"-e", "NODE_EXTRA_CA_CERTS=/etc/ssl/certs/ca-certificates.crt",
+			"-e", "NODE_EXTRA_CA_CERTS="+caBundle,
 			"-e", "NODE_OPTIONS=--use-openssl-ca",
-			"-e", "SSL_CERT_FILE=/etc/ssl/certs/ca-certificates.crt",
-			"-e", "CURL_CA_BUNDLE=/etc/ssl/certs/ca-certificates.crt",
-			"-e", "REQUESTS_CA_BUNDLE=/etc/ssl/certs/ca-certificates.crt",
+			"-e", "SSL_CERT_FILE="+caBundle,
+			"-e", "CURL_CA_BUNDLE="+caBundle,
+			"-e", "REQUESTS_CA_BUNDLE="+caBundle,
 		)
 	}
 
@@ -969,7 +1519,7 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 				"-e", "SSH_AUTH_SOCK=/ssh-agent",
 			)
 		} else {
-			fmt.Println("Warning: SSH enabled but SSH_AUTH_SOCK not set. Run 'ssh-add' first.")
+			logging.Warnf("SSH enabled but SSH_AUTH_SOCK not set. Run 'ssh-add' first.")
 		}
 
 		// Mount known_hosts from host to avoid SSH host key verification prompts
@@ -994,141 +1544,131 @@ func startContainerWithLabels(containerName string, labels map[string]string, we
 		}
 	}
 
-	// Use version-synchronized image (or config override if set)
-	imageName := getDockerImage()
-	if webEnabled {
-		imageName = getDockerWebImage()
+	// Use version-synchronized image (or config override if set), or the
+	// custom image built on it from containers.dockerfile
+	imageName, err := containerImage(webEnabled)
+	if err != nil {
+		return err
+	}
+
+	// Shared volumes (containers.shared_volumes), filled from the host the
+	// first time they are used
+	for _, spec := range config.Containers.SharedVolumes {
+		volume, err := container.ParseSharedVolume(expandPath(spec))
+		if err != nil {
+			logging.Warnf("Skipping shared volume: %v", err)
+			continue
+		}
+		if err := container.EnsureSharedVolume(volume, imageName); err != nil {
+			logging.Warnf("Skipping shared volume: %v", err)
+			continue
+		}
+		args = append(args, volume.Args()...)
 	}
+
 	args = append(args, imageName)
 
-	cmd := exec.Command("docker", args...)
-	if err := cmd.Run(); err != nil {
+	cmd := logging.Command("docker", args...)
+	if err := logging.Run(cmd); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// Wait for container startup script to complete
 	// The startup script runs npm update and claude --version, which can take several seconds
-	fmt.Println("Waiting for container initialization...")
+	logging.Infof("Waiting for container initialization...")
 	for i := 0; i < 30; i++ {
 		// Check if startup script has finished by looking for the "sleep infinity" process
-		checkCmd := exec.Command("docker", "exec", containerName, "pgrep", "-f", "sleep infinity")
-		if err := checkCmd.Run(); err == nil {
+		checkCmd := logging.Command("docker", "exec", containerName, "pgrep", "-f", "sleep infinity")
+		if err := logging.Run(checkCmd); err == nil {
 			// Found sleep infinity - startup is complete
 			break
 		}
 		if i == 29 {
-			fmt.Println("Warning: Container startup taking longer than expected, continuing anyway...")
+			logging.Warnf("Container startup taking longer than expected, continuing anyway...")
 		}
 		time.Sleep(1 * time.Second)
 	}
 
-	// Fix shell config for better terminal experience
-	shellFixCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		`# Remove TERM override
-sed -i '/^export TERM=xterm$/d' /home/node/.zshrc
-
-# Disable powerlevel10k theme (causes missing font glyphs)
-sed -i 's/^ZSH_THEME=.*/ZSH_THEME=""/' /home/node/.zshrc
-
-# Add custom prompt with readable symbols and colors
-cat >> /home/node/.zshrc << 'PROMPT_EOF'
-
-# Custom Maestro prompt with colors and git status
-autoload -Uz vcs_info
-precmd_vcs_info() { vcs_info }
-precmd_functions+=( precmd_vcs_info )
-setopt prompt_subst
-zstyle ':vcs_info:git:*' formats '%b'
-zstyle ':vcs_info:*' enable git
-
-# Git status indicators (matching maestro list command)
-git_status_symbols() {
-    if [[ -n ${vcs_info_msg_0_} ]]; then
-        local git_status=""
-        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
-        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
-        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
-
-        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
-        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
-        [[ $behind -gt 0 ]] && git_status+="↓$behind "
-        [[ -z $git_status ]] && git_status="✓ "
-
-        echo "$git_status"
-    fi
-}
+	// Make sure a custom workspace root exists and is writable by node
+	if workspace != container.DefaultWorkspace {
+		mkdirWSCmd := logging.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+			fmt.Sprintf("mkdir -p %s && chown node:node %s", workspace, workspace))
+		if err := logging.Run(mkdirWSCmd); err != nil {
+			logging.Warnf("Failed to create workspace %s: %v", workspace, err)
+		}
+	}
 
-PROMPT='%F{green}%n%f  %F{blue}%~%f  %F{magenta}${vcs_info_msg_0_}%f %F{yellow}$(git_status_symbols)%f'
-PROMPT_EOF`)
-	if err := shellFixCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to configure shell: %v\n", err)
+	// Fix shell config for better terminal experience
+	if err := configureContainerShell(containerName, containerShell()); err != nil {
+		logging.Warnf("Failed to configure shell: %v", err)
 	}
 
 	// Create IPC requests directory in container
-	mkdirIPCCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.maestro/requests")
-	if err := mkdirIPCCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to create IPC requests directory: %v\n", err)
+	mkdirIPCCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.maestro/requests")
+	if err := logging.Run(mkdirIPCCmd); err != nil {
+		logging.Warnf("Failed to create IPC requests directory: %v", err)
 	}
 
 	// Copy credentials and config files to container if they exist
 	// These files are shared across all containers, while other state files (debug/, statsig/) are container-specific
 	if credExists || configExists {
-		fmt.Println("Copying Claude credentials and configuration to container...")
+		logging.Infof("Copying Claude credentials and configuration to container...")
 
 		// Create .claude directory in container
-		mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.claude")
-		if err := mkdirCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to create .claude directory: %v\n", err)
+		mkdirCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.claude")
+		if err := logging.Run(mkdirCmd); err != nil {
+			logging.Warnf("Failed to create .claude directory: %v", err)
 		}
 
 		// Copy credentials file to .claude directory
 		if credExists {
-			copyCredCmd := exec.Command("docker", "cp", credPath, fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
-			if err := copyCredCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to copy credentials: %v\n", err)
+			copyCredCmd := logging.Command("docker", "cp", credPath, fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
+			if err := logging.Run(copyCredCmd); err != nil {
+				logging.Warnf("Failed to copy credentials: %v", err)
 			}
 		}
 
 		// Copy config file to home directory (NOT inside .claude/)
 		// .claude.json lives at /home/node/.claude.json, not /home/node/.claude/.claude.json
 		if configExists {
-			copyConfigCmd := exec.Command("docker", "cp", configPath, fmt.Sprintf("%s:/home/node/.claude.json", containerName))
-			if err := copyConfigCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to copy config: %v\n", err)
+			copyConfigCmd := logging.Command("docker", "cp", configPath, fmt.Sprintf("%s:/home/node/.claude.json", containerName))
+			if err := logging.Run(copyConfigCmd); err != nil {
+				logging.Warnf("Failed to copy config: %v", err)
 			}
 		}
 
 		// Fix ownership of .claude directory and .claude.json file
-		chownCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.claude")
-		if err := chownCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to fix .claude ownership: %v\n", err)
+		chownCmd := logging.Command("docker", "exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.claude")
+		if err := logging.Run(chownCmd); err != nil {
+			logging.Warnf("Failed to fix .claude ownership: %v", err)
 		}
 
 		if configExists {
-			chownConfigCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chown", "node:node", "/home/node/.claude.json")
-			if err := chownConfigCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to fix .claude.json ownership: %v\n", err)
+			chownConfigCmd := logging.Command("docker", "exec", "-u", "root", containerName, "chown", "node:node", "/home/node/.claude.json")
+			if err := logging.Run(chownConfigCmd); err != nil {
+				logging.Warnf("Failed to fix .claude.json ownership: %v", err)
 			}
 
 			// Inject fields to suppress interactive prompts that block unattended startup.
 			// The auth .claude.json may not have these if Claude Code was updated after auth ran.
-			patchScript := `node -e "
+			patchScript := fmt.Sprintf(`node -e "
 const fs = require('fs');
 const p = '/home/node/.claude.json';
+const ws = '%s';
 try {
   const d = JSON.parse(fs.readFileSync(p, 'utf8'));
   d.effortCalloutDismissed = true;
   d.hasCompletedOnboarding = true;
   if (!d.projects) d.projects = {};
-  if (!d.projects['/workspace']) d.projects['/workspace'] = {};
-  d.projects['/workspace'].hasTrustDialogAccepted = true;
-  d.projects['/workspace'].hasCompletedProjectOnboarding = true;
+  if (!d.projects[ws]) d.projects[ws] = {};
+  d.projects[ws].hasTrustDialogAccepted = true;
+  d.projects[ws].hasCompletedProjectOnboarding = true;
   fs.writeFileSync(p, JSON.stringify(d, null, 2));
 } catch(e) { process.exit(0); }
-"`
-			patchCmd := exec.Command("docker", "exec", "-u", "node", containerName, "bash", "-c", patchScript)
-			if err := patchCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to patch .claude.json: %v\n", err)
+"`, workspace)
+			patchCmd := logging.Command("docker", "exec", "-u", "node", containerName, "bash", "-c", patchScript)
+			if err := logging.Run(patchCmd); err != nil {
+				logging.Warnf("Failed to patch .claude.json: %v", err)
 			}
 		}
 	}
@@ -1137,51 +1677,63 @@ try {
 	if config.GitHub.Enabled {
 		ghConfigPath := expandPath(config.GitHub.ConfigPath)
 		if _, err := os.Stat(ghConfigPath); err == nil {
-			fmt.Println("Copying GitHub CLI configuration to container...")
+			logging.Infof("Copying GitHub CLI configuration to container...")
 
 			// Create .config directory in container
-			mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.config")
-			if err := mkdirCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to create .config directory: %v\n", err)
+			mkdirCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.config")
+			if err := logging.Run(mkdirCmd); err != nil {
+				logging.Warnf("Failed to create .config directory: %v", err)
 			}
 
 			// Copy entire gh config directory
-			copyGhCmd := exec.Command("docker", "cp", ghConfigPath, fmt.Sprintf("%s:/home/node/.config/gh", containerName))
-			if err := copyGhCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to copy GitHub config: %v\n", err)
+			copyGhCmd := logging.Command("docker", "cp", ghConfigPath, fmt.Sprintf("%s:/home/node/.config/gh", containerName))
+			if err := logging.Run(copyGhCmd); err != nil {
+				logging.Warnf("Failed to copy GitHub config: %v", err)
 			} else {
 				// Fix ownership
-				chownGhCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.config")
-				if err := chownGhCmd.Run(); err != nil {
-					fmt.Printf("Warning: Failed to fix .config ownership: %v\n", err)
+				chownGhCmd := logging.Command("docker", "exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.config")
+				if err := logging.Run(chownGhCmd); err != nil {
+					logging.Warnf("Failed to fix .config ownership: %v", err)
 				}
 			}
 		} else {
-			fmt.Printf("⚠️  Warning: GitHub integration enabled but config not found at %s\n", ghConfigPath)
+			fmt.Printf("%s  Warning: GitHub integration enabled but config not found at %s\n", style.Warning(), ghConfigPath)
 			fmt.Println("   Run 'gh auth login' on the host to set up GitHub CLI authentication")
 		}
 	}
 
 	// Copy and import SSL certificates for Java
 	if err := copySSLCertificates(containerName); err != nil {
-		fmt.Printf("Warning: Failed to install SSL certificates: %v\n", err)
+		logging.Warnf("Failed to install SSL certificates: %v", err)
 	}
 
 	// Setup Android SDK environment (SDK is mounted as volume)
 	if err := setupAndroidSDK(containerName); err != nil {
-		fmt.Printf("Warning: Failed to setup Android SDK: %v\n", err)
+		logging.Warnf("Failed to setup Android SDK: %v", err)
 	}
 
 	// Initialize firewall
-	fmt.Println("Setting up firewall...")
-	if err := initializeFirewall(containerName); err != nil {
-		fmt.Printf("Warning: Failed to initialize firewall: %v\n", err)
+	if labels[container.FirewallLabel] == container.FirewallDisabled {
+		if hostNetwork {
+			warnHostNetwork()
+		} else {
+			warnNoFirewall(containerName)
+		}
+		if err := copyAppsToContainer(containerName); err != nil {
+			logging.Warnf("Failed to copy apps: %v", err)
+		}
+		return nil
+	}
+	logging.Infof("Setting up firewall...")
+	if err := initializeFirewall(containerName, projectDomains); err != nil {
+		logging.Warnf("Failed to initialize firewall: %v", err)
 	}
 
 	return nil
 }
 
-// MultiProgress manages a multi-line progress display (like docker pull)
+// MultiProgress manages a multi-line progress display (like docker pull).
+// Nothing is drawn with --quiet.
 type MultiProgress struct {
 	mu          sync.Mutex
 	items       map[string]*ProgressItem
@@ -1299,7 +1851,7 @@ func (mp *MultiProgress) render() {
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
 
-	if len(mp.items) == 0 {
+	if len(mp.items) == 0 || logging.GetLevel() < logging.LevelNormal {
 		return
 	}
 
@@ -1335,6 +1887,10 @@ func (mp *MultiProgress) renderFinal() {
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
 
+	if logging.GetLevel() < logging.LevelNormal {
+		return
+	}
+
 	// Move cursor up to overwrite progress lines
 	if mp.initialized && mp.lineCount > 0 {
 		fmt.Printf("\033[%dA", mp.lineCount)
@@ -1381,9 +1937,9 @@ func (mp *MultiProgress) renderLine(item *ProgressItem) {
 	case "done":
 		duration := item.EndTime.Sub(item.StartTime).Seconds()
 		speed := float64(item.BytesRead) / duration / 1024 / 1024
-		fmt.Printf("%-40s  ✓ %s in %.1fs (%.1f MB/s)\n", displayName, formatBytes(item.BytesRead), duration, speed)
+		fmt.Printf("%-40s  %s %s in %.1fs (%.1f MB/s)\n", displayName, style.Check(), formatBytes(item.BytesRead), duration, speed)
 	case "error":
-		fmt.Printf("%-40s  ✗ Failed\n", displayName)
+		fmt.Printf("%-40s  %s Failed\n", displayName, style.Cross())
 	}
 }
 
@@ -1433,6 +1989,45 @@ func formatBytes(bytes int64) string {
 	}
 }
 
+// copyDirToContainer streams sourceDir, minus node_modules, .git and the
+// .maestroignore patterns, into destDir in the container and returns the bytes
+// sent. The archive is written in Go, so no host tar is needed. With
+// progressName set, batch mode shows progress under that container.
+func copyDirToContainer(containerName, sourceDir, destDir, progressName string) (int64, error) {
+	excludes := append([]string{"node_modules", ".git"}, readMaestroIgnore(sourceDir)...)
+	// Compression defaults on: slower for large projects, but a smaller transfer
+	useCompression := config.Sync.Compress == nil || *config.Sync.Compress
+	extractFlag := "-xf"
+	if useCompression {
+		extractFlag = "-xzf"
+	}
+
+	pipeR, pipeW := io.Pipe()
+	pr := &progressReader{reader: pipeR, containerName: progressName}
+	dockerCmd := logging.Command("docker", "exec", "-i", containerName, "tar", extractFlag, "-", "-C", destDir)
+	dockerCmd.Stdin = pr
+	if err := dockerCmd.Start(); err != nil {
+		return 0, err
+	}
+
+	archiveErr := make(chan error, 1)
+	go func() {
+		err := container.WriteProjectArchive(pipeW, sourceDir, excludes, useCompression)
+		pipeW.CloseWithError(err)
+		archiveErr <- err
+	}()
+	dockerErr := dockerCmd.Wait()
+	// Unblock the archive writer if tar exited before reading everything
+	pipeR.Close()
+	if err := <-archiveErr; err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		return 0, fmt.Errorf("failed to archive %s: %w", sourceDir, err)
+	}
+	if dockerErr != nil {
+		return 0, fmt.Errorf("failed to extract in the container: %w", dockerErr)
+	}
+	return pr.getBytesRead(), nil
+}
+
 // readMaestroIgnore reads exclusion patterns from .maestroignore file
 func readMaestroIgnore(dir string) []string {
 	ignorePath := filepath.Join(dir, ".maestroignore")
@@ -1461,9 +2056,6 @@ func copyProjectToContainer(containerName string) error {
 		return err
 	}
 
-	// Determine compression setting (default: true for backward compatibility)
-	useCompression := config.Sync.Compress == nil || *config.Sync.Compress
-
 	// Check if we're in batch mode (MultiProgress active)
 	mp := GetMultiProgress()
 	isBatchMode := mp != nil
@@ -1472,37 +2064,13 @@ func copyProjectToContainer(containerName string) error {
 	if isBatchMode {
 		mp.StartItem(containerName)
 	} else {
-		fmt.Printf("Copying source code to %s...\n", containerName)
+		logging.Infof("Copying source code to %s...", containerName)
 	}
 
 	startTime := time.Now()
 
-	// Build exclude arguments (defaults + .maestroignore)
-	excludeArgs := []string{"--exclude=node_modules", "--exclude=.git"}
-	for _, pattern := range readMaestroIgnore(cwd) {
-		excludeArgs = append(excludeArgs, "--exclude="+pattern)
-	}
-
-	// Create tar of current directory (excluding .git which is copied separately)
-	var tarCmd *exec.Cmd
-	var dockerCmd *exec.Cmd
-	if useCompression {
-		// Use gzip compression (slower for large projects but smaller transfer)
-		tarArgs := append([]string{"-czf", "-"}, excludeArgs...)
-		tarArgs = append(tarArgs, ".")
-		tarCmd = exec.Command("tar", tarArgs...)
-		dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xzf", "-", "-C", "/workspace")
-	} else {
-		// No compression (faster for large projects on local Docker)
-		tarArgs := append([]string{"-cf", "-"}, excludeArgs...)
-		tarArgs = append(tarArgs, ".")
-		tarCmd = exec.Command("tar", tarArgs...)
-		dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xf", "-", "-C", "/workspace")
-	}
-	tarCmd.Dir = cwd
-
-	// Connect pipes with progress tracking
-	pipe, err := tarCmd.StdoutPipe()
+	bytesRead, err := copyDirToContainer(containerName, cwd, workspaceDir(), containerName)
+	duration := time.Since(startTime)
 	if err != nil {
 		if isBatchMode {
 			mp.ErrorItem(containerName, err)
@@ -1510,334 +2078,241 @@ func copyProjectToContainer(containerName string) error {
 		return err
 	}
 
-	// Create progress reader
-	pr := &progressReader{
-		reader:        pipe,
-		containerName: containerName,
-	}
-	dockerCmd.Stdin = pr
-
-	// Start both commands
-	if err := tarCmd.Start(); err != nil {
-		if isBatchMode {
-			mp.ErrorItem(containerName, err)
-		}
-		return err
-	}
-	if err := dockerCmd.Start(); err != nil {
-		if isBatchMode {
-			mp.ErrorItem(containerName, err)
-		}
-		return err
-	}
-
-	// Wait for completion
-	tarErr := tarCmd.Wait()
-	dockerErr := dockerCmd.Wait()
-
-	bytesRead := pr.getBytesRead()
-	duration := time.Since(startTime)
-
-	if tarErr != nil {
-		if isBatchMode {
-			mp.ErrorItem(containerName, tarErr)
-		}
-		return tarErr
-	}
-	if dockerErr != nil {
-		if isBatchMode {
-			mp.ErrorItem(containerName, dockerErr)
-		}
-		return dockerErr
-	}
-
 	// Update final bytes and mark complete
 	if isBatchMode {
 		mp.UpdateItem(containerName, bytesRead)
 		mp.CompleteItem(containerName)
 	} else {
 		speed := float64(bytesRead) / duration.Seconds() / 1024 / 1024
-		fmt.Printf("  Copied %s in %.1fs (%.1f MB/s)\n", formatBytes(bytesRead), duration.Seconds(), speed)
+		logging.Infof("  Copied %s in %.1fs (%.1f MB/s)", formatBytes(bytesRead), duration.Seconds(), speed)
 	}
 
 	// Copy .git separately if it exists
 	if _, err := os.Stat(".git"); err == nil {
-		gitCmd := exec.Command("docker", "cp", ".git", fmt.Sprintf("%s:/workspace/", containerName))
-		if err := gitCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to copy .git: %v\n", err)
+		gitCmd := logging.Command("docker", "cp", ".git", fmt.Sprintf("%s:%s/", containerName, workspaceDir()))
+		if err := logging.Run(gitCmd); err != nil {
+			logging.Warnf("Failed to copy .git: %v", err)
 		}
 	}
 
-	// Fix ownership of /workspace to node user
-	chownCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node /workspace")
-	if err := chownCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to fix ownership: %v\n", err)
+	// Fix ownership of the workspace to node user
+	chownCmd := logging.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node "+workspaceDir())
+	if err := logging.Run(chownCmd); err != nil {
+		logging.Warnf("Failed to fix ownership: %v", err)
 	}
 
 	return nil
 }
 
-// copyProjectToContainerFrom copies a project from a specified source path (instead of cwd) to /workspace/
-func copyProjectToContainerFrom(containerName, sourcePath string) error {
-	useCompression := config.Sync.Compress == nil || *config.Sync.Compress
-
-	fmt.Printf("Copying source code from %s to %s...\n", sourcePath, containerName)
-	startTime := time.Now()
-
-	// Build exclude arguments
-	excludeArgs := []string{"--exclude=node_modules", "--exclude=.git"}
-	for _, pattern := range readMaestroIgnore(sourcePath) {
-		excludeArgs = append(excludeArgs, "--exclude="+pattern)
-	}
-
-	var tarCmd, dockerCmd *exec.Cmd
-	if useCompression {
-		tarArgs := append([]string{"-czf", "-"}, excludeArgs...)
-		tarArgs = append(tarArgs, ".")
-		tarCmd = exec.Command("tar", tarArgs...)
-		dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xzf", "-", "-C", "/workspace")
-	} else {
-		tarArgs := append([]string{"-cf", "-"}, excludeArgs...)
-		tarArgs = append(tarArgs, ".")
-		tarCmd = exec.Command("tar", tarArgs...)
-		dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xf", "-", "-C", "/workspace")
+// projectSourceDir returns the host directory setupContainer copies the
+// (primary) repository from: the project's primary path, SourceDir for
+// recreated containers, the parent's source for child containers, or the
+// working directory.
+func projectSourceDir(opts ContainerSetupOptions) string {
+	switch {
+	case opts.Project != nil:
+		return opts.Project.PrimaryPath()
+	case opts.SourceDir != "" || opts.RecreateFrom != "":
+		return opts.SourceDir
+	case opts.ParentContainer != "":
+		return container.GetLabel(opts.ParentContainer, container.SourceDirLabel)
 	}
-	tarCmd.Dir = sourcePath
-
-	pipe, err := tarCmd.StdoutPipe()
+	cwd, err := os.Getwd()
 	if err != nil {
-		return err
+		return ""
 	}
+	return cwd
+}
 
-	pr := &progressReader{reader: pipe, containerName: containerName}
-	dockerCmd.Stdin = pr
+// copyProjectToContainerFrom copies a project from a specified source path (instead of cwd) to the workspace root
+func copyProjectToContainerFrom(containerName, sourcePath string) error {
+	logging.Infof("Copying source code from %s to %s...", sourcePath, containerName)
+	startTime := time.Now()
 
-	if err := tarCmd.Start(); err != nil {
-		return err
-	}
-	if err := dockerCmd.Start(); err != nil {
+	bytesRead, err := copyDirToContainer(containerName, sourcePath, workspaceDir(), containerName)
+	if err != nil {
 		return err
 	}
-
-	tarErr := tarCmd.Wait()
-	dockerErr := dockerCmd.Wait()
-
-	bytesRead := pr.getBytesRead()
 	duration := time.Since(startTime)
 
-	if tarErr != nil {
-		return tarErr
-	}
-	if dockerErr != nil {
-		return dockerErr
-	}
-
 	speed := float64(bytesRead) / duration.Seconds() / 1024 / 1024
-	fmt.Printf("  Copied %s in %.1fs (%.1f MB/s)\n", formatBytes(bytesRead), duration.Seconds(), speed)
+	logging.Infof("  Copied %s in %.1fs (%.1f MB/s)", formatBytes(bytesRead), duration.Seconds(), speed)
 
 	// Copy .git separately if it exists
 	gitDir := filepath.Join(sourcePath, ".git")
 	if _, err := os.Stat(gitDir); err == nil {
-		gitCmd := exec.Command("docker", "cp", gitDir, fmt.Sprintf("%s:/workspace/", containerName))
-		if err := gitCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to copy .git: %v\n", err)
+		gitCmd := logging.Command("docker", "cp", gitDir, fmt.Sprintf("%s:%s/", containerName, workspaceDir()))
+		if err := logging.Run(gitCmd); err != nil {
+			logging.Warnf("Failed to copy .git: %v", err)
 		}
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node /workspace")
-	if err := chownCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to fix ownership: %v\n", err)
+	chownCmd := logging.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node "+workspaceDir())
+	if err := logging.Run(chownCmd); err != nil {
+		logging.Warnf("Failed to fix ownership: %v", err)
 	}
 
 	return nil
 }
 
-// copyMultiPathProject copies multiple repos to /workspace/<basename>/ each.
+// copyMultiPathProject copies multiple repos to <workspace>/<basename>/ each.
 func copyMultiPathProject(containerName string, paths []string) error {
-	useCompression := config.Sync.Compress == nil || *config.Sync.Compress
-
 	for _, sourcePath := range paths {
 		baseName := filepath.Base(sourcePath)
-		destDir := "/workspace/" + baseName
-		fmt.Printf("Copying %s to %s:%s...\n", baseName, containerName, destDir)
+		destDir := path.Join(workspaceDir(), baseName)
+		logging.Infof("Copying %s to %s:%s...", baseName, containerName, destDir)
 
 		// Create destination directory
-		mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", destDir)
-		if err := mkdirCmd.Run(); err != nil {
+		mkdirCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p", destDir)
+		if err := logging.Run(mkdirCmd); err != nil {
 			return fmt.Errorf("failed to create %s: %w", destDir, err)
 		}
 
-		// Build exclude arguments
-		excludeArgs := []string{"--exclude=node_modules", "--exclude=.git"}
-		for _, pattern := range readMaestroIgnore(sourcePath) {
-			excludeArgs = append(excludeArgs, "--exclude="+pattern)
-		}
-
-		var tarCmd, dockerCmd *exec.Cmd
-		if useCompression {
-			tarArgs := append([]string{"-czf", "-"}, excludeArgs...)
-			tarArgs = append(tarArgs, ".")
-			tarCmd = exec.Command("tar", tarArgs...)
-			dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xzf", "-", "-C", destDir)
-		} else {
-			tarArgs := append([]string{"-cf", "-"}, excludeArgs...)
-			tarArgs = append(tarArgs, ".")
-			tarCmd = exec.Command("tar", tarArgs...)
-			dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xf", "-", "-C", destDir)
-		}
-		tarCmd.Dir = sourcePath
-
-		pipe, err := tarCmd.StdoutPipe()
-		if err != nil {
-			return err
-		}
-		dockerCmd.Stdin = pipe
-
-		if err := tarCmd.Start(); err != nil {
-			return err
-		}
-		if err := dockerCmd.Start(); err != nil {
-			return err
-		}
-
-		if tarErr := tarCmd.Wait(); tarErr != nil {
-			return fmt.Errorf("tar of %s failed: %w", baseName, tarErr)
-		}
-		if dockerErr := dockerCmd.Wait(); dockerErr != nil {
-			return fmt.Errorf("extract of %s failed: %w", baseName, dockerErr)
+		if _, err := copyDirToContainer(containerName, sourcePath, destDir, ""); err != nil {
+			return fmt.Errorf("copy of %s failed: %w", baseName, err)
 		}
 
 		// Copy .git separately
 		gitDir := filepath.Join(sourcePath, ".git")
 		if _, err := os.Stat(gitDir); err == nil {
-			gitCmd := exec.Command("docker", "cp", gitDir, fmt.Sprintf("%s:%s/", containerName, destDir))
-			if err := gitCmd.Run(); err != nil {
-				fmt.Printf("Warning: Failed to copy .git for %s: %v\n", baseName, err)
+			gitCmd := logging.Command("docker", "cp", gitDir, fmt.Sprintf("%s:%s/", containerName, destDir))
+			if err := logging.Run(gitCmd); err != nil {
+				logging.Warnf("Failed to copy .git for %s: %v", baseName, err)
 			}
 		}
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node /workspace")
-	if err := chownCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to fix ownership: %v\n", err)
+	chownCmd := logging.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node "+workspaceDir())
+	if err := logging.Run(chownCmd); err != nil {
+		logging.Warnf("Failed to fix ownership: %v", err)
 	}
 
 	return nil
 }
 
-func copyAdditionalFolders(containerName string) error {
-	for _, folder := range config.Sync.AdditionalFolders {
-		expandedPath := expandPath(folder)
-		if _, err := os.Stat(expandedPath); err != nil {
-			fmt.Printf("Skipping %s (not found)\n", folder)
-			continue
-		}
-
-		baseName := filepath.Base(expandedPath)
-		fmt.Printf("Copying %s...\n", baseName)
-
-		cmd := exec.Command("docker", "cp", expandedPath, fmt.Sprintf("%s:/workspace/../%s", containerName, baseName))
-		if err := cmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to copy %s: %v\n", folder, err)
-		}
-	}
-	return nil
-}
-
-// linkPrimarySkills creates /workspace/.claude/commands/ and symlinks commands
-// from the primary repo into it so Claude discovers skills when starting at /workspace.
-// Also symlinks the primary repo's CLAUDE.md to /workspace/CLAUDE.md.
+// linkPrimarySkills creates <workspace>/.claude/commands/ and symlinks commands
+// from the primary repo into it so Claude discovers skills when starting at the
+// workspace root. Also symlinks the primary repo's CLAUDE.md to <workspace>/CLAUDE.md.
 // The workspace-level commands/ is a real directory, so workspace-specific commands
 // can be added later without conflicting with subproject commands.
 func linkPrimarySkills(containerName string, project *ProjectConfig) error {
-	primaryBase := filepath.Base(project.PrimaryPath())
-	primaryDir := "/workspace/" + primaryBase
+	workspace := workspaceDir()
+	primaryDir := path.Join(workspace, filepath.Base(project.PrimaryPath()))
+	commandsDir := path.Join(workspace, ".claude", "commands")
 
 	// Create workspace-level .claude/commands/ directory
-	mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", "/workspace/.claude/commands")
-	if err := mkdirCmd.Run(); err != nil {
-		return fmt.Errorf("failed to create /workspace/.claude/commands: %w", err)
+	mkdirCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p", commandsDir)
+	if err := logging.Run(mkdirCmd); err != nil {
+		return fmt.Errorf("failed to create %s: %w", commandsDir, err)
 	}
 
 	// Symlink each command from primary repo's .claude/commands/ into workspace
 	linkScript := fmt.Sprintf(`
 if [ -d "%s/.claude/commands" ]; then
   for cmd in %s/.claude/commands/*; do
-    [ -e "$cmd" ] && ln -s "$cmd" /workspace/.claude/commands/ 2>/dev/null
+    [ -e "$cmd" ] && ln -s "$cmd" %s/ 2>/dev/null
   done
 fi
-`, primaryDir, primaryDir)
-	linkCmd := exec.Command("docker", "exec", containerName, "sh", "-c", linkScript)
-	if err := linkCmd.Run(); err != nil {
+`, primaryDir, primaryDir, commandsDir)
+	linkCmd := logging.Command("docker", "exec", containerName, "sh", "-c", linkScript)
+	if err := logging.Run(linkCmd); err != nil {
 		return fmt.Errorf("failed to symlink commands: %w", err)
 	}
 
 	// Symlink primary CLAUDE.md to workspace root
-	claudeMDScript := fmt.Sprintf(`[ -f "%s/CLAUDE.md" ] && ln -s "%s/CLAUDE.md" /workspace/CLAUDE.md 2>/dev/null; true`,
-		primaryDir, primaryDir)
-	claudeMDCmd := exec.Command("docker", "exec", containerName, "sh", "-c", claudeMDScript)
-	if err := claudeMDCmd.Run(); err != nil {
+	claudeMDScript := fmt.Sprintf(`[ -f "%s/CLAUDE.md" ] && ln -s "%s/CLAUDE.md" %s/CLAUDE.md 2>/dev/null; true`,
+		primaryDir, primaryDir, workspace)
+	claudeMDCmd := logging.Command("docker", "exec", containerName, "sh", "-c", claudeMDScript)
+	if err := logging.Run(claudeMDCmd); err != nil {
 		return fmt.Errorf("failed to symlink CLAUDE.md: %w", err)
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node /workspace/.claude")
-	if err := chownCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to fix ownership on /workspace/.claude: %v\n", err)
+	claudeDir := path.Join(workspace, ".claude")
+	chownCmd := logging.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node "+claudeDir)
+	if err := logging.Run(chownCmd); err != nil {
+		logging.Warnf("Failed to fix ownership on %s: %v", claudeDir, err)
 	}
 
 	return nil
 }
 
 func initializeGitBranch(containerName, branchName string) error {
+	workspace := workspaceDir()
+
 	// Fix git ownership issue first
-	safeCmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "--add", "safe.directory", "/workspace")
-	if err := safeCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to set safe.directory: %v\n", err)
+	safeCmd := logging.Command("docker", "exec", containerName, "git", "config", "--global", "--add", "safe.directory", workspace)
+	if err := logging.Run(safeCmd); err != nil {
+		logging.Warnf("Failed to set safe.directory: %v", err)
 	}
 
-	// Check if git repo exists
-	checkCmd := exec.Command("docker", "exec", containerName, "test", "-d", "/workspace/.git")
-	if err := checkCmd.Run(); err != nil {
-		// Initialize git if not exists
-		initCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "cd /workspace && git init")
-		if err := initCmd.Run(); err != nil {
-			return err
-		}
+	// Projects copied from outside a git repository get a fresh one
+	checkCmd := logging.Command("docker", "exec", containerName, "test", "-e", workspace+"/.git")
+	if err := logging.Run(checkCmd); err != nil {
+		return initFreshRepo(containerName, branchName)
 	}
 
 	// Create and checkout new branch
-	cmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd /workspace && git checkout -b %s 2>/dev/null || git checkout %s", branchName, branchName))
-	return cmd.Run()
+	cmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		container.InWorkspace(workspace, fmt.Sprintf("git checkout -b %s 2>/dev/null || git checkout %s", branchName, branchName)))
+	return logging.Run(cmd)
+}
+
+// initFreshRepo starts a git repository in the workspace on branchName and
+// commits the copied files, so the container's changes show up as diffs
+// against what was copied in.
+func initFreshRepo(containerName, branchName string) error {
+	name, email := config.Git.UserName, config.Git.UserEmail
+	if name == "" {
+		name = "Maestro"
+	}
+	if email == "" {
+		email = "maestro@localhost"
+	}
+	script := fmt.Sprintf("git init -q && git symbolic-ref HEAD refs/heads/%s && git add -A && git commit -q --allow-empty -m 'Initial files'", branchName)
+	initCmd := logging.Command("docker", "exec",
+		"-e", "GIT_AUTHOR_NAME="+name, "-e", "GIT_AUTHOR_EMAIL="+email,
+		"-e", "GIT_COMMITTER_NAME="+name, "-e", "GIT_COMMITTER_EMAIL="+email,
+		containerName, "sh", "-c", container.InWorkspace(workspaceDir(), script))
+	if err := logging.Run(initCmd); err != nil {
+		return fmt.Errorf("failed to create a git repository: %w", err)
+	}
+	return nil
+}
+
+// isGitRepo reports whether dir is the top of a git checkout (or worktree),
+// which is what the project copy carries .git over from.
+func isGitRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
 }
 
 // initializeGitBranchInDir creates a git branch in a specific directory inside the container.
 func initializeGitBranchInDir(containerName, branchName, dir string) error {
 	// Add safe.directory
-	safeCmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "--add", "safe.directory", dir)
-	if err := safeCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to set safe.directory for %s: %v\n", dir, err)
+	safeCmd := logging.Command("docker", "exec", containerName, "git", "config", "--global", "--add", "safe.directory", dir)
+	if err := logging.Run(safeCmd); err != nil {
+		logging.Warnf("Failed to set safe.directory for %s: %v", dir, err)
 	}
 
 	// Check if git repo exists
-	checkCmd := exec.Command("docker", "exec", containerName, "test", "-d", dir+"/.git")
-	if err := checkCmd.Run(); err != nil {
+	checkCmd := logging.Command("docker", "exec", containerName, "test", "-d", dir+"/.git")
+	if err := logging.Run(checkCmd); err != nil {
 		return nil // Not a git repo, skip
 	}
 
 	// Create and checkout branch
-	cmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd %s && git checkout -b %s 2>/dev/null || git checkout %s", dir, branchName, branchName))
-	return cmd.Run()
+	cmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		container.InWorkspace(dir, fmt.Sprintf("git checkout -b %s 2>/dev/null || git checkout %s", branchName, branchName)))
+	return logging.Run(cmd)
 }
 
 // setupGitHubRemoteInDir converts SSH remotes to HTTPS in a specific directory.
 func setupGitHubRemoteInDir(containerName, dir string) error {
-	getOriginCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd %s && git config --get remote.origin.url", dir))
+	getOriginCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		container.InWorkspace(dir, "git config --get remote.origin.url"))
 	originOutput, err := getOriginCmd.Output()
 	if err != nil {
 		return nil
@@ -1866,21 +2341,21 @@ func setupGitHubRemoteInDir(containerName, dir string) error {
 	}
 	httpsURL := fmt.Sprintf("https://%s/%s", host, repoPath)
 
-	setCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd %s && git remote set-url origin %s", dir, httpsURL))
-	return setCmd.Run()
+	setCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		container.InWorkspace(dir, fmt.Sprintf("git remote set-url origin %s", httpsURL)))
+	return logging.Run(setCmd)
 }
 
 func configureGitUser(containerName string) error {
 	if config.Git.UserName != "" {
-		cmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "user.name", config.Git.UserName)
-		if err := cmd.Run(); err != nil {
+		cmd := logging.Command("docker", "exec", containerName, "git", "config", "--global", "user.name", config.Git.UserName)
+		if err := logging.Run(cmd); err != nil {
 			return fmt.Errorf("failed to set git user.name: %w", err)
 		}
 	}
 	if config.Git.UserEmail != "" {
-		cmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "user.email", config.Git.UserEmail)
-		if err := cmd.Run(); err != nil {
+		cmd := logging.Command("docker", "exec", containerName, "git", "config", "--global", "user.email", config.Git.UserEmail)
+		if err := logging.Run(cmd); err != nil {
 			return fmt.Errorf("failed to set git user.email: %w", err)
 		}
 	}
@@ -1889,8 +2364,8 @@ func configureGitUser(containerName string) error {
 
 func setupGitHubRemote(containerName string) error {
 	// Check if origin remote exists
-	getOriginCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		"cd /workspace && git config --get remote.origin.url")
+	getOriginCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		container.InWorkspace(workspaceDir(), "git config --get remote.origin.url"))
 	originOutput, err := getOriginCmd.Output()
 	if err != nil {
 		// No origin, nothing to do
@@ -1920,63 +2395,42 @@ func setupGitHubRemote(containerName string) error {
 	// Convert to HTTPS URL
 	httpsURL := fmt.Sprintf("https://github.com/%s", repoPath)
 
-	fmt.Printf("Converting SSH remote to HTTPS for GitHub authentication...\n")
-	fmt.Printf("  Old: %s\n", originURL)
-	fmt.Printf("  New: %s\n", httpsURL)
+	logging.Infof("Converting SSH remote to HTTPS for GitHub authentication...")
+	logging.Infof("  Old: %s", originURL)
+	logging.Infof("  New: %s", httpsURL)
 
 	// Update the origin URL
-	setOriginCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd /workspace && git remote set-url origin %s", httpsURL))
-	if err := setOriginCmd.Run(); err != nil {
+	setOriginCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		container.InWorkspace(workspaceDir(), fmt.Sprintf("git remote set-url origin %s", httpsURL)))
+	if err := logging.Run(setOriginCmd); err != nil {
 		return fmt.Errorf("failed to update origin URL: %w", err)
 	}
 
 	// Configure git to use gh for authentication
 	// Only do this if GitHub integration is enabled
 	if config.GitHub.Enabled {
-		fmt.Println("Configuring git to use GitHub CLI for authentication...")
-		ghSetupCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-			"cd /workspace && gh auth setup-git")
-		if err := ghSetupCmd.Run(); err != nil {
+		logging.Infof("Configuring git to use GitHub CLI for authentication...")
+		ghSetupCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+			container.InWorkspace(workspaceDir(), "gh auth setup-git"))
+		if err := logging.Run(ghSetupCmd); err != nil {
 			return fmt.Errorf("failed to setup gh auth: %w", err)
 		}
-		fmt.Println("✓ GitHub authentication configured")
+		logging.Infof("%s GitHub authentication configured", style.Check())
 	}
 
 	return nil
 }
 
 func startTmuxSession(containerName, branchName, planningPrompt string, exactPrompt bool, model string) error {
-	// Create tmux configuration with status line showing container info and true color support
-	tmuxConfig := generateTmuxConfig(containerName, branchName)
-
-	// Write tmux config to container - use cat with heredoc to preserve newlines
-	writeCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cat > /home/node/.tmux.conf << 'EOF'\n%s\nEOF", tmuxConfig))
-	if err := writeCmd.Run(); err != nil {
-		return err
+	// Write tmux config with status line showing container info and true color support
+	if err := writeTmuxConfig(containerName, branchName); err != nil {
+		return fmt.Errorf("failed to write tmux config: %w", err)
 	}
 
 	// Note: Config will be loaded when tmux session starts below
 
-	// Prepare the task prompt that will be sent via tmux
-	var taskPrompt string
-	if exactPrompt {
-		// In exact mode, send the prompt as-is without any wrapper
-		taskPrompt = planningPrompt
-	} else {
-		// In normal mode, wrap with planning instructions
-		taskPrompt = fmt.Sprintf(`%s
-
-Please analyze this task and create a detailed implementation plan. Do not start coding yet - just plan the implementation.`, planningPrompt)
-	}
-
-	// Write the bootstrap prompt to a file in the container
-	writePrompt := exec.Command("docker", "exec", "-i", containerName, "sh", "-c",
-		"cat > /tmp/maestro-bootstrap.txt")
-	writePrompt.Stdin = strings.NewReader(taskPrompt)
-	if err := writePrompt.Run(); err != nil {
-		return fmt.Errorf("failed to write bootstrap prompt: %w", err)
+	if err := writeBootstrapPrompt(containerName, planningPrompt, exactPrompt); err != nil {
+		return err
 	}
 
 	// Build Claude command — always pass --model explicitly so we don't
@@ -1987,38 +2441,39 @@ Please analyze this task and create a detailed implementation plan. Do not start
 	// Start tmux session with Claude, piping the bootstrap prompt via stdin.
 	// Piped input bypasses the bypass-permissions prompt entirely and delivers
 	// the initial prompt in one shot — no auto-input script needed.
-	tmuxCmd := exec.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
-		"cd /workspace && HOME=/home/node tmux new-session -d -s main "+
-			fmt.Sprintf("'cat /tmp/maestro-bootstrap.txt | %s'", claudeCmd))
+	session := tmuxSessionName()
+	tmuxCmd := logging.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
+		container.InWorkspace(workspaceDir(),
+			fmt.Sprintf("HOME=/home/node tmux new-session -d -s %s 'cat %s | %s'", session, bootstrapPromptPath, claudeCmd)))
 
 	// Capture output for debugging
 	var stdout, stderr bytes.Buffer
 	tmuxCmd.Stdout = &stdout
 	tmuxCmd.Stderr = &stderr
 
-	if err := tmuxCmd.Run(); err != nil {
+	if err := logging.Run(tmuxCmd); err != nil {
 		fmt.Printf("Tmux command stdout: %s\n", stdout.String())
 		fmt.Printf("Tmux command stderr: %s\n", stderr.String())
 		return fmt.Errorf("failed to start tmux: %w", err)
 	}
 
 	// Wait for tmux session to be ready
-	fmt.Println("Waiting for tmux session to start...")
+	logging.Infof("Waiting for tmux session to start...")
 	for i := 0; i < 10; i++ {
-		checkCmd := exec.Command("docker", "exec", "-u", "node", containerName, "tmux", "has-session", "-t", "main")
+		checkCmd := logging.Command("docker", "exec", "-u", "node", containerName, "tmux", "has-session", "-t", session)
 		var checkOut, checkErr bytes.Buffer
 		checkCmd.Stdout = &checkOut
 		checkCmd.Stderr = &checkErr
 
-		if err := checkCmd.Run(); err == nil {
+		if err := logging.Run(checkCmd); err == nil {
 			break
 		}
 		if i == 9 {
 			fmt.Printf("Timeout waiting for tmux session. Last check stderr: %s\n", checkErr.String())
-			listCmd := exec.Command("docker", "exec", "-u", "node", containerName, "tmux", "ls")
+			listCmd := logging.Command("docker", "exec", "-u", "node", containerName, "tmux", "ls")
 			listOut, _ := listCmd.CombinedOutput()
 			fmt.Printf("All tmux sessions: %s\n", string(listOut))
-			psCmd := exec.Command("docker", "exec", "-u", "node", containerName, "ps", "aux")
+			psCmd := logging.Command("docker", "exec", "-u", "node", containerName, "ps", "aux")
 			psOut, _ := psCmd.CombinedOutput()
 			fmt.Printf("Running processes:\n%s\n", string(psOut))
 			return fmt.Errorf("tmux session failed to start after 5 seconds")
@@ -2027,119 +2482,222 @@ Please analyze this task and create a detailed implementation plan. Do not start
 	}
 
 	// Start maestro-agent service in background (handles idle wake-up, heartbeat, clear timer)
-	agentService := exec.Command("docker", "exec", "-d", "-u", "node", containerName, "sh", "-c",
+	agentService := logging.Command("docker", "exec", "-d", "-u", "node", containerName, "sh", "-c",
 		"HOME=/home/node maestro-agent service")
-	if err := agentService.Run(); err != nil {
-		fmt.Printf("Warning: Failed to start maestro-agent service: %v\n", err)
+	if err := logging.Run(agentService); err != nil {
+		logging.Warnf("Failed to start maestro-agent service: %v", err)
 	}
 
-	fmt.Println("Claude started with piped bootstrap prompt...")
+	logging.Infof("Claude started with piped bootstrap prompt...")
 
 	// Window 1: Shell
-	newWinCmd := exec.Command("docker", "exec", "-u", "node", containerName,
-		"tmux", "new-window", "-t", "main:1", "-n", "shell", "-c", "cd /workspace && exec zsh")
-	if err := newWinCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to create shell window: %v\n", err)
+	newWinCmd := logging.Command("docker", "exec", "-u", "node", containerName,
+		"tmux", "new-window", "-t", session+":1", "-n", "shell", "-c", workspaceDir(), containerShell())
+	if err := logging.Run(newWinCmd); err != nil {
+		logging.Warnf("Failed to create shell window: %v", err)
 	}
 
 	// Rename window 0
-	renameCmd := exec.Command("docker", "exec", "-u", "node", containerName,
-		"tmux", "rename-window", "-t", "main:0", "claude")
-	if err := renameCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to rename claude window: %v\n", err)
+	renameCmd := logging.Command("docker", "exec", "-u", "node", containerName,
+		"tmux", "rename-window", "-t", session+":0", "claude")
+	if err := logging.Run(renameCmd); err != nil {
+		logging.Warnf("Failed to rename claude window: %v", err)
 	}
 
 	// Set Claude window as active
-	selectCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "select-window", "-t", "main:0")
-	if err := selectCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to select claude window: %v\n", err)
+	selectCmd := logging.Command("docker", "exec", containerName,
+		"tmux", "select-window", "-t", session+":0")
+	if err := logging.Run(selectCmd); err != nil {
+		logging.Warnf("Failed to select claude window: %v", err)
 	}
 
 	return nil
 }
 
-func initializeFirewall(containerName string) error {
-	// Write embedded firewall script to a temporary file
-	tmpFile, err := os.CreateTemp("", "init-firewall-*.sh")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+// bootstrapPromptPath holds the initial task prompt inside the container.
+const bootstrapPromptPath = "/tmp/maestro-bootstrap.txt"
+
+// writeBootstrapPrompt writes the initial task prompt to the container,
+// wrapped with planning instructions unless exactPrompt is set.
+func writeBootstrapPrompt(containerName, planningPrompt string, exactPrompt bool) error {
+	var taskPrompt string
+	if exactPrompt {
+		// In exact mode, send the prompt as-is without any wrapper
+		taskPrompt = planningPrompt
+	} else {
+		// In normal mode, wrap with planning instructions
+		taskPrompt = fmt.Sprintf(`%s
+
+Please analyze this task and create a detailed implementation plan. Do not start coding yet - just plan the implementation.`, planningPrompt)
 	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
 
-	if _, err := tmpFile.WriteString(assets.FirewallScript); err != nil {
-		return fmt.Errorf("failed to write firewall script: %w", err)
+	writePrompt := logging.Command("docker", "exec", "-i", containerName, "sh", "-c",
+		"cat > "+bootstrapPromptPath)
+	writePrompt.Stdin = strings.NewReader(taskPrompt)
+	if err := logging.Run(writePrompt); err != nil {
+		return fmt.Errorf("failed to write bootstrap prompt: %w", err)
 	}
-	tmpFile.Close()
+	return nil
+}
 
-	// Copy script to container
-	copyCmd := exec.Command("docker", "cp", tmpFile.Name(), fmt.Sprintf("%s:/usr/local/bin/init-firewall.sh", containerName))
-	if err := copyCmd.Run(); err != nil {
-		return err
+// directClaudeScript renders the launcher used by --no-tmux containers. The
+// first run consumes the bootstrap prompt; later runs continue the most
+// recent conversation if there is one. model is validated by setupContainer.
+func directClaudeScript(workspace, model string) string {
+	claudeCmd := fmt.Sprintf("claude --dangerously-skip-permissions --model %s", model)
+	return fmt.Sprintf(`#!/bin/sh
+# Launches Claude for a container created with 'maestro new --no-tmux'
+cd %[1]s || exit 1
+export HOME=/home/node
+if [ -f %[2]s ]; then
+    prompt="$(cat %[2]s)"
+    rm -f %[2]s
+    exec %[3]s -- "$prompt"
+fi
+if ls /home/node/.claude/projects/*/*.jsonl >/dev/null 2>&1; then
+    exec %[3]s --continue
+fi
+exec %[3]s
+`, workspace, bootstrapPromptPath, claudeCmd)
+}
+
+// writeDirectClaudeScript installs the --no-tmux Claude launcher in the container.
+func writeDirectClaudeScript(containerName, model string) error {
+	writeCmd := logging.Command("docker", "exec", "-i", "-u", "node", containerName, "sh", "-c",
+		fmt.Sprintf("mkdir -p %s && cat > %s && chmod +x %s",
+			path.Dir(container.DirectClaudeScript), container.DirectClaudeScript, container.DirectClaudeScript))
+	writeCmd.Stdin = strings.NewReader(directClaudeScript(workspaceDir(), model))
+	return logging.Run(writeCmd)
+}
+
+// writeRootFile writes content (plus a trailing newline) to filePath in the
+// container as root. The content goes over stdin rather than into a shell
+// command line, so config values containing quotes or other shell syntax
+// cannot break out of the write.
+func writeRootFile(containerName, filePath, content string) error {
+	writeCmd := logging.Command("docker", "exec", "-i", "-u", "root", containerName, "tee", filePath)
+	writeCmd.Stdin = strings.NewReader(content + "\n")
+	return logging.Run(writeCmd)
+}
+
+// mergeDomains appends extra domains to the configured allowlist, skipping
+// duplicates and keeping the configured order first.
+func mergeDomains(configured, extra []string) []string {
+	seen := make(map[string]bool, len(configured)+len(extra))
+	merged := make([]string, 0, len(configured)+len(extra))
+	for _, d := range append(append([]string{}, configured...), extra...) {
+		if d == "" || seen[d] {
+			continue
+		}
+		seen[d] = true
+		merged = append(merged, d)
 	}
+	return merged
+}
 
-	// Make the script executable (as root)
-	chmodCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chmod", "+x", "/usr/local/bin/init-firewall.sh")
-	if err := chmodCmd.Run(); err != nil {
-		return fmt.Errorf("failed to make firewall script executable: %w", err)
+func initializeFirewall(containerName string, projectDomains []string) error {
+	// Install the embedded firewall script. It goes over stdin rather than
+	// docker cp, which can't see the /run tmpfs of read-only containers.
+	mkdirCmd := logging.Command("docker", "exec", "-u", "root", containerName, "mkdir", "-p", container.FirewallDir)
+	if err := logging.Run(mkdirCmd); err != nil {
+		return fmt.Errorf("failed to create firewall directory: %w", err)
+	}
+	if err := writeRootFile(containerName, container.FirewallScriptPath, assets.FirewallScript); err != nil {
+		return fmt.Errorf("failed to write firewall script: %w", err)
 	}
 
-	// Write allowed domains to container (using sudo for /etc write access)
-	domainsList := strings.Join(config.Firewall.AllowedDomains, "\n")
-	writeDomainsCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
-		fmt.Sprintf("echo '%s' > /etc/allowed-domains.txt", domainsList))
-	if err := writeDomainsCmd.Run(); err != nil {
+	// Write allowed domains to container
+	domainsList := strings.Join(mergeDomains(config.Firewall.AllowedDomains, projectDomains), "\n")
+	if err := writeRootFile(containerName, container.FirewallDomainsPath, domainsList); err != nil {
 		return fmt.Errorf("failed to write allowed domains: %w", err)
 	}
 
 	// Write internal DNS config if configured (for corporate networks)
 	if config.Firewall.InternalDNS != "" {
-		writeInternalDNSCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
-			fmt.Sprintf("echo '%s' > /etc/internal-dns.txt", config.Firewall.InternalDNS))
-		if err := writeInternalDNSCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to write internal DNS config: %v\n", err)
+		if err := writeRootFile(containerName, container.FirewallInternalDNSPath, config.Firewall.InternalDNS); err != nil {
+			logging.Warnf("Failed to write internal DNS config: %v", err)
 		}
 	}
 
 	// Write internal domains if configured
 	if len(config.Firewall.InternalDomains) > 0 {
 		internalDomainsList := strings.Join(config.Firewall.InternalDomains, "\n")
-		writeInternalDomainsCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
-			fmt.Sprintf("echo '%s' > /etc/internal-domains.txt", internalDomainsList))
-		if err := writeInternalDomainsCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to write internal domains config: %v\n", err)
+		if err := writeRootFile(containerName, container.FirewallInternalDomainsPath, internalDomainsList); err != nil {
+			logging.Warnf("Failed to write internal domains config: %v", err)
 		}
 	}
 
 	// Write AWS config flag if Bedrock or AWS is enabled
 	// This tells the firewall script to add AWS domain rules
 	if config.AWS.Enabled || config.Bedrock.Enabled {
-		writeAWSConfigCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
-			"echo 'enabled' > /etc/aws-enabled.txt")
-		if err := writeAWSConfigCmd.Run(); err != nil {
-			fmt.Printf("Warning: Failed to write AWS config: %v\n", err)
+		if err := writeRootFile(containerName, container.FirewallAWSPath, "enabled"); err != nil {
+			logging.Warnf("Failed to write AWS config: %v", err)
 		}
 	}
 
-	// Run firewall initialization as root (with timeout in background)
-	// We run it in the background because the verification steps can hang
-	firewallCmd := exec.Command("docker", "exec", "-u", "root", "-d", containerName, "/usr/local/bin/init-firewall.sh")
-	if err := firewallCmd.Run(); err != nil {
+	// Run firewall initialization as root in the background, because the
+	// script's own verification steps can hang. Output goes to a log so
+	// failures can be diagnosed later with 'maestro firewall status'.
+	startFirewallCmd := logging.Command("docker", "exec", "-u", "root", "-d", containerName, "sh", "-c",
+		fmt.Sprintf("bash %s > %s 2>&1", container.FirewallScriptPath, container.FirewallLogPath))
+	if err := logging.Run(startFirewallCmd); err != nil {
 		return fmt.Errorf("failed to start firewall initialization: %w", err)
 	}
 
-	// Give the firewall a moment to initialize
-	time.Sleep(1 * time.Second)
+	// Copy configured apps to container while the firewall comes up
+	if err := copyAppsToContainer(containerName); err != nil {
+		logging.Warnf("Failed to copy apps: %v", err)
+	}
+
+	return verifyFirewall(containerName)
+}
 
-	fmt.Println("Firewall initialization started in background")
+// firewallWaitTimeout bounds how long creation waits for the firewall rules.
+const firewallWaitTimeout = 30 * time.Second
 
-	// Copy configured apps to container
-	if err := copyAppsToContainer(containerName); err != nil {
-		fmt.Printf("Warning: Failed to copy apps: %v\n", err)
+// verifyFirewall waits for the container's firewall to become active and
+// prints a prominent warning if it does not, since the container would
+// otherwise run with unrestricted network access.
+func verifyFirewall(containerName string) error {
+	status, err := container.WaitForFirewall(containerName, firewallWaitTimeout)
+	if err == nil && status.Active {
+		logging.Infof("Firewall active (%d outbound rules)", status.Rules)
+		return nil
 	}
 
-	return nil
+	reason := status.Reason
+	if err != nil {
+		reason = err.Error()
+	}
+	fmt.Println()
+	fmt.Println(style.Warning() + "  WARNING: The firewall is NOT active in this container.")
+	fmt.Printf("   %s\n", reason)
+	fmt.Println("   The container has unrestricted outbound network access.")
+	if log := container.FirewallLogTail(containerName, 5); log != "" {
+		fmt.Println("   Last firewall log lines:")
+		for _, line := range strings.Split(log, "\n") {
+			fmt.Printf("     %s\n", line)
+		}
+	}
+	fmt.Printf("   Check again with: maestro firewall status %s\n\n", strings.TrimPrefix(containerName, config.Containers.Prefix))
+	return fmt.Errorf("firewall not active: %s", reason)
+}
+
+// warnNoFirewall prints a prominent warning for containers created without
+// a firewall, matching the one verifyFirewall prints when setup fails.
+func warnNoFirewall(containerName string) {
+	fmt.Println()
+	fmt.Println(style.Warning() + "  WARNING: The firewall is disabled in this container (--no-firewall).")
+	fmt.Println("   The container has unrestricted outbound network access.")
+	fmt.Printf("   Enable it with: maestro firewall enable %s\n\n", strings.TrimPrefix(containerName, config.Containers.Prefix))
+}
+
+// warnHostNetwork explains that host networked containers have no firewall.
+func warnHostNetwork() {
+	fmt.Println()
+	fmt.Println(style.Warning() + "  WARNING: This container uses host networking (containers.network_mode: host).")
+	fmt.Println("   The firewall is bypassed; the container has the host's unrestricted network access.")
+	fmt.Println()
 }
 
 func setupAndroidSDK(containerName string) error {
@@ -2153,89 +2711,166 @@ func setupAndroidSDK(containerName string) error {
 		return nil // SDK not found
 	}
 
-	fmt.Println("Setting up Android SDK...")
+	logging.Infof("Setting up Android SDK...")
 
-	// Set ANDROID_HOME environment variable in .zshrc
-	envCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		`echo 'export ANDROID_HOME=/home/node/Android/Sdk' >> /home/node/.zshrc && echo 'export PATH=$PATH:$ANDROID_HOME/platform-tools:$ANDROID_HOME/cmdline-tools/latest/bin' >> /home/node/.zshrc`)
-	if err := envCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to set ANDROID_HOME: %v\n", err)
+	// Set ANDROID_HOME environment variable in the shell rc file
+	if rcFile := container.ShellRCFile(containerShell()); rcFile != "" {
+		envCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+			fmt.Sprintf(`echo 'export ANDROID_HOME=/home/node/Android/Sdk' >> %[1]s && echo 'export PATH=$PATH:$ANDROID_HOME/platform-tools:$ANDROID_HOME/cmdline-tools/latest/bin' >> %[1]s`, rcFile))
+		if err := logging.Run(envCmd); err != nil {
+			logging.Warnf("Failed to set ANDROID_HOME: %v", err)
+		}
 	}
 
 	// Update local.properties in workspace if it exists
-	updateLocalPropertiesCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		`if [ -f /workspace/local.properties ]; then
-			sed -i 's|sdk.dir=.*|sdk.dir=/home/node/Android/Sdk|' /workspace/local.properties
+	updateLocalPropertiesCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
+		fmt.Sprintf(`if [ -f %[1]s/local.properties ]; then
+			sed -i 's|sdk.dir=.*|sdk.dir=/home/node/Android/Sdk|' %[1]s/local.properties
 			echo "  ✓ Updated local.properties"
-		fi`)
-	if err := updateLocalPropertiesCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to update local.properties: %v\n", err)
+		fi`, workspaceDir()))
+	if err := logging.Run(updateLocalPropertiesCmd); err != nil {
+		logging.Warnf("Failed to update local.properties: %v", err)
 	}
 
-	fmt.Println("  ✓ Android SDK mounted at /home/node/Android/Sdk")
+	logging.Infof("  %s Android SDK mounted at /home/node/Android/Sdk", style.Check())
 
 	return nil
 }
 
-func copySSLCertificates(containerName string) error {
-	certsPath := expandPath(config.SSL.CertificatesPath)
-	if certsPath == "" {
-		return nil // No certificates configured
+// memlockWarning explains a containers.ulimits memlock entry: without
+// IPC_LOCK, which maestro does not add, the limit caps what processes lock.
+const memlockWarning = "memlock is set, but containers run without the IPC_LOCK capability, so processes can lock no more memory than this limit (and never more than resources.memory)"
+
+// ulimitArgs returns the docker run --ulimit arguments for containers.ulimits,
+// skipping invalid entries with a warning.
+func ulimitArgs(specs []string) []string {
+	var args []string
+	for _, spec := range specs {
+		name, err := container.ParseUlimit(spec)
+		if err != nil {
+			logging.Warnf("Skipping containers.ulimits entry: %v", err)
+			continue
+		}
+		if name == "memlock" {
+			logging.Warnf("containers.ulimits: %s", memlockWarning)
+		}
+		args = append(args, "--ulimit", spec)
 	}
+	return args
+}
 
-	// Check if certificates directory exists
-	if _, err := os.Stat(certsPath); err != nil {
-		return nil // No certificates to copy
+// securityOptArgs returns the docker run --security-opt arguments for
+// containers.security_opts. Unlike ulimits, an invalid entry fails creation
+// rather than quietly running the container with less confinement.
+func securityOptArgs(opts []string) ([]string, error) {
+	var args []string
+	for _, opt := range opts {
+		value, err := resolveSecurityOpt(opt)
+		if err != nil {
+			return nil, fmt.Errorf("containers.security_opts: %w", err)
+		}
+		args = append(args, "--security-opt", value)
 	}
+	return args, nil
+}
 
-	// List certificate files
-	entries, err := os.ReadDir(certsPath)
-	if err != nil {
-		return fmt.Errorf("failed to read certificates directory: %w", err)
+// resolveSecurityOpt checks a containers.security_opts entry and returns it
+// for --security-opt, with a seccomp profile's path expanded and checked to
+// exist on the host.
+func resolveSecurityOpt(opt string) (string, error) {
+	if err := container.ValidateSecurityOpt(opt); err != nil {
+		return "", err
+	}
+	profile, ok := strings.CutPrefix(opt, "seccomp=")
+	if !ok || profile == "unconfined" {
+		return opt, nil
+	}
+	path := expandPath(profile)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("seccomp profile %s not found: %w", profile, err)
 	}
+	return "seccomp=" + path, nil
+}
 
-	var certFiles []string
-	for _, entry := range entries {
-		if !entry.IsDir() && (filepath.Ext(entry.Name()) == ".crt" || filepath.Ext(entry.Name()) == ".pem") {
-			certFiles = append(certFiles, entry.Name())
-		}
+// readOnlyRootfsArgs returns the docker run arguments for
+// containers.read_only_rootfs: a read-only root filesystem with tmpfs /tmp
+// and /run (tmux and sudo keep their sockets and timestamps there, and the
+// firewall its script and config), and anonymous volumes for the workspace
+// and /home/node, which maestro copies into. The volumes start with the
+// image's files and are removed with the container, as are the volumes
+// copySSLCertificates writes 'maestro cert' certificates and their CA bundle
+// to. /etc/resolv.conf is read-only too, so firewalled containers are
+// pointed at the firewall's dnsmasq here rather than by the firewall script.
+func readOnlyRootfsArgs(firewall bool) []string {
+	args := []string{
+		"--read-only",
+		"--tmpfs", "/tmp:rw,exec,mode=1777",
+		"--tmpfs", "/run:rw,mode=755",
+		"-v", workspaceDir(),
+		"-v", "/home/node",
+		"-v", containerCertDir,
+		"-v", path.Dir(containerCABundle),
+	}
+	if firewall {
+		args = append(args, "--dns", "127.0.0.1")
 	}
+	return args
+}
+
+// containerCABundle is the CA bundle containers use when the certificates
+// directory has certificates: the base bundle plus those certificates. It sits
+// outside /etc/ssl/certs, which may be the host's, mounted read-only.
+const containerCABundle = "/etc/ssl/maestro/ca-certificates.crt"
 
+// containerCertDir is where certificates from the certificates directory are
+// copied in a container, the directory update-ca-certificates reads.
+const containerCertDir = "/usr/local/share/ca-certificates/maestro"
+
+// copySSLCertificates installs the certificates from ssl.certificates_path
+// (see 'maestro cert') in a container: into the system trust store, the
+// containerCABundle the CA environment variables point at, and the Java
+// keystore.
+func copySSLCertificates(containerName string) error {
+	certsPath := expandPath(config.SSL.CertificatesPath)
+	certFiles, err := listCertFiles(certsPath)
+	if err != nil {
+		return err
+	}
 	if len(certFiles) == 0 {
 		return nil // No certificate files found
 	}
 
-	fmt.Printf("Installing %d SSL certificate(s) for Java...\n", len(certFiles))
+	logging.Infof("Installing %d SSL certificate(s)...", len(certFiles))
 
-	// Create temporary directory in container for certificates
-	mkdirCmd := exec.Command("docker", "exec", "-u", "root", containerName, "mkdir", "-p", "/tmp/host-certs")
-	if err := mkdirCmd.Run(); err != nil {
-		return fmt.Errorf("failed to create temp certs directory: %w", err)
+	mkdirCmd := logging.Command("docker", "exec", "-u", "root", containerName, "mkdir", "-p", containerCertDir, path.Dir(containerCABundle))
+	if err := logging.Run(mkdirCmd); err != nil {
+		return fmt.Errorf("failed to create certificates directory: %w", err)
 	}
 
 	// Copy each certificate and import into Java keystore
 	for _, certFile := range certFiles {
 		certPath := filepath.Join(certsPath, certFile)
 
-		// Copy certificate to container
-		copyCmd := exec.Command("docker", "cp", certPath, fmt.Sprintf("%s:/tmp/host-certs/%s", containerName, certFile))
-		if err := copyCmd.Run(); err != nil {
-			fmt.Printf("  ⚠  Failed to copy %s: %v\n", certFile, err)
-			continue
-		}
-
 		// Generate alias from filename (remove extension, replace special chars)
 		alias := certFile[:len(certFile)-len(filepath.Ext(certFile))]
 		alias = regexp.MustCompile(`[^a-zA-Z0-9_-]`).ReplaceAllString(alias, "_")
 
+		// update-ca-certificates only picks up .crt files
+		dest := path.Join(containerCertDir, alias+".crt")
+		copyCmd := logging.Command("docker", "cp", certPath, fmt.Sprintf("%s:%s", containerName, dest))
+		if err := logging.Run(copyCmd); err != nil {
+			fmt.Printf("  %s  Failed to copy %s: %v\n", style.Warning(), certFile, err)
+			continue
+		}
+
 		// Import into Java keystore (using keytool)
 		// The default cacerts password is 'changeit'
-		importCmd := exec.Command("docker", "exec", "-u", "root", containerName, "keytool",
+		importCmd := logging.Command("docker", "exec", "-u", "root", containerName, "keytool",
 			"-importcert",
 			"-noprompt",
 			"-trustcacerts",
 			"-alias", alias,
-			"-file", fmt.Sprintf("/tmp/host-certs/%s", certFile),
+			"-file", dest,
 			"-keystore", "/usr/local/jdk-17.0.2/lib/security/cacerts",
 			"-storepass", "changeit",
 		)
@@ -2243,30 +2878,38 @@ func copySSLCertificates(containerName string) error {
 		if err != nil {
 			// Check if it's just a duplicate alias error (certificate already exists)
 			if !strings.Contains(string(output), "already exists") {
-				fmt.Printf("  ⚠  Failed to import %s: %v\n", certFile, err)
+				fmt.Printf("  %s  Failed to import %s into the Java keystore: %v\n", style.Warning(), certFile, err)
 			}
 			continue
 		}
-		fmt.Printf("  ✓ %s\n", certFile)
+		logging.Infof("  %s %s", style.Check(), certFile)
 	}
 
-	// Cleanup temp directory
-	cleanupCmd := exec.Command("docker", "exec", "-u", "root", containerName, "rm", "-rf", "/tmp/host-certs")
-	cleanupCmd.Run() // Ignore errors on cleanup
+	// Add them to the system trust store; this fails harmlessly when
+	// /etc/ssl/certs is the host's, mounted read-only, or the root filesystem
+	// is, so the bundle the CA environment variables point at is built
+	// separately
+	bundleScript := fmt.Sprintf("update-ca-certificates >/dev/null 2>&1; cat /etc/ssl/certs/ca-certificates.crt %s/*.crt > %s",
+		containerCertDir, containerCABundle)
+	if err := logging.Run(logging.Command("docker", "exec", "-u", "root", containerName, "sh", "-c", bundleScript)); err != nil {
+		fmt.Printf("  %s  Failed to build the CA bundle: %v\n", style.Warning(), err)
+	} else {
+		logging.Infof("  %s Added to the system trust store", style.Check())
+	}
 
 	// Change keystore password from default 'changeit' to a random password
 	// This prevents the default password from being used to tamper with the keystore
 	newPassword := generateRandomPassword(32)
-	changePassCmd := exec.Command("docker", "exec", "-u", "root", containerName, "keytool",
+	changePassCmd := logging.Command("docker", "exec", "-u", "root", containerName, "keytool",
 		"-storepasswd",
 		"-keystore", "/usr/local/jdk-17.0.2/lib/security/cacerts",
 		"-storepass", "changeit",
 		"-new", newPassword,
 	)
-	if err := changePassCmd.Run(); err != nil {
-		fmt.Printf("  ⚠  Failed to change keystore password: %v\n", err)
+	if err := logging.Run(changePassCmd); err != nil {
+		fmt.Printf("  %s  Failed to change keystore password: %v\n", style.Warning(), err)
 	} else {
-		fmt.Println("  ✓ Keystore password randomized")
+		logging.Infof("  %s Keystore password randomized", style.Check())
 	}
 
 	return nil
@@ -2288,21 +2931,13 @@ func copyAppsToContainer(containerName string) error {
 		return nil // No apps configured
 	}
 
-	fmt.Printf("Copying %d configured app(s) to container...\n", len(config.Apps))
+	logging.Infof("Copying %d configured app(s) to container...", len(config.Apps))
+	arch := containerArch(containerName)
 
-	for name, sourcePath := range config.Apps {
-		expandedPath := expandPath(sourcePath)
-
-		// Check for Linux-specific variant first (for cross-platform binaries)
-		linuxPath := expandedPath + ".linux_aarch64"
-		actualPath := expandedPath
-		if _, err := os.Stat(linuxPath); err == nil {
-			actualPath = linuxPath
-		}
-
-		// Check if source exists
-		if _, err := os.Stat(actualPath); err != nil {
-			fmt.Printf("  ⚠  Skipping %s (source not found: %s)\n", name, sourcePath)
+	for name, source := range config.Apps {
+		app, err := resolveApp(source, arch, true)
+		if err != nil {
+			fmt.Printf("  %s  Skipping %s (%v)\n", style.Warning(), name, err)
 			continue
 		}
 
@@ -2310,21 +2945,21 @@ func copyAppsToContainer(containerName string) error {
 		destPath := fmt.Sprintf("/usr/local/bin/%s", name)
 		containerPath := fmt.Sprintf("%s:%s", containerName, destPath)
 
-		cpCmd := exec.Command("docker", "cp", actualPath, containerPath)
-		if err := cpCmd.Run(); err != nil {
-			fmt.Printf("  ⚠  Failed to copy %s: %v\n", name, err)
+		cpCmd := logging.Command("docker", "cp", app.File, containerPath)
+		if err := logging.Run(cpCmd); err != nil {
+			fmt.Printf("  %s  Failed to copy %s: %v\n", style.Warning(), name, err)
 			continue
 		}
 
 		// Make executable and set ownership
-		chmodCmd := exec.Command("docker", "exec", "-u", "root", containerName,
+		chmodCmd := logging.Command("docker", "exec", "-u", "root", containerName,
 			"sh", "-c", fmt.Sprintf("chmod +x %s && chown node:node %s", destPath, destPath))
-		if err := chmodCmd.Run(); err != nil {
-			fmt.Printf("  ⚠  %s copied but failed to set permissions\n", name)
+		if err := logging.Run(chmodCmd); err != nil {
+			fmt.Printf("  %s  %s copied but failed to set permissions\n", style.Warning(), name)
 			continue
 		}
 
-		fmt.Printf("  ✓ %s\n", name)
+		logging.Infof("  %s %s", style.Check(), name)
 	}
 
 	return nil
@@ -2338,7 +2973,7 @@ func expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			fmt.Printf("Warning: Could not expand home directory: %v\n", err)
+			logging.Warnf("Could not expand home directory: %v", err)
 			return path
 		}
 		return filepath.Join(home, path[2:])
@@ -2394,26 +3029,26 @@ This container has Playwright browser automation available. You can use the ` +
 		var layout strings.Builder
 		layout.WriteString("- **Workspace layout**:\n")
 		for _, p := range project.ExpandedPaths() {
-			layout.WriteString(fmt.Sprintf("  - `/workspace/%s/`\n", filepath.Base(p)))
+			layout.WriteString(fmt.Sprintf("  - `%s/`\n", path.Join(workspaceDir(), filepath.Base(p))))
 		}
 		content = strings.ReplaceAll(content, "{{WORKSPACE_LAYOUT}}", layout.String())
 	} else {
-		content = strings.ReplaceAll(content, "{{WORKSPACE_LAYOUT}}", "- **Workspace**: `/workspace/` — your project files and git repo")
+		content = strings.ReplaceAll(content, "{{WORKSPACE_LAYOUT}}", fmt.Sprintf("- **Workspace**: `%s/` — your project files and git repo", workspaceDir()))
 	}
 
 	// Write to canonical location
-	writeCmd := exec.Command("docker", "exec", "-i", containerName, "sh", "-c",
+	writeCmd := logging.Command("docker", "exec", "-i", containerName, "sh", "-c",
 		"cat > /home/node/.maestro/MAESTRO.md")
 	writeCmd.Stdin = strings.NewReader(content)
-	if err := writeCmd.Run(); err != nil {
+	if err := logging.Run(writeCmd); err != nil {
 		return fmt.Errorf("failed to write MAESTRO.md: %w", err)
 	}
 
 	// Write to ~/.claude/CLAUDE.md for auto-discovery by Claude Code
-	writeClaudeCmd := exec.Command("docker", "exec", "-i", containerName, "sh", "-c",
+	writeClaudeCmd := logging.Command("docker", "exec", "-i", containerName, "sh", "-c",
 		"cat > /home/node/.claude/CLAUDE.md")
 	writeClaudeCmd.Stdin = strings.NewReader(content)
-	if err := writeClaudeCmd.Run(); err != nil {
+	if err := logging.Run(writeClaudeCmd); err != nil {
 		return fmt.Errorf("failed to write ~/.claude/CLAUDE.md: %w", err)
 	}
 
@@ -2424,16 +3059,16 @@ This container has Playwright browser automation available. You can use the ` +
 // /home/node/.maestro/docs/hooks-guide.md so agents can reference it locally.
 func writeHooksGuide(containerName string) error {
 	// Ensure docs directory exists
-	mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p",
+	mkdirCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p",
 		"/home/node/.maestro/docs")
-	if err := mkdirCmd.Run(); err != nil {
+	if err := logging.Run(mkdirCmd); err != nil {
 		return fmt.Errorf("failed to create docs directory: %w", err)
 	}
 
-	writeCmd := exec.Command("docker", "exec", "-i", containerName, "sh", "-c",
+	writeCmd := logging.Command("docker", "exec", "-i", containerName, "sh", "-c",
 		"cat > /home/node/.maestro/docs/hooks-guide.md")
 	writeCmd.Stdin = strings.NewReader(assets.HooksGuide)
-	if err := writeCmd.Run(); err != nil {
+	if err := logging.Run(writeCmd); err != nil {
 		return fmt.Errorf("failed to write hooks guide: %w", err)
 	}
 
@@ -2562,19 +3197,19 @@ func writeClaudeSettings(containerName, model string, webEnabled bool) error {
 }`
 
 	// Ensure maestro state directories exist
-	mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p",
+	mkdirCmd := logging.Command("docker", "exec", containerName, "mkdir", "-p",
 		"/home/node/.maestro/pending-messages",
 		"/home/node/.maestro/state",
 		"/home/node/.maestro/logs",
 		"/home/node/.maestro/alarms")
-	if err := mkdirCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to create maestro directories: %v\n", err)
+	if err := logging.Run(mkdirCmd); err != nil {
+		logging.Warnf("Failed to create maestro directories: %v", err)
 	}
 
-	writeCmd := exec.Command("docker", "exec", "-i", containerName, "sh", "-c",
+	writeCmd := logging.Command("docker", "exec", "-i", containerName, "sh", "-c",
 		"cat > /home/node/.claude/settings.json")
 	writeCmd.Stdin = strings.NewReader(settings)
-	return writeCmd.Run()
+	return logging.Run(writeCmd)
 }
 
 // copyProjectFromContainer copies the workspace from a source container to a destination container.
@@ -2584,10 +3219,11 @@ func copyProjectFromContainer(srcContainer, dstContainer string) error {
 	var lastErr error
 	for attempt := 1; attempt <= 3; attempt++ {
 		if attempt > 1 {
-			fmt.Printf("Retrying workspace copy (attempt %d/3)...\n", attempt)
+			logging.Infof("Retrying workspace copy (attempt %d/3)...", attempt)
 			// Clean destination before retry
-			cleanCmd := exec.Command("docker", "exec", dstContainer, "sh", "-c", "rm -rf /workspace/* /workspace/.* 2>/dev/null; true")
-			cleanCmd.Run()
+			cleanCmd := logging.Command("docker", "exec", dstContainer, "sh", "-c",
+				fmt.Sprintf("rm -rf %[1]s/* %[1]s/.* 2>/dev/null; true", workspaceDir()))
+			logging.Run(cleanCmd)
 			time.Sleep(2 * time.Second)
 		}
 
@@ -2595,7 +3231,7 @@ func copyProjectFromContainer(srcContainer, dstContainer string) error {
 		if lastErr == nil {
 			break
 		}
-		fmt.Printf("Warning: workspace copy attempt %d failed: %v\n", attempt, lastErr)
+		logging.Warnf("workspace copy attempt %d failed: %v", attempt, lastErr)
 	}
 	return lastErr
 }
@@ -2604,14 +3240,14 @@ func copyProjectFromContainerOnce(srcContainer, dstContainer string) error {
 	// Use tar pipe to copy full workspace including .git.
 	// --ignore-failed-read and --warning flags handle files changing mid-tar
 	// (e.g., git fetch modifying .git/ or maestro-agent writing state files).
-	tarCmd := exec.Command("docker", "exec", srcContainer,
+	tarCmd := logging.Command("docker", "exec", srcContainer,
 		"tar", "-cf", "-",
 		"--ignore-failed-read",
 		"--warning=no-file-changed",
 		"--warning=no-file-removed",
 		"--warning=no-file-shrank",
-		"-C", "/workspace", ".")
-	dockerCmd := exec.Command("docker", "exec", "-i", dstContainer, "tar", "-xf", "-", "-C", "/workspace")
+		"-C", container.WorkspaceRoot(srcContainer), ".")
+	dockerCmd := logging.Command("docker", "exec", "-i", dstContainer, "tar", "-xf", "-", "-C", workspaceDir())
 
 	pipe, err := tarCmd.StdoutPipe()
 	if err != nil {
@@ -2643,9 +3279,9 @@ func copyProjectFromContainerOnce(srcContainer, dstContainer string) error {
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", dstContainer, "sh", "-c", "sudo chown -R node:node /workspace")
-	if err := chownCmd.Run(); err != nil {
-		fmt.Printf("Warning: Failed to fix workspace ownership: %v\n", err)
+	chownCmd := logging.Command("docker", "exec", dstContainer, "sh", "-c", "sudo chown -R node:node "+workspaceDir())
+	if err := logging.Run(chownCmd); err != nil {
+		logging.Warnf("Failed to fix workspace ownership: %v", err)
 	}
 
 	return nil
@@ -2701,9 +3337,9 @@ func createContainerFromDaemonOpts(opts daemon.CreateContainerOpts) (string, err
 func CreateContainerFromDaemon(task, parentContainer, branch, model string, webEnabled bool) (string, error) {
 	// Use exact mode: the parent agent crafted a specific prompt, pass it through unmodified.
 	// We still need a branch name for container naming, so generate one separately.
-	branchName, err := generateBranchNameOnly(task)
+	branchName, err := branchname.Generate(task, branchPromptModel)
 	if err != nil {
-		branchName = generateSimpleBranch(task)
+		branchName = branchname.Simple(task)
 	}
 
 	// Get next container name
@@ -2733,6 +3369,7 @@ func CreateContainerFromDaemon(task, parentContainer, branch, model string, webE
 	if err := setupContainer(ContainerSetupOptions{
 		ContainerName:   containerName,
 		BranchName:      branchName,
+		Task:            task,
 		Prompt:          task,
 		ExactPrompt:     true,
 		Labels:          labels,
@@ -2740,6 +3377,7 @@ func CreateContainerFromDaemon(task, parentContainer, branch, model string, webE
 		SourceBranch:    branch,
 		Model:           model,
 		WebEnabled:      webEnabled,
+		NoFirewall:      config.Containers.DefaultNoFirewall,
 	}); err != nil {
 		return "", err
 	}
@@ -2753,7 +3391,7 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 		return fmt.Errorf("task description is required")
 	}
 
-	fmt.Printf("Creating container for: %s\n", truncateString(taskDescription, 80))
+	logging.Infof("Creating container for: %s", truncateString(taskDescription, 80))
 
 	// Step 1: Generate branch name (use override if provided, otherwise generate)
 	var branchName string
@@ -2773,9 +3411,10 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 			return fmt.Errorf("failed to generate branch name: %w", err)
 		}
 	}
+	planningPrompt = applyContextLimit(planningPrompt)
 
 	// Validate the branch name and prompt user if invalid
-	if !isValidBranchName(branchName) {
+	if !branchname.IsValid(branchName) {
 		fmt.Printf("Generated branch name '%s' is invalid.\n", branchName)
 		branchName, err = promptUserForBranchName(taskDescription)
 		if err != nil {
@@ -2789,8 +3428,8 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 		return fmt.Errorf("failed to generate container name: %w", err)
 	}
 
-	fmt.Printf("Container name: %s\n", containerName)
-	fmt.Printf("Branch name: %s\n", branchName)
+	logging.Infof("Container name: %s", containerName)
+	logging.Infof("Branch name: %s", branchName)
 
 	// Resolve model: normalize, validate, fall back to config default
 	model = resolveModel(model)
@@ -2801,10 +3440,12 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 	if err := setupContainer(ContainerSetupOptions{
 		ContainerName: containerName,
 		BranchName:    branchName,
+		Task:          taskDescription,
 		Prompt:        planningPrompt,
 		ExactPrompt:   exact,
 		Model:         model,
 		WebEnabled:    useWeb,
+		NoFirewall:    config.Containers.DefaultNoFirewall,
 	}); err != nil {
 		return err
 	}
@@ -2814,22 +3455,21 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 	// Auto-connect unless skipConnect is true
 	if !skipConnect {
 		fmt.Println("\nConnecting to container...")
-		fmt.Println("Detach with: Ctrl+b d")
-		fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
+		printTmuxHints()
 
 		// Connect to tmux session
-		connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
+		connectCmd := logging.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", tmuxSessionName())
 		connectCmd.Stdin = os.Stdin
 		connectCmd.Stdout = os.Stdout
 		connectCmd.Stderr = os.Stderr
 
-		if err := connectCmd.Run(); err != nil {
+		if err := logging.Run(connectCmd); err != nil {
 			fmt.Printf("\nWarning: Failed to connect: %v\n", err)
 			fmt.Printf("You can connect later with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
 		}
 	} else {
 		fmt.Printf("Connect with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
-		fmt.Printf("Detach with: Ctrl+b d\n")
+		fmt.Printf("Detach with: %s d\n", container.FormatTmuxKey(tmuxPrefix()))
 	}
 
 	return nil
@@ -0,0 +1,165 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestGenerateBranchAndPrompt_NoAI(t *testing.T) {
+	flagNoAI = true
+	defer func() { flagNoAI = false }()
+
+	for _, exact := range []bool{false, true} {
+		branch, prompt, err := generateBranchAndPrompt("Add the login page", exact)
+		if err != nil {
+			t.Fatalf("generateBranchAndPrompt(exact=%v) error: %v", exact, err)
+		}
+		if branch != "feat/add-login-page" {
+			t.Errorf("branch (exact=%v) = %q, want feat/add-login-page", exact, branch)
+		}
+		if prompt != "Add the login page" {
+			t.Errorf("prompt (exact=%v) = %q, want the raw description", exact, prompt)
+		}
+	}
+}
+
+func TestIsGitRepo(t *testing.T) {
+	plain := t.TempDir()
+	if isGitRepo(plain) {
+		t.Error("a directory without .git is not a repository")
+	}
+
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !isGitRepo(repo) {
+		t.Error("a directory with .git is a repository")
+	}
+
+	// Worktrees have a .git file pointing at the main repository
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: /src/repo/.git/worktrees/x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !isGitRepo(worktree) {
+		t.Error("a worktree is a repository")
+	}
+}
+
+func TestRetryStep(t *testing.T) {
+	calls, cleanups := 0, 0
+	err := retryStep(2, 0, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("docker glitch")
+		}
+		return nil
+	}, func() { cleanups++ })
+	if err != nil {
+		t.Fatalf("retryStep error: %v", err)
+	}
+	if calls != 3 || cleanups != 2 {
+		t.Errorf("calls = %d, cleanups = %d; want 3 and 2", calls, cleanups)
+	}
+
+	// Without retries the first failure is returned, with no cleanup
+	calls, cleanups = 0, 0
+	err = retryStep(0, 0, func() error {
+		calls++
+		return errors.New("pull failed")
+	}, func() { cleanups++ })
+	if err == nil || err.Error() != "pull failed" {
+		t.Errorf("retryStep error = %v, want pull failed", err)
+	}
+	if calls != 1 || cleanups != 0 {
+		t.Errorf("calls = %d, cleanups = %d; want 1 and 0", calls, cleanups)
+	}
+}
+
+func TestSecurityOptArgs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	profile := filepath.Join(os.Getenv("HOME"), "seccomp.json")
+	if err := os.WriteFile(profile, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	args, err := securityOptArgs([]string{"seccomp=~/seccomp.json", "apparmor=docker-default", "no-new-privileges"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--security-opt", "seccomp=" + profile, "--security-opt", "apparmor=docker-default", "--security-opt", "no-new-privileges"}
+	if !slices.Equal(args, want) {
+		t.Errorf("securityOptArgs = %v, want %v", args, want)
+	}
+
+	if _, err := securityOptArgs([]string{"seccomp=~/missing.json"}); err == nil {
+		t.Error("a missing seccomp profile should fail")
+	}
+	if _, err := securityOptArgs([]string{"privileged"}); err == nil {
+		t.Error("an unknown option should fail")
+	}
+}
+
+func TestReadOnlyRootfsArgs_SetupPathsWritable(t *testing.T) {
+	args := readOnlyRootfsArgs(true)
+
+	// Collect the writable mounts: tmpfs targets and volumes
+	var mounts []string
+	for i := 0; i+1 < len(args); i++ {
+		switch args[i] {
+		case "--tmpfs":
+			target, _, _ := strings.Cut(args[i+1], ":")
+			mounts = append(mounts, target)
+		case "-v":
+			mounts = append(mounts, args[i+1])
+		}
+	}
+
+	paths := []string{
+		container.FirewallScriptPath,
+		container.FirewallDomainsPath,
+		container.FirewallInternalDNSPath,
+		container.FirewallInternalDomainsPath,
+		container.FirewallAWSPath,
+		container.FirewallLogPath,
+		containerCABundle,
+		containerCertDir + "/corp.crt",
+	}
+	for _, path := range paths {
+		writable := slices.ContainsFunc(mounts, func(m string) bool {
+			return strings.HasPrefix(path, m+"/")
+		})
+		if !writable {
+			t.Errorf("%s is not under a writable mount %v", path, mounts)
+		}
+	}
+
+	// /etc/resolv.conf stays read-only, so DNS must point at dnsmasq
+	if !strings.Contains(strings.Join(args, " "), "--dns 127.0.0.1") {
+		t.Errorf("readOnlyRootfsArgs(true) = %v, want --dns 127.0.0.1", args)
+	}
+	if slices.Contains(readOnlyRootfsArgs(false), "--dns") {
+		t.Errorf("readOnlyRootfsArgs(false) sets --dns, leaving unfirewalled containers without DNS")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var pathsOpen bool
+
+var pathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Show where maestro keeps its files",
+	Long: `Print the config file, auth directories, caches, state and daemon files
+maestro uses, as resolved from the current config, and whether each exists.
+
+With --open, the config directory is revealed in the file manager.`,
+	Args: cobra.NoArgs,
+	RunE: runPaths,
+}
+
+func init() {
+	rootCmd.AddCommand(pathsCmd)
+	pathsCmd.Flags().BoolVar(&pathsOpen, "open", false, "Open the config directory in the file manager")
+}
+
+// pathEntry is one location 'maestro paths' reports.
+type pathEntry struct {
+	label string
+	path  string
+}
+
+// pathEntries lists the locations maestro uses. Paths the config can move
+// (auth, GitHub, certificates) come from the config rather than defaults.
+func pathEntries() []pathEntry {
+	configFile := viper.ConfigFileUsed()
+	if configFile == "" {
+		configFile = paths.ConfigFile()
+	}
+	authDir := expandPath(config.Claude.AuthPath)
+	entries := []pathEntry{
+		{"Config directory", paths.GetConfigDir()},
+		{"Config file", configFile},
+	}
+	if loadedProjectConfig.Path != "" {
+		entries = append(entries, pathEntry{"Project config", loadedProjectConfig.Path})
+	}
+	entries = append(entries,
+		pathEntry{"Claude auth", authDir},
+		pathEntry{"GitHub auth", expandPath(config.GitHub.ConfigPath)},
+		pathEntry{"Certificates", expandPath(config.SSL.CertificatesPath)},
+		pathEntry{"Daemon log", filepath.Join(authDir, "daemon.log")},
+		pathEntry{"Daemon IPC", daemonIPCFilePath()},
+		pathEntry{"Nicknames", filepath.Join(authDir, "nicknames.yml")},
+		pathEntry{"State", paths.StateDir()},
+		pathEntry{"Apps cache", paths.AppsCacheDir()},
+		pathEntry{"Template cache", paths.TemplateCacheDir()},
+		pathEntry{"Cache", paths.CacheDir()},
+	)
+	if paths.HasLegacyConfig() {
+		entries = append(entries, pathEntry{"Legacy config", paths.LegacyConfigFile()})
+	}
+	return entries
+}
+
+func runPaths(cmd *cobra.Command, args []string) error {
+	for _, e := range pathEntries() {
+		mark := style.Check()
+		if _, err := os.Stat(e.path); err != nil {
+			mark = "missing"
+		}
+		fmt.Printf("%-17s %s  %s\n", e.label+":", e.path, mark)
+	}
+
+	if pathsOpen {
+		return revealDir(paths.GetConfigDir())
+	}
+	return nil
+}
+
+// revealDir opens dir in the platform's file manager without waiting for it.
+func revealDir(dir string) error {
+	opener := "xdg-open"
+	switch runtime.GOOS {
+	case "darwin":
+		opener = "open"
+	case "windows":
+		opener = "explorer"
+	}
+	if err := exec.Command(opener, dir).Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", opener, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPathEntries(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	origConfig := config
+	defer func() { config = origConfig }()
+	config = &Config{}
+	config.Claude.AuthPath = "~/auth"
+	config.GitHub.ConfigPath = "~/gh"
+
+	got := map[string]string{}
+	for _, e := range pathEntries() {
+		got[e.label] = e.path
+	}
+	for label, want := range map[string]string{
+		"Claude auth": filepath.Join(home, "auth"),
+		"GitHub auth": filepath.Join(home, "gh"),
+		"Daemon log":  filepath.Join(home, "auth", "daemon.log"),
+		"Daemon IPC":  filepath.Join(home, "auth", "daemon-ipc.json"),
+	} {
+		if got[label] != want {
+			t.Errorf("%s = %q, want %q", label, got[label], want)
+		}
+	}
+	if got["Config file"] == "" || got["State"] == "" {
+		t.Errorf("config file and state dir should always be listed: %v", got)
+	}
+}
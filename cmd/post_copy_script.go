@@ -0,0 +1,96 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// postCopyScriptPath is where containers.post_copy_script is written inside
+// the container; it is removed once the script has run.
+const postCopyScriptPath = "/tmp/maestro-post-copy.sh"
+
+// resolvePostCopyScript returns the script for containers.post_copy_script.
+// A single-line value naming an existing host file is read from that file;
+// anything else is used as the script itself.
+func resolvePostCopyScript(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.Contains(value, "\n") {
+		return value, nil
+	}
+	path := expandPath(value)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return value, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read post-copy script: %w", err)
+	}
+	return string(content), nil
+}
+
+// postCopyScriptBody makes sure the script has an interpreter line so it can
+// be executed directly; scripts with their own shebang keep it.
+func postCopyScriptBody(script string) string {
+	if strings.HasPrefix(script, "#!") {
+		return script
+	}
+	return "#!/bin/sh\n" + script
+}
+
+// runPostCopyScript runs containers.post_copy_script in the container as node
+// from the workspace root, after the project and additional folders have been
+// copied. Its output is printed; failures are warned about and creation
+// continues.
+func runPostCopyScript(containerName, branchName, value string) {
+	script, err := resolvePostCopyScript(value)
+	if err != nil {
+		logging.Warnf("Skipping post-copy script: %v", err)
+		return
+	}
+	if script == "" {
+		return
+	}
+	logging.Infof("Running post-copy script...")
+
+	writeCmd := logging.Command("docker", "exec", "-i", "-u", "node", containerName, "sh", "-c",
+		fmt.Sprintf("cat > %s && chmod +x %s", postCopyScriptPath, postCopyScriptPath))
+	writeCmd.Stdin = strings.NewReader(postCopyScriptBody(script))
+	if err := logging.Run(writeCmd); err != nil {
+		logging.Warnf("Failed to copy post-copy script: %v", err)
+		return
+	}
+	defer logging.Run(logging.Command("docker", "exec", containerName, "rm", "-f", postCopyScriptPath))
+
+	output, err := logging.Command("docker", "exec", "-u", "node", "-w", workspaceDir(),
+		"-e", "MAESTRO_CONTAINER="+containerName,
+		"-e", "MAESTRO_BRANCH="+branchName,
+		"-e", "MAESTRO_WORKSPACE="+workspaceDir(),
+		containerName, postCopyScriptPath).CombinedOutput()
+	if len(output) > 0 {
+		fmt.Print(string(output))
+		if !strings.HasSuffix(string(output), "\n") {
+			fmt.Println()
+		}
+	}
+	if err != nil {
+		logging.Warnf("Post-copy script failed: %v", err)
+	}
+}
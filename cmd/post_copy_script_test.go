@@ -0,0 +1,59 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePostCopyScript(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "post-copy.sh")
+	if err := os.WriteFile(file, []byte("#!/bin/bash\nnpm link\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{file, "#!/bin/bash\nnpm link\n"},
+		{"rm -rf fixtures", "rm -rf fixtures"},
+		{"echo one\necho two\n", "echo one\necho two"},
+		{dir, dir},
+	}
+	for _, tt := range tests {
+		got, err := resolvePostCopyScript(tt.value)
+		if err != nil {
+			t.Errorf("resolvePostCopyScript(%q) error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolvePostCopyScript(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPostCopyScriptBody(t *testing.T) {
+	if got := postCopyScriptBody("echo hi"); got != "#!/bin/sh\necho hi" {
+		t.Errorf("got %q", got)
+	}
+	if got := postCopyScriptBody("#!/bin/bash\necho hi"); got != "#!/bin/bash\necho hi" {
+		t.Errorf("got %q", got)
+	}
+}
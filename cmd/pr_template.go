@@ -0,0 +1,97 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// prTemplateFetchTimeout bounds downloading a --pr-template URL.
+const prTemplateFetchTimeout = 10 * time.Second
+
+var (
+	htmlCommentRE = regexp.MustCompile(`(?s)<!--.*?-->`)
+	blankRunRE    = regexp.MustCompile(`\n{3,}`)
+)
+
+// stripHTMLComments removes <!-- ... --> blocks, which PR templates use for
+// instructions to the author, and squeezes the blank lines they leave.
+func stripHTMLComments(s string) string {
+	s = htmlCommentRE.ReplaceAllString(s, "")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.TrimSpace(blankRunRE.ReplaceAllString(s, "\n\n"))
+}
+
+// templateCachePath is where a downloaded template is kept, keyed by URL.
+func templateCachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(paths.TemplateCacheDir(), hex.EncodeToString(sum[:8])+".md")
+}
+
+// readPRTemplate loads a PR template from a path or an http(s) URL and strips
+// its HTML comments. Downloads are cached, and the cached copy is used when a
+// later download fails.
+func readPRTemplate(src string) (string, error) {
+	if !isSpecURL(src) {
+		content, err := os.ReadFile(expandPath(src))
+		if err != nil {
+			return "", fmt.Errorf("failed to read PR template: %w", err)
+		}
+		return stripHTMLComments(string(content)), nil
+	}
+
+	cachePath := templateCachePath(src)
+	ctx, cancel := context.WithTimeout(context.Background(), prTemplateFetchTimeout)
+	defer cancel()
+	content, err := fetchSpec(ctx, rawSpecURL(src))
+	if err != nil {
+		cached, cacheErr := os.ReadFile(cachePath)
+		if cacheErr != nil {
+			return "", fmt.Errorf("failed to download PR template: %w", err)
+		}
+		logging.Warnf("Using cached PR template: %v", err)
+		content = string(cached)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			if err := os.WriteFile(cachePath, []byte(content), 0644); err != nil {
+				logging.Warnf("Failed to cache PR template: %v", err)
+			}
+		}
+	}
+	return stripHTMLComments(content), nil
+}
+
+// withPRTemplate puts the task description after the template.
+func withPRTemplate(template, description string) string {
+	if template == "" {
+		return description
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return template
+	}
+	return template + "\n\n" + description
+}
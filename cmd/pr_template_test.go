@@ -0,0 +1,84 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStripHTMLComments(t *testing.T) {
+	in := "## Summary\r\n<!-- Describe the change.\nKeep it short. -->\n\n\n\n## Test plan\n<!-- How was it tested? -->\n- [ ] unit tests\n"
+	want := "## Summary\n\n## Test plan\n\n- [ ] unit tests"
+	if got := stripHTMLComments(in); got != want {
+		t.Errorf("stripHTMLComments() = %q, want %q", got, want)
+	}
+}
+
+func TestWithPRTemplate(t *testing.T) {
+	if got := withPRTemplate("## Summary", "  add caching\n"); got != "## Summary\n\nadd caching" {
+		t.Errorf("got %q", got)
+	}
+	if got := withPRTemplate("## Summary", ""); got != "## Summary" {
+		t.Errorf("got %q", got)
+	}
+	if got := withPRTemplate("", "spec\n"); got != "spec\n" {
+		t.Errorf("description without a template should be unchanged, got %q", got)
+	}
+}
+
+func TestReadPRTemplate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	file := filepath.Join(t.TempDir(), "pull_request_template.md")
+	if err := os.WriteFile(file, []byte("## Why\n<!-- link the issue -->\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readPRTemplate(file); err != nil || got != "## Why" {
+		t.Errorf("readPRTemplate(file) = %q, %v", got, err)
+	}
+
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "## Changes\n<!-- list them -->\n")
+	}))
+	defer srv.Close()
+
+	url := srv.URL + "/template.md"
+	if got, err := readPRTemplate(url); err != nil || got != "## Changes" {
+		t.Fatalf("readPRTemplate(url) = %q, %v", got, err)
+	}
+	if _, err := os.Stat(templateCachePath(url)); err != nil {
+		t.Fatalf("template was not cached: %v", err)
+	}
+
+	// A failed download falls back to the cached copy
+	up = false
+	if got, err := readPRTemplate(url); err != nil || got != "## Changes" {
+		t.Errorf("readPRTemplate(url) with server down = %q, %v", got, err)
+	}
+	if _, err := readPRTemplate(srv.URL + "/other.md"); err == nil {
+		t.Error("expected an error for an uncached template that fails to download")
+	}
+}
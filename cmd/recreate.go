@@ -0,0 +1,140 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var recreateCmd = &cobra.Command{
+	Use:   "recreate <name>",
+	Short: "Move a container's work to a new container on the current image",
+	Long: `Recreate a container from the image this maestro version uses.
+
+Containers keep the image they were created from, so after upgrading maestro
+existing containers still run the old Claude and tooling ('maestro list' marks
+them with ⬆). recreate copies the workspace, including the checked-out branch
+and uncommitted changes, into a new container on the current image and hands
+Claude the old session's context, as with 'maestro new --continue-from'.
+
+The old container is stopped, not deleted. Remove it with 'maestro cleanup'
+once the new one is working.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source, ok := getNicknameStore().Get(args[0])
+		if !ok {
+			source = resolveContainerName(args[0])
+		}
+		_, err := recreateContainer(source)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recreateCmd)
+}
+
+// containerNumberSuffix is the -N every container name ends with.
+var containerNumberSuffix = regexp.MustCompile(`-\d+$`)
+
+// recreateBaseName is the name a recreated container is numbered from: the
+// old short name without its number, so feat-auth-1 becomes feat-auth-2.
+func recreateBaseName(shortName string) string {
+	return containerNumberSuffix.ReplaceAllString(shortName, "")
+}
+
+// recreateContainer copies a running container's workspace into a new
+// container on the current image, carrying over its labels, web support,
+// firewall setting and session context, then stops the old container. It
+// returns the new container's name.
+func recreateContainer(source string) (string, error) {
+	prefix := config.Containers.Prefix
+	shortName := container.GetShortName(source, prefix)
+	if err := requireRunning(source, shortName); err != nil {
+		return "", fmt.Errorf("%w; start it with 'maestro restart %s --full' first", err, shortName)
+	}
+
+	sc, err := gatherSessionContext(source)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := getNextContainerName(recreateBaseName(shortName))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate container name: %w", err)
+	}
+
+	labels := map[string]string{continuedFromLabel: source}
+	for _, label := range []string{"maestro.project", "maestro.workspace", "maestro.contacts", "maestro.parent"} {
+		if value := container.GetLabel(source, label); value != "" {
+			labels[label] = value
+		}
+	}
+
+	// The original's project, so multi-path workspaces are set up per repo
+	var project *ProjectConfig
+	if p, ok := config.Projects[labels["maestro.project"]]; ok {
+		project = &p
+	}
+
+	task := container.GetLabel(source, container.TaskLabel)
+	prompt := "Pick up where the previous container left off. It was recreated to update its image."
+	if task != "" {
+		prompt += "\n\nOriginal task: " + task
+	}
+
+	// A detached HEAD gets a branch named after the container
+	branch, sourceBranch := container.GetBranchName(source), ""
+	if branch == "unknown" {
+		branch = recreateBaseName(shortName)
+	} else {
+		sourceBranch = branch
+	}
+
+	fmt.Printf("Recreating %s as %s...\n", shortName, container.GetShortName(name, prefix))
+	if err := setupContainer(ContainerSetupOptions{
+		ContainerName: name,
+		BranchName:    branch,
+		Task:          task,
+		Prompt:        continuePromptWithinLimit(sc.summary(shortName), prompt),
+		ExactPrompt:   true,
+		Labels:        labels,
+		RecreateFrom:  source,
+		SourceDir:     container.GetLabel(source, container.SourceDirLabel),
+		Project:       project,
+		SourceBranch:  sourceBranch,
+		Model:         resolveModel(""),
+		WebEnabled:    container.GetLabel(source, "maestro.web") == "true",
+		NoFirewall:    container.IsFirewallDisabled(source),
+	}); err != nil {
+		return "", fmt.Errorf("failed to recreate %s: %w", shortName, err)
+	}
+
+	if err := logging.Run(logging.Command("docker", "stop", source)); err != nil {
+		logging.Warnf("Failed to stop %s: %v", shortName, err)
+	}
+
+	newShort := container.GetShortName(name, prefix)
+	fmt.Printf("\n%s Recreated %s as %s\n", style.Check(), shortName, newShort)
+	fmt.Printf("  %s is stopped; remove it with 'maestro cleanup' once %s is working.\n", shortName, newShort)
+	fmt.Printf("  Connect with: maestro connect %s\n", newShort)
+	return name, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRecreateBaseName(t *testing.T) {
+	tests := map[string]string{
+		"feat-auth-1":        "feat-auth",
+		"webapp-fix-2fa-12":  "webapp-fix-2fa",
+		"no-number":          "no-number",
+		"feat-auth-1-copy-3": "feat-auth-1-copy",
+	}
+	for in, want := range tests {
+		if got := recreateBaseName(in); got != want {
+			t.Errorf("recreateBaseName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRecreateOptions_UseOriginalSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ContainerSetupOptions{RecreateFrom: "maestro-feat-x-1", SourceDir: dir}
+	if got := projectSourceDir(opts); got != dir {
+		t.Errorf("projectSourceDir = %q, want %q", got, dir)
+	}
+	if got := detectProjectDomains(opts); !slices.Contains(got, "proxy.golang.org") {
+		t.Errorf("detectProjectDomains = %v, want the Go registry domains", got)
+	}
+	if got := opts.workspaceContainer(); got != "maestro-feat-x-1" {
+		t.Errorf("workspaceContainer = %q, want the recreated container", got)
+	}
+
+	// An original without a recorded source has nothing to detect from
+	opts.SourceDir = ""
+	if got := projectSourceDir(opts); got != "" {
+		t.Errorf("projectSourceDir without SourceDir = %q, want empty", got)
+	}
+	if got := detectProjectDomains(opts); got != nil {
+		t.Errorf("detectProjectDomains without SourceDir = %v, want nil", got)
+	}
+}
@@ -17,13 +17,14 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var refreshTokensCmd = &cobra.Command{
@@ -63,9 +64,9 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 			creds:     hostCreds,
 			expiresAt: time.UnixMilli(hostCreds.ClaudeAiOauth.ExpiresAt),
 		})
-		fmt.Printf("  ✓ Host: %s\n", container.FormatExpiration(hostCreds))
+		fmt.Printf("  %s Host: %s\n", style.Check(), container.FormatExpiration(hostCreds))
 	} else {
-		fmt.Printf("  ✗ Host: Could not read credentials (%v)\n", err)
+		fmt.Printf("  %s Host: Could not read credentials (%v)\n", style.Cross(), err)
 	}
 
 	// 2. Check all running containers (including legacy prefix for backward compatibility)
@@ -83,11 +84,11 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 	for _, c := range containers {
 		// Extract credentials from container to temp file
 		tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("maestro-creds-%s.json", c.Name))
-		copyCmd := exec.Command("docker", "cp",
+		copyCmd := logging.Command("docker", "cp",
 			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", c.Name),
 			tmpFile)
-		if err := copyCmd.Run(); err != nil {
-			fmt.Printf("  ✗ %s: Could not read credentials\n", c.Name)
+		if err := logging.Run(copyCmd); err != nil {
+			fmt.Printf("  %s %s: Could not read credentials\n", style.Cross(), c.Name)
 			continue
 		}
 		defer os.Remove(tmpFile)
@@ -99,7 +100,7 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 				creds:     creds,
 				expiresAt: time.UnixMilli(creds.ClaudeAiOauth.ExpiresAt),
 			})
-			fmt.Printf("  ✓ %s: %s\n", c.Name, container.FormatExpiration(creds))
+			fmt.Printf("  %s %s: %s\n", style.Check(), c.Name, container.FormatExpiration(creds))
 		}
 	}
 
@@ -123,14 +124,14 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("all tokens expired")
 	}
 
-	fmt.Printf("\n✓ Found fresh token in %s\n", freshest.location)
+	fmt.Printf("\n%s Found fresh token in %s\n", style.Check(), freshest.location)
 	fmt.Printf("  Expires: %s\n", freshest.expiresAt.Format(time.RFC1123))
 	fmt.Printf("  Status: %s\n", container.FormatExpiration(freshest.creds))
 
 	// 5. Warn if expiring soon
 	timeUntilExp := container.TimeUntilExpiration(freshest.creds)
 	if timeUntilExp < 24*time.Hour {
-		fmt.Printf("\n⚠️  Token expires in less than 24 hours!\n")
+		fmt.Printf("\n%s  Token expires in less than 24 hours!\n", style.Warning())
 		fmt.Printf("   Consider running 'maestro auth' soon.\n")
 	}
 
@@ -142,9 +143,9 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 	// Sync to host (if not already source)
 	if freshest.location != "host" {
 		if err := copyCredentials(freshest.path, hostCredPath); err != nil {
-			fmt.Printf("  ✗ Failed to sync to host: %v\n", err)
+			fmt.Printf("  %s Failed to sync to host: %v\n", style.Cross(), err)
 		} else {
-			fmt.Println("  ✓ Synced to host")
+			fmt.Println("  " + style.Check() + " Synced to host")
 			syncCount++
 		}
 	}
@@ -161,20 +162,20 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 			tmpFile = hostCredPath
 		}
 
-		copyCmd := exec.Command("docker", "cp", tmpFile,
+		copyCmd := logging.Command("docker", "cp", tmpFile,
 			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", container.Name))
-		if err := copyCmd.Run(); err != nil {
-			fmt.Printf("  ✗ Failed to sync to %s: %v\n", container.Name, err)
+		if err := logging.Run(copyCmd); err != nil {
+			fmt.Printf("  %s Failed to sync to %s: %v\n", style.Cross(), container.Name, err)
 			continue
 		}
 
 		// Fix ownership
-		chownCmd := exec.Command("docker", "exec", "-u", "root", container.Name,
+		chownCmd := logging.Command("docker", "exec", "-u", "root", container.Name,
 			"chown", "node:node", "/home/node/.claude/.credentials.json")
-		if err := chownCmd.Run(); err != nil {
-			fmt.Printf("  ⚠  Synced to %s but failed to fix ownership\n", container.Name)
+		if err := logging.Run(chownCmd); err != nil {
+			fmt.Printf("  %s  Synced to %s but failed to fix ownership\n", style.Warning(), container.Name)
 		} else {
-			fmt.Printf("  ✓ Synced to %s\n", container.Name)
+			fmt.Printf("  %s Synced to %s\n", style.Check(), container.Name)
 		}
 		syncCount++
 	}
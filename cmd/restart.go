@@ -18,13 +18,13 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
 var (
@@ -103,8 +103,8 @@ func runRestart(cmd *cobra.Command, args []string) error {
 
 // checkDockerRunning verifies that Docker is running
 func checkDockerRunning() error {
-	cmd := exec.Command("docker", "info")
-	err := cmd.Run()
+	cmd := logging.Command("docker", "info")
+	err := logging.Run(cmd)
 	if err != nil {
 		// Check if it's a connection error (Docker not running)
 		if strings.Contains(err.Error(), "connection refused") ||
@@ -147,13 +147,18 @@ func selectContainerForRestart(containers []container.Info) (container.Info, err
 }
 
 func performClaudeRestart(containerName, shortName string) error {
+	if !container.UsesTmux(containerName) {
+		return fmt.Errorf("%s runs Claude without tmux; exit Claude and run 'maestro connect %s' to start it again", shortName, shortName)
+	}
+
 	fmt.Printf("Restarting Claude process in %s...\n", shortName)
+	session := container.TmuxSession(containerName)
 
 	// Step 1: Kill any existing Claude processes (including zombies)
 	fmt.Println("  Stopping Claude process...")
-	killCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	killCmd := logging.Command("docker", "exec", containerName, "sh", "-c",
 		"pkill -9 claude || true")
-	if err := killCmd.Run(); err != nil {
+	if err := logging.Run(killCmd); err != nil {
 		fmt.Printf("  Warning: Failed to kill Claude: %v\n", err)
 	}
 
@@ -162,26 +167,27 @@ func performClaudeRestart(containerName, shortName string) error {
 
 	// Step 2: Kill the tmux window 0 (Claude window)
 	fmt.Println("  Recreating Claude window...")
-	killWindowCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "kill-window", "-t", "main:0")
-	if err := killWindowCmd.Run(); err != nil {
+	killWindowCmd := logging.Command("docker", "exec", containerName,
+		"tmux", "kill-window", "-t", session+":0")
+	if err := logging.Run(killWindowCmd); err != nil {
 		// Window might already be dead, that's OK
 		fmt.Printf("  Window already closed\n")
 	}
 
 	// Step 3: Create new window 0 with Claude
-	createWindowCmd := exec.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
-		"cd /workspace && HOME=/home/node tmux new-window -t main:0 -n claude 'claude --dangerously-skip-permissions'")
-	if err := createWindowCmd.Run(); err != nil {
+	createWindowCmd := logging.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
+		container.InWorkspace(container.WorkspaceRoot(containerName),
+			fmt.Sprintf("HOME=/home/node tmux new-window -t %s:0 -n claude 'claude --dangerously-skip-permissions'", session)))
+	if err := logging.Run(createWindowCmd); err != nil {
 		return fmt.Errorf("failed to create new Claude window: %w", err)
 	}
 
 	// Step 4: Make window 0 active
 	time.Sleep(500 * time.Millisecond)
 
-	selectCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "select-window", "-t", "main:0")
-	if err := selectCmd.Run(); err != nil {
+	selectCmd := logging.Command("docker", "exec", containerName,
+		"tmux", "select-window", "-t", session+":0")
+	if err := logging.Run(selectCmd); err != nil {
 		fmt.Printf("  Warning: Failed to select window: %v\n", err)
 	}
 
@@ -196,15 +202,15 @@ func performFullRestart(containerName, shortName string) error {
 
 	// Step 1: Stop container
 	fmt.Println("  Stopping container...")
-	stopCmd := exec.Command("docker", "stop", containerName)
-	if err := stopCmd.Run(); err != nil {
+	stopCmd := logging.Command("docker", "stop", containerName)
+	if err := logging.Run(stopCmd); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 
 	// Step 2: Start container
 	fmt.Println("  Starting container...")
-	startCmd := exec.Command("docker", "start", containerName)
-	if err := startCmd.Run(); err != nil {
+	startCmd := logging.Command("docker", "start", containerName)
+	if err := logging.Run(startCmd); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
@@ -213,54 +219,12 @@ func performFullRestart(containerName, shortName string) error {
 	time.Sleep(2 * time.Second)
 
 	// Step 3.5: Fix shell config for better terminal experience
-	checkPromptCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		"grep -q 'Custom Maestro prompt' /home/node/.zshrc")
-	if err := checkPromptCmd.Run(); err != nil {
-		// Prompt not found, apply all shell fixes
-		shellFixCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-			`# Remove TERM override
-sed -i '/^export TERM=xterm$/d' /home/node/.zshrc
-
-# Disable powerlevel10k theme (causes missing font glyphs)
-sed -i 's/^ZSH_THEME=.*/ZSH_THEME=""/' /home/node/.zshrc
-
-# Add custom prompt with readable symbols and colors
-cat >> /home/node/.zshrc << 'PROMPT_EOF'
-
-# Custom Maestro prompt with colors and git status
-autoload -Uz vcs_info
-precmd_vcs_info() { vcs_info }
-precmd_functions+=( precmd_vcs_info )
-setopt prompt_subst
-zstyle ':vcs_info:git:*' formats '%b'
-zstyle ':vcs_info:*' enable git
-
-# Git status indicators (matching maestro list command)
-git_status_symbols() {
-    if [[ -n ${vcs_info_msg_0_} ]]; then
-        local git_status=""
-        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
-        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
-        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
-
-        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
-        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
-        [[ $behind -gt 0 ]] && git_status+="↓$behind "
-        [[ -z $git_status ]] && git_status="✓ "
-
-        echo "$git_status"
-    fi
-}
-
-PROMPT='%F{green}%n%f  %F{blue}%~%f  %F{magenta}${vcs_info_msg_0_}%f %F{yellow}$(git_status_symbols)%f'
-PROMPT_EOF`)
-		if err := shellFixCmd.Run(); err != nil {
-			fmt.Printf("  Warning: Failed to configure shell: %v\n", err)
-		}
+	if err := configureContainerShell(containerName, container.Shell(containerName)); err != nil {
+		fmt.Printf("  Warning: Failed to configure shell: %v\n", err)
 	}
 
 	// Step 4: Get branch name for tmux config
-	branchCmd := exec.Command("docker", "exec", containerName, "git", "-C", "/workspace", "branch", "--show-current")
+	branchCmd := logging.Command("docker", "exec", containerName, "git", "-C", container.WorkspaceRoot(containerName), "branch", "--show-current")
 	branchOutput, err := branchCmd.Output()
 	branchName := "main"
 	if err == nil {
@@ -268,35 +232,17 @@ PROMPT_EOF`)
 	}
 
 	// Step 5: Always write tmux config with true color support
-	tmuxConfig := generateTmuxConfig(containerName, branchName)
-	writeCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cat > /home/node/.tmux.conf << 'EOF'\n%s\nEOF", tmuxConfig))
-	if err := writeCmd.Run(); err != nil {
+	if err := writeTmuxConfig(containerName, branchName); err != nil {
 		fmt.Printf("  Warning: Failed to write tmux config: %v\n", err)
 	}
 
-	// Step 6: Check if tmux session exists
-	checkCmd := exec.Command("docker", "exec", containerName, "tmux", "has-session", "-t", "main")
-	if err := checkCmd.Run(); err != nil {
-		fmt.Println("  Recreating tmux session...")
-
-		// Start tmux with Claude
-		tmuxStartCmd := exec.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
-			"cd /workspace && HOME=/home/node tmux new-session -d -s main 'claude --dangerously-skip-permissions'")
-		if err := tmuxStartCmd.Run(); err != nil {
-			return fmt.Errorf("failed to start tmux session: %w", err)
-		}
-
-		time.Sleep(1 * time.Second)
-
-		// Add shell window
-		shellCmd := exec.Command("docker", "exec", containerName,
-			"tmux", "new-window", "-t", "main:1", "-n", "shell", "-c", "cd /workspace && exec zsh")
-		shellCmd.Run()
-
-		// Rename and configure windows
-		exec.Command("docker", "exec", containerName, "tmux", "rename-window", "-t", "main:0", "claude").Run()
-		exec.Command("docker", "exec", containerName, "tmux", "select-window", "-t", "main:0").Run()
+	// Step 6: Recreate the tmux session (it does not survive a container stop)
+	recreated, err := container.EnsureTmuxSession(containerName)
+	if err != nil {
+		return err
+	}
+	if recreated {
+		fmt.Println("  Recreated tmux session")
 	}
 
 	fmt.Printf("\n✅ Container %s restarted successfully\n", shortName)
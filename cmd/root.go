@@ -17,18 +17,29 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/settings"
 	"github.com/uprockcom/maestro/pkg/tui"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var (
-	cfgFile string
-	config  *Config
+	cfgFile     string
+	config      *Config
+	flagVerbose bool
+	flagQuiet   bool
+
+	flagTUIContainer  string
+	flagNoConfirmQuit bool
+	flagTUIBenchmark  bool
+	flagTUIScreenshot string
+	flagFontCheck     bool
 )
 
 // Config represents the maestro configuration
@@ -46,7 +57,24 @@ type Config struct {
 			Memory string `mapstructure:"memory"`
 			CPUs   string `mapstructure:"cpus"`
 		} `mapstructure:"resources"`
-		DefaultReturnToTUI bool `mapstructure:"default_return_to_tui"`
+		DefaultReturnToTUI bool              `mapstructure:"default_return_to_tui"`
+		Shell              string            `mapstructure:"shell"`     // Interactive shell: zsh, bash or sh
+		Workspace          string            `mapstructure:"workspace"` // Project root inside the container
+		DefaultNoFirewall  bool              `mapstructure:"default_no_firewall"`
+		Dockerfile         string            `mapstructure:"dockerfile"`         // Custom Dockerfile extending the image
+		BuildArgs          map[string]string `mapstructure:"build_args"`         // --build-arg values for local builds
+		InitCommands       []string          `mapstructure:"init_commands"`      // Shell commands run in new containers after setup
+		PostCopyScript     string            `mapstructure:"post_copy_script"`   // Script path or inline script run after the project is copied
+		ImagePullPolicy    string            `mapstructure:"image_pull_policy"`  // if-not-present, always or never
+		OfflineMode        bool              `mapstructure:"offline_mode"`       // Never pull images (image_pull_policy: never)
+		NetworkMode        string            `mapstructure:"network_mode"`       // bridge, host or a Docker network name
+		VolumeDriver       string            `mapstructure:"volume_driver"`      // Driver for cache volumes (default local)
+		VolumeOpts         map[string]string `mapstructure:"volume_opts"`        // Driver options for cache volumes
+		SharedVolumes      []string          `mapstructure:"shared_volumes"`     // host_path:container_path[:options] volumes shared by all containers
+		MaxContextTokens   int               `mapstructure:"max_context_tokens"` // Planning prompt token budget; 0 disables
+		Ulimits            []string          `mapstructure:"ulimits"`            // Docker --ulimit values, <type>=<soft>[:<hard>]
+		SecurityOpts       []string          `mapstructure:"security_opts"`      // Docker --security-opt values (seccomp, apparmor, label, no-new-privileges)
+		ReadOnlyRootfs     bool              `mapstructure:"read_only_rootfs"`   // Run with --read-only, keeping the workspace, home and /tmp writable
 	} `mapstructure:"containers"`
 
 	Tmux struct {
@@ -61,8 +89,8 @@ type Config struct {
 	} `mapstructure:"firewall"`
 
 	Sync struct {
-		AdditionalFolders []string `mapstructure:"additional_folders"`
-		Compress          *bool    `mapstructure:"compress"` // Use gzip compression when copying (default: true)
+		AdditionalFolders []any `mapstructure:"additional_folders"` // Paths or {source, dest, exclude} maps
+		Compress          *bool `mapstructure:"compress"`           // Use gzip compression when copying (default: true)
 	} `mapstructure:"sync"`
 
 	SSH struct {
@@ -72,6 +100,7 @@ type Config struct {
 
 	SSL struct {
 		CertificatesPath string `mapstructure:"certificates_path"`
+		MountHostCerts   bool   `mapstructure:"mount_host_certs"`
 	} `mapstructure:"ssl"`
 
 	Android struct {
@@ -111,17 +140,23 @@ type Config struct {
 		ShowNag             bool   `mapstructure:"show_nag"`
 		UpdateCheck         bool   `mapstructure:"update_check"`
 		UpdateCheckInterval string `mapstructure:"update_check_interval"`
+		MaxContainers       int    `mapstructure:"max_containers"`    // Creation guard; 0 disables
+		IgnoreIdleAfter     string `mapstructure:"ignore_idle_after"` // No attention notifications after this long without activity
 		TokenRefresh        struct {
 			Enabled   bool   `mapstructure:"enabled"`
 			Threshold string `mapstructure:"threshold"`
 		} `mapstructure:"token_refresh"`
 		Notifications struct {
-			Enabled            bool     `mapstructure:"enabled"`
-			AttentionThreshold string   `mapstructure:"attention_threshold"`
-			NotifyOn           []string `mapstructure:"notify_on"`
+			Enabled            bool              `mapstructure:"enabled"`
+			AttentionThreshold string            `mapstructure:"attention_threshold"`
+			NotifyOn           []string          `mapstructure:"notify_on"`
+			RateLimit          string            `mapstructure:"rate_limit"`  // Minimum time between attention notifications per container
+			RateLimits         map[string]string `mapstructure:"rate_limits"` // Per-type overrides of rate_limit
+			Icon               string            `mapstructure:"icon"`        // Linux notification icon: theme icon name or file path
 			QuietHours         struct {
-				Start string `mapstructure:"start"`
-				End   string `mapstructure:"end"`
+				Start string   `mapstructure:"start"`
+				End   string   `mapstructure:"end"`
+				Allow []string `mapstructure:"allow"` // Notification types that still come through
 			} `mapstructure:"quiet_hours"`
 			Providers struct {
 				Desktop struct {
@@ -151,7 +186,18 @@ type Config struct {
 		} `mapstructure:"notifications"`
 	} `mapstructure:"daemon"`
 
-	Apps     map[string]string         `mapstructure:"apps"`     // name -> source path
+	TUI struct {
+		ASCIIFallback bool     `mapstructure:"ascii_fallback"` // ASCII art and glyphs for limited terminals
+		Color         string   `mapstructure:"color"`          // auto (follows NO_COLOR), always or never
+		PinAttention  bool     `mapstructure:"pin_attention"`  // Containers needing attention first on the home view
+		HomeOrder     string   `mapstructure:"home_order"`     // default, recent for last connected first, or git for most unmerged work first
+		Columns       []string `mapstructure:"columns"`        // Home view columns, in order
+		ConnectWindow string   `mapstructure:"connect_window"` // tmux window TUI connects open: claude, shell or a number
+		CacheTTL      string   `mapstructure:"cache_ttl"`      // How old a saved container list may be to show at startup; 0 disables
+		ConfirmQuit   bool     `mapstructure:"confirm_quit"`   // Ask before quitting while containers are running
+	} `mapstructure:"tui"`
+
+	Apps     map[string]any            `mapstructure:"apps"`     // name -> path, URL, or per-arch map (see app_source.go)
 	Projects map[string]ProjectConfig  `mapstructure:"projects"` // name -> project config
 	Contacts map[string]ContactProfile `mapstructure:"contacts"` // name -> contact profile
 }
@@ -174,14 +220,45 @@ var rootCmd = &cobra.Command{
 for Claude Code development. It allows you to run multiple Claude instances in
 parallel, each in their own isolated environment with proper branch management.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if flagTUIScreenshot != "" {
+			if err := writeTUIScreenshot(flagTUIScreenshot); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		if flagFontCheck {
+			if err := runFontCheck(os.Stdin, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		warnNonUnicodeLocale()
+
 		// Auto-start daemon if not running
-		EnsureDaemonRunning()
+		_ = EnsureDaemonRunning()
 
 		// Keep running TUI in a loop until user explicitly quits
 		// Maintain cached state for seamless return from containers
 		var cachedState *tui.CachedState
+		if flagTUIContainer != "" {
+			cachedState = &tui.CachedState{SelectedContainerName: flagTUIContainer}
+		}
+		images := currentImages()
 		for {
-			result, newState, err := tui.Run(config.Containers.Prefix, cachedState)
+			opts := tui.RunOptions{CurrentImages: images, StartDaemon: EnsureDaemonRunning, NoConfirmQuit: flagNoConfirmQuit}
+			if daemonServiceSupported {
+				opts.InstallDaemon = func() error {
+					_, err := InstallDaemonService()
+					return err
+				}
+			}
+			if flagTUIBenchmark {
+				opts.Benchmark = os.Stderr
+			}
+			result, newState, err := tui.Run(config.Containers.Prefix, cachedState, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 				os.Exit(1)
@@ -198,7 +275,12 @@ parallel, each in their own isolated environment with proper branch management.`
 			switch result.Action {
 			case tui.ActionConnect:
 				// Connect to the selected container
-				err := performConnect(result.ContainerName)
+				// An invalid tui.connect_window is reported by config validate
+				window := result.Window
+				if _, err := container.TmuxWindowIndex(config.TUI.ConnectWindow); window == "" && err == nil {
+					window = config.TUI.ConnectWindow
+				}
+				err := performConnect(result.ContainerName, window)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error connecting: %v\n", err)
 					fmt.Println("Press Enter to continue...")
@@ -232,11 +314,23 @@ parallel, each in their own isolated environment with proper branch management.`
 					fmt.Println("Press Enter to continue...")
 					fmt.Scanln()
 				} else {
-					fmt.Println("\n✓ Authentication complete!")
+					fmt.Println("\n" + style.Check() + " Authentication complete!")
 					fmt.Println("Press Enter to return to Maestro...")
 					fmt.Scanln()
 				}
 				// Loop continues, TUI will restart with cached state
+			case tui.ActionEnableFirewall:
+				if err := enableFirewall(result.ContainerName); err != nil {
+					fmt.Fprintf(os.Stderr, "Error enabling firewall: %v\n", err)
+				}
+				fmt.Println("Press Enter to return to Maestro...")
+				fmt.Scanln()
+			case tui.ActionRecreate:
+				if _, err := recreateContainer(result.ContainerName); err != nil {
+					fmt.Fprintf(os.Stderr, "Error recreating container: %v\n", err)
+				}
+				fmt.Println("Press Enter to return to Maestro...")
+				fmt.Scanln()
 			case tui.ActionQuit:
 				// Exit the loop
 				return
@@ -254,23 +348,90 @@ func Execute() {
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(initLogging, initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "",
 		"config file (default is $HOME/.maestro/config.yml)")
+	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false,
+		"debug output, including the docker commands being run (also MAESTRO_DEBUG=1)")
+	rootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false,
+		"only print warnings and errors")
+	rootCmd.Flags().StringVarP(&flagTUIContainer, "container", "c", "",
+		"open the TUI with this container selected (full or short name)")
+	rootCmd.Flags().BoolVar(&flagNoConfirmQuit, "no-confirm-quit", false,
+		"quit the TUI without asking while containers are running")
+	rootCmd.Flags().BoolVar(&flagFontCheck, "font-check", false,
+		"show the characters the TUI uses and switch to ASCII if they don't render")
+
+	// Maintainer flags for catching rendering regressions
+	rootCmd.Flags().BoolVar(&flagTUIBenchmark, "benchmark", false,
+		"print TUI render timings to stderr every 10 renders")
+	rootCmd.Flags().StringVar(&flagTUIScreenshot, "screenshot", "",
+		"render one TUI frame as plain text to this file and exit")
+	rootCmd.Flags().MarkHidden("benchmark")
+	rootCmd.Flags().MarkHidden("screenshot")
+}
+
+// screenshotWidth and screenshotHeight are the terminal size --screenshot
+// renders at, so screenshots compare across machines.
+const (
+	screenshotWidth  = 120
+	screenshotHeight = 40
+)
+
+// writeTUIScreenshot renders one TUI frame to path for screenshot tests.
+func writeTUIScreenshot(path string) error {
+	frame := tui.Screenshot(config.Containers.Prefix, screenshotWidth, screenshotHeight)
+	if err := os.WriteFile(path, []byte(frame+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	return nil
+}
+
+// initLogging sets the log level from --verbose, --quiet and MAESTRO_DEBUG.
+// Debug output wins if both are requested.
+func initLogging() {
+	switch {
+	case flagVerbose || logging.DebugFromEnv():
+		logging.SetLevel(logging.LevelDebug)
+	case flagQuiet:
+		logging.SetLevel(logging.LevelQuiet)
+	}
+}
+
+// warnUnseparatedPrefixContainers warns about containers created under a
+// containers.prefix without a separator ("maestrofeat-1"), which the
+// normalized prefix no longer lists, and says how to rename them.
+func warnUnseparatedPrefixContainers(configured, prefix string) {
+	if configured == "" {
+		return
+	}
+	names, err := container.UnseparatedPrefixContainers(configured, prefix)
+	if err != nil {
+		logging.Debugf("Failed to look for containers under prefix %q: %v", configured, err)
+		return
+	}
+	if len(names) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s  Warning: %d container(s) named with %q no longer match; rename them to keep using them:\n", style.Warning(), len(names), configured)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "   docker rename %s %s\n", name, prefix+strings.TrimPrefix(name, configured))
+	}
 }
 
-// performConnect connects to a container's tmux session
-func performConnect(containerName string) error {
+// performConnect connects to a container's tmux session, on window if one
+// is given
+func performConnect(containerName, window string) error {
 	// Verify container is running
-	checkCmd := exec.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName)
+	checkCmd := logging.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName)
 	output, err := checkCmd.Output()
 	if err != nil {
 		// Try as short name
 		shortName := containerName
 		if !strings.HasPrefix(shortName, config.Containers.Prefix) {
 			containerName = config.Containers.Prefix + shortName
-			checkCmd = exec.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName)
+			checkCmd = logging.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName)
 			output, err = checkCmd.Output()
 			if err != nil {
 				return fmt.Errorf("container %s not found", shortName)
@@ -289,16 +450,7 @@ func performConnect(containerName string) error {
 	}
 
 	fmt.Printf("Connecting to %s...\n", containerName)
-	fmt.Println("Detach with: Ctrl+b d")
-	fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
-
-	// Connect to tmux session
-	connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
-	connectCmd.Stdin = os.Stdin
-	connectCmd.Stdout = os.Stdout
-	connectCmd.Stderr = os.Stderr
-
-	return connectCmd.Run()
+	return connectContainer(containerName, window)
 }
 
 // performCreate creates a new container from TUI form data
@@ -335,77 +487,14 @@ func initConfig() {
 		if paths.HasLegacyConfig() {
 			legacyFile := paths.LegacyConfigFile()
 			if _, err := os.Stat(legacyFile); err == nil {
-				fmt.Fprintf(os.Stderr, "\n⚠️  Warning: Found old configuration at %s\n", legacyFile)
-				fmt.Fprintf(os.Stderr, "   Run: ./scripts/migrate-configs.sh to migrate to %s\n\n", configFile)
+				fmt.Fprintf(os.Stderr, "\n%s  Warning: Found old configuration at %s\n", style.Warning(), legacyFile)
+				fmt.Fprintf(os.Stderr, "   Run: maestro migrate-config to migrate to %s\n\n", configFile)
 			}
 		}
 	}
 
-	// Set defaults - use paths package for directory defaults
-	viper.SetDefault("claude.config_path", "~/.claude")
-	viper.SetDefault("claude.auth_path", paths.AuthDir())
-	viper.SetDefault("claude.default_mode", "yolo")
-	viper.SetDefault("containers.prefix", "maestro-")
-	viper.SetDefault("containers.image", "ghcr.io/uprockcom/maestro:latest")
-	viper.SetDefault("containers.resources.memory", "4g")
-	viper.SetDefault("containers.resources.cpus", "2")
-	viper.SetDefault("containers.default_return_to_tui", false)
-	viper.SetDefault("containers.default_model", "opus")
-	viper.SetDefault("tmux.default_session", "main")
-	viper.SetDefault("tmux.prefix", "C-b")
-	viper.SetDefault("firewall.allowed_domains", []string{
-		"registry.npmjs.org",
-		"api.anthropic.com",
-		"github.com",
-		"pypi.org",
-		"files.pythonhosted.org",
-		"sentry.io",
-		"statsig.anthropic.com",
-		"statsig.com",
-		// AWS Bedrock domains
-		"sts.amazonaws.com",
-		"bedrock.amazonaws.com",
-		"bedrock-runtime.amazonaws.com",
-	})
-	viper.SetDefault("firewall.internal_dns", "")
-	viper.SetDefault("firewall.internal_domains", []string{})
-	viper.SetDefault("ssh.enabled", false)
-	viper.SetDefault("ssh.known_hosts_path", "~/.ssh/known_hosts")
-	viper.SetDefault("ssl.certificates_path", paths.CertificatesDir())
-	viper.SetDefault("android.sdk_path", "")
-	viper.SetDefault("git.user_name", "")
-	viper.SetDefault("git.user_email", "")
-	viper.SetDefault("github.enabled", false)
-	viper.SetDefault("github.config_path", paths.GitHubAuthDir())
-	viper.SetDefault("aws.enabled", false)
-	viper.SetDefault("aws.profile", "")
-	viper.SetDefault("aws.region", "")
-	viper.SetDefault("bedrock.enabled", false)
-	viper.SetDefault("bedrock.model", "")
-	viper.SetDefault("web.enabled", false)
-	viper.SetDefault("web.image", "")
-	viper.SetDefault("web.shm_size", "256m")
-	viper.SetDefault("daemon.check_interval", "30s")
-	viper.SetDefault("daemon.show_nag", true)
-	viper.SetDefault("daemon.update_check", true)
-	viper.SetDefault("daemon.update_check_interval", "6h")
-	viper.SetDefault("daemon.token_refresh.enabled", true)
-	viper.SetDefault("daemon.token_refresh.threshold", "6h")
-	viper.SetDefault("daemon.notifications.enabled", true)
-	viper.SetDefault("daemon.notifications.attention_threshold", "5m")
-	viper.SetDefault("daemon.notifications.notify_on", []string{"attention_needed", "token_expiring", "tasks_completed", "container_notification"})
-	viper.SetDefault("daemon.notifications.quiet_hours.start", "")
-	viper.SetDefault("daemon.notifications.quiet_hours.end", "")
-	viper.SetDefault("daemon.notifications.providers.desktop.enabled", true)
-	viper.SetDefault("daemon.notifications.providers.local.enabled", true)
-	viper.SetDefault("daemon.notifications.providers.slack.enabled", false)
-	viper.SetDefault("daemon.notifications.providers.signal.enabled", false)
-	viper.SetDefault("daemon.notifications.providers.signal.container_port", 8080)
-	viper.SetDefault("daemon.notifications.providers.signal.url", "")
-	viper.SetDefault("daemon.notifications.providers.signal.api_key", "")
-	viper.SetDefault("apps", map[string]string{})
-	viper.SetDefault("wizard.always_run", false)
-	viper.SetDefault("wizard.resume_after_auth", false)
+	// Set defaults (see pkg/settings, shared with 'maestro config init')
+	settings.ApplyDefaults()
 
 	// Read config
 	if err := viper.ReadInConfig(); err != nil {
@@ -415,10 +504,34 @@ func initConfig() {
 		}
 	}
 
+	// Merge a project config from the working directory over the global one
+	if cwd, err := os.Getwd(); err == nil {
+		overlay, err := mergeProjectConfig(viper.GetViper(), cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading project config: %v\n", err)
+		}
+		loadedProjectConfig = overlay
+	}
+
 	// Unmarshal config
 	config = &Config{}
 	if err := viper.Unmarshal(config); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing config: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Prefix matching assumes the prefix ends at a separator
+	if prefix, changed := container.NormalizePrefix(config.Containers.Prefix); changed {
+		fmt.Fprintf(os.Stderr, "%s  Warning: containers.prefix %q should end in '-', '_' or '.'; using %q\n", style.Warning(), config.Containers.Prefix, prefix)
+		warnUnseparatedPrefixContainers(config.Containers.Prefix, prefix)
+		config.Containers.Prefix = prefix
+	}
+
+	// Values the getters fall back from are reported once, here
+	for _, p := range fallbackProblems(config) {
+		fmt.Fprintf(os.Stderr, "%s  Warning: %s: %s\n", style.Warning(), p.key, p.message)
+	}
+
+	// Honor tui.color and NO_COLOR in both the TUI and styled CLI output
+	style.ApplyColorMode()
 }
@@ -0,0 +1,127 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+	"github.com/uprockcom/maestro/pkg/update"
+	"github.com/uprockcom/maestro/pkg/version"
+)
+
+var selfUpdateForce bool
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update maestro to the latest release",
+	Long: `Download the latest maestro release for this platform, verify it against
+the release checksums and replace the running binary.
+
+Homebrew installs should be upgraded with 'brew upgrade maestro' instead. If
+the binary's directory is not writable, the new binary is saved to a temporary
+file and the command to install it is printed.`,
+	Args: cobra.NoArgs,
+	RunE: runSelfUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Reinstall even if up to date, or replace a development build")
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	if version.IsDevelopment() && !selfUpdateForce {
+		return fmt.Errorf("this is a development build; use --force to replace it with the latest release")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate maestro binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if update.IsHomebrewInstall(exe) {
+		fmt.Println("maestro was installed with Homebrew. Update it with:")
+		fmt.Println("  brew upgrade maestro")
+		return nil
+	}
+
+	fmt.Println("Checking for updates...")
+	release, err := update.NewChecker(paths.GetConfigDir(), 0, nil).CheckNow()
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %w", err)
+	}
+	if !release.UpdateAvail && !selfUpdateForce {
+		fmt.Printf("%s maestro %s is up to date\n", style.Check(), release.CurrentVersion)
+		return nil
+	}
+
+	asset := update.AssetName(release.LatestVersion, runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("Downloading %s...\n", asset)
+	binary, err := update.DownloadBinary(release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+	fmt.Println(style.Check() + " Checksum verified")
+
+	var notWritable *update.NotWritableError
+	err = update.ReplaceExecutable(exe, binary)
+	switch {
+	case errors.As(err, &notWritable):
+		return saveUpdateForManualInstall(binary, release.LatestVersion, exe)
+	case err != nil:
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+
+	fmt.Printf("%s Updated maestro %s → %s\n", style.Check(), release.CurrentVersion, release.LatestVersion)
+	return nil
+}
+
+// saveUpdateForManualInstall writes the verified binary to a temp file when
+// the installed one cannot be replaced, and prints how to finish the job.
+func saveUpdateForManualInstall(binary []byte, latest, exe string) error {
+	tmp, err := os.CreateTemp("", "maestro-"+latest+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to save update: %w", err)
+	}
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save update: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save update: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return fmt.Errorf("failed to save update: %w", err)
+	}
+
+	fmt.Printf("\n%s is not writable by the current user.\n", exe)
+	fmt.Printf("The verified maestro %s binary was saved to %s\n", latest, tmp.Name())
+	if runtime.GOOS == "windows" {
+		fmt.Printf("Copy it over %s from an administrator prompt.\n", exe)
+	} else {
+		fmt.Println("Install it with:")
+		fmt.Printf("  sudo install -m 0755 %s %s\n", tmp.Name(), exe)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// shellPromptMarker identifies an rc file that already has the Maestro prompt.
+const shellPromptMarker = "Custom Maestro prompt"
+
+// zshPrompt is the prompt block appended to .zshrc: readable symbols and
+// colors, plus git status matching the maestro list command.
+const zshPrompt = `
+# Custom Maestro prompt with colors and git status
+autoload -Uz vcs_info
+precmd_vcs_info() { vcs_info }
+precmd_functions+=( precmd_vcs_info )
+setopt prompt_subst
+zstyle ':vcs_info:git:*' formats '%b'
+zstyle ':vcs_info:*' enable git
+
+# Git status indicators (matching maestro list command)
+git_status_symbols() {
+    if [[ -n ${vcs_info_msg_0_} ]]; then
+        local git_status=""
+        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
+        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
+        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
+
+        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
+        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
+        [[ $behind -gt 0 ]] && git_status+="↓$behind "
+        [[ -z $git_status ]] && git_status="✓ "
+
+        echo "$git_status"
+    fi
+}
+
+PROMPT='%F{green}%n%f  %F{blue}%~%f  %F{magenta}${vcs_info_msg_0_}%f %F{yellow}$(git_status_symbols)%f'`
+
+// bashPrompt is the bash equivalent of zshPrompt, appended to .bashrc.
+const bashPrompt = `
+# Custom Maestro prompt with colors and git status
+git_branch_name() {
+    git branch --show-current 2>/dev/null
+}
+
+# Git status indicators (matching maestro list command)
+git_status_symbols() {
+    if [[ -n $(git_branch_name) ]]; then
+        local git_status=""
+        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
+        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
+        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
+
+        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
+        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
+        [[ $behind -gt 0 ]] && git_status+="↓$behind "
+        [[ -z $git_status ]] && git_status="✓ "
+
+        echo "$git_status"
+    fi
+}
+
+PS1='\[\e[32m\]\u\[\e[0m\]  \[\e[34m\]\w\[\e[0m\]  \[\e[35m\]$(git_branch_name)\[\e[0m\] \[\e[33m\]$(git_status_symbols)\[\e[0m\]'`
+
+// shellFixScript returns the script that sets up the Maestro prompt for
+// shell, or "" if the shell has no rc file to configure. The script is a
+// no-op when the prompt is already present, so it is safe to re-run.
+func shellFixScript(shell string) string {
+	rcFile := container.ShellRCFile(shell)
+	if rcFile == "" {
+		return ""
+	}
+
+	var setup, prompt string
+	switch shell {
+	case "zsh":
+		setup = fmt.Sprintf(`# Remove TERM override
+sed -i '/^export TERM=xterm$/d' %[1]s
+
+# Disable powerlevel10k theme (causes missing font glyphs)
+sed -i 's/^ZSH_THEME=.*/ZSH_THEME=""/' %[1]s
+`, rcFile)
+		prompt = zshPrompt
+	case "bash":
+		setup = fmt.Sprintf(`# Remove TERM override
+touch %[1]s
+sed -i '/^export TERM=xterm$/d' %[1]s
+`, rcFile)
+		prompt = bashPrompt
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf(`grep -q '%[1]s' %[2]s 2>/dev/null && exit 0
+
+%[3]s
+# Add custom prompt with readable symbols and colors
+cat >> %[2]s << 'PROMPT_EOF'
+%[4]s
+PROMPT_EOF`, shellPromptMarker, rcFile, setup, prompt)
+}
+
+// configureContainerShell applies the Maestro prompt to the container's
+// interactive shell for a better terminal experience.
+func configureContainerShell(containerName, shell string) error {
+	script := shellFixScript(shell)
+	if script == "" {
+		return nil
+	}
+	return logging.Run(logging.Command("docker", "exec", containerName, "sh", "-c", script))
+}
@@ -33,7 +33,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/notify/signal"
+	"github.com/uprockcom/maestro/pkg/settings"
 )
 
 var signalCmd = &cobra.Command{
@@ -117,16 +119,16 @@ func runSignalSetup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to pull image: %w", err)
 	}
 	// Check that relay image exists; try pulling from registry first, then build locally
-	checkRelay := exec.Command("docker", "image", "inspect", "maestro-signal-relay:latest")
-	if err := checkRelay.Run(); err != nil {
+	checkRelay := logging.Command("docker", "image", "inspect", "maestro-signal-relay:latest")
+	if err := logging.Run(checkRelay); err != nil {
 		fmt.Println("Pulling signal-relay Docker image...")
-		pullCmd := exec.Command("docker", "pull", "ghcr.io/uprockcom/maestro-signal-relay:latest")
+		pullCmd := logging.Command("docker", "pull", "ghcr.io/uprockcom/maestro-signal-relay:latest")
 		pullCmd.Stdout = os.Stdout
 		pullCmd.Stderr = os.Stderr
-		if pullErr := pullCmd.Run(); pullErr == nil {
+		if pullErr := logging.Run(pullCmd); pullErr == nil {
 			// Tag as local name
-			tagCmd := exec.Command("docker", "tag", "ghcr.io/uprockcom/maestro-signal-relay:latest", "maestro-signal-relay:latest")
-			tagCmd.Run()
+			tagCmd := logging.Command("docker", "tag", "ghcr.io/uprockcom/maestro-signal-relay:latest", "maestro-signal-relay:latest")
+			logging.Run(tagCmd)
 		} else {
 			fmt.Println("Pull failed, building signal-relay Docker image locally...")
 			buildCmd := exec.Command("make", "docker-relay")
@@ -247,20 +249,14 @@ func runSignalSetup(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 12: Save config
-	viper.Set("daemon.notifications.providers.signal.enabled", true)
-	viper.Set("daemon.notifications.providers.signal.number", botNumber)
-	viper.Set("daemon.notifications.providers.signal.recipient", recipient)
-	viper.Set("daemon.notifications.providers.signal.url", relayURL)
-	viper.Set("daemon.notifications.providers.signal.api_key", apiKey)
-
-	if err := viper.WriteConfig(); err != nil {
-		configFile := viper.ConfigFileUsed()
-		if configFile == "" {
-			return fmt.Errorf("failed to save config: %w", err)
-		}
-		if err := viper.WriteConfigAs(configFile); err != nil {
-			return fmt.Errorf("failed to write config: %w", err)
-		}
+	if err := settings.Save(map[string]any{
+		"daemon.notifications.providers.signal.enabled":   true,
+		"daemon.notifications.providers.signal.number":    botNumber,
+		"daemon.notifications.providers.signal.recipient": recipient,
+		"daemon.notifications.providers.signal.url":       relayURL,
+		"daemon.notifications.providers.signal.api_key":   apiKey,
+	}); err != nil {
+		return err
 	}
 
 	fmt.Println()
@@ -283,7 +279,7 @@ func runSignalBackup(cmd *cobra.Command, args []string) error {
 
 	// Back up the Docker volume
 	fmt.Println("Backing up Signal registration data...")
-	dockerCmd := exec.Command("docker", "run", "--rm",
+	dockerCmd := logging.Command("docker", "run", "--rm",
 		"-v", "maestro-signal-data:/data:ro",
 		"-v", backupDir+":/backup",
 		"alpine",
@@ -390,7 +386,7 @@ func runSignalRestore(cmd *cobra.Command, args []string) error {
 
 	// Restore the volume
 	fmt.Println("Restoring registration data...")
-	dockerCmd := exec.Command("docker", "run", "--rm",
+	dockerCmd := logging.Command("docker", "run", "--rm",
 		"-v", "maestro-signal-data:/data",
 		"-v", filepath.Dir(backupFile)+":/backup:ro",
 		"alpine",
@@ -484,20 +480,14 @@ func runSignalConnect(cmd *cobra.Command, args []string) error {
 
 	// Step 6: Save config
 	fmt.Println()
-	viper.Set("daemon.notifications.providers.signal.enabled", true)
-	viper.Set("daemon.notifications.providers.signal.number", botNumber)
-	viper.Set("daemon.notifications.providers.signal.recipient", recipient)
-	viper.Set("daemon.notifications.providers.signal.url", relayURL)
-	viper.Set("daemon.notifications.providers.signal.api_key", apiKey)
-
-	if err := viper.WriteConfig(); err != nil {
-		configFile := viper.ConfigFileUsed()
-		if configFile == "" {
-			return fmt.Errorf("failed to save config: %w", err)
-		}
-		if err := viper.WriteConfigAs(configFile); err != nil {
-			return fmt.Errorf("failed to write config: %w", err)
-		}
+	if err := settings.Save(map[string]any{
+		"daemon.notifications.providers.signal.enabled":   true,
+		"daemon.notifications.providers.signal.number":    botNumber,
+		"daemon.notifications.providers.signal.recipient": recipient,
+		"daemon.notifications.providers.signal.url":       relayURL,
+		"daemon.notifications.providers.signal.api_key":   apiKey,
+	}); err != nil {
+		return err
 	}
 
 	fmt.Println("Signal relay configured successfully!")
@@ -638,17 +628,11 @@ func runSignalAddContact(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 4: Save to config
-	viper.Set(fmt.Sprintf("contacts.%s.signal.recipient", name), recipient)
-	viper.Set(fmt.Sprintf("contacts.%s.signal.api_key", name), apiKey)
-
-	if err := viper.WriteConfig(); err != nil {
-		configFile := viper.ConfigFileUsed()
-		if configFile == "" {
-			return fmt.Errorf("failed to save config: %w", err)
-		}
-		if err := viper.WriteConfigAs(configFile); err != nil {
-			return fmt.Errorf("failed to write config: %w", err)
-		}
+	if err := settings.Save(map[string]any{
+		fmt.Sprintf("contacts.%s.signal.recipient", name): recipient,
+		fmt.Sprintf("contacts.%s.signal.api_key", name):   apiKey,
+	}); err != nil {
+		return err
 	}
 
 	fmt.Println()
@@ -691,11 +675,11 @@ func runSignalResetKey(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save url and api_key to config
-	viper.Set("daemon.notifications.providers.signal.url", relayURL)
-	viper.Set("daemon.notifications.providers.signal.api_key", apiKey)
-
-	if err := viper.WriteConfig(); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
+	if err := settings.Save(map[string]any{
+		"daemon.notifications.providers.signal.url":     relayURL,
+		"daemon.notifications.providers.signal.api_key": apiKey,
+	}); err != nil {
+		return err
 	}
 
 	fmt.Println("API key regenerated and config saved.")
@@ -721,7 +705,7 @@ func replaceDefaultKeyInVolume(recipient string) (string, error) {
 	var kf signalKeysFile
 
 	// Read existing keys.json (ignore error — volume may be empty on first use)
-	readCmd := exec.Command("docker", "run", "--rm",
+	readCmd := logging.Command("docker", "run", "--rm",
 		"-v", volName+":/config:ro",
 		"alpine", "cat", "/config/keys.json")
 	if data, err := readCmd.Output(); err == nil {
@@ -747,7 +731,7 @@ func replaceDefaultKeyInVolume(recipient string) (string, error) {
 		return "", fmt.Errorf("failed to marshal keys file: %w", err)
 	}
 
-	writeCmd := exec.Command("docker", "run", "--rm",
+	writeCmd := logging.Command("docker", "run", "--rm",
 		"-v", volName+":/config",
 		"-i", "alpine", "sh", "-c", "cat > /config/keys.json")
 	writeCmd.Stdin = strings.NewReader(string(data))
@@ -780,7 +764,7 @@ func generateAndWriteKeysJSON(name, recipient string) (string, error) {
 	volName := signal.RelayConfigVolume()
 	var kf signalKeysFile
 
-	readCmd := exec.Command("docker", "run", "--rm",
+	readCmd := logging.Command("docker", "run", "--rm",
 		"-v", volName+":/config:ro",
 		"alpine", "cat", "/config/keys.json")
 	if data, err := readCmd.Output(); err == nil {
@@ -797,7 +781,7 @@ func generateAndWriteKeysJSON(name, recipient string) (string, error) {
 	}
 
 	// Write keys.json back to the volume
-	writeCmd := exec.Command("docker", "run", "--rm",
+	writeCmd := logging.Command("docker", "run", "--rm",
 		"-v", volName+":/config",
 		"-i", "alpine", "sh", "-c", "cat > /config/keys.json")
 	writeCmd.Stdin = strings.NewReader(string(data))
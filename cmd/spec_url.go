@@ -0,0 +1,187 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+const (
+	// specFetchTimeout bounds the whole request for --spec-url, including
+	// reading the body.
+	specFetchTimeout = 30 * time.Second
+	// specMaxBytes caps a fetched spec; anything larger is almost certainly
+	// not a task description.
+	specMaxBytes = 1 << 20
+)
+
+// isSpecURL reports whether s looks like an http(s) URL rather than a path.
+func isSpecURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// githubRef is an issue or pull request parsed from a GitHub web URL.
+type githubRef struct {
+	Host   string
+	Repo   string // owner/name
+	Kind   string // "issue" or "pr"
+	Number string
+}
+
+// parseGitHubRef recognizes https://<host>/<owner>/<repo>/issues/<n> and
+// .../pull/<n> URLs on github.com or the configured github.hostname.
+func parseGitHubRef(raw, hostname string) (githubRef, bool) {
+	u, err := url.Parse(raw)
+	if err != nil || !githubHost(u.Host, hostname) {
+		return githubRef{}, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 {
+		return githubRef{}, false
+	}
+	var kind string
+	switch parts[2] {
+	case "issues":
+		kind = "issue"
+	case "pull":
+		kind = "pr"
+	default:
+		return githubRef{}, false
+	}
+	if _, err := fmt.Sscanf(parts[3], "%d", new(int)); err != nil {
+		return githubRef{}, false
+	}
+	return githubRef{Host: u.Host, Repo: parts[0] + "/" + parts[1], Kind: kind, Number: parts[3]}, true
+}
+
+func githubHost(host, hostname string) bool {
+	return host == "github.com" || (hostname != "" && host == hostname)
+}
+
+// rawSpecURL rewrites GitHub page URLs for files and gists to their raw
+// content, so --spec-url can take the link a browser shows. Other URLs are
+// returned unchanged.
+func rawSpecURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case u.Host == "github.com" && len(parts) > 4 && parts[2] == "blob":
+		// github.com/<owner>/<repo>/blob/<ref>/<path>
+		u.Host = "raw.githubusercontent.com"
+		u.Path = "/" + strings.Join(append(parts[:2], parts[3:]...), "/")
+		u.RawQuery = ""
+		return u.String()
+	case u.Host == "gist.github.com" && len(parts) == 2:
+		// gist.github.com/<owner>/<id>
+		u.Host = "gist.githubusercontent.com"
+		u.Path = "/" + parts[0] + "/" + parts[1] + "/raw"
+		u.RawQuery = ""
+		return u.String()
+	}
+	return raw
+}
+
+// readSpecURL returns the task description behind a --spec-url. GitHub issue
+// and pull request links are read through gh with the maestro credentials,
+// since their pages are HTML; everything else is fetched directly.
+func readSpecURL(raw string) (string, error) {
+	if ref, ok := parseGitHubRef(raw, config.GitHub.Hostname); ok {
+		if !config.GitHub.Enabled {
+			return "", fmt.Errorf("%s is a GitHub %s; enable github.enabled and run 'maestro auth' to read it, or pass a raw URL", raw, ref.Kind)
+		}
+		return fetchGitHubSpec(ref)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), specFetchTimeout)
+	defer cancel()
+	return fetchSpec(ctx, rawSpecURL(raw))
+}
+
+// fetchSpec downloads a spec over HTTP(S), rejecting non-200 responses and
+// bodies over specMaxBytes.
+func fetchSpec(ctx context.Context, raw string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, raw, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid spec URL: %w", err)
+	}
+	client := &http.Client{Timeout: specFetchTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch spec: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch spec: %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, specMaxBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch spec: %w", err)
+	}
+	if len(body) > specMaxBytes {
+		return "", fmt.Errorf("spec at %s is larger than %d bytes", raw, specMaxBytes)
+	}
+	return string(body), nil
+}
+
+// fetchGitHubSpec reads an issue or pull request with the host's gh, pointed
+// at the credentials 'maestro auth' stored for containers.
+func fetchGitHubSpec(ref githubRef) (string, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return "", errors.New("reading GitHub issues and pull requests needs the gh CLI on the host")
+	}
+	repo := ref.Repo
+	if ref.Host != "github.com" {
+		repo = ref.Host + "/" + repo
+	}
+	cmd := logging.Command("gh", ref.Kind, "view", ref.Number, "--repo", repo, "--json", "title,body")
+	cmd.Env = append(os.Environ(), "GH_CONFIG_DIR="+expandPath(config.GitHub.ConfigPath))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("gh %s view failed: %s", ref.Kind, msg)
+		}
+		return "", fmt.Errorf("gh %s view failed: %w", ref.Kind, err)
+	}
+	return formatGitHubSpec(out)
+}
+
+// formatGitHubSpec turns gh's {"title","body"} JSON into a markdown task.
+func formatGitHubSpec(data []byte) (string, error) {
+	var item struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return "", fmt.Errorf("unexpected gh output: %w", err)
+	}
+	return fmt.Sprintf("# %s\n\n%s", item.Title, strings.TrimSpace(item.Body)), nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseGitHubRef(t *testing.T) {
+	tests := []struct {
+		url      string
+		hostname string
+		want     githubRef
+		ok       bool
+	}{
+		{"https://github.com/org/repo/issues/42", "", githubRef{"github.com", "org/repo", "issue", "42"}, true},
+		{"https://github.com/org/repo/pull/7/files", "", githubRef{"github.com", "org/repo", "pr", "7"}, true},
+		{"https://ghe.corp/org/repo/issues/3", "ghe.corp", githubRef{"ghe.corp", "org/repo", "issue", "3"}, true},
+		{"https://ghe.corp/org/repo/issues/3", "", githubRef{}, false},
+		{"https://github.com/org/repo/issues", "", githubRef{}, false},
+		{"https://github.com/org/repo/issues/new", "", githubRef{}, false},
+		{"https://github.com/org/repo/blob/main/SPEC.md", "", githubRef{}, false},
+		{"https://example.com/org/repo/issues/1", "", githubRef{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseGitHubRef(tt.url, tt.hostname)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseGitHubRef(%q, %q) = %+v, %v; want %+v, %v", tt.url, tt.hostname, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRawSpecURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/repo/blob/main/docs/spec.md": "https://raw.githubusercontent.com/org/repo/main/docs/spec.md",
+		"https://gist.github.com/alice/abc123":               "https://gist.githubusercontent.com/alice/abc123/raw",
+		"https://example.com/spec.md":                        "https://example.com/spec.md",
+		"https://github.com/org/repo":                        "https://github.com/org/repo",
+	}
+	for in, want := range tests {
+		if got := rawSpecURL(in); got != want {
+			t.Errorf("rawSpecURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFetchSpec(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spec.md":
+			fmt.Fprint(w, "# Add caching\n")
+		case "/big":
+			w.Write([]byte(strings.Repeat("x", specMaxBytes+1)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	got, err := fetchSpec(context.Background(), srv.URL+"/spec.md")
+	if err != nil || got != "# Add caching\n" {
+		t.Fatalf("fetchSpec = %q, %v", got, err)
+	}
+	if _, err := fetchSpec(context.Background(), srv.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+	if _, err := fetchSpec(context.Background(), srv.URL+"/big"); err == nil {
+		t.Error("expected oversized spec to be rejected")
+	}
+}
+
+func TestFormatGitHubSpec(t *testing.T) {
+	got, err := formatGitHubSpec([]byte(`{"title":"Fix login","body":"Steps:\n1. log in\n"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Fix login\n\nSteps:\n1. log in"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/api"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+var flagStatusJSON bool
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "One-screen overview of the daemon, auth, containers and image",
+	Long: `Show whether the daemon is running, when the host token expires, how many
+containers are in each state and which need attention, the disk used by
+maestro volumes, and whether the container image matches this binary.
+
+Exits 1 if anything is in a red state (Docker unreachable, expired token,
+daemon stopped while daemon.token_refresh is enabled, or a missing
+containers.volume_driver), so it can be used in
+shell prompts. Use --json for scripts.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		report := collectStatus(cmd.Context())
+		if flagStatusJSON {
+			out, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(out))
+		} else {
+			printStatus(report)
+		}
+		if len(report.Problems) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&flagStatusJSON, "json", false, "Print the report as JSON")
+}
+
+// statusReport is the data behind 'maestro status'.
+type statusReport struct {
+	Daemon struct {
+		Running bool   `json:"running"`
+		PID     int    `json:"pid,omitempty"`
+		Uptime  string `json:"uptime,omitempty"`
+	} `json:"daemon"`
+	Token struct {
+		State     string     `json:"state"` // valid, expiring, expired, missing or aws
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		Detail    string     `json:"detail,omitempty"`
+	} `json:"token"`
+	Containers struct {
+		Total          int            `json:"total"`
+		ByState        map[string]int `json:"by_state"`
+		NeedsAttention []string       `json:"needs_attention"`
+		Error          string         `json:"error,omitempty"`
+	} `json:"containers"`
+	Volumes struct {
+		Count           int    `json:"count"`
+		Bytes           int64  `json:"bytes"`
+		Driver          string `json:"driver,omitempty"` // containers.volume_driver
+		DriverInstalled bool   `json:"driver_installed,omitempty"`
+		DriverError     string `json:"driver_error,omitempty"`
+		Error           string `json:"error,omitempty"`
+	} `json:"volumes"`
+	Image struct {
+		Expected     string   `json:"expected"`
+		Present      bool     `json:"present"`
+		OtherVersion []string `json:"other_version"` // containers created from another image
+	} `json:"image"`
+	Problems []string `json:"problems"` // red states; non-empty means exit 1
+}
+
+// tokenExpiringWindow is when the host token is reported as expiring.
+const tokenExpiringWindow = 24 * time.Hour
+
+// collectStatus gathers the report. Each section degrades on its own, so a
+// failing docker call does not hide the daemon or token state.
+func collectStatus(ctx context.Context) statusReport {
+	var r statusReport
+	r.Containers.ByState = map[string]int{}
+	r.Containers.NeedsAttention = []string{}
+	r.Image.OtherVersion = []string{}
+	r.Problems = []string{}
+
+	// Daemon
+	if running, info := isDaemonRunning(); running {
+		r.Daemon.Running = true
+		r.Daemon.PID = info.PID
+		callCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		if status, err := api.Call(callCtx, newDaemonClient(info), api.GetStatus, nil); err == nil {
+			r.Daemon.Uptime = status.Uptime
+		}
+		cancel()
+	} else if config.Daemon.TokenRefresh.Enabled {
+		r.Problems = append(r.Problems, "daemon is not running but token refresh is enabled")
+	}
+
+	// Host token
+	if config.AWS.Enabled || config.Bedrock.Enabled {
+		r.Token.State = "aws"
+		r.Token.Detail = "AWS authentication, no Claude token"
+	} else {
+		credPath := filepath.Join(expandPath(config.Claude.AuthPath), ".credentials.json")
+		creds, err := container.ReadCredentials(credPath)
+		switch {
+		case err != nil:
+			r.Token.State = "missing"
+			r.Token.Detail = "run 'maestro auth'"
+		default:
+			expires := time.UnixMilli(creds.ClaudeAiOauth.ExpiresAt)
+			r.Token.ExpiresAt = &expires
+			r.Token.Detail = container.FormatExpiration(creds)
+			switch remaining := container.TimeUntilExpiration(creds); {
+			case remaining < 0:
+				r.Token.State = "expired"
+				r.Problems = append(r.Problems, "host token has expired")
+			case remaining < tokenExpiringWindow:
+				r.Token.State = "expiring"
+			default:
+				r.Token.State = "valid"
+			}
+		}
+	}
+
+	if !container.IsDockerResponsive() {
+		r.Containers.Error = "Docker is not responding"
+		r.Volumes.Error = r.Containers.Error
+		r.Problems = append(r.Problems, "Docker is not responding")
+		return r
+	}
+
+	// Containers
+	svc := newContainerService()
+	containers, err := svc.ListAll(ctx)
+	svc.Close()
+	if err != nil {
+		r.Containers.Error = err.Error()
+	}
+	for _, c := range container.SortByPriority(containers) {
+		r.Containers.Total++
+		_, state := containerState(c)
+		r.Containers.ByState[state]++
+		if container.NeedsAttention(c) {
+			r.Containers.NeedsAttention = append(r.Containers.NeedsAttention, c.ShortName)
+		}
+	}
+
+	// Volumes
+	if out, err := logging.Command("docker", "system", "df", "-v", "--format", "json").Output(); err != nil {
+		r.Volumes.Error = fmt.Sprintf("docker system df failed: %v", err)
+	} else if count, size, err := parseVolumeUsage(out, config.Containers.Prefix); err != nil {
+		r.Volumes.Error = err.Error()
+	} else {
+		r.Volumes.Count, r.Volumes.Bytes = count, size
+	}
+	if driver := config.Containers.VolumeDriver; driver != "" {
+		r.Volumes.Driver = driver
+		installed, err := container.VolumeDriverInstalled(driver)
+		if err != nil {
+			r.Volumes.DriverError = err.Error()
+		} else if r.Volumes.DriverInstalled = installed; !installed {
+			r.Problems = append(r.Problems, fmt.Sprintf("volume driver %s is not installed", driver))
+		}
+	}
+
+	// Image
+	expected, err := containerImage(false)
+	if err != nil {
+		expected = getDockerImage()
+	}
+	r.Image.Expected = expected
+	r.Image.Present = imageExists(expected)
+	r.Image.OtherVersion = containersOnOtherImages()
+
+	return r
+}
+
+// containersOnOtherImages lists maestro containers created from an image
+// other than the one this binary would use for new containers.
+func containersOnOtherImages() []string {
+	current := map[string]bool{}
+	for _, image := range currentImages() {
+		current[image] = true
+	}
+
+	prefix := config.Containers.Prefix
+	out, err := logging.Command("docker", "ps", "-a", "--filter", "name="+prefix, "--format", "{{.Names}}\t{{.Image}}").Output()
+	if err != nil {
+		return []string{}
+	}
+	others := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name, image, ok := strings.Cut(line, "\t")
+		if !ok || !strings.HasPrefix(name, prefix) || container.IsInfraContainer(name) {
+			continue
+		}
+		if !current[image] {
+			others = append(others, container.GetShortName(name, prefix))
+		}
+	}
+	sort.Strings(others)
+	return others
+}
+
+// parseVolumeUsage sums the volumes whose names start with prefix in the
+// output of 'docker system df -v --format json'.
+func parseVolumeUsage(output []byte, prefix string) (int, int64, error) {
+	sizes, err := container.ParseVolumeSizes(output)
+	if err != nil {
+		return 0, 0, err
+	}
+	count := 0
+	var total int64
+	for name, size := range sizes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		count++
+		total += size
+	}
+	return count, total, nil
+}
+
+var (
+	statusOK   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	statusWarn = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	statusBad  = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	statusDim  = lipgloss.NewStyle().Faint(true)
+)
+
+// printStatus renders the report. lipgloss drops the colors when stdout is
+// not a terminal.
+func printStatus(r statusReport) {
+	line := func(label, value string) {
+		fmt.Printf("%-12s %s\n", label, value)
+	}
+
+	// Daemon
+	switch {
+	case r.Daemon.Running && r.Daemon.Uptime != "":
+		line("Daemon", statusOK.Render(style.Check()+" running")+statusDim.Render(fmt.Sprintf(" (PID %d, up %s)", r.Daemon.PID, r.Daemon.Uptime)))
+	case r.Daemon.Running:
+		line("Daemon", statusOK.Render(style.Check()+" running")+statusDim.Render(fmt.Sprintf(" (PID %d)", r.Daemon.PID)))
+	case config.Daemon.TokenRefresh.Enabled:
+		line("Daemon", statusBad.Render(style.Cross()+" not running")+" - start with 'maestro daemon start'")
+	default:
+		line("Daemon", statusWarn.Render("○ not running"))
+	}
+
+	// Token
+	switch r.Token.State {
+	case "valid":
+		line("Token", statusOK.Render(style.Check()+" "+r.Token.Detail))
+	case "expiring":
+		line("Token", statusWarn.Render(style.Warning()+" "+r.Token.Detail)+" - run 'maestro refresh-tokens'")
+	case "expired":
+		line("Token", statusBad.Render(style.Cross()+" "+r.Token.Detail)+" - run 'maestro auth'")
+	case "missing":
+		line("Token", statusWarn.Render(style.Warning()+" no credentials")+" - run 'maestro auth'")
+	default:
+		line("Token", statusDim.Render(r.Token.Detail))
+	}
+
+	// Containers
+	if r.Containers.Error != "" {
+		line("Containers", statusBad.Render(style.Cross()+" "+r.Containers.Error))
+	} else if r.Containers.Total == 0 {
+		line("Containers", statusDim.Render("none"))
+	} else {
+		states := make([]string, 0, len(r.Containers.ByState))
+		for state, n := range r.Containers.ByState {
+			states = append(states, fmt.Sprintf("%d %s", n, state))
+		}
+		sort.Strings(states)
+		line("Containers", fmt.Sprintf("%d total: %s", r.Containers.Total, strings.Join(states, ", ")))
+	}
+	if len(r.Containers.NeedsAttention) > 0 {
+		line("Attention", statusWarn.Render("🔔 "+strings.Join(r.Containers.NeedsAttention, ", ")))
+	}
+
+	// Volumes
+	if r.Volumes.Error != "" {
+		line("Volumes", statusDim.Render("unknown ("+r.Volumes.Error+")"))
+	} else {
+		line("Volumes", fmt.Sprintf("%s in %d volume(s)", formatBytes(r.Volumes.Bytes), r.Volumes.Count))
+	}
+	if r.Volumes.Driver != "" {
+		switch {
+		case r.Volumes.DriverError != "":
+			line("", statusDim.Render("driver "+r.Volumes.Driver+" unknown ("+r.Volumes.DriverError+")"))
+		case r.Volumes.DriverInstalled:
+			line("", statusDim.Render("driver "+r.Volumes.Driver))
+		default:
+			line("", statusBad.Render(style.Cross()+" volume driver "+r.Volumes.Driver+" not installed")+" - see 'docker plugin ls'")
+		}
+	}
+
+	// Image
+	switch {
+	case r.Image.Expected == "":
+	case !r.Image.Present:
+		line("Image", statusWarn.Render(style.Warning()+" "+r.Image.Expected+" not pulled yet")+statusDim.Render(" (fetched on next 'maestro new')"))
+	default:
+		line("Image", statusOK.Render(style.Check()+" "+r.Image.Expected))
+	}
+	if n := len(r.Image.OtherVersion); n > 0 {
+		line("", statusWarn.Render(fmt.Sprintf("%s %d container(s) on another image: %s", style.Warning(), n, strings.Join(r.Image.OtherVersion, ", "))))
+	}
+}
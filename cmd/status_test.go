@@ -0,0 +1,36 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "testing"
+
+func TestParseVolumeUsage(t *testing.T) {
+	output := []byte(`{"Images":[],"Containers":[],"Volumes":[
+		{"Name":"maestro-feat-1-npm","Links":"1","Size":"1.5GB"},
+		{"Name":"maestro-feat-1-history","Links":"1","Size":"10kB"},
+		{"Name":"postgres-data","Links":"1","Size":"3GB"}
+	],"BuildCache":[]}`)
+	count, size, err := parseVolumeUsage(output, "maestro-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || size != 1500010000 {
+		t.Errorf("got %d volumes, %d bytes; want 2, 1500010000", count, size)
+	}
+
+	if _, _, err := parseVolumeUsage([]byte("not json"), "maestro-"); err == nil {
+		t.Error("expected error for unparseable output")
+	}
+}
@@ -20,31 +20,41 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/containerservice"
 )
 
+var stopAll bool
+
 var stopCmd = &cobra.Command{
 	Use:   "stop [name]",
 	Short: "Stop a running container",
 	Long: `Stop a running maestro container. The container can be restarted later.
 
-If no name is provided, will prompt to stop all dormant containers (where Claude is not running).`,
+If no name is provided, will prompt to stop all dormant containers (where Claude is not running).
+With --all, prompts to stop every running container. Containers are stopped a
+few at a time.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStop,
 }
 
 func init() {
 	rootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().BoolVarP(&stopAll, "all", "a", false, "Stop all running containers")
 }
 
 func runStop(cmd *cobra.Command, args []string) error {
-	// If no arguments, prompt to stop dormant containers
-	if len(args) == 0 {
-		return stopDormantContainers(cmd.Context())
+	if stopAll && len(args) > 0 {
+		return fmt.Errorf("--all can't be combined with a container name")
 	}
 
+	// If no arguments, prompt to stop dormant (or, with --all, all) containers
+	if len(args) == 0 {
+		return stopRunningContainers(cmd.Context(), stopAll)
+	}
 	// Stop specific container via ContainerService
 	svc := newContainerService()
 	defer svc.Close()
@@ -66,7 +76,9 @@ func runStop(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func stopDormantContainers(ctx context.Context) error {
+// stopRunningContainers prompts to stop the dormant containers, or every
+// running one if all is set.
+func stopRunningContainers(ctx context.Context, all bool) error {
 	svc := newContainerService()
 	defer svc.Close()
 
@@ -77,27 +89,33 @@ func stopDormantContainers(ctx context.Context) error {
 	}
 
 	// Filter for dormant containers
-	var dormantContainers []container.Info
+	var toStop []container.Info
 	for _, c := range containers {
-		if c.IsDormant {
-			dormantContainers = append(dormantContainers, c)
+		if all || c.IsDormant {
+			toStop = append(toStop, c)
 		}
 	}
 
-	if len(dormantContainers) == 0 {
-		fmt.Println("No dormant containers found.")
-		fmt.Println("(Dormant = containers where Claude is not running)")
+	what := "dormant"
+	if all {
+		what = "running"
+	}
+	if len(toStop) == 0 {
+		fmt.Printf("No %s containers found.\n", what)
+		if !all {
+			fmt.Println("(Dormant = containers where Claude is not running)")
+		}
 		return nil
 	}
 
-	// Display dormant containers
-	fmt.Printf("Found %d dormant container(s):\n", len(dormantContainers))
-	for _, c := range dormantContainers {
+	// Display the containers
+	fmt.Printf("Found %d %s container(s):\n", len(toStop), what)
+	for _, c := range toStop {
 		fmt.Printf("  - %s (branch: %s)\n", c.ShortName, c.Branch)
 	}
 
 	// Prompt for confirmation
-	fmt.Print("\nStop all dormant containers? (y/N): ")
+	fmt.Printf("\nStop all %s containers? (y/N): ", what)
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
 	if err != nil {
@@ -110,35 +128,62 @@ func stopDormantContainers(ctx context.Context) error {
 		return nil
 	}
 
-	// Stop all dormant containers via ContainerService
-	// Use state hash from the list call for optimistic concurrency
-	stateHash := svc.StateHash()
-	fmt.Println("\nStopping dormant containers...")
-	successCount := 0
-	for _, c := range dormantContainers {
-		fmt.Printf("  Stopping %s... ", c.ShortName)
-		if err := svc.StopContainer(ctx, c.Name, stateHash); err != nil {
-			if isStateHashMismatch(err) {
-				fmt.Printf("FAILED: container state changed — re-run 'maestro stop'\n")
-				break
-			}
-			fmt.Printf("FAILED: %v\n", err)
-			continue
-		}
-		fmt.Println("done")
-		successCount++
-		// Clear state hash after first mutation — subsequent stops go through
-		// without validation since the state has already changed
-		stateHash = ""
+	fmt.Printf("\nStopping %s containers...\n", what)
+	successCount, err := stopContainers(ctx, svc, toStop)
+	if err != nil {
+		return err
 	}
 
-	if successCount == len(dormantContainers) {
+	if successCount == len(toStop) {
 		fmt.Printf("\nSuccessfully stopped %d container(s)\n", successCount)
 	} else {
-		fmt.Printf("\nStopped %d/%d container(s)\n", successCount, len(dormantContainers))
+		fmt.Printf("\nStopped %d/%d container(s)\n", successCount, len(toStop))
 	}
 
 	fmt.Println("\nTo remove stopped containers, run: maestro cleanup")
 
 	return nil
 }
+
+// stopContainers stops containers via ContainerService, a few at a time, and
+// returns how many stopped. The first stop carries the state hash from the
+// list call for optimistic concurrency; once it has changed the state, the
+// rest go through without validation.
+func stopContainers(ctx context.Context, svc containerservice.ContainerService, containers []container.Info) (int, error) {
+	stopped := 0
+	if err := svc.StopContainer(ctx, containers[0].Name, svc.StateHash()); err != nil {
+		if isStateHashMismatch(err) {
+			return 0, fmt.Errorf("container state changed — re-run 'maestro stop'")
+		}
+		fmt.Printf("  %s: FAILED: %v\n", containers[0].ShortName, err)
+	} else {
+		fmt.Printf("  Stopped %s\n", containers[0].ShortName)
+		stopped++
+	}
+
+	var mu sync.Mutex
+	rest := containers[1:]
+	names := make([]string, len(rest))
+	for i, c := range rest {
+		names[i] = c.Name
+	}
+	errs := container.Bulk(ctx, names, container.DefaultBulkConcurrency, func(ctx context.Context, name string) error {
+		err := svc.StopContainer(ctx, name, "")
+		shortName := container.GetShortName(name, config.Containers.Prefix)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			fmt.Printf("  %s: FAILED: %v\n", shortName, err)
+		} else {
+			fmt.Printf("  Stopped %s\n", shortName)
+		}
+		return err
+	})
+
+	for _, err := range errs {
+		if err == nil {
+			stopped++
+		}
+	}
+	return stopped, nil
+}
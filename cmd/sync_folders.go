@@ -0,0 +1,232 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// syncFolder is one sync.additional_folders entry. Entries are either a plain
+// host path, copied next to the workspace under its base name, or a map:
+//
+//	source: ~/datasets/foo
+//	dest: /data/foo
+//	exclude: [.cache, "*.tmp"]
+type syncFolder struct {
+	Source  string   // Host path as configured (~ allowed)
+	Dest    string   // Absolute path inside the container ("" = next to the workspace)
+	Exclude []string // tar --exclude patterns, relative to Source
+}
+
+// parseSyncFolder interprets a raw sync.additional_folders value.
+func parseSyncFolder(value any) (syncFolder, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return syncFolder{}, fmt.Errorf("empty path")
+		}
+		return syncFolder{Source: strings.TrimSpace(v)}, nil
+	case map[string]any:
+		var f syncFolder
+		for key, raw := range v {
+			switch key {
+			case "source", "dest":
+				s, ok := raw.(string)
+				if !ok {
+					return syncFolder{}, fmt.Errorf("%s must be a string", key)
+				}
+				if key == "source" {
+					f.Source = strings.TrimSpace(s)
+				} else {
+					f.Dest = strings.TrimSpace(s)
+				}
+			case "exclude":
+				patterns, err := stringList(raw)
+				if err != nil {
+					return syncFolder{}, fmt.Errorf("exclude: %w", err)
+				}
+				f.Exclude = patterns
+			default:
+				return syncFolder{}, fmt.Errorf("unknown key %q", key)
+			}
+		}
+		if f.Source == "" {
+			return syncFolder{}, fmt.Errorf("source is required")
+		}
+		if f.Dest != "" && !container.ValidWorkspacePath(f.Dest) {
+			return syncFolder{}, fmt.Errorf("dest %q must be a clean absolute path", f.Dest)
+		}
+		return f, nil
+	default:
+		return syncFolder{}, fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+// stringList converts a YAML list (or a single string) to []string.
+func stringList(raw any) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%v is not a string", item)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected a list of strings")
+	}
+}
+
+// destination returns where the folder lands in the container.
+func (f syncFolder) destination() string {
+	if f.Dest != "" {
+		return f.Dest
+	}
+	return path.Join(path.Dir(workspaceDir()), filepath.Base(expandPath(f.Source)))
+}
+
+// resolveSyncFolders parses sync.additional_folders, warning about and
+// skipping invalid or missing entries.
+func resolveSyncFolders() []syncFolder {
+	var folders []syncFolder
+	for _, raw := range config.Sync.AdditionalFolders {
+		f, err := parseSyncFolder(raw)
+		if err != nil {
+			logging.Warnf("Ignoring sync.additional_folders entry %v: %v", raw, err)
+			continue
+		}
+		if _, err := os.Stat(expandPath(f.Source)); err != nil {
+			logging.Infof("Skipping %s (not found)", f.Source)
+			continue
+		}
+		folders = append(folders, f)
+	}
+	return folders
+}
+
+// syncedFolderLabels describes folders for the maestro.synced_folders label.
+func syncedFolderLabels(folders []syncFolder) []container.SyncedFolder {
+	labels := make([]container.SyncedFolder, 0, len(folders))
+	for _, f := range folders {
+		labels = append(labels, container.SyncedFolder{Source: f.Source, Dest: f.destination()})
+	}
+	return labels
+}
+
+func copyAdditionalFolders(containerName string, folders []syncFolder) error {
+	for _, f := range folders {
+		dest := f.destination()
+		logging.Infof("Copying %s to %s...", f.Source, dest)
+
+		startTime := time.Now()
+		size, err := copySyncFolder(containerName, f, dest)
+		if err != nil {
+			logging.Warnf("Failed to copy %s: %v", f.Source, err)
+			continue
+		}
+		duration := time.Since(startTime)
+		speed := float64(size) / duration.Seconds() / 1024 / 1024
+		logging.Infof("  Copied %s in %.1fs (%.1f MB/s)", formatBytes(size), duration.Seconds(), speed)
+	}
+	return nil
+}
+
+// copySyncFolder streams one folder into the container through tar so exclude
+// patterns apply, and returns the number of bytes transferred. Single files
+// are copied with docker cp.
+func copySyncFolder(containerName string, f syncFolder, dest string) (int64, error) {
+	source := expandPath(f.Source)
+	info, err := os.Stat(source)
+	if err != nil {
+		return 0, err
+	}
+
+	if !info.IsDir() {
+		mkdirCmd := logging.Command("docker", "exec", "-u", "root", containerName, "mkdir", "-p", path.Dir(dest))
+		if err := logging.Run(mkdirCmd); err != nil {
+			return 0, fmt.Errorf("failed to create %s: %w", path.Dir(dest), err)
+		}
+		cpCmd := logging.Command("docker", "cp", source, fmt.Sprintf("%s:%s", containerName, dest))
+		if output, err := cpCmd.CombinedOutput(); err != nil {
+			return 0, fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+		}
+		chownCmd := logging.Command("docker", "exec", "-u", "root", containerName, "chown", "node:node", dest)
+		if err := logging.Run(chownCmd); err != nil {
+			logging.Warnf("Failed to fix ownership of %s: %v", dest, err)
+		}
+		return info.Size(), nil
+	}
+
+	useCompression := config.Sync.Compress == nil || *config.Sync.Compress
+	createFlag, extractFlag := "-cf", "-xf"
+	if useCompression {
+		createFlag, extractFlag = "-czf", "-xzf"
+	}
+
+	tarArgs := []string{createFlag, "-"}
+	for _, pattern := range f.Exclude {
+		tarArgs = append(tarArgs, "--exclude="+pattern)
+	}
+	tarArgs = append(tarArgs, ".")
+	tarCmd := exec.Command("tar", tarArgs...)
+	tarCmd.Dir = source
+
+	// dest is validated by parseSyncFolder or derived from the workspace, so it
+	// is safe to interpolate
+	extractCmd := logging.Command("docker", "exec", "-i", "-u", "root", containerName, "sh", "-c",
+		fmt.Sprintf("mkdir -p %s && tar %s - -C %s && chown -R node:node %s", dest, extractFlag, dest, dest))
+
+	pipe, err := tarCmd.StdoutPipe()
+	if err != nil {
+		return 0, err
+	}
+	pr := &progressReader{reader: pipe}
+	extractCmd.Stdin = pr
+
+	if err := tarCmd.Start(); err != nil {
+		return 0, err
+	}
+	if err := extractCmd.Start(); err != nil {
+		tarCmd.Process.Kill()
+		tarCmd.Wait()
+		return 0, err
+	}
+
+	tarErr := tarCmd.Wait()
+	extractErr := extractCmd.Wait()
+	if tarErr != nil {
+		return 0, fmt.Errorf("tar failed: %w", tarErr)
+	}
+	if extractErr != nil {
+		return 0, fmt.Errorf("extract failed: %w", extractErr)
+	}
+	return pr.getBytesRead(), nil
+}
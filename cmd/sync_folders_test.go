@@ -0,0 +1,67 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSyncFolder(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    syncFolder
+		wantErr bool
+	}{
+		{"plain path", "~/Code/shared-libs", syncFolder{Source: "~/Code/shared-libs"}, false},
+		{"structured", map[string]any{
+			"source":  "~/datasets/foo",
+			"dest":    "/data/foo",
+			"exclude": []any{".cache", "*.tmp"},
+		}, syncFolder{Source: "~/datasets/foo", Dest: "/data/foo", Exclude: []string{".cache", "*.tmp"}}, false},
+		{"single exclude string", map[string]any{"source": "~/x", "exclude": ".cache"},
+			syncFolder{Source: "~/x", Exclude: []string{".cache"}}, false},
+		{"missing source", map[string]any{"dest": "/data/foo"}, syncFolder{}, true},
+		{"relative dest", map[string]any{"source": "~/x", "dest": "data/foo"}, syncFolder{}, true},
+		{"unsafe dest", map[string]any{"source": "~/x", "dest": "/data/$(rm)"}, syncFolder{}, true},
+		{"unknown key", map[string]any{"source": "~/x", "target": "/y"}, syncFolder{}, true},
+		{"empty string", " ", syncFolder{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSyncFolder(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSyncFolder() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncFolderDestination(t *testing.T) {
+	saved := config
+	defer func() { config = saved }()
+	config = &Config{}
+
+	if got := (syncFolder{Source: "/home/me/shared-libs"}).destination(); got != "/shared-libs" {
+		t.Errorf("default destination = %q, want /shared-libs", got)
+	}
+	if got := (syncFolder{Source: "/home/me/foo", Dest: "/data/foo"}).destination(); got != "/data/foo" {
+		t.Errorf("explicit destination = %q, want /data/foo", got)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
+	"github.com/uprockcom/maestro/pkg/notify"
+)
+
+// taskWatchDebounce is how long the task file must stay unchanged after a
+// write before it is read, so a file written in several chunks (or rewritten
+// in quick succession) creates one container.
+const taskWatchDebounce = time.Second
+
+// taskFileWatch creates containers from a task file as it is written.
+type taskFileWatch struct {
+	path     string
+	debounce time.Duration
+	loop     bool                    // keep watching after the first creation
+	create   func(task string) error // called with the file's contents
+}
+
+// runTaskFileWatch implements 'maestro new --task-file-watch'.
+func runTaskFileWatch(cmd *cobra.Command, file string, loop bool) error {
+	w := taskFileWatch{
+		path:     file,
+		debounce: taskWatchDebounce,
+		loop:     loop,
+		create: func(task string) error {
+			fmt.Printf("%s changed, creating container for: %s\n", file, truncateString(task, 80))
+			containerName, _, err := createNewContainer(cmd, task)
+			if err != nil {
+				return err
+			}
+			// The name on a line of its own, for scripts reading our output
+			fmt.Println(containerName)
+			notifyTaskFileContainer(containerName, file)
+			return nil
+		},
+	}
+	if loop {
+		fmt.Printf("Watching %s; a container is created on every write (Ctrl+C to stop)\n", file)
+	} else {
+		fmt.Printf("Watching %s; a container is created on the next write\n", file)
+	}
+	return w.run(cmd.Context())
+}
+
+// run watches the task file until ctx is done, the first container is created,
+// or, with loop set, indefinitely. The parent directory is watched rather than
+// the file itself, so the watch survives the file being deleted, recreated or
+// replaced by a rename.
+func (w taskFileWatch) run(ctx context.Context) error {
+	path, err := filepath.Abs(w.path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", w.path, err)
+	}
+	dir := filepath.Dir(path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	var settle <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Name == dir && event.Has(fsnotify.Remove) {
+				return fmt.Errorf("%s was removed while watching %s", dir, w.path)
+			}
+			if event.Name != path {
+				continue
+			}
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				logging.Warnf("%s was removed; waiting for it to be written again", w.path)
+				settle = nil
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+				settle = time.After(w.debounce)
+			}
+
+		case <-settle:
+			settle = nil
+			content, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				logging.Warnf("%s was removed; waiting for it to be written again", w.path)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read task file: %w", err)
+			}
+			task := strings.TrimSpace(string(content))
+			if task == "" {
+				logging.Warnf("%s is empty; waiting for a task", w.path)
+				continue
+			}
+			if err := w.create(task); err != nil {
+				if !w.loop {
+					return err
+				}
+				logging.Warnf("Failed to create container: %v", err)
+				continue
+			}
+			if !w.loop {
+				return nil
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logging.Warnf("File watcher error: %v", err)
+		}
+	}
+}
+
+// notifyTaskFileContainer sends a desktop notification for a container created
+// by --task-file-watch when daemon.notifications.enabled is set.
+func notifyTaskFileContainer(containerName, file string) {
+	if !config.Daemon.Notifications.Enabled {
+		return
+	}
+	hasTerminalNotifier := false
+	if runtime.GOOS == "darwin" {
+		_, err := exec.LookPath("terminal-notifier")
+		hasTerminalNotifier = err == nil
+	}
+	provider := notify.NewDesktopProvider("", hasTerminalNotifier)
+	if !provider.Available() {
+		return
+	}
+	shortName := container.GetShortName(containerName, config.Containers.Prefix)
+	if err := provider.Send(context.Background(), notify.Event{
+		ContainerName: containerName,
+		ShortName:     shortName,
+		Title:         "Maestro",
+		Message:       fmt.Sprintf("Created %s from %s", shortName, filepath.Base(file)),
+		Type:          notify.EventContainerNotification,
+		Timestamp:     time.Now(),
+	}); err != nil {
+		logging.Warnf("Failed to send notification: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// keepWriting writes content to path until done is closed, so the test does
+// not race the watcher being set up.
+func keepWriting(t *testing.T, path, content string, done <-chan struct{}) {
+	t.Helper()
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				os.WriteFile(path, []byte(content), 0644)
+			}
+		}
+	}()
+}
+
+func TestTaskFileWatch_CreatesOnceOnWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TASK.md")
+
+	var tasks []string
+	w := taskFileWatch{
+		path:     path,
+		debounce: 100 * time.Millisecond,
+		create: func(task string) error {
+			tasks = append(tasks, task)
+			return nil
+		},
+	}
+
+	done := make(chan struct{})
+	keepWriting(t, path, "  fix the flaky test\n", done)
+	// Writes every 50ms keep resetting the debounce, so stop them and let
+	// the file settle
+	time.AfterFunc(500*time.Millisecond, func() { close(done) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := w.run(ctx); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0] != "fix the flaky test" {
+		t.Errorf("tasks = %q, want one trimmed task", tasks)
+	}
+}
+
+func TestTaskFileWatch_LoopSurvivesDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TASK.md")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	created := make(chan string, 10)
+	w := taskFileWatch{
+		path:     path,
+		debounce: 20 * time.Millisecond,
+		loop:     true,
+		create: func(task string) error {
+			created <- task
+			return nil
+		},
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- w.run(ctx) }()
+
+	for _, task := range []string{"first", "second"} {
+		done := make(chan struct{})
+		keepWriting(t, path, task, done)
+		select {
+		case got := <-created:
+			if got != task {
+				t.Errorf("created %q, want %q", got, task)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %q", task)
+		}
+		close(done)
+		time.Sleep(100 * time.Millisecond)
+		os.Remove(path)
+		// Drain creations from writes that raced the removal
+		time.Sleep(100 * time.Millisecond)
+		for len(created) > 0 {
+			<-created
+		}
+	}
+
+	cancel()
+	if err := <-errc; err != nil {
+		t.Errorf("run: %v", err)
+	}
+}
+
+func TestTaskFileWatch_SkipsEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TASK.md")
+
+	calls := 0
+	w := taskFileWatch{
+		path:     path,
+		debounce: 20 * time.Millisecond,
+		create: func(task string) error {
+			calls++
+			return nil
+		},
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	keepWriting(t, path, "\n", done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := w.run(ctx); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("create called %d times for an empty file", calls)
+	}
+}
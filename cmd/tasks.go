@@ -24,12 +24,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
 var (
 	tasksWatch    bool
 	tasksInterval int
-	tasksVerbose  bool
+	tasksAll      bool
 )
 
 var tasksCmd = &cobra.Command{
@@ -44,7 +45,7 @@ Examples:
   maestro tasks              # Show tasks for all running containers
   maestro tasks my-feature   # Show tasks for specific container
   maestro tasks --watch      # Continuously update task display
-  maestro tasks -v           # Show verbose task details`,
+  maestro tasks --all        # Show every task of every session`,
 	RunE: runTasks,
 }
 
@@ -52,10 +53,17 @@ func init() {
 	rootCmd.AddCommand(tasksCmd)
 	tasksCmd.Flags().BoolVarP(&tasksWatch, "watch", "w", false, "Continuously watch and update task display")
 	tasksCmd.Flags().IntVarP(&tasksInterval, "interval", "i", 2, "Update interval in seconds (with --watch)")
-	tasksCmd.Flags().BoolVarP(&tasksVerbose, "verbose", "v", false, "Show verbose task details")
+	tasksCmd.Flags().BoolVarP(&tasksAll, "all", "a", false, "Show every task of every session")
 }
 
 func runTasks(cmd *cobra.Command, args []string) error {
+	// -v showed every task before it became the global --verbose; keep
+	// that working for now
+	if flagVerbose && !tasksAll {
+		fmt.Fprintln(os.Stderr, "Note: 'maestro tasks -v' showing every task is deprecated; use --all (-a)")
+		tasksAll = true
+	}
+
 	// Check if Docker is responsive
 	if !container.IsDockerResponsive() {
 		fmt.Println("Cannot connect to Docker.")
@@ -115,7 +123,7 @@ func displayContainerTasks(ct *container.ContainerTasks) {
 	fmt.Printf("📦 %s\n", ct.ShortName)
 
 	if ct.Error != nil {
-		fmt.Printf("   ⚠️  %v\n", ct.Error)
+		fmt.Printf("   %s  %v\n", style.Warning(), ct.Error)
 		return
 	}
 
@@ -148,16 +156,16 @@ func displayContainerTasks(ct *container.ContainerTasks) {
 			fmt.Printf("   ▶ %s\n", currentTask)
 		}
 
-		// Verbose: show all tasks
-		if tasksVerbose {
+		// --all: show every task
+		if tasksAll {
 			fmt.Println()
 			for _, task := range session.Tasks {
 				displayTask(task, "   ")
 			}
 		}
 
-		// Only show most recent session unless verbose
-		if !tasksVerbose {
+		// Only show most recent session unless --all
+		if !tasksAll {
 			break
 		}
 	}
@@ -167,11 +175,11 @@ func displayTask(task container.Task, indent string) {
 	var statusIcon string
 	switch task.Status {
 	case container.TaskStatusCompleted:
-		statusIcon = "✓"
+		statusIcon = style.Check()
 	case container.TaskStatusInProgress:
-		statusIcon = "▶"
+		statusIcon = style.Glyph("▶", ">")
 	case container.TaskStatusPending:
-		statusIcon = "○"
+		statusIcon = style.Glyph("○", "o")
 	default:
 		statusIcon = "?"
 	}
@@ -0,0 +1,117 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// tokenExpiringWithin is how close to expiry a token is reported as expiring,
+// the same 24h GetAuthStatus and refresh-tokens warn at.
+const tokenExpiringWithin = 24 * time.Hour
+
+var tokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Show when each running container's auth token expires",
+	Long: `Read the Claude credentials of every running container and list when each
+token expires, soonest first, as ok, expiring (within 24h) or expired.
+
+Run 'maestro refresh-tokens' to sync the freshest token to every container,
+or 'maestro auth' if they have all expired.`,
+	Args: cobra.NoArgs,
+	RunE: runTokens,
+}
+
+func init() {
+	rootCmd.AddCommand(tokensCmd)
+}
+
+// containerToken is one row of 'maestro tokens'.
+type containerToken struct {
+	name  string
+	creds *container.Credentials // nil if they couldn't be read
+}
+
+// tokenStatus classifies a token by the time it has left.
+func tokenStatus(timeLeft time.Duration) string {
+	switch {
+	case timeLeft <= 0:
+		return "expired"
+	case timeLeft < tokenExpiringWithin:
+		return "expiring"
+	default:
+		return "ok"
+	}
+}
+
+// sortTokens orders rows by expiry, soonest first, with unreadable
+// credentials last.
+func sortTokens(tokens []containerToken) {
+	sort.SliceStable(tokens, func(i, j int) bool {
+		a, b := tokens[i].creds, tokens[j].creds
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.ClaudeAiOauth.ExpiresAt < b.ClaudeAiOauth.ExpiresAt
+	})
+}
+
+func runTokens(cmd *cobra.Command, args []string) error {
+	containers, err := container.GetRunningContainers(config.Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	if len(containers) == 0 {
+		fmt.Println("No running containers")
+		return nil
+	}
+
+	tokens := make([]containerToken, 0, len(containers))
+	for _, c := range containers {
+		creds, _ := container.ReadContainerCredentials(c.Name)
+		tokens = append(tokens, containerToken{name: c.ShortName, creds: creds})
+	}
+	sortTokens(tokens)
+
+	needsRefresh := false
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTAINER\tEXPIRES\tTOKEN\tSTATUS")
+	fmt.Fprintln(w, "---------\t-------\t-----\t------")
+	for _, t := range tokens {
+		if t.creds == nil {
+			fmt.Fprintf(w, "%s\t-\t-\tno credentials\n", t.name)
+			continue
+		}
+		status := tokenStatus(container.TimeUntilExpiration(t.creds))
+		if status != "ok" {
+			needsRefresh = true
+		}
+		expires := time.UnixMilli(t.creds.ClaudeAiOauth.ExpiresAt).Local().Format("Jan 2 15:04")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.name, expires, container.FormatExpiration(t.creds), status)
+	}
+	w.Flush()
+
+	if needsRefresh {
+		fmt.Println("\nRun 'maestro refresh-tokens' to sync the freshest token to every container.")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestTokenStatus(t *testing.T) {
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{-time.Hour, "expired"},
+		{0, "expired"},
+		{time.Hour, "expiring"},
+		{23 * time.Hour, "expiring"},
+		{24 * time.Hour, "ok"},
+		{72 * time.Hour, "ok"},
+	}
+	for _, tt := range tests {
+		if got := tokenStatus(tt.left); got != tt.want {
+			t.Errorf("tokenStatus(%s) = %q, want %q", tt.left, got, tt.want)
+		}
+	}
+}
+
+func TestSortTokens(t *testing.T) {
+	creds := func(expiresAt int64) *container.Credentials {
+		c := &container.Credentials{}
+		c.ClaudeAiOauth.ExpiresAt = expiresAt
+		return c
+	}
+	tokens := []containerToken{
+		{name: "late", creds: creds(300)},
+		{name: "none"},
+		{name: "soon", creds: creds(100)},
+		{name: "mid", creds: creds(200)},
+	}
+	sortTokens(tokens)
+
+	var got []string
+	for _, tok := range tokens {
+		got = append(got, tok.name)
+	}
+	want := []string{"soon", "mid", "late", "none"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
@@ -16,11 +16,11 @@ package cmd
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
 var updateCmd = &cobra.Command{
@@ -53,7 +53,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if container is running
-	checkCmd := exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
+	checkCmd := logging.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
 	output, err := checkCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to check container status: %w", err)
@@ -18,14 +18,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/uprockcom/maestro/pkg/api"
+	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/containerservice"
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/tui/style"
 	"github.com/uprockcom/maestro/pkg/update"
 )
 
@@ -109,26 +111,121 @@ func showUpdateWarning(prefetched ...*api.StatusResponse) {
 // showUpdateFromStatus prints the update warning from a daemon status response.
 func showUpdateFromStatus(status *api.StatusResponse) {
 	if status.Update != nil && status.Update.Available {
-		fmt.Printf("\n⚠️  Update available: %s → %s\n", status.Update.CurrentVersion, status.Update.LatestVersion)
-		fmt.Println("   Run: brew upgrade maestro  (or download from GitHub)")
+		fmt.Printf("\n%s  Update available: %s → %s\n", style.Warning(), status.Update.CurrentVersion, status.Update.LatestVersion)
+		fmt.Println("   Run: maestro self-update  (or brew upgrade maestro)")
 		if status.Update.ReleaseURL != "" {
 			fmt.Printf("   %s\n", status.Update.ReleaseURL)
 		}
 	}
 }
 
-// generateTmuxConfig creates a tmux configuration string with true color support
-func generateTmuxConfig(containerName, branchName string) string {
+// tmuxPrefix returns the configured tmux prefix key (tmux.prefix), falling
+// back to C-b when unset or when the value cannot be a single tmux key.
+func tmuxPrefix() string {
+	if config == nil {
+		return container.DefaultTmuxPrefix
+	}
+	prefix, valid := container.TmuxPrefix(config.Tmux.Prefix)
+	if !valid {
+		logging.Warnf("invalid tmux.prefix %q, using %s", config.Tmux.Prefix, prefix)
+	}
+	return prefix
+}
+
+// tmuxSessionName returns the configured tmux session name for new
+// containers (tmux.default_session), falling back to "main" when unset or
+// invalid. Existing containers keep the name recorded in their label; use
+// container.TmuxSession for those.
+func tmuxSessionName() string {
+	if config == nil || !container.ValidTmuxSessionName(config.Tmux.DefaultSession) {
+		return container.DefaultTmuxSession
+	}
+	return config.Tmux.DefaultSession
+}
+
+// workspaceDir returns the configured project root for new containers
+// (containers.workspace), falling back to /workspace when unset or invalid.
+// Existing containers keep the root recorded in their label; use
+// container.WorkspaceRoot for those.
+func workspaceDir() string {
+	if config == nil || !container.ValidWorkspacePath(config.Containers.Workspace) {
+		return container.DefaultWorkspace
+	}
+	return config.Containers.Workspace
+}
+
+// containerShell returns the configured interactive shell for new containers
+// (containers.shell). Unset means zsh; shells the image does not provide fall
+// back to sh.
+func containerShell() string {
+	if config == nil || config.Containers.Shell == "" {
+		return container.DefaultShell
+	}
+	if !container.SupportedShell(config.Containers.Shell) {
+		return "sh"
+	}
+	return config.Containers.Shell
+}
+
+// printTmuxHints prints the detach and window-switch key hints for the
+// configured tmux prefix.
+func printTmuxHints() {
+	prefix := container.FormatTmuxKey(tmuxPrefix())
+	fmt.Printf("Detach with: %s d\n", prefix)
+	fmt.Printf("Switch windows: %s 0 (Claude), %s 1 (shell)\n", prefix, prefix)
+}
+
+// tmuxConfigPath is where the generated tmux config is written in containers.
+const tmuxConfigPath = "/home/node/.tmux.conf"
+
+// writeTmuxConfig writes the tmux config for a container. The config goes
+// over stdin rather than a shell heredoc, so no branch name can end it early.
+func writeTmuxConfig(containerName, branchName string) error {
+	writeCmd := logging.Command("docker", "exec", "-i", containerName, "sh", "-c", "cat > "+tmuxConfigPath)
+	writeCmd.Stdin = strings.NewReader(generateTmuxConfig(containerName, branchName, tmuxPrefix()) + "\n")
+	return logging.Run(writeCmd)
+}
+
+// tmuxStatusText makes s safe inside a single-quoted tmux status string.
+// Git allows quotes and '#' in branch names, and tmux would run a
+// "#(command)" in the status line, so '#' is escaped and quotes, backslashes
+// and control characters are replaced.
+func tmuxStatusText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '#':
+			b.WriteString("##")
+		case r == '\'' || r == '\\' || r < ' ' || r == 0x7f:
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// generateTmuxConfig creates a tmux configuration string with true color
+// support and the given prefix key
+func generateTmuxConfig(containerName, branchName, prefix string) string {
+	prefixConfig := fmt.Sprintf("set -g prefix %s\nbind %s send-prefix", prefix, prefix)
+	if prefix != container.DefaultTmuxPrefix {
+		prefixConfig = fmt.Sprintf("unbind %s\n%s", container.DefaultTmuxPrefix, prefixConfig)
+	}
+
 	return fmt.Sprintf(`# True color support
 set -g default-terminal "tmux-256color"
 set -ga terminal-overrides ",xterm-256color:Tc"
 set -ga terminal-overrides ",tmux-256color:RGB"
 set -as terminal-features ",*:RGB"
 
+# Prefix key (tmux.prefix in maestro config)
+%s
+
 # Status bar configuration
 set -g status-left '[%s | %s] '
 set -g status-left-length 50
-set -g status-right '%%%%H:%%%%M'`, containerName, branchName)
+set -g status-right '%%%%H:%%%%M'`, prefixConfig, tmuxStatusText(containerName), tmuxStatusText(branchName))
 }
 
 // resolveContainerName resolves a short name or full name to the actual container name
@@ -147,14 +244,14 @@ func resolveContainerName(shortName string) string {
 	fullName := config.Containers.Prefix + shortName
 
 	// Check if this exact name exists
-	checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", fullName), "--format", "{{.Names}}")
+	checkCmd := logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", fullName), "--format", "{{.Names}}")
 	output, err := checkCmd.Output()
 	if err == nil && len(output) > 0 {
 		return strings.TrimSpace(string(output))
 	}
 
 	// Try pattern match (for cases where user omits the number)
-	checkCmd = exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", fullName), "--format", "{{.Names}}")
+	checkCmd = logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", fullName), "--format", "{{.Names}}")
 	output, err = checkCmd.Output()
 	if err == nil && len(output) > 0 {
 		names := strings.Split(string(output), "\n")
@@ -169,14 +266,14 @@ func resolveContainerName(shortName string) string {
 		legacyFullName := "mcl-" + shortName
 
 		// Check exact match with legacy prefix
-		checkCmd = exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", legacyFullName), "--format", "{{.Names}}")
+		checkCmd = logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", legacyFullName), "--format", "{{.Names}}")
 		output, err = checkCmd.Output()
 		if err == nil && len(output) > 0 {
 			return strings.TrimSpace(string(output))
 		}
 
 		// Try pattern match with legacy prefix
-		checkCmd = exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", legacyFullName), "--format", "{{.Names}}")
+		checkCmd = logging.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", legacyFullName), "--format", "{{.Names}}")
 		output, err = checkCmd.Output()
 		if err == nil && len(output) > 0 {
 			names := strings.Split(string(output), "\n")
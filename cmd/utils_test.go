@@ -0,0 +1,104 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateTmuxConfig_Prefix(t *testing.T) {
+	def := generateTmuxConfig("maestro-feat-x-1", "feat/x", "C-b")
+	if !strings.Contains(def, "set -g prefix C-b") {
+		t.Errorf("default config should set prefix C-b:\n%s", def)
+	}
+	if strings.Contains(def, "unbind") {
+		t.Errorf("default config should not unbind the default prefix:\n%s", def)
+	}
+
+	custom := generateTmuxConfig("maestro-feat-x-1", "feat/x", "C-a")
+	for _, want := range []string{"unbind C-b", "set -g prefix C-a", "bind C-a send-prefix"} {
+		if !strings.Contains(custom, want) {
+			t.Errorf("custom config missing %q:\n%s", want, custom)
+		}
+	}
+	if !strings.Contains(custom, "set -g status-right '%%H:%%M'") {
+		t.Errorf("status-right format escaping changed:\n%s", custom)
+	}
+}
+
+func TestGenerateTmuxConfig_EscapesBranch(t *testing.T) {
+	conf := generateTmuxConfig("maestro-fix-1", "fix/it's-#(id)\nEOF", "C-b")
+	want := "set -g status-left '[maestro-fix-1 | fix/it_s-##(id)_EOF] '"
+	if !strings.Contains(conf, want) {
+		t.Errorf("config missing %q:\n%s", want, conf)
+	}
+}
+
+func TestWorkspaceDirAndShell_Fallbacks(t *testing.T) {
+	saved := config
+	defer func() { config = saved }()
+
+	config = &Config{}
+	config.Containers.Workspace = "/srv/app"
+	config.Containers.Shell = "bash"
+	if got := workspaceDir(); got != "/srv/app" {
+		t.Errorf("workspaceDir() = %q, want /srv/app", got)
+	}
+	if got := containerShell(); got != "bash" {
+		t.Errorf("containerShell() = %q, want bash", got)
+	}
+
+	config.Containers.Workspace = "relative/path"
+	config.Containers.Shell = "fish"
+	if got := workspaceDir(); got != "/workspace" {
+		t.Errorf("workspaceDir() with invalid path = %q, want /workspace", got)
+	}
+	if got := containerShell(); got != "sh" {
+		t.Errorf("containerShell() with unknown shell = %q, want sh", got)
+	}
+	if config.Containers.Workspace != "relative/path" || config.Containers.Shell != "fish" {
+		t.Error("the getters should not change config")
+	}
+	if problems := fallbackProblems(config); len(problems) != 2 {
+		t.Errorf("fallbackProblems() = %v, want the workspace and shell", problems)
+	}
+}
+
+func TestShellFixScript(t *testing.T) {
+	if got := shellFixScript("sh"); got != "" {
+		t.Errorf("sh should have no shell fix script, got:\n%s", got)
+	}
+	for shell, rc := range map[string]string{"zsh": "/home/node/.zshrc", "bash": "/home/node/.bashrc"} {
+		script := shellFixScript(shell)
+		if !strings.Contains(script, "cat >> "+rc) {
+			t.Errorf("%s script should append to %s:\n%s", shell, rc, script)
+		}
+		if !strings.Contains(script, "grep -q '"+shellPromptMarker+"' "+rc) {
+			t.Errorf("%s script should skip an already configured rc file:\n%s", shell, script)
+		}
+	}
+}
+
+func TestMergeDomains(t *testing.T) {
+	got := mergeDomains(
+		[]string{"github.com", "registry.npmjs.org"},
+		[]string{"registry.npmjs.org", "nodejs.org", ""},
+	)
+	want := []string{"github.com", "registry.npmjs.org", "nodejs.org"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("mergeDomains() = %v, want %v", got, want)
+	}
+}
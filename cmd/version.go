@@ -16,21 +16,71 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+	"github.com/uprockcom/maestro/pkg/update"
 	"github.com/uprockcom/maestro/pkg/version"
 )
 
+// changelogExcerptLines bounds the release notes shown by version --check.
+const changelogExcerptLines = 15
+
+var versionCheck bool
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Long:  `Display version information including build details and container image.`,
+	Long: `Display version information including build details and container image.
+
+With --check, query GitHub for the latest release and show its changelog.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println(version.Info())
+		if versionCheck {
+			checkLatestVersion()
+			return
+		}
 		showUpdateWarning()
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, "Check GitHub for a newer release")
+}
+
+// checkLatestVersion prints current vs latest release. Network failures are
+// reported but not treated as errors, so the command works offline.
+func checkLatestVersion() {
+	release, err := update.NewChecker(paths.GetConfigDir(), 0, nil).CheckNow()
+	if err != nil {
+		fmt.Printf("Could not check for updates: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Current version: %s\n", release.CurrentVersion)
+	fmt.Printf("Latest version:  %s\n", release.LatestVersion)
+	switch {
+	case version.IsDevelopment():
+		fmt.Println("\nDevelopment build; install a release to receive updates.")
+		return
+	case !release.UpdateAvail:
+		fmt.Println("\n" + style.Check() + " maestro is up to date")
+		return
+	}
+
+	fmt.Printf("\n%s  Update available: %s → %s\n", style.Warning(), release.CurrentVersion, release.LatestVersion)
+	if notes := update.ChangelogExcerpt(release.Notes, changelogExcerptLines); notes != "" {
+		fmt.Println()
+		for _, line := range strings.Split(notes, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	fmt.Println()
+	if release.ReleaseURL != "" {
+		fmt.Printf("Release notes: %s\n", release.ReleaseURL)
+	}
+	fmt.Println("Run: maestro self-update  (or brew upgrade maestro)")
 }
@@ -0,0 +1,87 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+const (
+	// waitPollInterval is how often 'maestro new --wait' checks on Claude
+	waitPollInterval = 5 * time.Second
+
+	// defaultWaitTimeout is how long --wait waits unless --wait-timeout is set
+	defaultWaitTimeout = 30 * time.Minute
+
+	// waitScreenLines is how much of Claude's window --wait searches and prints
+	// at the end
+	waitScreenLines = 40
+)
+
+// waitPattern compiles --wait-pattern, returning nil when it is unset.
+// There are no built-in patterns: the window includes the task prompt, so
+// phrases like "task complete" would match a task that merely mentions them.
+func waitPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --wait-pattern: %w", err)
+	}
+	return re, nil
+}
+
+// claudeFinished reports whether Claude has finished, given its maestro-agent
+// state and its window: it went back to the prompt or the window matches
+// pattern. The Stop hook writes "waiting" when a turn ends, or "idle" instead
+// when a user is attached, as they are with --wait but without -n.
+func claudeFinished(state, screen string, pattern *regexp.Regexp) bool {
+	if state == "waiting" || state == "idle" {
+		return true
+	}
+	return pattern != nil && pattern.MatchString(screen)
+}
+
+// waitForClaude implements 'maestro new --wait': it polls the container until
+// Claude finishes or timeout passes, then prints the end of Claude's window.
+func waitForClaude(containerName string, pattern *regexp.Regexp, timeout time.Duration) error {
+	shortName := container.GetShortName(containerName, config.Containers.Prefix)
+	fmt.Printf("Waiting up to %s for Claude to finish in %s...\n", timeout, shortName)
+
+	deadline := time.Now().Add(timeout)
+	var screen string
+	for {
+		var err error
+		screen, err = container.CaptureClaudePane(containerName, waitScreenLines)
+		if err != nil {
+			return err
+		}
+		if claudeFinished(container.ReadAgentState(containerName), screen, pattern) {
+			fmt.Printf("Claude finished in %s:\n\n%s\n", shortName, screen)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(waitPollInterval)
+	}
+	fmt.Println(screen)
+	return fmt.Errorf("claude did not finish in %s within %s", shortName, timeout)
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "testing"
+
+func TestClaudeFinished(t *testing.T) {
+	pattern, err := waitPattern(`ALL DONE \d+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, state, screen string
+		want                bool
+	}{
+		{"working", "active", "Editing main.go", false},
+		{"back at the prompt", "waiting", "", true},
+		{"back at the prompt with a user attached", "idle", "", true},
+		{"question", "question", "Which database?", false},
+		{"prompt mentioning completion", "active", "> Print 'Task complete' when done", false},
+		{"custom pattern", "active", "ALL DONE 3", true},
+		{"custom pattern mismatch", "active", "ALL DONE x", false},
+	}
+	for _, tt := range tests {
+		if got := claudeFinished(tt.state, tt.screen, pattern); got != tt.want {
+			t.Errorf("%s: claudeFinished(%q, %q) = %v, want %v", tt.name, tt.state, tt.screen, got, tt.want)
+		}
+	}
+
+	if _, err := waitPattern("("); err == nil {
+		t.Error("expected an invalid --wait-pattern to be rejected")
+	}
+	if re, err := waitPattern(""); re != nil || err != nil {
+		t.Error("no --wait-pattern should mean no pattern")
+	}
+	if claudeFinished("active", "Task complete", nil) {
+		t.Error("without --wait-pattern only the waiting and idle states should count")
+	}
+}
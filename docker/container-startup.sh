@@ -40,9 +40,10 @@ claude --version
 # Note: Firewall will be initialized by Maestro after container is set up
 # This avoids timing issues where the firewall script hasn't been copied yet
 
-# Ensure proper permissions on workspace
-if [ -d /workspace ]; then
-    cd /workspace
+# Start in the project root (MAESTRO_WORKSPACE is set by maestro new)
+WORKSPACE="${MAESTRO_WORKSPACE:-/workspace}"
+if [ -d "$WORKSPACE" ]; then
+    cd "$WORKSPACE"
 fi
 
 # Keep container running
@@ -121,7 +121,7 @@ func runAssemblyScript(scriptPath string) string {
 	}
 
 	cmd := exec.Command("bash", fullPath)
-	cmd.Dir = "/workspace"
+	cmd.Dir = workspaceDir
 	output, err := cmd.Output()
 	if err != nil {
 		LogError("Assembly script failed", "error", err.Error())
@@ -140,20 +140,20 @@ func resolvePath(path string) string {
 		return ""
 	}
 
-	// Check directly under /workspace
-	candidate := filepath.Join("/workspace", path)
+	// Check directly under the workspace root
+	candidate := filepath.Join(workspaceDir, path)
 	if _, err := os.Stat(candidate); err == nil {
 		return candidate
 	}
 
 	// Check under workspace project subdirectories
-	entries, err := os.ReadDir("/workspace")
+	entries, err := os.ReadDir(workspaceDir)
 	if err != nil {
 		return ""
 	}
 	for _, entry := range entries {
 		if entry.IsDir() {
-			candidate := filepath.Join("/workspace", entry.Name(), path)
+			candidate := filepath.Join(workspaceDir, entry.Name(), path)
 			if _, err := os.Stat(candidate); err == nil {
 				return candidate
 			}
@@ -224,14 +224,14 @@ func watchScript(ctx context.Context, scriptPath string, ch chan<- watcherResult
 	fullPath := scriptPath
 	if !filepath.IsAbs(scriptPath) {
 		// Try relative to workspace first
-		if _, err := os.Stat(filepath.Join("/workspace", scriptPath)); err == nil {
-			fullPath = filepath.Join("/workspace", scriptPath)
+		if _, err := os.Stat(filepath.Join(workspaceDir, scriptPath)); err == nil {
+			fullPath = filepath.Join(workspaceDir, scriptPath)
 		}
 		// Then try relative to project dirs
-		entries, _ := os.ReadDir("/workspace")
+		entries, _ := os.ReadDir(workspaceDir)
 		for _, entry := range entries {
 			if entry.IsDir() {
-				candidate := filepath.Join("/workspace", entry.Name(), scriptPath)
+				candidate := filepath.Join(workspaceDir, entry.Name(), scriptPath)
 				if _, err := os.Stat(candidate); err == nil {
 					fullPath = candidate
 					break
@@ -296,9 +296,9 @@ func watchScript(ctx context.Context, scriptPath string, ch chan<- watcherResult
 	}
 }
 
-// isUserConnected checks if a tmux client is attached to the main session
+// isUserConnected checks if a tmux client is attached to Claude's session
 func isUserConnected() bool {
-	cmd := exec.Command("tmux", "list-clients", "-t", "main")
+	cmd := exec.Command("tmux", "list-clients", "-t", tmuxSession)
 	output, err := cmd.Output()
 	if err != nil {
 		return false
@@ -14,6 +14,8 @@
 
 package main
 
+import "os"
+
 // Path variables — declared as var so tests can redirect to temp dirs.
 // Production code never modifies these.
 var (
@@ -44,3 +46,27 @@ var (
 	// Logs
 	agentLogFile = logsDir + "/maestro-agent.log"
 )
+
+// tmuxSession is the tmux session running Claude. The host passes the
+// configured tmux.default_session via MAESTRO_TMUX_SESSION at container
+// creation; older containers fall back to "main".
+var tmuxSession = tmuxSessionFromEnv()
+
+func tmuxSessionFromEnv() string {
+	if s := os.Getenv("MAESTRO_TMUX_SESSION"); s != "" {
+		return s
+	}
+	return "main"
+}
+
+// workspaceDir is the project root inside the container. The host passes the
+// configured containers.workspace via MAESTRO_WORKSPACE at container creation;
+// older containers fall back to "/workspace".
+var workspaceDir = workspaceDirFromEnv()
+
+func workspaceDirFromEnv() string {
+	if ws := os.Getenv("MAESTRO_WORKSPACE"); ws != "" {
+		return ws
+	}
+	return "/workspace"
+}
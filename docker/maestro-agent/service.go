@@ -153,7 +153,7 @@ func wakeClaudeFromIdle() {
 
 	// Write "continue" to tmux buffer and paste + enter
 	writeCmd := exec.Command("bash", "-c",
-		`echo "continue" | tmux load-buffer - && tmux paste-buffer -t main:0 -d && tmux send-keys -t main:0 C-m`)
+		fmt.Sprintf(`echo "continue" | tmux load-buffer - && tmux paste-buffer -t %[1]s:0 -d && tmux send-keys -t %[1]s:0 C-m`, tmuxSession))
 	if err := writeCmd.Run(); err != nil {
 		LogError("Failed to wake Claude", "error", err.Error())
 		return
@@ -178,7 +178,7 @@ func performClear(manifest *Manifest) error {
 		if fullPath != "" {
 			LogInfo("Running pre-clear script", "trigger", manifest.OnIdle.PreClear)
 			cmd := exec.Command("bash", fullPath)
-			cmd.Dir = "/workspace"
+			cmd.Dir = workspaceDir
 			if output, err := cmd.CombinedOutput(); err != nil {
 				LogError("Pre-clear script failed",
 					"error", err.Error(),
@@ -248,11 +248,11 @@ func performClear(manifest *Manifest) error {
 
 	// Restart Claude in tmux — try respawn-pane first, fall back to new session
 	bootstrapShell := fmt.Sprintf("cat %s | claude --dangerously-skip-permissions", tmpFile)
-	respawnCmd := exec.Command("tmux", "respawn-pane", "-k", "-t", "main:0", bootstrapShell)
+	respawnCmd := exec.Command("tmux", "respawn-pane", "-k", "-t", tmuxSession+":0", bootstrapShell)
 	if err := respawnCmd.Run(); err != nil {
 		// Tmux server may have died — create a fresh session
 		LogInfo("Tmux respawn failed, creating new session", "error", err.Error())
-		newSessionCmd := exec.Command("tmux", "new-session", "-d", "-s", "main", bootstrapShell)
+		newSessionCmd := exec.Command("tmux", "new-session", "-d", "-s", tmuxSession, bootstrapShell)
 		if err := newSessionCmd.Run(); err != nil {
 			return fmt.Errorf("failed to start Claude in tmux: %w", err)
 		}
@@ -284,7 +284,7 @@ func deliverHeartbeat(manifest *Manifest) {
 		fullPath := resolvePath(manifest.Heartbeat.Script)
 		if fullPath != "" {
 			cmd := exec.Command("bash", fullPath)
-			cmd.Dir = "/workspace"
+			cmd.Dir = workspaceDir
 			output, err := cmd.Output()
 			if err != nil {
 				LogError("Heartbeat script failed", "error", err.Error())
@@ -10,21 +10,31 @@ import "time"
 // in pkg/container/types.go, and update the conversion functions in
 // pkg/daemon/container_cache.go and pkg/containerservice/service.go.
 type ContainerInfo struct {
-	Name          string                       `json:"name"`
-	ShortName     string                       `json:"short_name"`
-	Status        string                       `json:"status"`
-	StatusDetails string                       `json:"status_details,omitempty"`
-	Branch        string                       `json:"branch,omitempty"`
-	AgentState    string                       `json:"agent_state,omitempty"`
-	IsDormant     bool                         `json:"is_dormant"`
-	HasWeb        bool                         `json:"has_web"`
-	AuthStatus    string                       `json:"auth_status,omitempty"`
-	LastActivity  string                       `json:"last_activity,omitempty"`
-	GitStatus     string                       `json:"git_status,omitempty"`
-	CreatedAt     time.Time                    `json:"created_at"`
-	CurrentTask   string                       `json:"current_task,omitempty"`
-	TaskProgress  string                       `json:"task_progress,omitempty"`
-	Contacts      map[string]map[string]string `json:"contacts,omitempty"`
+	Name            string                       `json:"name"`
+	ShortName       string                       `json:"short_name"`
+	Status          string                       `json:"status"`
+	StatusDetails   string                       `json:"status_details,omitempty"`
+	Branch          string                       `json:"branch,omitempty"`
+	AgentState      string                       `json:"agent_state,omitempty"`
+	AgentStateSince time.Time                    `json:"agent_state_since"`
+	IsDormant       bool                         `json:"is_dormant"`
+	HasWeb          bool                         `json:"has_web"`
+	NoFirewall      bool                         `json:"no_firewall,omitempty"`
+	Project         string                       `json:"project,omitempty"`
+	Task            string                       `json:"task,omitempty"`
+	AuthStatus      string                       `json:"auth_status,omitempty"`
+	LastActivity    string                       `json:"last_activity,omitempty"`
+	GitStatus       string                       `json:"git_status,omitempty"`
+	GitRepo         bool                         `json:"git_repo,omitempty"`
+	GitUncommitted  int                          `json:"git_uncommitted,omitempty"`
+	GitUnpushed     int                          `json:"git_unpushed,omitempty"`
+	GitBehind       int                          `json:"git_behind,omitempty"`
+	CreatedAt       time.Time                    `json:"created_at"`
+	StartedAt       time.Time                    `json:"started_at"`
+	CurrentTask     string                       `json:"current_task,omitempty"`
+	TaskProgress    string                       `json:"task_progress,omitempty"`
+	Contacts        map[string]map[string]string `json:"contacts,omitempty"`
+	Image           string                       `json:"image,omitempty"`
 }
 
 // ListContainersRequest is the request for GET /api/v1/containers.
@@ -64,6 +74,8 @@ type CleanupContainersRequest struct {
 	Names       []string `json:"names"`
 	StateHash   string   `json:"state_hash"`
 	SkipRefresh bool     `json:"skip_refresh,omitempty"`
+	// RemoveVolumes also removes the containers' npm/uv/history cache volumes
+	RemoveVolumes bool `json:"remove_volumes,omitempty"`
 }
 
 // CleanupContainersResponse is the response for POST /api/v1/containers/cleanup.
@@ -0,0 +1,176 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package branchname turns task descriptions into git branch names.
+package branchname
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// DefaultModel is the Claude model used to generate branch names. Haiku keeps
+// this step fast and cheap.
+const DefaultModel = "haiku"
+
+// MaxLength is the longest branch name generated or accepted.
+const MaxLength = 40
+
+// Generate generates a branch name for a task with the given Claude model,
+// without a planning prompt. It retries when the AI returns invalid output.
+func Generate(taskDescription, model string) (string, error) {
+	const maxRetries = 3
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		var claudePrompt string
+		if attempt == 1 {
+			claudePrompt = fmt.Sprintf(`Extract the CORE TASK from this description and create a git branch name.
+
+Description: %s
+
+Instructions:
+1. Identify what is actually being built/fixed/reviewed (ignore instructions like "read file X" or "switch to branch")
+2. Extract key identifiers (PR numbers, ticket IDs, feature names)
+3. Create a branch name: prefix/2-4-word-summary
+
+Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/
+
+Examples:
+- "Please read requirements.txt and implement user login" -> feat/user-login
+- "Review PR #42 for the authentication module" -> review/pr-42
+- "Fix the bug in issue #123 where payments fail" -> fix/issue-123-payments
+- "After reading the spec, add dark mode support" -> feat/dark-mode
+- "Refactor the database queries in the user service" -> refactor/user-db-queries
+
+Output ONLY the branch name (lowercase, max 40 chars):`, taskDescription)
+		} else {
+			// More explicit prompt on retry
+			claudePrompt = fmt.Sprintf(`What is the MAIN GOAL of this task? Create a branch name for it.
+
+Task: %s
+
+DO NOT include filler words from the description. Extract the semantic meaning.
+BAD: feat/please-read-file-and-do-thing (too literal)
+GOOD: feat/thing (captures the actual goal)
+
+Format: prefix/short-name (lowercase, letters/numbers/hyphens only)
+Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/
+
+Output ONLY the branch name:`, taskDescription)
+		}
+
+		// Call Claude CLI in --print mode to generate just the branch name (haiku by default for speed/cost)
+		cmd := exec.Command("claude", "--print", "Generate branch name", "--model", model, "--dangerously-skip-permissions")
+		cmd.Stdin = strings.NewReader(claudePrompt)
+		output, err := cmd.Output()
+		if err != nil {
+			if attempt == maxRetries {
+				return "", fmt.Errorf("AI unavailable after %d attempts: %w", maxRetries, err)
+			}
+			continue
+		}
+
+		// Parse output - just take the first line and trim it
+		branchName := strings.TrimSpace(strings.Split(string(output), "\n")[0])
+
+		// Skip empty results
+		if branchName == "" {
+			if attempt == maxRetries {
+				return "", fmt.Errorf("empty branch name from AI after %d attempts", maxRetries)
+			}
+			continue
+		}
+
+		// Normalize: convert to lowercase and remove any surrounding quotes
+		branchName = strings.ToLower(branchName)
+		branchName = strings.Trim(branchName, "\"'`")
+
+		// Enforce max length in case AI ignored the instruction
+		if len(branchName) > MaxLength {
+			branchName = branchName[:MaxLength]
+			branchName = strings.TrimRight(branchName, "-/")
+		}
+
+		// Validate the branch name format
+		if IsValid(branchName) {
+			return branchName, nil
+		}
+
+		// If invalid, log and retry
+		if attempt < maxRetries {
+			logging.Infof("Branch name attempt %d returned invalid format, retrying...", attempt)
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate valid branch name after %d attempts", maxRetries)
+}
+
+// IsValid checks if a string looks like a valid git branch name
+// (lowercase with optional prefix like feat/, fix/, etc. containing only alphanumeric and hyphens)
+func IsValid(name string) bool {
+	if name == "" {
+		return false
+	}
+	// Must match pattern: optional prefix (feat/, fix/, etc.) followed by lowercase alphanumeric and hyphens
+	// Valid examples: feat/add-auth, fix/bug-123, refactor/db-pool, add-new-feature
+	validPattern := regexp.MustCompile(`^[a-z][a-z0-9-]*(/[a-z0-9][a-z0-9-]*)?$`)
+	return validPattern.MatchString(name)
+}
+
+// Simple derives a branch name from a description without AI: filler words
+// dropped, the rest hyphenated under feat/.
+func Simple(description string) string {
+	// Simple branch name generation from description
+	desc := strings.ToLower(description)
+
+	// Remove common filler words to keep it concise
+	fillerWords := []string{"the", "a", "an", "and", "or", "but", "in", "on", "at", "to", "for"}
+	words := strings.Fields(desc)
+	var filtered []string
+	for _, word := range words {
+		isFillerWord := false
+		for _, filler := range fillerWords {
+			if word == filler {
+				isFillerWord = true
+				break
+			}
+		}
+		if !isFillerWord {
+			filtered = append(filtered, word)
+		}
+	}
+	desc = strings.Join(filtered, " ")
+
+	// Convert to branch-safe format
+	desc = regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(desc, "-")
+	desc = strings.Trim(desc, "-")
+
+	// Keep it short (max 35 chars for the description part)
+	if len(desc) > 35 {
+		desc = desc[:35]
+	}
+	desc = strings.TrimRight(desc, "-")
+
+	// Handle edge case where description has no usable characters
+	if desc == "" {
+		desc = fmt.Sprintf("task-%d", time.Now().Unix()%100000)
+	}
+
+	return fmt.Sprintf("feat/%s", desc)
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branchname
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsValid(t *testing.T) {
+	for _, name := range []string{"feat/add-auth", "fix/bug-123", "add-new-feature"} {
+		if !IsValid(name) {
+			t.Errorf("IsValid(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"", "Feat/x", "feat/a/b", "feat add", "-x"} {
+		if IsValid(name) {
+			t.Errorf("IsValid(%q) = true, want false", name)
+		}
+	}
+}
+
+func TestSimple(t *testing.T) {
+	if got := Simple("Add the login page"); got != "feat/add-login-page" {
+		t.Errorf("Simple() = %q, want feat/add-login-page", got)
+	}
+	if got := Simple(strings.Repeat("word ", 20)); !IsValid(got) || len(got) > MaxLength {
+		t.Errorf("Simple() of a long description = %q, want a valid name within %d characters", got, MaxLength)
+	}
+	if got := Simple("!!!"); !strings.HasPrefix(got, "feat/task-") {
+		t.Errorf("Simple() of an unusable description = %q, want feat/task-<n>", got)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// archiveEntry is what readArchive records for each entry.
+type archiveEntry struct {
+	typeflag byte
+	mode     fs.FileMode
+	link     string
+	content  string
+}
+
+func readArchive(t *testing.T, data []byte, compressed bool) map[string]archiveEntry {
+	t.Helper()
+	var r io.Reader = bytes.NewReader(data)
+	if compressed {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			t.Fatalf("archive is not gzipped: %v", err)
+		}
+		r = gz
+	}
+	entries := make(map[string]archiveEntry)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("reading archive: %v", err)
+		}
+		content, _ := io.ReadAll(tr)
+		entries[hdr.Name] = archiveEntry{
+			typeflag: hdr.Typeflag,
+			mode:     fs.FileMode(hdr.Mode).Perm(),
+			link:     hdr.Linkname,
+			content:  string(content),
+		}
+	}
+}
+
+func TestWriteProjectArchive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes and symlinks need a Unix filesystem")
+	}
+	dir := t.TempDir()
+	write := func(rel, content string, mode fs.FileMode) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+		os.Chmod(path, mode) // Not subject to the umask
+	}
+	write("main.go", "package main\n", 0644)
+	write("scripts/build.sh", "#!/bin/sh\n", 0755)
+	write("node_modules/dep/index.js", "x", 0644)
+	write("src/node_modules/nested.js", "x", 0644)
+	write(".git/HEAD", "ref: refs/heads/main\n", 0644)
+	write("build/out.bin", "x", 0644)
+	write("debug.log", "x", 0644)
+	if err := os.Symlink("main.go", filepath.Join(dir, "link-inside")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../outside/secret", filepath.Join(dir, "scripts", "link-outside")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc/hosts", filepath.Join(dir, "link-absolute")); err != nil {
+		t.Fatal(err)
+	}
+
+	excludes := []string{"node_modules", ".git", "build", "*.log"}
+	for _, compressed := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := WriteProjectArchive(&buf, dir, excludes, compressed); err != nil {
+			t.Fatalf("WriteProjectArchive(compress=%v): %v", compressed, err)
+		}
+		entries := readArchive(t, buf.Bytes(), compressed)
+
+		if e := entries["main.go"]; e.typeflag != tar.TypeReg || e.mode != 0644 || e.content != "package main\n" {
+			t.Errorf("main.go = %+v", e)
+		}
+		if e := entries["scripts/build.sh"]; e.mode != 0755 || e.content != "#!/bin/sh\n" {
+			t.Errorf("scripts/build.sh = %+v, want mode 0755", e)
+		}
+		if e, ok := entries["scripts/"]; !ok || e.typeflag != tar.TypeDir {
+			t.Errorf("scripts/ = %+v, want a directory entry", e)
+		}
+		for name, target := range map[string]string{
+			"link-inside":          "main.go",
+			"scripts/link-outside": "../../outside/secret",
+			"link-absolute":        "/etc/hosts",
+		} {
+			if e := entries[name]; e.typeflag != tar.TypeSymlink || e.link != target {
+				t.Errorf("%s = %+v, want a symlink to %s", name, e, target)
+			}
+		}
+		for _, name := range []string{
+			"node_modules/", "node_modules/dep/index.js", "src/node_modules/nested.js",
+			".git/HEAD", "build/out.bin", "debug.log",
+		} {
+			if _, ok := entries[name]; ok {
+				t.Errorf("%s should be excluded", name)
+			}
+		}
+		if _, ok := entries["src/"]; !ok {
+			t.Error("src/ should be kept when only its node_modules is excluded")
+		}
+	}
+}
+
+func TestWriteProjectArchive_SymlinkedRoot(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need a Unix filesystem")
+	}
+	target := t.TempDir()
+	if err := os.WriteFile(filepath.Join(target, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// As with a symlinked working directory or project path
+	root := filepath.Join(t.TempDir(), "project")
+	if err := os.Symlink(target, root); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteProjectArchive(&buf, root, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	entries := readArchive(t, buf.Bytes(), false)
+	if e := entries["main.go"]; e.content != "package main\n" {
+		t.Errorf("main.go = %+v, want the symlinked root's file; entries %v", e, entries)
+	}
+}
+
+func TestWriteProjectArchive_ExtractsWithTar(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil || runtime.GOOS == "windows" {
+		t.Skip("needs tar, as in the container")
+	}
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "bin", "run"), []byte("#!/bin/sh\necho hi\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.Chmod(filepath.Join(src, "bin", "run"), 0755)
+	if err := os.Symlink("../shared/config", filepath.Join(src, "config")); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "project.tgz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteProjectArchive(f, src, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	dest := t.TempDir()
+	if out, err := exec.Command("tar", "-xzf", archive, "-C", dest).CombinedOutput(); err != nil {
+		t.Fatalf("tar -xzf: %v: %s", err, out)
+	}
+	info, err := os.Stat(filepath.Join(dest, "bin", "run"))
+	if err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("bin/run = %v, %v; want mode 0755", info, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "bin", "run")); string(data) != "#!/bin/sh\necho hi\n" {
+		t.Errorf("bin/run content = %q", data)
+	}
+	if target, err := os.Readlink(filepath.Join(dest, "config")); err != nil || target != "../shared/config" {
+		t.Errorf("config link = %q, %v; want ../shared/config", target, err)
+	}
+}
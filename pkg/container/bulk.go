@@ -0,0 +1,76 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBulkConcurrency is how many containers bulk operations work on at
+// once. Stopping or removing many containers in parallel can overwhelm
+// Docker, so they go a few at a time.
+const DefaultBulkConcurrency = 3
+
+// Bulk runs op on each container, at most concurrency at a time, and returns
+// one error per name in the same order (nil on success). Containers not yet
+// started when ctx is done are skipped with ctx's error.
+func Bulk(ctx context.Context, names []string, concurrency int, op func(ctx context.Context, name string) error) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(names); j++ {
+				errs[j] = err
+			}
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = op(ctx, name)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// BulkStop stops the containers, at most concurrency at a time. It returns
+// one error per name (nil on success).
+func BulkStop(ctx context.Context, names []string, concurrency int) []error {
+	return Bulk(ctx, names, concurrency, func(_ context.Context, name string) error {
+		return StopContainer(name)
+	})
+}
+
+// BulkDelete removes the containers, keeping their cache volumes, at most
+// concurrency at a time. It returns one error per name (nil on success).
+func BulkDelete(ctx context.Context, names []string, concurrency int) []error {
+	return Bulk(ctx, names, concurrency, func(_ context.Context, name string) error {
+		_, err := DeleteContainer(name, false)
+		return err
+	})
+}
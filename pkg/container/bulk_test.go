@@ -0,0 +1,103 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBulkBackend records the containers it is asked to act on and how many
+// calls overlap.
+type fakeBulkBackend struct {
+	mu      sync.Mutex
+	calls   []string
+	running int
+	peak    int
+	fail    map[string]bool
+}
+
+func (f *fakeBulkBackend) op(ctx context.Context, name string) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, name)
+	f.running++
+	f.peak = max(f.peak, f.running)
+	f.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	f.running--
+	f.mu.Unlock()
+	if f.fail[name] {
+		return fmt.Errorf("failed to stop %s", name)
+	}
+	return nil
+}
+
+func TestBulk_LimitsConcurrency(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	f := &fakeBulkBackend{fail: map[string]bool{"c": true}}
+
+	errs := Bulk(context.Background(), names, 3, f.op)
+
+	if f.peak != 3 {
+		t.Errorf("peak concurrency = %d, want 3", f.peak)
+	}
+	if len(f.calls) != len(names) {
+		t.Fatalf("calls = %v, want every container once", f.calls)
+	}
+	// Containers are started in order, so the first three go before the last
+	if i := slices.Index(f.calls, "g"); i < 3 {
+		t.Errorf("g started at position %d, before a slot was free: %v", i, f.calls)
+	}
+	for i, err := range errs {
+		if (err != nil) != (names[i] == "c") {
+			t.Errorf("%s: err = %v", names[i], err)
+		}
+	}
+}
+
+func TestBulk_SerialWithConcurrencyOne(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	f := &fakeBulkBackend{}
+	Bulk(context.Background(), names, 0, f.op)
+	if f.peak != 1 || !slices.Equal(f.calls, names) {
+		t.Errorf("peak = %d, calls = %v; want 1 and %v", f.peak, f.calls, names)
+	}
+}
+
+func TestBulk_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	names := []string{"a", "b", "c", "d"}
+	errs := Bulk(ctx, names, 1, func(ctx context.Context, name string) error {
+		if name == "b" {
+			cancel()
+		}
+		return nil
+	})
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("started containers should succeed: %v", errs)
+	}
+	// c may have been started before the cancellation was seen
+	if !errors.Is(errs[3], context.Canceled) {
+		t.Errorf("d: err = %v, want context.Canceled", errs[3])
+	}
+}
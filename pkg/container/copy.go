@@ -0,0 +1,142 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// copyEstimateTimeout bounds the size estimate so slow filesystems (NFS
+// mounts, huge trees) never hold up container creation.
+const copyEstimateTimeout = 5 * time.Second
+
+// LargeCopyThreshold is the project size above which creation asks for
+// confirmation before copying.
+const LargeCopyThreshold = 500 * 1024 * 1024
+
+var tarTotalsRe = regexp.MustCompile(`Total bytes written: (\d+)`)
+
+// EstimateProjectCopySize estimates how many bytes copying dir into a
+// container will transfer, honoring the same tar-style exclude patterns as
+// the copy itself. On Linux it asks GNU tar for totals without writing an
+// archive; elsewhere it walks the tree. Gives up after a few seconds.
+func EstimateProjectCopySize(dir string, excludes []string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), copyEstimateTimeout)
+	defer cancel()
+
+	if runtime.GOOS == "linux" {
+		if size, err := estimateWithTar(ctx, dir, excludes); err == nil {
+			return size, nil
+		}
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("size estimate timed out after %s", copyEstimateTimeout)
+		}
+	}
+	return estimateWithWalk(ctx, dir, excludes)
+}
+
+// estimateWithTar runs GNU tar against /dev/null, which skips reading file
+// contents but still reports the archive size.
+func estimateWithTar(ctx context.Context, dir string, excludes []string) (int64, error) {
+	args := []string{"--totals", "-cf", "/dev/null"}
+	for _, pattern := range excludes {
+		args = append(args, "--exclude="+pattern)
+	}
+	args = append(args, ".")
+
+	cmd := exec.CommandContext(ctx, "tar", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, err
+	}
+	return parseTarTotals(string(output))
+}
+
+// parseTarTotals extracts the byte count from tar --totals output
+func parseTarTotals(output string) (int64, error) {
+	match := tarTotalsRe.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("no totals in tar output")
+	}
+	return strconv.ParseInt(match[1], 10, 64)
+}
+
+// estimateWithWalk sums regular file sizes under dir, skipping anything a
+// tar --exclude pattern would skip (matched against the name or the path
+// relative to dir).
+func estimateWithWalk(ctx context.Context, dir string, excludes []string) (int64, error) {
+	// Resolved like WriteProjectArchive's root, which WalkDir won't follow
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("size estimate timed out after %s", copyEstimateTimeout)
+		}
+		if err != nil {
+			// Unreadable entries are skipped by the copy too
+			return nil
+		}
+		if path != dir && isExcluded(dir, path, excludes) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// isExcluded reports whether path matches any tar-style exclude pattern
+func isExcluded(root, path string, excludes []string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	name := filepath.Base(path)
+	for _, pattern := range excludes {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, "./"+rel); ok {
+			return true
+		}
+	}
+	return false
+}
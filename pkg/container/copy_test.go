@@ -0,0 +1,85 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSizedFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEstimateWithWalk_Excludes(t *testing.T) {
+	dir := t.TempDir()
+	writeSizedFile(t, filepath.Join(dir, "main.go"), 1000)
+	writeSizedFile(t, filepath.Join(dir, "pkg", "lib.go"), 500)
+	writeSizedFile(t, filepath.Join(dir, "node_modules", "dep", "index.js"), 100000)
+	writeSizedFile(t, filepath.Join(dir, ".git", "objects", "pack"), 100000)
+	writeSizedFile(t, filepath.Join(dir, "debug.log"), 7000)
+
+	size, err := estimateWithWalk(context.Background(), dir, []string{"node_modules", ".git", "*.log"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size != 1500 {
+		t.Errorf("expected 1500 bytes, got %d", size)
+	}
+}
+
+func TestEstimateWithWalk_Cancelled(t *testing.T) {
+	dir := t.TempDir()
+	writeSizedFile(t, filepath.Join(dir, "a"), 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := estimateWithWalk(ctx, dir, nil); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
+
+func TestEstimateProjectCopySize(t *testing.T) {
+	dir := t.TempDir()
+	writeSizedFile(t, filepath.Join(dir, "big.bin"), 64*1024)
+	writeSizedFile(t, filepath.Join(dir, "node_modules", "skip.bin"), 1024*1024)
+
+	size, err := EstimateProjectCopySize(dir, []string{"node_modules"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// tar adds headers and padding; the walk counts raw bytes
+	if size < 64*1024 || size > 1024*1024 {
+		t.Errorf("estimate %d out of expected range", size)
+	}
+}
+
+func TestParseTarTotals(t *testing.T) {
+	size, err := parseTarTotals("Total bytes written: 102400 (100KiB, 505MiB/s)\n")
+	if err != nil || size != 102400 {
+		t.Errorf("parseTarTotals = %d, %v; want 102400", size, err)
+	}
+	if _, err := parseTarTotals("tar: something went wrong\n"); err == nil {
+		t.Error("expected error when totals are missing")
+	}
+}
@@ -15,9 +15,13 @@
 package container
 
 import (
+	"cmp"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 // formatAgentStateIndicator returns a short state indicator for the CLI table
@@ -141,6 +145,9 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 			if c.HasWeb {
 				displayStatus += "/web"
 			}
+			if c.NoFirewall {
+				displayStatus += "/no-fw"
+			}
 
 			// Use default values for stopped containers
 			gitStatus := c.GitStatus
@@ -201,3 +208,75 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 
 	return sorted
 }
+
+// NeedsAttention reports whether a container's agent is waiting on the user:
+// it is running, Claude is alive and the agent is idle, waiting or asking a
+// question. This is the same condition the daemon notifies on.
+func NeedsAttention(c Info) bool {
+	if c.Status != "running" || c.IsDormant {
+		return false
+	}
+	switch c.AgentState {
+	case "question", "idle", "waiting":
+		return true
+	}
+	return false
+}
+
+// WaitingFor returns how long a container that needs attention has been
+// waiting on the user, or 0 if it doesn't need attention or the time the
+// agent started waiting is unknown.
+func WaitingFor(c Info, now time.Time) time.Duration {
+	if !NeedsAttention(c) || c.AgentStateSince.IsZero() {
+		return 0
+	}
+	return max(now.Sub(c.AgentStateSince), 0)
+}
+
+// PinAttention returns containers with those needing attention moved to the
+// front, longest waiting first. The order is otherwise unchanged.
+func PinAttention(containers []Info) []Info {
+	now := time.Now()
+	pinned := slices.Clone(containers)
+	slices.SortStableFunc(pinned, func(a, b Info) int {
+		aWaiting, bWaiting := NeedsAttention(a), NeedsAttention(b)
+		switch {
+		case aWaiting && !bWaiting:
+			return -1
+		case !aWaiting && bWaiting:
+			return 1
+		case aWaiting && bWaiting:
+			return cmp.Compare(WaitingFor(b, now), WaitingFor(a, now))
+		}
+		return 0
+	})
+	return pinned
+}
+
+// SortByUnmergedWork returns containers ordered by the work that exists only
+// in them: most unpushed commits first, then most uncommitted files. The
+// order is otherwise unchanged.
+func SortByUnmergedWork(containers []Info) []Info {
+	sorted := slices.Clone(containers)
+	slices.SortStableFunc(sorted, func(a, b Info) int {
+		if c := cmp.Compare(b.Git.Unpushed, a.Git.Unpushed); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Git.Uncommitted, a.Git.Uncommitted)
+	})
+	return sorted
+}
+
+// Uptime returns how long a running container has been up, taken from
+// Docker's status text (e.g. "Up 2 hours (healthy)" -> "2 hours"). It returns
+// "" for containers that are not running.
+func Uptime(c Info) string {
+	rest, ok := strings.CutPrefix(c.StatusDetails, "Up ")
+	if !ok {
+		return ""
+	}
+	if i := strings.Index(rest, " ("); i >= 0 {
+		rest = rest[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(rest))
+}
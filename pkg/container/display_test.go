@@ -0,0 +1,117 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUptime(t *testing.T) {
+	tests := []struct{ status, want string }{
+		{"Up 2 hours", "2 hours"},
+		{"Up About a minute", "about a minute"},
+		{"Up 3 days (healthy)", "3 days"},
+		{"Up 5 minutes (Paused)", "5 minutes"},
+		{"Exited (0) 2 hours ago", ""},
+		{"Created", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Uptime(Info{StatusDetails: tt.status}); got != tt.want {
+			t.Errorf("Uptime(%q) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNeedsAttention(t *testing.T) {
+	tests := []struct {
+		name string
+		info Info
+		want bool
+	}{
+		{"question", Info{Status: "running", AgentState: "question"}, true},
+		{"idle", Info{Status: "running", AgentState: "idle"}, true},
+		{"waiting", Info{Status: "running", AgentState: "waiting"}, true},
+		{"active", Info{Status: "running", AgentState: "active"}, false},
+		{"dormant", Info{Status: "running", AgentState: "idle", IsDormant: true}, false},
+		{"stopped", Info{Status: "exited", AgentState: "idle"}, false},
+	}
+	for _, tt := range tests {
+		if got := NeedsAttention(tt.info); got != tt.want {
+			t.Errorf("%s: NeedsAttention = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPinAttention(t *testing.T) {
+	now := time.Now()
+	containers := []Info{
+		{Name: "a", Status: "running", AgentState: "active"},
+		{Name: "b", Status: "running", AgentState: "idle", AgentStateSince: now.Add(-time.Minute)},
+		{Name: "c", Status: "exited"},
+		{Name: "d", Status: "running", AgentState: "question", AgentStateSince: now.Add(-time.Hour)},
+		{Name: "e", Status: "running", AgentState: "active"},
+	}
+	var got []string
+	for _, c := range PinAttention(containers) {
+		got = append(got, c.Name)
+	}
+	want := "d b a c e"
+	if joined := strings.Join(got, " "); joined != want {
+		t.Errorf("PinAttention order = %s, want %s", joined, want)
+	}
+	if containers[0].Name != "a" || containers[1].Name != "b" {
+		t.Error("PinAttention modified its input")
+	}
+}
+
+func TestSortByUnmergedWork(t *testing.T) {
+	containers := []Info{
+		{Name: "a", Git: GitState{Repo: true}},
+		{Name: "b", Git: GitState{Repo: true, Uncommitted: 4}},
+		{Name: "c", Status: "exited"},
+		{Name: "d", Git: GitState{Repo: true, Unpushed: 12}},
+		{Name: "e", Git: GitState{Repo: true, Unpushed: 2, Uncommitted: 1}},
+	}
+	var got []string
+	for _, c := range SortByUnmergedWork(containers) {
+		got = append(got, c.Name)
+	}
+	want := "d e b a c"
+	if joined := strings.Join(got, " "); joined != want {
+		t.Errorf("SortByUnmergedWork order = %s, want %s", joined, want)
+	}
+	if containers[0].Name != "a" {
+		t.Error("SortByUnmergedWork modified its input")
+	}
+}
+
+func TestWaitingFor(t *testing.T) {
+	now := time.Now()
+	waiting := Info{Status: "running", AgentState: "waiting", AgentStateSince: now.Add(-90 * time.Second)}
+	if got := WaitingFor(waiting, now); got != 90*time.Second {
+		t.Errorf("WaitingFor = %v, want 90s", got)
+	}
+	waiting.AgentStateSince = time.Time{}
+	if got := WaitingFor(waiting, now); got != 0 {
+		t.Errorf("WaitingFor with unknown start = %v, want 0", got)
+	}
+	active := Info{Status: "running", AgentState: "active", AgentStateSince: now.Add(-time.Hour)}
+	if got := WaitingFor(active, now); got != 0 {
+		t.Errorf("WaitingFor for an active container = %v, want 0", got)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// Why Docker can't be used, as reported by CheckDocker and the container
+// listing. Callers tell them apart with errors.Is.
+var (
+	ErrDockerNotInstalled = errors.New("docker is not installed")
+	ErrDockerPermission   = errors.New("permission denied connecting to the Docker daemon")
+	ErrDockerUnreachable  = errors.New("cannot connect to the Docker daemon")
+)
+
+// CheckDocker checks that the Docker daemon is responding, returning one of
+// the errors above when the reason is recognized.
+func CheckDocker() error {
+	return classifyDockerError(logging.Run(logging.Command("docker", "info")))
+}
+
+// classifyDockerError wraps a failed docker command's error in the matching
+// ErrDocker* error, judging by its message and stderr. Errors with any other
+// cause are returned unchanged.
+func classifyDockerError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%w: %v", ErrDockerNotInstalled, err)
+	}
+
+	msg := err.Error()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		msg += " " + string(exitErr.Stderr)
+	}
+	msg = strings.ToLower(msg)
+
+	switch {
+	case strings.Contains(msg, "permission denied") && strings.Contains(msg, "docker"):
+		return fmt.Errorf("%w: %v", ErrDockerPermission, err)
+	case strings.Contains(msg, "cannot connect to the docker daemon"),
+		strings.Contains(msg, "is the docker daemon running"),
+		strings.Contains(msg, "error during connect"):
+		return fmt.Errorf("%w: %v", ErrDockerUnreachable, err)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+func TestClassifyDockerError(t *testing.T) {
+	notFound := &exec.Error{Name: "docker", Err: exec.ErrNotFound}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not installed", notFound, ErrDockerNotInstalled},
+		{"daemon down", errors.New("exit status 1: Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?"), ErrDockerUnreachable},
+		{"windows daemon down", errors.New("exit status 1: error during connect: this error may indicate that the docker daemon is not running"), ErrDockerUnreachable},
+		{"socket permission", errors.New("exit status 1: permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock"), ErrDockerPermission},
+		{"stderr on exit error", &exec.ExitError{Stderr: []byte("Cannot connect to the Docker daemon")}, ErrDockerUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyDockerError(tt.err)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("classifyDockerError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	other := fmt.Errorf("exit status 1: unknown flag")
+	if got := classifyDockerError(other); got != other {
+		t.Errorf("an unrecognized error should be returned unchanged, got %v", got)
+	}
+	if classifyDockerError(nil) != nil {
+		t.Error("nil should stay nil")
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+const (
+	// FirewallDir holds the firewall script and the files maestro writes for
+	// it. It is under /run so it stays writable with read-only root
+	// filesystems, where /run is a tmpfs.
+	FirewallDir = "/run/maestro-firewall"
+
+	// FirewallScriptPath is where the firewall script is installed in
+	// containers. Run it with bash: the /run tmpfs may be mounted noexec.
+	FirewallScriptPath = FirewallDir + "/init-firewall.sh"
+
+	// FirewallDomainsPath lists the allowed domains, one per line. It is
+	// written when the firewall is initialized, so it also marks containers
+	// whose firewall was enabled after creation
+	FirewallDomainsPath = FirewallDir + "/allowed-domains.txt"
+
+	// FirewallInternalDNSPath holds firewall.internal_dns
+	FirewallInternalDNSPath = FirewallDir + "/internal-dns.txt"
+
+	// FirewallInternalDomainsPath lists firewall.internal_domains
+	FirewallInternalDomainsPath = FirewallDir + "/internal-domains.txt"
+
+	// FirewallAWSPath exists when AWS or Bedrock is enabled, adding the AWS
+	// domains to the allowlist
+	FirewallAWSPath = FirewallDir + "/aws-enabled.txt"
+
+	// FirewallLogPath captures the firewall script's output inside the container
+	FirewallLogPath = "/tmp/firewall-init.log"
+
+	// FirewallLabel is set to FirewallDisabled on containers created with
+	// --no-firewall
+	FirewallLabel = "maestro.firewall"
+
+	// FirewallDisabled is the FirewallLabel value for unfirewalled containers
+	FirewallDisabled = "disabled"
+
+	// ProjectDomainsLabel lists the firewall domains of the project type
+	// detected at creation, comma separated, so a firewall enabled later
+	// allows them too
+	ProjectDomainsLabel = "maestro.project_domains"
+
+	// legacyFirewallDomainsPath is where containers created before
+	// FirewallDir have their allowed domains
+	legacyFirewallDomainsPath = "/etc/allowed-domains.txt"
+
+	// firewallAllowRule is the OUTPUT rule init-firewall.sh adds for the
+	// dnsmasq-populated allowlist
+	firewallAllowRule = "--match-set allowed-domains dst -j ACCEPT"
+)
+
+// FirewallStatus describes whether a container's outbound firewall is in place.
+type FirewallStatus struct {
+	Active bool   // OUTPUT policy is DROP and the allowlist rule is present
+	Rules  int    // Number of rules in the OUTPUT chain
+	Reason string // Why the firewall is not active (empty when Active)
+}
+
+// parseFirewallRules interprets `iptables -S OUTPUT` output. The firewall is
+// only considered active once both the DROP policy and the allowlist rule
+// exist, since init-firewall.sh sets the policy last.
+func parseFirewallRules(output string) FirewallStatus {
+	var status FirewallStatus
+	policy := ""
+	hasAllowRule := false
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "-P OUTPUT "):
+			policy = strings.TrimPrefix(line, "-P OUTPUT ")
+		case strings.HasPrefix(line, "-A OUTPUT "):
+			status.Rules++
+			if strings.Contains(line, firewallAllowRule) {
+				hasAllowRule = true
+			}
+		}
+	}
+
+	switch {
+	case policy == "":
+		status.Reason = "could not read the OUTPUT chain"
+	case policy != "DROP":
+		status.Reason = fmt.Sprintf("outbound policy is %s, firewall rules were not applied", policy)
+	case !hasAllowRule:
+		status.Reason = "allowlist rule is missing from the OUTPUT chain"
+	default:
+		status.Active = true
+	}
+	return status
+}
+
+// CheckFirewall inspects the container's iptables OUTPUT chain.
+func CheckFirewall(containerName string) (FirewallStatus, error) {
+	cmd := logging.Command("docker", "exec", "-u", "root", containerName, "iptables", "-S", "OUTPUT")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return FirewallStatus{}, fmt.Errorf("failed to read iptables rules: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return parseFirewallRules(string(output)), nil
+}
+
+// firewallScriptRunning reports whether init-firewall.sh is still running.
+func firewallScriptRunning(containerName string) bool {
+	cmd := logging.Command("docker", "exec", containerName, "pgrep", "-f", FirewallScriptPath)
+	return logging.Run(cmd) == nil
+}
+
+// WaitForFirewall polls until the container's firewall is active, the
+// firewall script exits without activating it, or timeout elapses. The last
+// observed status is returned either way.
+func WaitForFirewall(containerName string, timeout time.Duration) (FirewallStatus, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := CheckFirewall(containerName)
+		if err == nil && status.Active {
+			return status, nil
+		}
+		if !firewallScriptRunning(containerName) {
+			// One last look in case the script finished between checks
+			return CheckFirewall(containerName)
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return status, err
+			}
+			status.Reason = fmt.Sprintf("timed out after %s: %s", timeout, status.Reason)
+			return status, nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// firewallInitialized reports whether the firewall was set up in the
+// container, even if it was created with --no-firewall.
+func firewallInitialized(ctx context.Context, containerName string) bool {
+	for _, path := range []string{FirewallDomainsPath, legacyFirewallDomainsPath} {
+		cmd := logging.CommandContext(ctx, "docker", "exec", containerName, "test", "-f", path)
+		if logging.Run(cmd) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// FirewallDNSReady reports whether the firewall can send the container's DNS
+// through its dnsmasq: the script rewrites /etc/resolv.conf, which a
+// read-only root filesystem prevents unless the container was started with
+// --dns 127.0.0.1.
+func FirewallDNSReady(containerName string) (bool, error) {
+	cmd := logging.Command("docker", "inspect", "-f", "{{.HostConfig.ReadonlyRootfs}} {{json .HostConfig.Dns}}", containerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return parseFirewallDNSReady(strings.TrimSpace(string(output))), nil
+}
+
+// parseFirewallDNSReady interprets FirewallDNSReady's inspect output: the
+// read-only flag followed by the DNS servers as JSON.
+func parseFirewallDNSReady(output string) bool {
+	readOnly, dnsJSON, _ := strings.Cut(output, " ")
+	if readOnly != "true" {
+		return true
+	}
+	var dns []string
+	if err := json.Unmarshal([]byte(dnsJSON), &dns); err != nil {
+		return false
+	}
+	return slices.Contains(dns, "127.0.0.1")
+}
+
+// IsFirewallDisabled reports whether the container was created without a
+// firewall and has not had one enabled since. Docker labels cannot change
+// after creation, so the label alone is not enough.
+func IsFirewallDisabled(containerName string) bool {
+	if GetLabel(containerName, FirewallLabel) != FirewallDisabled {
+		return false
+	}
+	return !firewallInitialized(context.Background(), containerName)
+}
+
+// ProjectDomainsLabelValue encodes domains for ProjectDomainsLabel.
+func ProjectDomainsLabelValue(domains []string) string {
+	return strings.Join(domains, ",")
+}
+
+// ProjectDomains returns the project firewall domains recorded on a
+// container at creation, or nil for containers without any.
+func ProjectDomains(containerName string) []string {
+	raw := GetLabel(containerName, ProjectDomainsLabel)
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
+// FirewallLogTail returns the last lines of the firewall script's output, or
+// "" if the log is missing.
+func FirewallLogTail(containerName string, lines int) string {
+	cmd := logging.Command("docker", "exec", containerName, "tail", "-n", fmt.Sprint(lines), FirewallLogPath)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
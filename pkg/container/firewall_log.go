@@ -0,0 +1,183 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+const (
+	// FirewallLogPrefix starts the kernel log lines init-firewall.sh writes
+	// for rejected outbound connections
+	FirewallLogPrefix = "MAESTRO_DROP "
+
+	// firewallLogLimit is how many of the latest events GetFirewallLog returns
+	firewallLogLimit = 100
+
+	// firewallLogClearedPath records when the firewall log was last cleared
+	firewallLogClearedPath = "/tmp/maestro-firewall-log-cleared"
+
+	// dmesgTimeLayout is util-linux dmesg's --time-format iso
+	dmesgTimeLayout = "2006-01-02T15:04:05,000000-07:00"
+)
+
+// nfLogAllNetnsPath is the host sysctl that lets iptables LOG rules in
+// container network namespaces reach the kernel log.
+var nfLogAllNetnsPath = "/proc/sys/net/netfilter/nf_log_all_netns"
+
+// ErrFirewallLogDisabled means the host kernel drops the firewall's log lines
+// because they come from a container's network namespace.
+var ErrFirewallLogDisabled = errors.New("this host doesn't log connections rejected inside containers; enable it with 'sudo sysctl -w net.netfilter.nf_log_all_netns=1'")
+
+// hostLogsContainerNetns reports whether the kernel logs LOG rules from
+// network namespaces other than the host's, read from the sysctl at path.
+// known is false when that can't be told, such as with Docker in a VM.
+func hostLogsContainerNetns(path string) (enabled, known bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, false
+	}
+	return strings.TrimSpace(string(data)) != "0", true
+}
+
+// FirewallEvent is one outbound connection the firewall rejected.
+type FirewallEvent struct {
+	Timestamp time.Time
+	SourceIP  string
+	DestIP    string
+	DestPort  int // 0 for protocols without ports
+	Protocol  string
+}
+
+// parseFirewallLog picks the firewall's lines out of dmesg output. The
+// kernel log is shared by every container on the host, so only events from
+// sources (the container's IPs, when known) after since are kept. Events
+// come back oldest first, at most the latest firewallLogLimit.
+func parseFirewallLog(output string, sources []string, since time.Time) []FirewallEvent {
+	var events []FirewallEvent
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, FirewallLogPrefix) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		ts, err := time.Parse(dmesgTimeLayout, fields[0])
+		if err != nil || !ts.After(since) {
+			continue
+		}
+		event := FirewallEvent{Timestamp: ts}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "SRC":
+				event.SourceIP = value
+			case "DST":
+				event.DestIP = value
+			case "DPT":
+				event.DestPort, _ = strconv.Atoi(value)
+			case "PROTO":
+				event.Protocol = value
+			}
+		}
+		if len(sources) > 0 && !slices.Contains(sources, event.SourceIP) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	if len(events) > firewallLogLimit {
+		events = events[len(events)-firewallLogLimit:]
+	}
+	return events
+}
+
+// GetFirewallLog returns the latest connections the container's firewall
+// rejected, read from the kernel log with readKernelLog. The events are only
+// logged at all with net.netfilter.nf_log_all_netns=1 on the host; when that
+// is off it returns ErrFirewallLogDisabled.
+func GetFirewallLog(containerName string) ([]FirewallEvent, error) {
+	if enabled, known := hostLogsContainerNetns(nfLogAllNetnsPath); known && !enabled {
+		return nil, ErrFirewallLogDisabled
+	}
+	image, err := logging.Command("docker", "inspect", "-f", "{{.Config.Image}}", containerName).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	output, err := readKernelLog(strings.TrimSpace(string(image)))
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if out, err := logging.Command("docker", "exec", containerName, "cat", firewallLogClearedPath).Output(); err == nil {
+		since, _ = time.Parse(time.RFC3339Nano, strings.TrimSpace(string(out)))
+	}
+
+	ips, err := logging.Command("docker", "inspect",
+		"--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}", containerName).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container IP: %w", err)
+	}
+	return parseFirewallLog(output, strings.Fields(string(ips)), since), nil
+}
+
+// readKernelLog returns dmesg's output from a short-lived container of image.
+// Maestro's containers can't read the kernel log themselves: Docker's default
+// seccomp profile only allows syslog(2) with CAP_SYSLOG, which they don't
+// have. The kernel log isn't namespaced, so the helper, given only that
+// capability and no network, sees the whole host's (or Docker VM's) log.
+func readKernelLog(image string) (string, error) {
+	// As root: the capability isn't effective for the image's own user
+	output, err := logging.Command("docker", "run", "--rm", "--network", "none", "-u", "root",
+		"--cap-drop", "ALL", "--cap-add", "SYSLOG", "--entrypoint", "dmesg",
+		image, "--time-format", "iso").CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if strings.Contains(msg, "Operation not permitted") {
+			return "", fmt.Errorf("docker can't grant CAP_SYSLOG to read the kernel log (user namespaces or a custom seccomp profile?); check 'sudo dmesg | grep %s' on the Docker host", strings.TrimSpace(FirewallLogPrefix))
+		}
+		return "", fmt.Errorf("failed to read the kernel log: %s: %w", msg, err)
+	}
+	return string(output), nil
+}
+
+// ClearFirewallLog hides the events logged so far from GetFirewallLog. The
+// kernel log itself belongs to the host and is left alone. The time is taken
+// inside the container, on the same clock as the kernel log's timestamps.
+func ClearFirewallLog(containerName string) error {
+	cmd := logging.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+		"date -u +%Y-%m-%dT%H:%M:%S.%NZ > "+firewallLogClearedPath)
+	if err := logging.Run(cmd); err != nil {
+		return fmt.Errorf("failed to clear the firewall log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFirewallLog(t *testing.T) {
+	output := `2026-10-16T10:00:02,000000+00:00 MAESTRO_DROP IN= OUT=eth0 SRC=172.17.0.3 DST=93.184.216.34 LEN=60 PROTO=TCP SPT=40000 DPT=443 SYN
+2026-10-16T10:00:01,500000+00:00 MAESTRO_DROP IN= OUT=eth0 SRC=172.17.0.2 DST=1.1.1.1 LEN=84 PROTO=ICMP TYPE=8 CODE=0
+2026-10-16T10:00:00,000000+00:00 eth0: link becomes ready
+2026-10-16T09:59:00,000000+00:00 MAESTRO_DROP IN= OUT=eth0 SRC=172.17.0.2 DST=8.8.8.8 LEN=60 PROTO=UDP SPT=5000 DPT=123
+2026-10-16T10:00:03,250000+00:00 MAESTRO_DROP IN= OUT=eth0 SRC=172.17.0.2 DST=140.82.112.3 LEN=60 PROTO=TCP SPT=40001 DPT=22 SYN
+`
+	since := time.Date(2026, 10, 16, 9, 59, 30, 0, time.UTC)
+	events := parseFirewallLog(output, []string{"172.17.0.2"}, since)
+	if len(events) != 2 {
+		t.Fatalf("want 2 events from 172.17.0.2 after the cutoff, got %+v", events)
+	}
+	if events[0].DestIP != "1.1.1.1" || events[0].Protocol != "ICMP" || events[0].DestPort != 0 {
+		t.Errorf("first event = %+v, want the ICMP one", events[0])
+	}
+	want := FirewallEvent{
+		Timestamp: time.Date(2026, 10, 16, 10, 0, 3, 250000000, time.UTC),
+		SourceIP:  "172.17.0.2",
+		DestIP:    "140.82.112.3",
+		DestPort:  22,
+		Protocol:  "TCP",
+	}
+	if !events[1].Timestamp.Equal(want.Timestamp) || events[1].DestIP != want.DestIP || events[1].DestPort != want.DestPort {
+		t.Errorf("second event = %+v, want %+v", events[1], want)
+	}
+
+	// Without known sources every container's events are kept
+	if events := parseFirewallLog(output, nil, time.Time{}); len(events) != 4 {
+		t.Errorf("want all 4 events, got %d", len(events))
+	}
+
+	// Only the latest entries are returned
+	var many strings.Builder
+	for i := 0; i < firewallLogLimit+20; i++ {
+		fmt.Fprintf(&many, "2026-10-16T10:%02d:%02d,000000+00:00 MAESTRO_DROP SRC=172.17.0.2 DST=10.0.0.%d PROTO=TCP DPT=80\n", i/60, i%60, i%250)
+	}
+	events = parseFirewallLog(many.String(), nil, time.Time{})
+	if len(events) != firewallLogLimit || events[0].DestIP != "10.0.0.20" {
+		t.Errorf("want the last %d events starting at 10.0.0.20, got %d starting at %+v", firewallLogLimit, len(events), events[0])
+	}
+}
+
+func TestHostLogsContainerNetns(t *testing.T) {
+	dir := t.TempDir()
+	if _, known := hostLogsContainerNetns(filepath.Join(dir, "missing")); known {
+		t.Error("a missing sysctl should be unknown, as with Docker in a VM")
+	}
+	path := filepath.Join(dir, "nf_log_all_netns")
+	for content, want := range map[string]bool{"0\n": false, "1\n": true} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if enabled, known := hostLogsContainerNetns(path); !known || enabled != want {
+			t.Errorf("%q: enabled = %v, known = %v; want %v, true", content, enabled, known, want)
+		}
+	}
+}
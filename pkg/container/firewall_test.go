@@ -0,0 +1,74 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFirewallRules(t *testing.T) {
+	active := `-P OUTPUT DROP
+-A OUTPUT -p udp -m udp --dport 53 -j ACCEPT
+-A OUTPUT -o lo -j ACCEPT
+-A OUTPUT -m state --state RELATED,ESTABLISHED -j ACCEPT
+-A OUTPUT -m set --match-set allowed-domains dst -j ACCEPT
+-A OUTPUT -j REJECT --reject-with icmp-admin-prohibited
+`
+	tests := []struct {
+		name       string
+		output     string
+		wantActive bool
+		wantRules  int
+		wantReason string
+	}{
+		{"active", active, true, 5, ""},
+		{"never applied", "-P OUTPUT ACCEPT\n", false, 0, "outbound policy is ACCEPT"},
+		{"policy set but allowlist missing", "-P OUTPUT DROP\n-A OUTPUT -o lo -j ACCEPT\n", false, 1, "allowlist rule is missing"},
+		{"rules added but policy not yet DROP", "-P OUTPUT ACCEPT\n-A OUTPUT -m set --match-set allowed-domains dst -j ACCEPT\n", false, 1, "outbound policy is ACCEPT"},
+		{"empty", "", false, 0, "could not read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseFirewallRules(tt.output)
+			if got.Active != tt.wantActive || got.Rules != tt.wantRules {
+				t.Errorf("parseFirewallRules() = %+v, want active=%v rules=%d", got, tt.wantActive, tt.wantRules)
+			}
+			if !strings.Contains(got.Reason, tt.wantReason) || (tt.wantReason == "" && got.Reason != "") {
+				t.Errorf("Reason = %q, want containing %q", got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestParseFirewallDNSReady(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"false null", true},
+		{"false []", true},
+		{`true ["127.0.0.1"]`, true},
+		{`true ["8.8.8.8","127.0.0.1"]`, true},
+		{"true null", false},
+		{`true ["8.8.8.8"]`, false},
+		{"true", false},
+	}
+	for _, tt := range tests {
+		if got := parseFirewallDNSReady(tt.output); got != tt.want {
+			t.Errorf("parseFirewallDNSReady(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
@@ -15,14 +15,18 @@
 package container
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
 // infraContainers are maestro infrastructure containers that should be excluded
@@ -52,6 +56,25 @@ func ReadCredentials(path string) (*Credentials, error) {
 	return &creds, nil
 }
 
+// ReadContainerCredentials copies a container's Claude credentials out with
+// docker cp and reads them.
+func ReadContainerCredentials(containerName string) (*Credentials, error) {
+	tmp, err := os.CreateTemp("", "maestro-creds-*.json")
+	if err != nil {
+		return nil, err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	copyCmd := logging.Command("docker", "cp",
+		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName),
+		tmp.Name())
+	if err := logging.Run(copyCmd); err != nil {
+		return nil, fmt.Errorf("failed to copy credentials: %w", err)
+	}
+	return ReadCredentials(tmp.Name())
+}
+
 // IsTokenExpired checks if token is expired (true) or valid (false)
 func IsTokenExpired(creds *Credentials) bool {
 	currentTimeMs := time.Now().UnixMilli()
@@ -66,9 +89,7 @@ func TimeUntilExpiration(creds *Credentials) time.Duration {
 
 // IsDockerResponsive checks if Docker daemon is responding
 func IsDockerResponsive() bool {
-	cmd := exec.Command("docker", "info")
-	err := cmd.Run()
-	return err == nil
+	return CheckDocker() == nil
 }
 
 // FormatExpiration returns human-readable expiration status
@@ -83,6 +104,58 @@ func FormatExpiration(creds *Credentials) string {
 	return fmt.Sprintf("Valid for %.1fd", duration.Hours()/24)
 }
 
+// DefaultPrefix is the default containers.prefix
+const DefaultPrefix = "maestro-"
+
+// prefixPattern is what Docker accepts at the start of a container name.
+var prefixPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ValidatePrefix checks that a container prefix can start a Docker
+// container name.
+func ValidatePrefix(prefix string) error {
+	if !prefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid prefix %q: use letters, digits, '_', '.' and '-', starting with a letter or digit", prefix)
+	}
+	return nil
+}
+
+// NormalizePrefix makes a container prefix end in a separator, so that
+// prefix matching and short names split at a word boundary: "maestro"
+// would otherwise also match "maestrofoo". An empty prefix, which matches
+// every container, becomes DefaultPrefix. Reports whether it changed.
+func NormalizePrefix(prefix string) (string, bool) {
+	if prefix == "" {
+		return DefaultPrefix, true
+	}
+	if strings.HasSuffix(prefix, "-") || strings.HasSuffix(prefix, "_") || strings.HasSuffix(prefix, ".") {
+		return prefix, false
+	}
+	return prefix + "-", true
+}
+
+// UnseparatedPrefixContainers returns the containers named with a prefix
+// NormalizePrefix changed, like "maestrofeat-1" for "maestro", which the
+// normalized prefix no longer matches.
+func UnseparatedPrefixContainers(configured, prefix string) ([]string, error) {
+	output, err := logging.Command("docker", "ps", "-a", "--format", "{{.Names}}").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	return unseparatedPrefixNames(strings.Fields(string(output)), configured, prefix), nil
+}
+
+// unseparatedPrefixNames filters names to those starting with configured
+// but not with prefix.
+func unseparatedPrefixNames(names []string, configured, prefix string) []string {
+	var matched []string
+	for _, name := range names {
+		if strings.HasPrefix(name, configured) && !strings.HasPrefix(name, prefix) {
+			matched = append(matched, name)
+		}
+	}
+	return matched
+}
+
 // GetShortName removes the prefix from a container name
 func GetShortName(containerName, prefix string) string {
 	if strings.HasPrefix(containerName, prefix) {
@@ -93,7 +166,33 @@ func GetShortName(containerName, prefix string) string {
 
 // GetLabel reads a Docker label from a container.
 func GetLabel(containerName, label string) string {
-	cmd := exec.Command("docker", "inspect", "-f", fmt.Sprintf("{{index .Config.Labels %q}}", label), containerName)
+	cmd := logging.Command("docker", "inspect", "-f", fmt.Sprintf("{{index .Config.Labels %q}}", label), containerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	val := strings.TrimSpace(string(output))
+	if val == "<no value>" {
+		return ""
+	}
+	return val
+}
+
+// ImageArchitecture returns the CPU architecture of a local Docker image as
+// reported by docker image inspect (e.g. "amd64", "arm64").
+func ImageArchitecture(image string) (string, error) {
+	cmd := logging.Command("docker", "image", "inspect", "-f", "{{.Architecture}}", image)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image %s: %w", image, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ImageLabel reads a label from a local Docker image, or "" if the image or
+// label does not exist.
+func ImageLabel(image, label string) string {
+	cmd := logging.Command("docker", "image", "inspect", "-f", fmt.Sprintf("{{index .Config.Labels %q}}", label), image)
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -105,6 +204,17 @@ func GetLabel(containerName, label string) string {
 	return val
 }
 
+// ContainerArchitecture returns the CPU architecture of the image a
+// container was created from.
+func ContainerArchitecture(containerName string) (string, error) {
+	cmd := logging.Command("docker", "inspect", "-f", "{{.Image}}", containerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container %s: %w", containerName, err)
+	}
+	return ImageArchitecture(strings.TrimSpace(string(output)))
+}
+
 // readContactsLabel reads and parses the maestro.contacts JSON label from a container.
 func readContactsLabel(containerName string) map[string]map[string]string {
 	raw := GetLabel(containerName, "maestro.contacts")
@@ -118,23 +228,27 @@ func readContactsLabel(containerName string) map[string]map[string]string {
 	return contacts
 }
 
-// getWorkspaceDir returns the primary git workspace directory for a container.
+// GitWorkspace returns the primary git workspace directory for a container.
 // For multi-path projects this is read from the maestro.workspace label;
-// for single-path and ad-hoc containers it defaults to /workspace.
-func getWorkspaceDir(containerName string) string {
+// for single-path and ad-hoc containers it is the container's workspace root.
+func GitWorkspace(containerName string) string {
 	if ws := GetLabel(containerName, "maestro.workspace"); ws != "" {
 		return ws
 	}
-	return "/workspace"
+	return WorkspaceRoot(containerName)
 }
 
 // GetBranchName retrieves the current git branch from a container.
 // For multi-path projects, it uses the maestro.workspace label to identify
-// the primary repo directory. Falls back to /workspace for single-path and ad-hoc containers.
+// the primary repo directory. Falls back to the workspace root for single-path and ad-hoc containers.
 func GetBranchName(containerName string) string {
-	gitDir := getWorkspaceDir(containerName)
+	return branchName(context.Background(), containerName, GitWorkspace(containerName))
+}
 
-	cmd := exec.Command("docker", "exec", containerName, "git", "-C", gitDir, "branch", "--show-current")
+// branchName is GetBranchName for a known repository directory, stopping
+// once ctx is cancelled.
+func branchName(ctx context.Context, containerName, gitDir string) string {
+	cmd := logging.CommandContext(ctx, "docker", "exec", containerName, "git", "-C", gitDir, "branch", "--show-current")
 	output, err := cmd.Output()
 	if err == nil {
 		if branch := strings.TrimSpace(string(output)); branch != "" {
@@ -145,12 +259,39 @@ func GetBranchName(containerName string) string {
 	return "unknown"
 }
 
+// agentStateFile is where maestro-agent records its state in the container.
+const agentStateFile = "/home/node/.maestro/state/agent-state"
+
+// ReadAgentStateSince reads the maestro-agent state along with when it was
+// written, which is when the agent entered that state. The time is zero if
+// the state file doesn't exist.
+func ReadAgentStateSince(containerName string) (string, time.Time) {
+	cmd := logging.Command("docker", "exec", containerName,
+		"sh", "-c", "stat -c %Y "+agentStateFile+" && cat "+agentStateFile)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", time.Time{}
+	}
+	return parseAgentStateSince(string(output))
+}
+
+// parseAgentStateSince parses the "<mtime>\n<state>" output of
+// ReadAgentStateSince.
+func parseAgentStateSince(output string) (string, time.Time) {
+	mtime, state, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	secs, err := strconv.ParseInt(strings.TrimSpace(mtime), 10, 64)
+	if err != nil {
+		return "", time.Time{}
+	}
+	return strings.TrimSpace(state), time.Unix(secs, 0)
+}
+
 // ReadAgentState reads the maestro-agent state from the container.
 // Returns the state string (starting, active, waiting, idle, clearing, connected)
 // or empty string if the state file doesn't exist (pre-maestro-agent containers).
 func ReadAgentState(containerName string) string {
-	cmd := exec.Command("docker", "exec", containerName,
-		"cat", "/home/node/.maestro/state/agent-state")
+	cmd := logging.Command("docker", "exec", containerName,
+		"cat", agentStateFile)
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -164,7 +305,7 @@ func IsClaudeRunning(containerName string) bool {
 	// Search for claude processes using [c]laude to avoid grep matching itself
 	// Then filter out zombies (STAT column starts with 'Z')
 	// The regex matches 7 columns followed by 'Z' at the start of the STAT column
-	cmd := exec.Command("docker", "exec", containerName,
+	cmd := logging.Command("docker", "exec", containerName,
 		"sh", "-c", "ps aux | grep -E '[c]laude' | grep -v -E '^\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+Z'")
 	output, err := cmd.Output()
 	if err != nil {
@@ -178,14 +319,19 @@ func IsClaudeRunning(containerName string) bool {
 
 // GetAuthStatus retrieves the authentication status for a container
 func GetAuthStatus(containerName string) string {
+	return authStatus(context.Background(), containerName)
+}
+
+// authStatus is GetAuthStatus stopping once ctx is cancelled.
+func authStatus(ctx context.Context, containerName string) string {
 	// Extract credentials from container to temp file
 	tmpFile := fmt.Sprintf("/tmp/maestro-creds-%s.json", containerName)
 	defer os.Remove(tmpFile)
 
-	copyCmd := exec.Command("docker", "cp",
+	copyCmd := logging.CommandContext(ctx, "docker", "cp",
 		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName),
 		tmpFile)
-	if err := copyCmd.Run(); err != nil {
+	if err := logging.Run(copyCmd); err != nil {
 		return "✗ NO AUTH"
 	}
 
@@ -208,20 +354,25 @@ func GetAuthStatus(containerName string) string {
 
 // GetRunningContainers returns a list of all running containers with the given prefix
 func GetRunningContainers(prefix string) ([]Info, error) {
-	dockerCmd := exec.Command("docker", "ps", "--format",
-		"{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}\t{{.Label \"maestro.web\"}}")
+	dockerCmd := logging.Command("docker", "ps", "--format",
+		"{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}\t{{.Label \"maestro.web\"}}\t{{.Label \"maestro.firewall\"}}\t{{.Label \"maestro.project\"}}\t{{json (.Label \"maestro.task\")}}\t{{.Image}}\t{{.Networks}}")
 	output, err := dockerCmd.Output()
 	if err != nil {
-		return nil, err
+		return nil, classifyDockerError(err)
 	}
 
 	// Parse basic container info first
 	type basicInfo struct {
-		name      string
-		status    string
-		state     string
-		createdAt time.Time
-		hasWeb    bool
+		name       string
+		status     string
+		state      string
+		createdAt  time.Time
+		hasWeb     bool
+		noFirewall bool
+		hostNet    bool
+		project    string
+		task       string
+		image      string
 	}
 	var basics []basicInfo
 
@@ -255,14 +406,28 @@ func GetRunningContainers(prefix string) ([]Info, error) {
 		}
 
 		basics = append(basics, basicInfo{
-			name:      name,
-			status:    parts[1],
-			state:     parts[2],
-			createdAt: createdAt,
-			hasWeb:    hasWeb,
+			name:       name,
+			status:     parts[1],
+			state:      parts[2],
+			createdAt:  createdAt,
+			hasWeb:     hasWeb,
+			noFirewall: len(parts) > 5 && parts[5] == FirewallDisabled,
+			hostNet:    len(parts) > 9 && parts[9] == NetworkHost,
+			project:    projectLabel(parts),
+			task:       taskLabel(parts),
+			image:      imageField(parts),
 		})
 	}
 
+	// Start times of the running containers, in one inspect
+	var runningNames []string
+	for _, b := range basics {
+		if b.state == "running" {
+			runningNames = append(runningNames, b.name)
+		}
+	}
+	started := startedTimes(runningNames)
+
 	// Fetch detailed info for all containers in parallel
 	containers := make([]Info, len(basics))
 	var wg sync.WaitGroup
@@ -278,13 +443,31 @@ func GetRunningContainers(prefix string) ([]Info, error) {
 				Status:        basic.state,
 				StatusDetails: basic.status,
 				CreatedAt:     basic.createdAt,
+				StartedAt:     started[basic.name],
 				HasWeb:        basic.hasWeb,
+				NoFirewall:    basic.noFirewall,
+				HostNetwork:   basic.hostNet,
+				Project:       basic.project,
+				Task:          basic.task,
+				Image:         basic.image,
 			}
 
 			// Fetch details in parallel
 			var detailWg sync.WaitGroup
 			var mu sync.Mutex
 
+			// Firewall enabled after creation
+			if basic.noFirewall {
+				detailWg.Add(1)
+				go func() {
+					defer detailWg.Done()
+					initialized := firewallInitialized(context.Background(), basic.name)
+					mu.Lock()
+					info.NoFirewall = !initialized
+					mu.Unlock()
+				}()
+			}
+
 			// Branch name
 			detailWg.Add(1)
 			go func() {
@@ -299,9 +482,10 @@ func GetRunningContainers(prefix string) ([]Info, error) {
 			detailWg.Add(1)
 			go func() {
 				defer detailWg.Done()
-				agentState := ReadAgentState(basic.name)
+				agentState, since := ReadAgentStateSince(basic.name)
 				mu.Lock()
 				info.AgentState = agentState
+				info.AgentStateSince = since
 				mu.Unlock()
 			}()
 
@@ -336,20 +520,25 @@ func GetRunningContainers(prefix string) ([]Info, error) {
 
 // GetAllContainers returns a list of all containers (including stopped) with the given prefix
 func GetAllContainers(prefix string) ([]Info, error) {
-	dockerCmd := exec.Command("docker", "ps", "-a", "--format",
-		"{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}\t{{.Label \"maestro.web\"}}")
+	dockerCmd := logging.Command("docker", "ps", "-a", "--format",
+		"{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}\t{{.Label \"maestro.web\"}}\t{{.Label \"maestro.firewall\"}}\t{{.Label \"maestro.project\"}}\t{{json (.Label \"maestro.task\")}}\t{{.Image}}\t{{.Networks}}")
 	output, err := dockerCmd.Output()
 	if err != nil {
-		return nil, err
+		return nil, classifyDockerError(err)
 	}
 
 	// Parse basic container info first
 	type basicInfo struct {
-		name      string
-		status    string
-		state     string
-		createdAt time.Time
-		hasWeb    bool
+		name       string
+		status     string
+		state      string
+		createdAt  time.Time
+		hasWeb     bool
+		noFirewall bool
+		hostNet    bool
+		project    string
+		task       string
+		image      string
 	}
 	var basics []basicInfo
 
@@ -383,14 +572,28 @@ func GetAllContainers(prefix string) ([]Info, error) {
 		}
 
 		basics = append(basics, basicInfo{
-			name:      name,
-			status:    parts[1],
-			state:     parts[2],
-			createdAt: createdAt,
-			hasWeb:    hasWeb,
+			name:       name,
+			status:     parts[1],
+			state:      parts[2],
+			createdAt:  createdAt,
+			hasWeb:     hasWeb,
+			noFirewall: len(parts) > 5 && parts[5] == FirewallDisabled,
+			hostNet:    len(parts) > 9 && parts[9] == NetworkHost,
+			project:    projectLabel(parts),
+			task:       taskLabel(parts),
+			image:      imageField(parts),
 		})
 	}
 
+	// Start times of the running containers, in one inspect
+	var runningNames []string
+	for _, b := range basics {
+		if b.state == "running" {
+			runningNames = append(runningNames, b.name)
+		}
+	}
+	started := startedTimes(runningNames)
+
 	// Fetch detailed info for all containers in parallel
 	containers := make([]Info, len(basics))
 	var wg sync.WaitGroup
@@ -406,7 +609,13 @@ func GetAllContainers(prefix string) ([]Info, error) {
 				Status:        basic.state,
 				StatusDetails: basic.status,
 				CreatedAt:     basic.createdAt,
+				StartedAt:     started[basic.name],
 				HasWeb:        basic.hasWeb,
+				NoFirewall:    basic.noFirewall,
+				HostNetwork:   basic.hostNet,
+				Project:       basic.project,
+				Task:          basic.task,
+				Image:         basic.image,
 				LastActivity:  "-",
 				GitStatus:     "-",
 			}
@@ -416,6 +625,18 @@ func GetAllContainers(prefix string) ([]Info, error) {
 				var detailWg sync.WaitGroup
 				var mu sync.Mutex
 
+				// Firewall enabled after creation
+				if basic.noFirewall {
+					detailWg.Add(1)
+					go func() {
+						defer detailWg.Done()
+						initialized := firewallInitialized(context.Background(), basic.name)
+						mu.Lock()
+						info.NoFirewall = !initialized
+						mu.Unlock()
+					}()
+				}
+
 				// Branch name
 				detailWg.Add(1)
 				go func() {
@@ -430,9 +651,10 @@ func GetAllContainers(prefix string) ([]Info, error) {
 				detailWg.Add(1)
 				go func() {
 					defer detailWg.Done()
-					agentState := ReadAgentState(basic.name)
+					agentState, since := ReadAgentStateSince(basic.name)
 					mu.Lock()
 					info.AgentState = agentState
+					info.AgentStateSince = since
 					mu.Unlock()
 				}()
 
@@ -470,9 +692,10 @@ func GetAllContainers(prefix string) ([]Info, error) {
 				detailWg.Add(1)
 				go func() {
 					defer detailWg.Done()
-					gitStatus := GetGitStatus(basic.name)
+					gitState := GetGitState(basic.name)
 					mu.Lock()
-					info.GitStatus = gitStatus
+					info.Git = gitState
+					info.GitStatus = padGitStatus(gitState.String())
 					mu.Unlock()
 				}()
 
@@ -511,12 +734,44 @@ func GetAllContainers(prefix string) ([]Info, error) {
 	return containers, nil
 }
 
-// GetLastActivity gets the last activity time for a container
+// projectLabel returns the maestro.project column of a docker ps line.
+func projectLabel(parts []string) string {
+	if len(parts) > 6 {
+		return parts[6]
+	}
+	return ""
+}
+
+// imageField returns the image from a docker ps line, or "" if it's missing.
+func imageField(parts []string) string {
+	if len(parts) > 8 {
+		return parts[8]
+	}
+	return ""
+}
+
+// MarkOutdatedImages sets ImageOutdated on the containers whose image isn't
+// one of current, the images this binary uses for new containers. Containers
+// with an unknown image are left alone.
+func MarkOutdatedImages(containers []Info, current []string) {
+	for i := range containers {
+		image := containers[i].Image
+		containers[i].ImageOutdated = image != "" && len(current) > 0 && !slices.Contains(current, image)
+	}
+}
+
+// GetLastActivity gets how long ago the Claude window last had output,
+// which tmux records as window_activity. The daemon tracks activity more
+// precisely; see daemon.ContainerCache.
 func GetLastActivity(containerName string) string {
-	// Check docker container stats for last activity via process CPU usage
-	// For now, we'll use a simpler approach: check tmux pane activity
-	cmd := exec.Command("docker", "exec", containerName,
-		"tmux", "display-message", "-t", "main:0", "-p", "#{pane_active_since}")
+	return lastActivity(context.Background(), containerName, TmuxSession(containerName))
+}
+
+// lastActivity is GetLastActivity for a known tmux session, stopping once
+// ctx is cancelled.
+func lastActivity(ctx context.Context, containerName, session string) string {
+	cmd := logging.CommandContext(ctx, "docker", "exec", containerName,
+		"tmux", "display-message", "-t", session+":0", "-p", "#{window_activity}")
 	output, err := cmd.Output()
 	if err != nil {
 		return "-"
@@ -532,11 +787,56 @@ func GetLastActivity(containerName string) string {
 	lastActive := time.Unix(timestamp, 0)
 	duration := time.Since(lastActive)
 
-	return formatDuration(duration)
+	return FormatDuration(duration)
+}
+
+// startedTimes returns when each of the named containers was last started,
+// from a single docker inspect. Containers it can't read are left out.
+func startedTimes(names []string) map[string]time.Time {
+	started := make(map[string]time.Time, len(names))
+	if len(names) == 0 {
+		return started
+	}
+	args := append([]string{"inspect", "--format", "{{.Name}}\t{{.State.StartedAt}}"}, names...)
+	// inspect exits non-zero if a container vanished, but still prints the rest
+	output, _ := logging.Command("docker", args...).Output()
+	return parseStartedTimes(string(output))
+}
+
+// parseStartedTimes reads the "/name<TAB>startedAt" lines of startedTimes.
+func parseStartedTimes(output string) map[string]time.Time {
+	started := make(map[string]time.Time)
+	for _, line := range strings.Split(output, "\n") {
+		name, ts, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil || t.Year() < 2000 {
+			// Docker reports 0001-01-01 for containers never started
+			continue
+		}
+		started[strings.TrimPrefix(name, "/")] = t
+	}
+	return started
+}
+
+// ShortDuration formats a duration compactly in whole units for table
+// columns: "45s", "12m", "7h", "3d".
+func ShortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
 }
 
-// formatDuration formats a duration in human-readable form
-func formatDuration(d time.Duration) string {
+// FormatDuration formats a duration in human-readable form
+func FormatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%.0fs", d.Seconds())
 	}
@@ -549,54 +849,143 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%.1fd", d.Hours()/24)
 }
 
-// GetGitStatus gets git status indicators for a container
-// Returns a fixed-width string for proper column alignment
-func GetGitStatus(containerName string) string {
-	wsDir := getWorkspaceDir(containerName)
+// GitState summarizes the workspace repository of a running container.
+type GitState struct {
+	Repo        bool // The workspace is a git repository
+	Uncommitted int  // Files with uncommitted changes, untracked included
+	Unpushed    int  // Commits ahead of the upstream branch
+	Behind      int  // Commits behind the upstream branch
+}
 
-	// Check if git repo exists
-	checkCmd := exec.Command("docker", "exec", containerName, "test", "-d", wsDir+"/.git")
-	if err := checkCmd.Run(); err != nil {
-		return padGitStatus("-")
+// String returns the indicators shown in listings: "Δ3 ↑1 ↓2" for
+// uncommitted files, unpushed and unpulled commits, "✓" when in sync and "-"
+// without a repository.
+func (g GitState) String() string {
+	if !g.Repo {
+		return "-"
 	}
-
 	var indicators []string
+	if g.Uncommitted > 0 {
+		indicators = append(indicators, fmt.Sprintf("Δ%d", g.Uncommitted))
+	}
+	if g.Unpushed > 0 {
+		indicators = append(indicators, fmt.Sprintf("↑%d", g.Unpushed))
+	}
+	if g.Behind > 0 {
+		indicators = append(indicators, fmt.Sprintf("↓%d", g.Behind))
+	}
+	if len(indicators) == 0 {
+		return "✓"
+	}
+	return strings.Join(indicators, " ")
+}
 
-	// Check for uncommitted changes
-	statusCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd %s && git status --porcelain 2>/dev/null | wc -l", wsDir))
-	if output, err := statusCmd.Output(); err == nil {
-		count := strings.TrimSpace(string(output))
-		if count != "0" {
-			indicators = append(indicators, fmt.Sprintf("Δ%s", count))
-		}
+// gitStateScript prints whether the workspace is a git repository, then the
+// number of uncommitted files and the commits ahead of and behind the
+// upstream (empty without one), one per line, in a single exec.
+const gitStateScript = `test -d .git || { echo none; exit 0; }
+echo repo
+git status --porcelain 2>/dev/null | wc -l
+git rev-list --count @{u}..HEAD 2>/dev/null || echo
+git rev-list --count HEAD..@{u} 2>/dev/null || echo`
+
+// GetGitState reports the uncommitted changes and unpushed commits in a
+// running container's workspace repository.
+func GetGitState(containerName string) GitState {
+	return gitState(context.Background(), containerName, GitWorkspace(containerName))
+}
+
+// gitState is GetGitState for a known repository directory, stopping once
+// ctx is cancelled.
+func gitState(ctx context.Context, containerName, wsDir string) GitState {
+	output, err := logging.CommandContext(ctx, "docker", "exec", containerName, "sh", "-c",
+		InWorkspace(wsDir, gitStateScript)).Output()
+	if err != nil {
+		return GitState{}
 	}
+	return parseGitState(string(output))
+}
 
-	// Check commits ahead of remote
-	aheadCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd %s && git rev-list --count @{u}..HEAD 2>/dev/null", wsDir))
-	if output, err := aheadCmd.Output(); err == nil {
-		count := strings.TrimSpace(string(output))
-		if count != "0" && count != "" {
-			indicators = append(indicators, fmt.Sprintf("↑%s", count))
-		}
+// unsavedWorkScript is gitStateScript for deciding whether deleting a
+// container loses work. A branch without an upstream, like the ones maestro
+// creates, counts the commits on no remote branch as unpushed.
+const unsavedWorkScript = `test -d .git || { echo none; exit 0; }
+echo repo
+git status --porcelain 2>/dev/null | wc -l
+git rev-list --count @{u}..HEAD 2>/dev/null || git rev-list --count HEAD --not --remotes 2>/dev/null || echo
+echo`
+
+// GetUnsavedWork reports the uncommitted files and unpushed commits in a
+// running container's workspace, which deleting the container would lose.
+// Behind is not filled in. It fails when the container can't be inspected,
+// for example because it is stopped.
+func GetUnsavedWork(containerName string) (GitState, error) {
+	wsDir := GitWorkspace(containerName)
+	output, err := logging.Command("docker", "exec", containerName, "sh", "-c",
+		InWorkspace(wsDir, unsavedWorkScript)).Output()
+	if err != nil {
+		return GitState{}, fmt.Errorf("failed to check git state of %s: %w", containerName, err)
 	}
+	return parseGitState(string(output)), nil
+}
 
-	// Check commits behind remote
-	behindCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		fmt.Sprintf("cd %s && git rev-list --count HEAD..@{u} 2>/dev/null", wsDir))
-	if output, err := behindCmd.Output(); err == nil {
-		count := strings.TrimSpace(string(output))
-		if count != "0" && count != "" {
-			indicators = append(indicators, fmt.Sprintf("↓%s", count))
+// parseGitState reads the output of gitStateScript.
+func parseGitState(output string) GitState {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "repo" {
+		return GitState{}
+	}
+	count := func(i int) int {
+		if i >= len(lines) {
+			return 0
 		}
+		n, _ := strconv.Atoi(strings.TrimSpace(lines[i]))
+		return n
 	}
+	return GitState{Repo: true, Uncommitted: count(1), Unpushed: count(2), Behind: count(3)}
+}
 
-	if len(indicators) == 0 {
-		return padGitStatus("✓")
+// MaxDirtyFiles is how many uncommitted files GetDirtyFiles lists.
+const MaxDirtyFiles = 20
+
+// GetDirtyFiles lists up to MaxDirtyFiles files with uncommitted changes in a
+// running container's workspace, as "git status --porcelain" lines such as
+// "M  main.go" or "?? notes.txt".
+func GetDirtyFiles(containerName string) ([]string, error) {
+	return dirtyFiles(context.Background(), containerName, GitWorkspace(containerName))
+}
+
+// dirtyFiles is GetDirtyFiles for a known repository directory, stopping
+// once ctx is cancelled.
+func dirtyFiles(ctx context.Context, containerName, wsDir string) ([]string, error) {
+	output, err := logging.CommandContext(ctx, "docker", "exec", containerName, "sh", "-c",
+		InWorkspace(wsDir, fmt.Sprintf("git status --porcelain 2>/dev/null | head -n %d", MaxDirtyFiles))).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files in %s: %w", containerName, err)
+	}
+	return parseDirtyFiles(string(output)), nil
+}
+
+// parseDirtyFiles splits git status --porcelain output into lines, keeping
+// at most MaxDirtyFiles.
+func parseDirtyFiles(output string) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		files = append(files, strings.TrimRight(line, "\r"))
+		if len(files) == MaxDirtyFiles {
+			break
+		}
 	}
+	return files
+}
 
-	return padGitStatus(strings.Join(indicators, " "))
+// GetGitStatus gets git status indicators for a container
+// Returns a fixed-width string for proper column alignment
+func GetGitStatus(containerName string) string {
+	return padGitStatus(GetGitState(containerName).String())
 }
 
 // padGitStatus pads git status to fixed width for alignment
@@ -611,8 +1000,14 @@ func padGitStatus(status string) string {
 
 // GetContainerDetails fetches comprehensive information about a container
 func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error) {
+	return GetContainerDetailsContext(context.Background(), containerName, prefix)
+}
+
+// GetContainerDetailsContext is GetContainerDetails that stops once ctx is
+// cancelled, killing the docker command in progress and skipping the rest.
+func GetContainerDetailsContext(ctx context.Context, containerName, prefix string) (*ContainerDetails, error) {
 	// Use docker inspect to get detailed container info
-	inspectCmd := exec.Command("docker", "inspect", containerName)
+	inspectCmd := logging.CommandContext(ctx, "docker", "inspect", containerName)
 	output, err := inspectCmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to inspect container: %w", err)
@@ -641,12 +1036,19 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 			details.Status = status
 		}
 		if startedAt, ok := state["StartedAt"].(string); ok {
-			if started, err := time.Parse(time.RFC3339Nano, startedAt); err == nil {
-				uptime := time.Since(started)
-				details.Uptime = formatDuration(uptime)
+			if started, err := time.Parse(time.RFC3339Nano, startedAt); err == nil && started.Year() >= 2000 {
+				details.StartedAt = started
+				if details.Status == "running" {
+					details.Uptime = FormatDuration(time.Since(started))
+				}
 			}
 		}
 	}
+	if created, ok := data["Created"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, created); err == nil {
+			details.CreatedAt = t
+		}
+	}
 
 	// Extract host config (resources)
 	if hostConfig, ok := data["HostConfig"].(map[string]interface{}); ok {
@@ -656,6 +1058,9 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 			details.CPUs = "unlimited"
 		}
 
+		networkMode, _ := hostConfig["NetworkMode"].(string)
+		details.NetworkMode = normalizeNetworkMode(networkMode)
+
 		if memory, ok := hostConfig["Memory"].(float64); ok && memory > 0 {
 			details.Memory = fmt.Sprintf("%.1f GB", memory/(1024*1024*1024))
 		} else {
@@ -695,7 +1100,13 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 	}
 
 	// Extract environment variables (filter sensitive ones)
+	var gitDir string
+	session := DefaultTmuxSession
 	if config, ok := data["Config"].(map[string]interface{}); ok {
+		if image, ok := config["Image"].(string); ok {
+			details.Image = image
+		}
+
 		if env, ok := config["Env"].([]interface{}); ok {
 			for _, e := range env {
 				if envStr, ok := e.(string); ok {
@@ -711,14 +1122,43 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 		if status, ok := config["Status"].(string); ok {
 			details.StatusDetails = status
 		}
+
+		if labels, ok := config["Labels"].(map[string]interface{}); ok {
+			if raw, ok := labels[SyncedFoldersLabel].(string); ok {
+				details.SyncedFolders = parseSyncedFolders(raw)
+			}
+			details.NoFirewall = labels[FirewallLabel] == FirewallDisabled
+			details.Task, _ = labels[TaskLabel].(string)
+			// What GitWorkspace and TmuxSession would look up again
+			gitDir, _ = labels["maestro.workspace"].(string)
+			if gitDir == "" {
+				gitDir, _ = labels[WorkspaceRootLabel].(string)
+			}
+			if s, _ := labels[TmuxSessionLabel].(string); ValidTmuxSessionName(s) {
+				session = s
+			}
+		}
+	}
+	if gitDir == "" {
+		gitDir = DefaultWorkspace
 	}
 
 	// Get branch, git status, and auth status from existing functions
-	details.Branch = GetBranchName(containerName)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	details.Branch = branchName(ctx, containerName, gitDir)
 	if details.Status == "running" {
-		details.GitStatus = GetGitStatus(containerName)
-		details.AuthStatus = GetAuthStatus(containerName)
-		details.LastActivity = GetLastActivity(containerName)
+		state := gitState(ctx, containerName, gitDir)
+		details.GitStatus = padGitStatus(state.String())
+		if state.Uncommitted > 0 {
+			details.DirtyFiles, _ = dirtyFiles(ctx, containerName, gitDir)
+		}
+		details.AuthStatus = authStatus(ctx, containerName)
+		details.LastActivity = lastActivity(ctx, containerName, session)
+		if details.NoFirewall {
+			details.NoFirewall = !firewallInitialized(ctx, containerName)
+		}
 	} else {
 		details.GitStatus = "-"
 		details.AuthStatus = "-"
@@ -726,7 +1166,10 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 	}
 
 	// Get recent logs (last 50 lines)
-	logsCmd := exec.Command("docker", "logs", "--tail", "50", containerName)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	logsCmd := logging.CommandContext(ctx, "docker", "logs", "--tail", "50", containerName)
 	logsOutput, err := logsCmd.CombinedOutput()
 	if err == nil {
 		details.RecentLogs = string(logsOutput)
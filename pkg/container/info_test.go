@@ -0,0 +1,223 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetShortName(t *testing.T) {
+	tests := []struct {
+		name, containerName, prefix, want string
+	}{
+		{"has prefix", "maestro-feat-auth-1", "maestro-", "feat-auth-1"},
+		{"number suffix", "maestro-fix-bug-12", "maestro-", "fix-bug-12"},
+		{"equals prefix", "maestro-", "maestro-", ""},
+		{"no prefix", "other-container", "maestro-", "other-container"},
+		{"legacy prefix kept", "mcl-feat-1", "maestro-", "mcl-feat-1"},
+		{"prefix only stripped once", "maestro-maestro-x-1", "maestro-", "maestro-x-1"},
+		{"prefix elsewhere in name", "feat-maestro-1", "maestro-", "feat-maestro-1"},
+		{"empty name", "", "maestro-", ""},
+		{"empty prefix", "maestro-feat-1", "", "maestro-feat-1"},
+		{"prefix longer than name", "mae", "maestro-", "mae"},
+		{"regex characters in prefix", "m.*+?[x]-feat-1", "m.*+?[x]-", "feat-1"},
+		{"regex prefix does not match as pattern", "mabc-feat-1", "m.*-", "mabc-feat-1"},
+		{"case sensitive", "Maestro-feat-1", "maestro-", "Maestro-feat-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetShortName(tt.containerName, tt.prefix); got != tt.want {
+				t.Errorf("GetShortName(%q, %q) = %q, want %q", tt.containerName, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePrefix(t *testing.T) {
+	tests := []struct {
+		prefix, want string
+		changed      bool
+	}{
+		{"maestro-", "maestro-", false},
+		{"box_", "box_", false},
+		{"team.", "team.", false},
+		{"maestro", "maestro-", true},
+		{"", DefaultPrefix, true},
+	}
+	for _, tt := range tests {
+		got, changed := NormalizePrefix(tt.prefix)
+		if got != tt.want || changed != tt.changed {
+			t.Errorf("NormalizePrefix(%q) = %q, %v; want %q, %v", tt.prefix, got, changed, tt.want, tt.changed)
+		}
+	}
+
+	// Without the separator a prefix matches unrelated names and leaves
+	// the separator on the short name
+	if got := GetShortName("maestrofoo-1", "maestro"); got != "foo-1" {
+		t.Errorf("unseparated prefix: GetShortName = %q", got)
+	}
+	if got := GetShortName("maestro-feat-1", "maestro"); got != "-feat-1" {
+		t.Errorf("unseparated prefix: GetShortName = %q", got)
+	}
+	prefix, _ := NormalizePrefix("maestro")
+	if got := GetShortName("maestrofoo-1", prefix); got != "maestrofoo-1" {
+		t.Errorf("normalized prefix should not match maestrofoo-1, got %q", got)
+	}
+	if got := GetShortName("maestro-feat-1", prefix); got != "feat-1" {
+		t.Errorf("normalized prefix: GetShortName = %q, want feat-1", got)
+	}
+}
+
+func TestUnseparatedPrefixNames(t *testing.T) {
+	names := []string{"maestrofeat-1", "maestro-fix-2", "postgres", "maestroapi-3"}
+	got := unseparatedPrefixNames(names, "maestro", "maestro-")
+	if want := []string{"maestrofeat-1", "maestroapi-3"}; !slices.Equal(got, want) {
+		t.Errorf("unseparatedPrefixNames = %v, want %v", got, want)
+	}
+}
+
+func TestValidatePrefix(t *testing.T) {
+	for _, prefix := range []string{"maestro-", "mcl-", "Team_1."} {
+		if err := ValidatePrefix(prefix); err != nil {
+			t.Errorf("ValidatePrefix(%q) = %v", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"-maestro", "my box-", "a/b-", ""} {
+		if ValidatePrefix(prefix) == nil {
+			t.Errorf("ValidatePrefix(%q) should fail", prefix)
+		}
+	}
+}
+
+// TestGetShortName_RoundTrip checks the invariant container listing relies
+// on: names that pass the prefix filter can be rebuilt from their short name.
+func TestGetShortName_RoundTrip(t *testing.T) {
+	prefix := "maestro-"
+	for _, name := range []string{"maestro-feat-1", "maestro-maestro-1", "maestro-a", "maestro-"} {
+		if got := prefix + GetShortName(name, prefix); got != name {
+			t.Errorf("prefix + GetShortName(%q) = %q, want %q", name, got, name)
+		}
+	}
+}
+
+func TestParseAgentStateSince(t *testing.T) {
+	state, since := parseAgentStateSince("1767225600\nwaiting\n")
+	if state != "waiting" || !since.Equal(time.Unix(1767225600, 0)) {
+		t.Errorf("got (%q, %v)", state, since)
+	}
+	if state, since := parseAgentStateSince("stat: cannot stat\n"); state != "" || !since.IsZero() {
+		t.Errorf("unparsable output: got (%q, %v), want empty", state, since)
+	}
+}
+
+func TestMarkOutdatedImages(t *testing.T) {
+	containers := []Info{
+		{Name: "current", Image: "ghcr.io/uprockcom/maestro:1.4.0"},
+		{Name: "web", Image: "ghcr.io/uprockcom/maestro-web:1.4.0"},
+		{Name: "old", Image: "ghcr.io/uprockcom/maestro:1.3.2"},
+		{Name: "unknown", ImageOutdated: true},
+	}
+	MarkOutdatedImages(containers, []string{"ghcr.io/uprockcom/maestro:1.4.0", "ghcr.io/uprockcom/maestro-web:1.4.0"})
+
+	want := map[string]bool{"current": false, "web": false, "old": true, "unknown": false}
+	for _, c := range containers {
+		if c.ImageOutdated != want[c.Name] {
+			t.Errorf("%s: ImageOutdated = %v, want %v", c.Name, c.ImageOutdated, want[c.Name])
+		}
+	}
+
+	MarkOutdatedImages(containers, nil)
+	for _, c := range containers {
+		if c.ImageOutdated {
+			t.Errorf("%s: marked outdated with no current images", c.Name)
+		}
+	}
+}
+
+func TestParseGitState(t *testing.T) {
+	tests := []struct {
+		output string
+		want   GitState
+	}{
+		{"none\n", GitState{}},
+		{"", GitState{}},
+		{"repo\n       3\n1\n0\n", GitState{Repo: true, Uncommitted: 3, Unpushed: 1}},
+		{"repo\n0\n\n\n", GitState{Repo: true}},
+	}
+	for _, tt := range tests {
+		if got := parseGitState(tt.output); got != tt.want {
+			t.Errorf("parseGitState(%q) = %+v, want %+v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestParseDirtyFiles(t *testing.T) {
+	got := parseDirtyFiles(" M main.go\n?? notes.txt\n\n")
+	if len(got) != 2 || got[0] != " M main.go" || got[1] != "?? notes.txt" {
+		t.Errorf("parseDirtyFiles = %q", got)
+	}
+
+	many := strings.Repeat("?? file\n", MaxDirtyFiles+5)
+	if got := parseDirtyFiles(many); len(got) != MaxDirtyFiles {
+		t.Errorf("parseDirtyFiles kept %d files, want %d", len(got), MaxDirtyFiles)
+	}
+}
+
+func TestGitStateString(t *testing.T) {
+	tests := []struct {
+		state GitState
+		want  string
+	}{
+		{GitState{}, "-"},
+		{GitState{Repo: true}, "✓"},
+		{GitState{Repo: true, Behind: 2}, "↓2"},
+		{GitState{Repo: true, Uncommitted: 3, Unpushed: 1, Behind: 2}, "Δ3 ↑1 ↓2"},
+	}
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestShortDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		-time.Second:                 "0s",
+		45 * time.Second:             "45s",
+		12*time.Minute + time.Second: "12m",
+		7*time.Hour + 59*time.Minute: "7h",
+		3*24*time.Hour + 5*time.Hour: "3d",
+		40 * 24 * time.Hour:          "40d",
+	}
+	for d, want := range tests {
+		if got := ShortDuration(d); got != want {
+			t.Errorf("ShortDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestParseStartedTimes(t *testing.T) {
+	out := "/maestro-a-1\t2026-10-12T09:14:03.123456789Z\n/maestro-b-1\t0001-01-01T00:00:00Z\ngarbage\n"
+	started := parseStartedTimes(out)
+	if len(started) != 1 {
+		t.Fatalf("parseStartedTimes = %v, want only maestro-a-1", started)
+	}
+	if want := time.Date(2026, 10, 12, 9, 14, 3, 123456789, time.UTC); !started["maestro-a-1"].Equal(want) {
+		t.Errorf("maestro-a-1 started %v, want %v", started["maestro-a-1"], want)
+	}
+}
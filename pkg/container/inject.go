@@ -16,50 +16,52 @@ package container
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/notify"
 )
 
 // InjectTextToContainer sends text into a container's Claude tmux pane.
 // It writes the message to a temp file, loads it into a tmux buffer, pastes
-// it into window 0 of the "main" session, presses Enter, and cleans up.
+// it into window 0 of the container's session, presses Enter, and cleans up.
 func InjectTextToContainer(containerName, text string) error {
+	claudePane := TmuxSession(containerName) + ":0"
+
 	// Write message to temp file in container
-	writeCmd := exec.Command("docker", "exec", "-i", containerName, "tee", "/tmp/maestro-msg")
+	writeCmd := logging.Command("docker", "exec", "-i", containerName, "tee", "/tmp/maestro-msg")
 	writeCmd.Stdin = strings.NewReader(text)
 	writeCmd.Stdout = nil // suppress tee echo
-	if err := writeCmd.Run(); err != nil {
+	if err := logging.Run(writeCmd); err != nil {
 		return fmt.Errorf("failed to write message to container: %w", err)
 	}
 
 	// Load into tmux buffer
-	loadCmd := exec.Command("docker", "exec", containerName, "tmux", "load-buffer", "/tmp/maestro-msg")
-	if err := loadCmd.Run(); err != nil {
+	loadCmd := logging.Command("docker", "exec", containerName, "tmux", "load-buffer", "/tmp/maestro-msg")
+	if err := logging.Run(loadCmd); err != nil {
 		return fmt.Errorf("failed to load tmux buffer: %w", err)
 	}
 
 	// Paste into Claude pane
-	pasteCmd := exec.Command("docker", "exec", containerName, "tmux", "paste-buffer", "-t", "main:0", "-d")
-	if err := pasteCmd.Run(); err != nil {
+	pasteCmd := logging.Command("docker", "exec", containerName, "tmux", "paste-buffer", "-t", claudePane, "-d")
+	if err := logging.Run(pasteCmd); err != nil {
 		return fmt.Errorf("failed to paste message: %w", err)
 	}
 
 	// Press enter
-	enterCmd := exec.Command("docker", "exec", containerName, "tmux", "send-keys", "-t", "main:0", "C-m")
-	if err := enterCmd.Run(); err != nil {
+	enterCmd := logging.Command("docker", "exec", containerName, "tmux", "send-keys", "-t", claudePane, "C-m")
+	if err := logging.Run(enterCmd); err != nil {
 		return fmt.Errorf("failed to send enter key: %w", err)
 	}
 
 	// Clean up temp file (best-effort)
-	cleanCmd := exec.Command("docker", "exec", containerName, "rm", "-f", "/tmp/maestro-msg")
-	_ = cleanCmd.Run()
+	cleanCmd := logging.Command("docker", "exec", containerName, "rm", "-f", "/tmp/maestro-msg")
+	_ = logging.Run(cleanCmd)
 
 	// Remove idle flag proactively (prevents race with hooks)
-	rmIdleCmd := exec.Command("docker", "exec", containerName, "rm", "-f", "/home/node/.maestro/claude-idle")
-	_ = rmIdleCmd.Run()
+	rmIdleCmd := logging.Command("docker", "exec", containerName, "rm", "-f", "/home/node/.maestro/claude-idle")
+	_ = logging.Run(rmIdleCmd)
 
 	return nil
 }
@@ -76,10 +78,10 @@ func QueueMessage(containerName, message string) error {
 	filename := fmt.Sprintf("/home/node/.maestro/pending-messages/%s.txt", ts)
 
 	// Write message to queue
-	writeCmd := exec.Command("docker", "exec", "-i", containerName, "tee", filename)
+	writeCmd := logging.Command("docker", "exec", "-i", containerName, "tee", filename)
 	writeCmd.Stdin = strings.NewReader(message)
 	writeCmd.Stdout = nil
-	if err := writeCmd.Run(); err != nil {
+	if err := logging.Run(writeCmd); err != nil {
 		return fmt.Errorf("failed to queue message: %w", err)
 	}
 
@@ -99,11 +101,11 @@ func WriteQuestionResponse(containerName string, selections []string, text strin
 	qd, _ := notify.ReadContainerQuestion(containerName)
 	answer := formatQuestionAnswer(qd, selections, text)
 
-	writeCmd := exec.Command("docker", "exec", "-i", containerName,
+	writeCmd := logging.Command("docker", "exec", "-i", containerName,
 		"tee", "/home/node/.maestro/question-response.txt")
 	writeCmd.Stdin = strings.NewReader(answer)
 	writeCmd.Stdout = nil // suppress tee echo
-	if err := writeCmd.Run(); err != nil {
+	if err := logging.Run(writeCmd); err != nil {
 		return fmt.Errorf("failed to write question response: %w", err)
 	}
 	return nil
@@ -0,0 +1,114 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// DefaultMaxContainers is the default daemon.max_containers limit
+const DefaultMaxContainers = 20
+
+// DefaultMaxContextTokens is the default containers.max_context_tokens
+// budget for planning prompts
+const DefaultMaxContextTokens = 8000
+
+// UlimitTypes are the ulimits containers.ulimits may set.
+var UlimitTypes = []string{"nofile", "nproc", "stack", "core", "memlock"}
+
+// ParseUlimit checks a containers.ulimits entry in Docker's
+// <type>=<soft>[:<hard>] format and returns its type. Limits are numbers, or
+// -1 for unlimited; the soft limit may not exceed the hard one.
+func ParseUlimit(spec string) (string, error) {
+	name, limits, ok := strings.Cut(spec, "=")
+	if !ok || !slices.Contains(UlimitTypes, name) {
+		return "", fmt.Errorf("invalid ulimit %q: use <type>=<soft>[:<hard>] with type %s", spec, strings.Join(UlimitTypes, ", "))
+	}
+	softStr, hardStr, hasHard := strings.Cut(limits, ":")
+	parse := func(s string) (int64, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < -1 {
+			return 0, fmt.Errorf("invalid ulimit %q: %q is not a number or -1 (unlimited)", spec, s)
+		}
+		return n, nil
+	}
+	soft, err := parse(softStr)
+	if err != nil {
+		return "", err
+	}
+	if hasHard {
+		hard, err := parse(hardStr)
+		if err != nil {
+			return "", err
+		}
+		if hard != -1 && (soft == -1 || soft > hard) {
+			return "", fmt.Errorf("invalid ulimit %q: the soft limit is above the hard limit", spec)
+		}
+	}
+	return name, nil
+}
+
+// RunningContainerNames returns the names of running containers with the
+// given prefix. Unlike GetRunningContainers it only lists names, so it is
+// cheap enough to call before every creation.
+func RunningContainerNames(prefix string) ([]string, error) {
+	cmd := logging.Command("docker", "ps", "--format", "{{.Names}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range strings.Split(string(output), "\n") {
+		name = strings.TrimSpace(name)
+		if name != "" && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// CountRunningContainers returns the number of running containers with the
+// given prefix.
+func CountRunningContainers(prefix string) (int, error) {
+	names, err := RunningContainerNames(prefix)
+	if err != nil {
+		return 0, err
+	}
+	return len(names), nil
+}
+
+// NearContainerLimit reports whether count is above 80% of max.
+// A max of zero or less disables the limit.
+func NearContainerLimit(count, max int) bool {
+	if max <= 0 {
+		return false
+	}
+	return count*5 > max*4
+}
+
+// AtContainerLimit reports whether creating one more container would exceed
+// max. A max of zero or less disables the limit.
+func AtContainerLimit(count, max int) bool {
+	if max <= 0 {
+		return false
+	}
+	return count >= max
+}
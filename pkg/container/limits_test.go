@@ -0,0 +1,77 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestParseUlimit(t *testing.T) {
+	valid := map[string]string{
+		"nofile=65536:65536": "nofile",
+		"nproc=4096":         "nproc",
+		"core=-1":            "core",
+		"memlock=-1:-1":      "memlock",
+		"stack=8192:-1":      "stack",
+	}
+	for spec, want := range valid {
+		if got, err := ParseUlimit(spec); err != nil || got != want {
+			t.Errorf("ParseUlimit(%q) = %q, %v; want %q", spec, got, err, want)
+		}
+	}
+
+	for _, spec := range []string{"", "nofile", "fsize=100", "nofile=lots", "nofile=1024:abc", "nofile=2048:1024", "nproc=-1:100", "nofile=-2"} {
+		if _, err := ParseUlimit(spec); err == nil {
+			t.Errorf("ParseUlimit(%q) should fail", spec)
+		}
+	}
+}
+
+func TestNearContainerLimit(t *testing.T) {
+	tests := []struct {
+		count, max int
+		want       bool
+	}{
+		{0, 20, false},
+		{16, 20, false}, // exactly 80% is not "above"
+		{17, 20, true},
+		{20, 20, true},
+		{25, 20, true},
+		{100, 0, false}, // disabled
+		{100, -1, false},
+	}
+	for _, tc := range tests {
+		if got := NearContainerLimit(tc.count, tc.max); got != tc.want {
+			t.Errorf("NearContainerLimit(%d, %d) = %v, want %v", tc.count, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestAtContainerLimit(t *testing.T) {
+	tests := []struct {
+		count, max int
+		want       bool
+	}{
+		{19, 20, false},
+		{20, 20, true},
+		{21, 20, true},
+		{0, 1, false},
+		{1, 1, true},
+		{50, 0, false}, // disabled
+	}
+	for _, tc := range tests {
+		if got := AtContainerLimit(tc.count, tc.max); got != tc.want {
+			t.Errorf("AtContainerLimit(%d, %d) = %v, want %v", tc.count, tc.max, got, tc.want)
+		}
+	}
+}
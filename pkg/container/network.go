@@ -0,0 +1,95 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+const (
+	// NetworkBridge is Docker's default bridge network, used unless
+	// containers.network_mode says otherwise
+	NetworkBridge = "bridge"
+
+	// NetworkHost shares the host's network stack. The firewall can't be used
+	// there, since its iptables rules would apply to the host.
+	NetworkHost = "host"
+)
+
+// networkNamePattern matches the names Docker accepts for networks.
+var networkNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ValidateNetworkMode checks a containers.network_mode value: bridge, host
+// or the name of a Docker network. Empty means bridge.
+func ValidateNetworkMode(mode string) error {
+	if mode == "" || mode == NetworkBridge || mode == NetworkHost {
+		return nil
+	}
+	if !networkNamePattern.MatchString(mode) {
+		return fmt.Errorf("invalid network_mode %q: use bridge, host or a Docker network name", mode)
+	}
+	return nil
+}
+
+// NetworkArgs returns the docker run arguments for a network mode, creating
+// a named network first if it doesn't exist.
+func NetworkArgs(mode string) ([]string, error) {
+	if err := ValidateNetworkMode(mode); err != nil {
+		return nil, err
+	}
+	switch mode {
+	case "", NetworkBridge:
+		return nil, nil
+	case NetworkHost:
+		return []string{"--network", NetworkHost}, nil
+	}
+	if err := ensureNetwork(mode); err != nil {
+		return nil, err
+	}
+	return []string{"--network", mode}, nil
+}
+
+// NetworkMode returns the network mode a container was created with.
+func NetworkMode(containerName string) (string, error) {
+	out, err := logging.Command("docker", "inspect", "-f", "{{.HostConfig.NetworkMode}}", containerName).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return normalizeNetworkMode(strings.TrimSpace(string(out))), nil
+}
+
+// normalizeNetworkMode maps Docker's "default" network mode to bridge.
+func normalizeNetworkMode(mode string) string {
+	if mode == "" || mode == "default" {
+		return NetworkBridge
+	}
+	return mode
+}
+
+// ensureNetwork creates a Docker network if it doesn't exist.
+func ensureNetwork(name string) error {
+	if logging.Run(logging.Command("docker", "network", "inspect", name)) == nil {
+		return nil
+	}
+	logging.Infof("Creating Docker network %s...", name)
+	if out, err := logging.Command("docker", "network", "create", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create network %s: %s", name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestValidateNetworkMode(t *testing.T) {
+	for _, mode := range []string{"", "bridge", "host", "my-net", "proj_net.1"} {
+		if err := ValidateNetworkMode(mode); err != nil {
+			t.Errorf("ValidateNetworkMode(%q) = %v, want nil", mode, err)
+		}
+	}
+	for _, mode := range []string{"my net", "-net", "net/1", "container:x"} {
+		if ValidateNetworkMode(mode) == nil {
+			t.Errorf("ValidateNetworkMode(%q) should fail", mode)
+		}
+	}
+}
+
+func TestNormalizeNetworkMode(t *testing.T) {
+	tests := map[string]string{"": "bridge", "default": "bridge", "host": "host", "my-net": "my-net"}
+	for in, want := range tests {
+		if got := normalizeNetworkMode(in); got != want {
+			t.Errorf("normalizeNetworkMode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	// OAuthTokenURL is the endpoint Claude Code exchanges refresh tokens at.
+	OAuthTokenURL = "https://console.anthropic.com/v1/oauth/token"
+
+	// oauthClientID is Claude Code's public OAuth client ID.
+	oauthClientID = "9d1c250a-e61b-44d9-88ed-5944d1962f5e"
+)
+
+// ErrRefreshTokenExpired means the refresh token was rejected, so only a full
+// 'maestro auth' can get new credentials.
+var ErrRefreshTokenExpired = errors.New("refresh token is expired or revoked")
+
+// oauthTokenResponse is the subset of the token endpoint's response we need.
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // seconds
+	Error        string `json:"error"`
+}
+
+// RefreshOAuthToken exchanges the refresh token in creds for a new access
+// token at tokenURL, returning updated credentials. The endpoint may rotate
+// the refresh token too, which invalidates the old one wherever it is copied.
+func RefreshOAuthToken(creds *Credentials, tokenURL string) (*Credentials, error) {
+	if creds.ClaudeAiOauth.RefreshToken == "" {
+		return nil, fmt.Errorf("credentials have no refresh token: %w", ErrRefreshTokenExpired)
+	}
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": creds.ClaudeAiOauth.RefreshToken,
+		"client_id":     oauthClientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(tokenURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	var tok oauthTokenResponse
+	jsonErr := json.Unmarshal(data, &tok)
+	switch {
+	case tok.Error == "invalid_grant" || resp.StatusCode == http.StatusUnauthorized:
+		return nil, ErrRefreshTokenExpired
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("token endpoint returned %s", resp.Status)
+	case jsonErr != nil:
+		return nil, fmt.Errorf("failed to parse token response: %w", jsonErr)
+	case tok.AccessToken == "":
+		return nil, fmt.Errorf("token response has no access token")
+	}
+
+	updated := *creds
+	updated.ClaudeAiOauth.AccessToken = tok.AccessToken
+	if tok.RefreshToken != "" {
+		updated.ClaudeAiOauth.RefreshToken = tok.RefreshToken
+	}
+	updated.ClaudeAiOauth.ExpiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second).UnixMilli()
+	return &updated, nil
+}
+
+// WriteCredentials writes creds to path, keeping any other top-level entries
+// already in the file.
+func WriteCredentials(path string, creds *Credentials) error {
+	file := make(map[string]json.RawMessage)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	oauth, err := json.Marshal(creds.ClaudeAiOauth)
+	if err != nil {
+		return err
+	}
+	file["claudeAiOauth"] = oauth
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
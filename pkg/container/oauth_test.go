@@ -0,0 +1,90 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCredentials() *Credentials {
+	var creds Credentials
+	creds.ClaudeAiOauth.AccessToken = "old-access"
+	creds.ClaudeAiOauth.RefreshToken = "old-refresh"
+	creds.ClaudeAiOauth.SubscriptionType = "max"
+	return &creds
+}
+
+func TestRefreshOAuthToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["grant_type"] != "refresh_token" || req["refresh_token"] != "old-refresh" {
+			t.Errorf("unexpected request %v", req)
+		}
+		w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	got, err := RefreshOAuthToken(testCredentials(), srv.URL)
+	if err != nil {
+		t.Fatalf("RefreshOAuthToken: %v", err)
+	}
+	if got.ClaudeAiOauth.AccessToken != "new-access" || got.ClaudeAiOauth.RefreshToken != "new-refresh" {
+		t.Errorf("tokens not updated: %+v", got.ClaudeAiOauth)
+	}
+	if got.ClaudeAiOauth.SubscriptionType != "max" {
+		t.Errorf("SubscriptionType = %q, want it kept", got.ClaudeAiOauth.SubscriptionType)
+	}
+	if d := TimeUntilExpiration(got); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("expires in %v, want about an hour", d)
+	}
+}
+
+func TestRefreshOAuthToken_Expired(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer srv.Close()
+
+	if _, err := RefreshOAuthToken(testCredentials(), srv.URL); !errors.Is(err, ErrRefreshTokenExpired) {
+		t.Errorf("err = %v, want ErrRefreshTokenExpired", err)
+	}
+}
+
+func TestWriteCredentials_KeepsOtherEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".credentials.json")
+	os.WriteFile(path, []byte(`{"mcpOAuth":{"x":1},"claudeAiOauth":{"accessToken":"old"}}`), 0600)
+
+	if err := WriteCredentials(path, testCredentials()); err != nil {
+		t.Fatalf("WriteCredentials: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"mcpOAuth"`) {
+		t.Errorf("other entries dropped: %s", data)
+	}
+	creds, err := ReadCredentials(path)
+	if err != nil || creds.ClaudeAiOauth.AccessToken != "old-access" {
+		t.Errorf("ReadCredentials = %+v, %v", creds, err)
+	}
+}
@@ -18,11 +18,11 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/uprockcom/maestro/pkg/logging"
 	"github.com/uprockcom/maestro/pkg/paths"
 )
 
@@ -94,10 +94,28 @@ const (
 	OperationUpdateResources OperationType = "update-resources"
 )
 
+// Progress describes the operation while it runs ("Restarting").
+func (o OperationType) Progress() string {
+	switch o {
+	case OperationStop:
+		return "Stopping"
+	case OperationRestart:
+		return "Restarting"
+	case OperationDelete:
+		return "Deleting"
+	case OperationRefreshTokens:
+		return "Refreshing tokens"
+	case OperationUpdateResources:
+		return "Updating resources"
+	default:
+		return "Running " + string(o)
+	}
+}
+
 // StopContainer stops a running container
 func StopContainer(containerName string) error {
-	cmd := exec.Command("docker", "stop", containerName)
-	if err := cmd.Run(); err != nil {
+	cmd := logging.Command("docker", "stop", containerName)
+	if err := logging.Run(cmd); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 	return nil
@@ -105,8 +123,8 @@ func StopContainer(containerName string) error {
 
 // StartContainer starts a stopped container
 func StartContainer(containerName string) error {
-	cmd := exec.Command("docker", "start", containerName)
-	if err := cmd.Run(); err != nil {
+	cmd := logging.Command("docker", "start", containerName)
+	if err := logging.Run(cmd); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 	return nil
@@ -115,44 +133,56 @@ func StartContainer(containerName string) error {
 // RestartContainer performs a full container restart (docker stop + start)
 func RestartContainer(containerName string) error {
 	// Stop container
-	stopCmd := exec.Command("docker", "stop", containerName)
-	if err := stopCmd.Run(); err != nil {
+	stopCmd := logging.Command("docker", "stop", containerName)
+	if err := logging.Run(stopCmd); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 
 	// Start container
-	startCmd := exec.Command("docker", "start", containerName)
-	if err := startCmd.Run(); err != nil {
+	startCmd := logging.Command("docker", "start", containerName)
+	if err := logging.Run(startCmd); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// Wait for container to be ready
 	time.Sleep(2 * time.Second)
 
+	// The tmux server does not survive a container stop, so bring the
+	// session back so reconnecting lands in Claude instead of nothing
+	if _, err := EnsureTmuxSession(containerName); err != nil {
+		return fmt.Errorf("container restarted but %w", err)
+	}
+
 	return nil
 }
 
-// DeleteContainer removes a container and its volumes
-func DeleteContainer(containerName string) error {
-	// Remove container with volumes
-	rmCmd := exec.Command("docker", "rm", "-f", "-v", containerName)
-	if err := rmCmd.Run(); err != nil {
-		return fmt.Errorf("failed to remove container: %w", err)
+// DeleteContainer removes a container. With removeVolumes its cache volumes
+// (see CacheVolumes) are removed too; otherwise they are kept for the next
+// container on the same branch. Returns the number of volumes removed.
+// Failing to remove a volume is logged rather than returned, since the
+// container itself is already gone.
+func DeleteContainer(containerName string, removeVolumes bool) (int, error) {
+	// Remove container with its anonymous volumes
+	rmCmd := logging.Command("docker", "rm", "-f", "-v", containerName)
+	if err := logging.Run(rmCmd); err != nil {
+		return 0, fmt.Errorf("failed to remove container: %w", err)
 	}
 
-	// Remove associated named volumes
-	volumes := []string{
-		fmt.Sprintf("%s-npm", containerName),
-		fmt.Sprintf("%s-uv", containerName),
-		fmt.Sprintf("%s-history", containerName),
+	if !removeVolumes {
+		return 0, nil
 	}
-
-	for _, volume := range volumes {
-		volCmd := exec.Command("docker", "volume", "rm", volume)
-		volCmd.Run() // Ignore errors - volume might not exist
+	removed := 0
+	for _, volume := range CacheVolumes(containerName) {
+		ok, err := removeVolume(volume)
+		if err != nil {
+			logging.Warnf("%v", err)
+			continue
+		}
+		if ok {
+			removed++
+		}
 	}
-
-	return nil
+	return removed, nil
 }
 
 // TokenSource represents where a token was found
@@ -207,10 +237,10 @@ func FindFreshestToken(containerPrefix string) (*TokenSource, error) {
 		tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("maestro-creds-%s.json", c.Name))
 		tempFiles = append(tempFiles, tmpFile)
 
-		copyCmd := exec.Command("docker", "cp",
+		copyCmd := logging.Command("docker", "cp",
 			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", c.Name),
 			tmpFile)
-		if err := copyCmd.Run(); err != nil {
+		if err := logging.Run(copyCmd); err != nil {
 			continue
 		}
 
@@ -254,14 +284,14 @@ func EnsureFreshToken(containerName, containerPrefix string) error {
 	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("maestro-check-%s.json", containerName))
 	defer os.Remove(tmpFile)
 
-	copyCmd := exec.Command("docker", "cp",
+	copyCmd := logging.Command("docker", "cp",
 		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName),
 		tmpFile)
 
 	targetHasValidToken := false
 	var targetExpiresAt time.Time
 
-	if err := copyCmd.Run(); err == nil {
+	if err := logging.Run(copyCmd); err == nil {
 		if creds, err := ReadCredentials(tmpFile); err == nil {
 			if !IsTokenExpired(creds) {
 				targetHasValidToken = true
@@ -292,15 +322,15 @@ func EnsureFreshToken(containerName, containerPrefix string) error {
 
 	// Target either has no valid token or has an older one - sync the freshest
 	destPath := fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName)
-	syncCmd := exec.Command("docker", "cp", freshest.Path, destPath)
-	if err := syncCmd.Run(); err != nil {
+	syncCmd := logging.Command("docker", "cp", freshest.Path, destPath)
+	if err := logging.Run(syncCmd); err != nil {
 		return fmt.Errorf("failed to sync credentials to container: %w", err)
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", "-u", "root", containerName,
+	chownCmd := logging.Command("docker", "exec", "-u", "root", containerName,
 		"chown", "node:node", "/home/node/.claude/.credentials.json")
-	if err := chownCmd.Run(); err != nil {
+	if err := logging.Run(chownCmd); err != nil {
 		return fmt.Errorf("failed to fix credentials ownership: %w", err)
 	}
 
@@ -330,10 +360,10 @@ func RefreshTokens(containerName string) error {
 	// Check each container's credentials
 	for _, c := range containers {
 		tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("maestro-creds-%s.json", c.Name))
-		copyCmd := exec.Command("docker", "cp",
+		copyCmd := logging.Command("docker", "cp",
 			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", c.Name),
 			tmpFile)
-		if err := copyCmd.Run(); err != nil {
+		if err := logging.Run(copyCmd); err != nil {
 			continue
 		}
 		defer os.Remove(tmpFile)
@@ -362,16 +392,16 @@ func RefreshTokens(containerName string) error {
 	}
 
 	// Copy freshest credentials to target container
-	copyCmd := exec.Command("docker", "cp", freshestPath,
+	copyCmd := logging.Command("docker", "cp", freshestPath,
 		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
-	if err := copyCmd.Run(); err != nil {
+	if err := logging.Run(copyCmd); err != nil {
 		return fmt.Errorf("failed to copy credentials to container: %w", err)
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", "-u", "root", containerName,
+	chownCmd := logging.Command("docker", "exec", "-u", "root", containerName,
 		"chown", "node:node", "/home/node/.claude/.credentials.json")
-	if err := chownCmd.Run(); err != nil {
+	if err := logging.Run(chownCmd); err != nil {
 		return fmt.Errorf("failed to fix credentials ownership: %w", err)
 	}
 
@@ -391,7 +421,7 @@ func UpdateContainerResources(containerName, memory, cpus string) error {
 
 	args = append(args, containerName)
 
-	cmd := exec.Command("docker", args...)
+	cmd := logging.Command("docker", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to update container resources: %s: %w", strings.TrimSpace(string(output)), err)
@@ -405,9 +435,9 @@ func AddIPToContainer(containerName, ip string) error {
 	if err := ValidateIP(ip); err != nil {
 		return fmt.Errorf("invalid IP for firewall: %w", err)
 	}
-	cmd := exec.Command("docker", "exec", "-u", "root", containerName,
+	cmd := logging.Command("docker", "exec", "-u", "root", containerName,
 		"sh", "-c", `ipset add allowed-domains "$1" 2>/dev/null || true`, "_", ip)
-	if err := cmd.Run(); err != nil {
+	if err := logging.Run(cmd); err != nil {
 		return fmt.Errorf("failed to add IP to container firewall: %w", err)
 	}
 	return nil
@@ -439,29 +469,29 @@ func AddDomainToContainer(containerName, domain string) error {
 	dnsmasqConf := "/tmp/dnsmasq-firewall.conf"
 
 	// Check if domain already in config (grep arg is safe — not passed through shell)
-	checkConfCmd := exec.Command("docker", "exec", containerName, "grep", "-qF",
+	checkConfCmd := logging.Command("docker", "exec", containerName, "grep", "-qF",
 		fmt.Sprintf("ipset=/%s/", domain), dnsmasqConf)
-	if checkConfCmd.Run() == nil {
+	if logging.Run(checkConfCmd) == nil {
 		return nil // Already configured
 	}
 
 	// Append domain to dnsmasq config using positional parameters (no interpolation)
-	appendCmd := exec.Command("docker", "exec", "-u", "root", containerName,
+	appendCmd := logging.Command("docker", "exec", "-u", "root", containerName,
 		"sh", "-c", `printf '%s\n' "ipset=/$1/allowed-domains" "server=/$1/8.8.8.8" >> "$2"`,
 		"_", domain, dnsmasqConf)
-	if err := appendCmd.Run(); err != nil {
+	if err := logging.Run(appendCmd); err != nil {
 		return fmt.Errorf("failed to update dnsmasq config: %w", err)
 	}
 
 	// Restart dnsmasq (no user input in this command)
-	restartCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	restartCmd := logging.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
 		"pkill -9 dnsmasq 2>/dev/null || true; sleep 0.2; dnsmasq --conf-file=/tmp/dnsmasq-firewall.conf")
-	if err := restartCmd.Run(); err != nil {
+	if err := logging.Run(restartCmd); err != nil {
 		return fmt.Errorf("failed to restart dnsmasq: %w", err)
 	}
 
 	// Perform initial DNS resolution using positional parameter
-	resolveCmd := exec.Command("docker", "exec", containerName,
+	resolveCmd := logging.Command("docker", "exec", containerName,
 		"sh", "-c", `dig +short "$1" | head -5`, "_", domain)
 	_, _ = resolveCmd.Output() // Ignore errors from resolution
 
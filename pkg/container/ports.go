@@ -0,0 +1,119 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// ExposeSidecarPrefix starts the names of the socat sidecars 'maestro expose'
+// runs to forward container ports: <prefix><container>-<port>.
+const ExposeSidecarPrefix = "maestro-expose-"
+
+// PortMapping is a container port reachable on a host port.
+type PortMapping struct {
+	HostPort      int
+	ContainerPort int
+}
+
+// browserPorts are container ports that commonly serve HTTP, most likely
+// first.
+var browserPorts = []int{80, 3000, 8080, 8000, 5173, 4200, 5000, 8888}
+
+// HostPorts returns the container's ports reachable from the host: the ones
+// it publishes itself and the ones forwarded by 'maestro expose'.
+func HostPorts(containerName, prefix string) ([]PortMapping, error) {
+	details, err := GetContainerDetails(containerName, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var mappings []PortMapping
+	for _, p := range details.Ports {
+		if m, ok := parseDetailsPort(p); ok {
+			mappings = append(mappings, m)
+		}
+	}
+
+	out, err := logging.Command("docker", "ps",
+		"--filter", "name=^"+ExposeSidecarPrefix+containerName+`-\d+$`,
+		"--format", "{{.Ports}}").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list port forwards: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		mappings = append(mappings, parsePsPorts(line)...)
+	}
+	return mappings, nil
+}
+
+// parseDetailsPort parses a ContainerDetails.Ports entry ("8080 -> 3000/tcp").
+func parseDetailsPort(s string) (PortMapping, bool) {
+	host, cport, ok := strings.Cut(s, " -> ")
+	if !ok {
+		return PortMapping{}, false
+	}
+	return newPortMapping(host, cport)
+}
+
+// psPortPattern matches one published port in docker ps's Ports column
+// ("0.0.0.0:3000->3000/tcp").
+var psPortPattern = regexp.MustCompile(`:(\d+)->(\d+)/tcp`)
+
+// parsePsPorts parses docker ps's Ports column.
+func parsePsPorts(s string) []PortMapping {
+	var mappings []PortMapping
+	for _, match := range psPortPattern.FindAllStringSubmatch(s, -1) {
+		if m, ok := newPortMapping(match[1], match[2]); ok {
+			mappings = append(mappings, m)
+		}
+	}
+	return mappings
+}
+
+// newPortMapping parses a host port and a container port, which may carry a
+// protocol suffix ("3000/tcp").
+func newPortMapping(host, cport string) (PortMapping, bool) {
+	cport, proto, _ := strings.Cut(strings.TrimSpace(cport), "/")
+	if proto != "" && proto != "tcp" {
+		return PortMapping{}, false
+	}
+	h, err1 := strconv.Atoi(strings.TrimSpace(host))
+	c, err2 := strconv.Atoi(cport)
+	if err1 != nil || err2 != nil {
+		return PortMapping{}, false
+	}
+	return PortMapping{HostPort: h, ContainerPort: c}, true
+}
+
+// BrowserPort picks the mapping to open in a browser: the first that serves
+// a common HTTP port, otherwise the first mapping.
+func BrowserPort(mappings []PortMapping) (PortMapping, bool) {
+	if len(mappings) == 0 {
+		return PortMapping{}, false
+	}
+	for _, port := range browserPorts {
+		for _, m := range mappings {
+			if m.ContainerPort == port {
+				return m, true
+			}
+		}
+	}
+	return mappings[0], true
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDetailsPort(t *testing.T) {
+	tests := []struct {
+		in   string
+		want PortMapping
+		ok   bool
+	}{
+		{"8080 -> 3000/tcp", PortMapping{HostPort: 8080, ContainerPort: 3000}, true},
+		{"5353 -> 53/udp", PortMapping{}, false},
+		{"3000/tcp", PortMapping{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseDetailsPort(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseDetailsPort(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParsePsPorts(t *testing.T) {
+	got := parsePsPorts("0.0.0.0:3001->3000/tcp, :::3001->3000/tcp, 0.0.0.0:53->53/udp")
+	want := []PortMapping{{HostPort: 3001, ContainerPort: 3000}, {HostPort: 3001, ContainerPort: 3000}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePsPorts() = %v, want %v", got, want)
+	}
+	if got := parsePsPorts(""); got != nil {
+		t.Errorf("parsePsPorts(\"\") = %v, want nil", got)
+	}
+}
+
+func TestBrowserPort(t *testing.T) {
+	if _, ok := BrowserPort(nil); ok {
+		t.Error("BrowserPort(nil) should find nothing")
+	}
+
+	mappings := []PortMapping{
+		{HostPort: 5432, ContainerPort: 5432},
+		{HostPort: 9000, ContainerPort: 8080},
+		{HostPort: 3000, ContainerPort: 3000},
+	}
+	if got, _ := BrowserPort(mappings); got.ContainerPort != 3000 {
+		t.Errorf("BrowserPort() picked %d, want 3000", got.ContainerPort)
+	}
+
+	other := []PortMapping{{HostPort: 5432, ContainerPort: 5432}, {HostPort: 6379, ContainerPort: 6379}}
+	if got, _ := BrowserPort(other); got.ContainerPort != 5432 {
+		t.Errorf("BrowserPort() picked %d, want the first mapping", got.ContainerPort)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// SourceDirLabel records the host directory a container's project was
+// copied from, which PullBranch fetches the container's branch into.
+const SourceDirLabel = "maestro.source_dir"
+
+// PullBranch fetches the branch checked out in a running container into the
+// host repository it was copied from (SourceDirLabel), under the same name,
+// and returns the branch. Only commits are fetched; uncommitted changes stay
+// in the container. The host branch is only fast-forwarded, so one that has
+// diverged or is checked out is left alone and reported as an error.
+func PullBranch(containerName string) (string, error) {
+	source := GetLabel(containerName, SourceDirLabel)
+	if source == "" {
+		return "", fmt.Errorf("%s does not record the host directory it was created from", containerName)
+	}
+	branch := GetBranchName(containerName)
+	if branch == "unknown" {
+		return "", fmt.Errorf("no branch is checked out in %s", containerName)
+	}
+
+	refspec := fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch)
+	output, err := logging.Command("git", "-C", source, "-c", "protocol.ext.allow=always",
+		"fetch", containerRemote(containerName, GitWorkspace(containerName)), refspec).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s into %s: %s", branch, source, strings.TrimSpace(string(output)))
+	}
+	return branch, nil
+}
+
+// containerRemote is a git remote URL reaching the repository at dir in a
+// container through docker exec, with git's ext transport (%S is the git
+// service, such as git-upload-pack).
+func containerRemote(containerName, dir string) string {
+	return fmt.Sprintf("ext::docker exec -i %s %%S %s", containerName, dir)
+}
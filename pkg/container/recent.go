@@ -0,0 +1,126 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// maxRecentConnects bounds the last-connect file; the oldest entries are
+// dropped first.
+const maxRecentConnects = 200
+
+// LastConnectFile is where RecordConnect keeps the time each container was
+// last connected to.
+func LastConnectFile() string {
+	return filepath.Join(paths.StateDir(), "last-connect.json")
+}
+
+// LastConnects returns the time each container was last connected to, keyed
+// by full container name. A missing or unreadable file yields an empty map.
+func LastConnects() map[string]time.Time {
+	last := map[string]time.Time{}
+	data, err := os.ReadFile(LastConnectFile())
+	if err != nil {
+		return last
+	}
+	if err := json.Unmarshal(data, &last); err != nil {
+		return map[string]time.Time{}
+	}
+	return last
+}
+
+// RecordConnect notes that containerName was connected to now.
+func RecordConnect(containerName string) error {
+	last := LastConnects()
+	last[containerName] = time.Now().UTC()
+	if len(last) > maxRecentConnects {
+		names := slices.SortedFunc(maps.Keys(last), func(a, b string) int {
+			return last[a].Compare(last[b])
+		})
+		for _, name := range names[:len(last)-maxRecentConnects] {
+			delete(last, name)
+		}
+	}
+
+	data, err := json.MarshalIndent(last, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode last connects: %w", err)
+	}
+	if err := os.MkdirAll(paths.StateDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	return os.WriteFile(LastConnectFile(), data, 0644)
+}
+
+// SortByLastConnect returns containers ordered by when they were last
+// connected to, most recent first. Containers never connected to keep their
+// order after the rest.
+func SortByLastConnect(containers []Info, last map[string]time.Time) []Info {
+	sorted := slices.Clone(containers)
+	slices.SortStableFunc(sorted, func(a, b Info) int {
+		return last[b.Name].Compare(last[a.Name])
+	})
+	return sorted
+}
+
+// maxRecentProjects bounds the recent-projects file.
+const maxRecentProjects = 20
+
+// RecentProjectsFile is where RecordProjectDir keeps the directories
+// containers were created from, most recent first.
+func RecentProjectsFile() string {
+	return filepath.Join(paths.StateDir(), "recent-projects.json")
+}
+
+// RecentProjectDirs returns up to n directories containers were last created
+// from, most recent first. A missing or unreadable file yields none.
+func RecentProjectDirs(n int) []string {
+	var dirs []string
+	data, err := os.ReadFile(RecentProjectsFile())
+	if err != nil || json.Unmarshal(data, &dirs) != nil {
+		return nil
+	}
+	if len(dirs) > n {
+		dirs = dirs[:n]
+	}
+	return dirs
+}
+
+// RecordProjectDir moves dir to the front of the recent project directories.
+func RecordProjectDir(dir string) error {
+	dirs := slices.DeleteFunc(RecentProjectDirs(maxRecentProjects), func(d string) bool { return d == dir })
+	dirs = append([]string{dir}, dirs...)
+	if len(dirs) > maxRecentProjects {
+		dirs = dirs[:maxRecentProjects]
+	}
+
+	data, err := json.MarshalIndent(dirs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recent projects: %w", err)
+	}
+	if err := os.MkdirAll(paths.StateDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	return os.WriteFile(RecentProjectsFile(), data, 0644)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordConnect(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	if last := LastConnects(); len(last) != 0 {
+		t.Fatalf("LastConnects() = %v, want empty before any connect", last)
+	}
+	before := time.Now().Add(-time.Second)
+	if err := RecordConnect("maestro-feat-a-1"); err != nil {
+		t.Fatal(err)
+	}
+	if at := LastConnects()["maestro-feat-a-1"]; at.Before(before) {
+		t.Errorf("recorded connect time %v is too old", at)
+	}
+}
+
+func TestSortByLastConnect(t *testing.T) {
+	now := time.Now()
+	containers := []Info{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+	last := map[string]time.Time{
+		"c": now,
+		"b": now.Add(-time.Hour),
+	}
+	sorted := SortByLastConnect(containers, last)
+	var names []string
+	for _, c := range sorted {
+		names = append(names, c.Name)
+	}
+	if got := names; got[0] != "c" || got[1] != "b" || got[2] != "a" || got[3] != "d" {
+		t.Errorf("SortByLastConnect order = %v, want [c b a d]", got)
+	}
+	if containers[0].Name != "a" {
+		t.Error("input slice was reordered")
+	}
+}
+
+func TestRecordProjectDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	if dirs := RecentProjectDirs(3); len(dirs) != 0 {
+		t.Fatalf("RecentProjectDirs() = %v, want none before any record", dirs)
+	}
+	for _, dir := range []string{"/src/a", "/src/b", "/src/c", "/src/a", "/src/d"} {
+		if err := RecordProjectDir(dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dirs := RecentProjectDirs(3)
+	if len(dirs) != 3 || dirs[0] != "/src/d" || dirs[1] != "/src/a" || dirs[2] != "/src/c" {
+		t.Errorf("RecentProjectDirs(3) = %v, want [/src/d /src/a /src/c]", dirs)
+	}
+}
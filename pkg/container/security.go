@@ -0,0 +1,44 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// securityOptPrefixes are the docker run --security-opt forms
+// containers.security_opts may use, besides no-new-privileges.
+var securityOptPrefixes = []string{"seccomp=", "apparmor=", "label="}
+
+// ValidateSecurityOpt checks a containers.security_opts entry: seccomp=<profile
+// path or unconfined>, apparmor=<profile>, label=<SELinux option> or
+// no-new-privileges[:true|false].
+func ValidateSecurityOpt(opt string) error {
+	switch opt {
+	case "no-new-privileges", "no-new-privileges:true", "no-new-privileges:false",
+		"no-new-privileges=true", "no-new-privileges=false":
+		return nil
+	}
+	for _, prefix := range securityOptPrefixes {
+		if value, ok := strings.CutPrefix(opt, prefix); ok {
+			if value == "" {
+				return fmt.Errorf("invalid security option %q: %s needs a value", opt, prefix)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid security option %q: use seccomp=<profile>, apparmor=<profile>, label=<option> or no-new-privileges", opt)
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestValidateSecurityOpt(t *testing.T) {
+	for _, opt := range []string{
+		"seccomp=/etc/docker/seccomp.json", "seccomp=unconfined", "apparmor=docker-default",
+		"label=type:container_t", "no-new-privileges", "no-new-privileges:true", "no-new-privileges=false",
+	} {
+		if err := ValidateSecurityOpt(opt); err != nil {
+			t.Errorf("ValidateSecurityOpt(%q): %v", opt, err)
+		}
+	}
+	for _, opt := range []string{"", "seccomp=", "apparmor", "privileged", "no-new-privileges:maybe", "systempaths=unconfined"} {
+		if err := ValidateSecurityOpt(opt); err == nil {
+			t.Errorf("ValidateSecurityOpt(%q) should fail", opt)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// SharedVolumePrefix starts the names of the volumes created for
+// containers.shared_volumes. They are shared by every container and belong
+// to none, so cleanup-volumes leaves them alone.
+const SharedVolumePrefix = "maestro-shared-"
+
+// SharedVolumeSourceLabel records the host path a shared volume was
+// populated from.
+const SharedVolumeSourceLabel = "maestro.shared-source"
+
+// SharedVolume is a containers.shared_volumes entry: a named volume filled
+// from HostPath the first time it is used and mounted at ContainerPath in
+// every new container.
+type SharedVolume struct {
+	HostPath      string
+	ContainerPath string
+	Options       string // -v options such as ro; empty for the default
+}
+
+// sharedVolumeOptions are the -v options a shared volume entry may set.
+var sharedVolumeOptions = []string{"ro", "rw", "nocopy", "z", "Z"}
+
+// ParseSharedVolume parses a host_path:container_path[:options] entry. Both
+// paths must be absolute (expand ~ before calling).
+func ParseSharedVolume(spec string) (SharedVolume, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return SharedVolume{}, fmt.Errorf("invalid shared volume %q: use host_path:container_path[:options]", spec)
+	}
+	v := SharedVolume{HostPath: parts[0], ContainerPath: parts[1]}
+	if len(parts) == 3 {
+		v.Options = parts[2]
+		for _, opt := range strings.Split(v.Options, ",") {
+			if !slices.Contains(sharedVolumeOptions, opt) {
+				return SharedVolume{}, fmt.Errorf("invalid shared volume %q: unknown option %q (use %s)", spec, opt, strings.Join(sharedVolumeOptions, ", "))
+			}
+		}
+	}
+	if !path.IsAbs(v.HostPath) {
+		return SharedVolume{}, fmt.Errorf("invalid shared volume %q: host path must be absolute", spec)
+	}
+	if !path.IsAbs(v.ContainerPath) {
+		return SharedVolume{}, fmt.Errorf("invalid shared volume %q: container path must be absolute", spec)
+	}
+	return v, nil
+}
+
+// VolumeName returns the named volume for the entry. It depends only on the
+// host path, so every container (and entries mounting the same directory in
+// different places) share one volume.
+func (v SharedVolume) VolumeName() string {
+	sum := sha256.Sum256([]byte(path.Clean(v.HostPath)))
+	return SharedVolumePrefix + hex.EncodeToString(sum[:])[:12]
+}
+
+// Args returns the docker run arguments mounting the volume.
+func (v SharedVolume) Args() []string {
+	mount := v.VolumeName() + ":" + v.ContainerPath
+	if v.Options != "" {
+		mount += ":" + v.Options
+	}
+	return []string{"-v", mount}
+}
+
+// EnsureSharedVolume creates the entry's volume if it doesn't exist yet and
+// copies the host directory into it, owned by node, using image (which only
+// needs sh, cp and chown). A volume that already exists is left as it is, so
+// later changes on the host are not copied again. If the copy fails the
+// volume is removed so the next container retries.
+func EnsureSharedVolume(v SharedVolume, image string) error {
+	name := v.VolumeName()
+	if logging.Command("docker", "volume", "inspect", name).Run() == nil {
+		return nil
+	}
+
+	if info, err := os.Stat(v.HostPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("cannot fill volume %s: %s is not a directory", name, v.HostPath)
+	}
+
+	create := logging.Command("docker", "volume", "create",
+		"--label", fmt.Sprintf("%s=%s", SharedVolumeSourceLabel, v.HostPath), name)
+	if output, err := create.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create volume %s: %s", name, strings.TrimSpace(string(output)))
+	}
+
+	populate := logging.Command("docker", "run", "--rm",
+		"--user", "root",
+		"--entrypoint", "sh",
+		"-v", v.HostPath+":/maestro-src:ro",
+		"-v", name+":/maestro-dst",
+		image,
+		"-c", "cp -a /maestro-src/. /maestro-dst/ && chown -R node:node /maestro-dst")
+	if output, err := populate.CombinedOutput(); err != nil {
+		removeVolume(name)
+		return fmt.Errorf("failed to copy %s into volume %s: %s", v.HostPath, name, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
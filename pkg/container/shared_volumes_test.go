@@ -0,0 +1,76 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseSharedVolume(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    SharedVolume
+		wantErr bool
+	}{
+		{spec: "/home/me/.cargo:/home/node/.cargo", want: SharedVolume{HostPath: "/home/me/.cargo", ContainerPath: "/home/node/.cargo"}},
+		{spec: "/home/me/.gradle:/home/node/.gradle:ro", want: SharedVolume{HostPath: "/home/me/.gradle", ContainerPath: "/home/node/.gradle", Options: "ro"}},
+		{spec: "/data:/data:ro,nocopy", want: SharedVolume{HostPath: "/data", ContainerPath: "/data", Options: "ro,nocopy"}},
+		{spec: "/home/me/.cargo", wantErr: true},
+		{spec: "cargo:/home/node/.cargo", wantErr: true},
+		{spec: "/home/me/.cargo:.cargo", wantErr: true},
+		{spec: "/a:/b:rx", wantErr: true},
+		{spec: "/a:/b:ro:z", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSharedVolume(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSharedVolume(%q) = %+v, want an error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSharedVolume(%q) error: %v", tt.spec, err)
+		} else if got != tt.want {
+			t.Errorf("ParseSharedVolume(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestSharedVolume_NameAndArgs(t *testing.T) {
+	cargo := SharedVolume{HostPath: "/home/me/.cargo", ContainerPath: "/home/node/.cargo"}
+	name := cargo.VolumeName()
+	if !strings.HasPrefix(name, SharedVolumePrefix) || len(name) != len(SharedVolumePrefix)+12 {
+		t.Errorf("VolumeName() = %q, want %s<12 hex digits>", name, SharedVolumePrefix)
+	}
+
+	elsewhere := SharedVolume{HostPath: "/home/me/.cargo/", ContainerPath: "/opt/cargo", Options: "ro"}
+	if elsewhere.VolumeName() != name {
+		t.Errorf("the same host directory got volumes %q and %q", name, elsewhere.VolumeName())
+	}
+	gradle := SharedVolume{HostPath: "/home/me/.gradle", ContainerPath: "/home/node/.gradle"}
+	if gradle.VolumeName() == name {
+		t.Errorf("different host directories share volume %q", name)
+	}
+
+	if got, want := cargo.Args(), []string{"-v", name + ":/home/node/.cargo"}; !slices.Equal(got, want) {
+		t.Errorf("Args() = %v, want %v", got, want)
+	}
+	if got, want := elsewhere.Args(), []string{"-v", name + ":/opt/cargo:ro"}; !slices.Equal(got, want) {
+		t.Errorf("Args() with options = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "encoding/json"
+
+// SyncedFoldersLabel records where sync.additional_folders landed in a container
+const SyncedFoldersLabel = "maestro.synced_folders"
+
+// SyncedFolder is a host folder copied into a container at creation
+type SyncedFolder struct {
+	Source string `json:"source"` // Host path as configured
+	Dest   string `json:"dest"`   // Path inside the container
+}
+
+// SyncedFoldersLabelValue encodes folders for the maestro.synced_folders label.
+// Returns "" when there is nothing to record.
+func SyncedFoldersLabelValue(folders []SyncedFolder) string {
+	if len(folders) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(folders)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// parseSyncedFolders decodes a maestro.synced_folders label, ignoring
+// malformed values.
+func parseSyncedFolders(raw string) []SyncedFolder {
+	if raw == "" {
+		return nil
+	}
+	var folders []SyncedFolder
+	if err := json.Unmarshal([]byte(raw), &folders); err != nil {
+		return nil
+	}
+	return folders
+}
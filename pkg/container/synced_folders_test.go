@@ -0,0 +1,35 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestSyncedFoldersLabelRoundTrip(t *testing.T) {
+	folders := []SyncedFolder{
+		{Source: "~/datasets/foo", Dest: "/data/foo"},
+		{Source: "~/Code/shared-libs", Dest: "/shared-libs"},
+	}
+	got := parseSyncedFolders(SyncedFoldersLabelValue(folders))
+	if len(got) != 2 || got[0] != folders[0] || got[1] != folders[1] {
+		t.Errorf("round trip = %+v, want %+v", got, folders)
+	}
+
+	if SyncedFoldersLabelValue(nil) != "" {
+		t.Error("empty folder list should produce no label")
+	}
+	if parseSyncedFolders("not json") != nil {
+		t.Error("malformed label should be ignored")
+	}
+}
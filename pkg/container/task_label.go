@@ -0,0 +1,71 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// TaskLabel records the task description a container was created for
+	TaskLabel = "maestro.task"
+
+	// UnknownTask is shown for containers created before TaskLabel existed
+	UnknownTask = "(unknown)"
+
+	// maxTaskLabelBytes caps the label, since tasks read from spec files can
+	// be arbitrarily long
+	maxTaskLabelBytes = 8192
+)
+
+// TaskLabelValue prepares a task description for TaskLabel, trimming it and
+// cutting overly long descriptions at a character boundary.
+func TaskLabelValue(task string) string {
+	task = strings.TrimSpace(task)
+	if len(task) <= maxTaskLabelBytes {
+		return task
+	}
+	cut := maxTaskLabelBytes - len("…")
+	for cut > 0 && !utf8.RuneStart(task[cut]) {
+		cut--
+	}
+	return task[:cut] + "…"
+}
+
+// TaskDescriptionLine collapses a task description onto one line for list
+// views, returning UnknownTask when there is none.
+func TaskDescriptionLine(task string) string {
+	summary := strings.Join(strings.Fields(task), " ")
+	if summary == "" {
+		return UnknownTask
+	}
+	return summary
+}
+
+// taskLabel decodes the JSON-quoted TaskLabel column of a docker ps line.
+// The label is quoted in the ps template because task descriptions may
+// contain tabs and newlines.
+func taskLabel(parts []string) string {
+	if len(parts) <= 7 {
+		return ""
+	}
+	var task string
+	if err := json.Unmarshal([]byte(parts[7]), &task); err != nil {
+		return ""
+	}
+	return task
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTaskLabelValue(t *testing.T) {
+	if got := TaskLabelValue("  fix the bug\n"); got != "fix the bug" {
+		t.Errorf("got %q, want trimmed task", got)
+	}
+
+	long := strings.Repeat("é", maxTaskLabelBytes)
+	got := TaskLabelValue(long)
+	if len(got) > maxTaskLabelBytes || !utf8.ValidString(got) || !strings.HasSuffix(got, "…") {
+		t.Errorf("long task: %d bytes, valid=%v", len(got), utf8.ValidString(got))
+	}
+}
+
+func TestTaskDescriptionLine(t *testing.T) {
+	tests := []struct {
+		task string
+		want string
+	}{
+		{"", UnknownTask},
+		{"  \n ", UnknownTask},
+		{"Add OAuth\n\n- use sessions\n\t- add tests", "Add OAuth - use sessions - add tests"},
+	}
+	for _, tt := range tests {
+		if got := TaskDescriptionLine(tt.task); got != tt.want {
+			t.Errorf("TaskDescriptionLine(%q) = %q, want %q", tt.task, got, tt.want)
+		}
+	}
+}
+
+func TestTaskLabel(t *testing.T) {
+	tests := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"maestro-a-1", "Up", "running", "", "", "", ""}, ""},
+		{[]string{"maestro-a-1", "Up", "running", "", "", "", "", `""`}, ""},
+		{[]string{"maestro-a-1", "Up", "running", "", "", "", "", `"line one\nline\ttwo <b>"`}, "line one\nline\ttwo <b>"},
+		{[]string{"maestro-a-1", "Up", "running", "", "", "", "", `not json`}, ""},
+	}
+	for _, tt := range tests {
+		if got := taskLabel(tt.parts); got != tt.want {
+			t.Errorf("taskLabel(%q) = %q, want %q", tt.parts, got, tt.want)
+		}
+	}
+}
@@ -17,12 +17,13 @@ package container
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
 // TaskStatus represents the status of a Claude Code task
@@ -129,7 +130,7 @@ func GetContainerTasks(containerName string) (*ContainerTasks, error) {
 	}
 
 	// Check if container is running
-	checkCmd := exec.Command("docker", "inspect", "-f", "{{.State.Running}}", containerName)
+	checkCmd := logging.Command("docker", "inspect", "-f", "{{.State.Running}}", containerName)
 	output, err := checkCmd.Output()
 	if err != nil {
 		result.Error = fmt.Errorf("container not found or not accessible")
@@ -143,7 +144,7 @@ func GetContainerTasks(containerName string) (*ContainerTasks, error) {
 
 	// Read from OLD format: /home/node/.claude/todos/
 	// Files are named: {session-uuid}-agent-{agent-uuid}.json and contain an array of tasks
-	listCmd := exec.Command("docker", "exec", containerName,
+	listCmd := logging.Command("docker", "exec", containerName,
 		"find", "/home/node/.claude/todos", "-maxdepth", "1", "-name", "*.json", "-type", "f")
 	if output, err := listCmd.Output(); err == nil {
 		todoFiles := strings.Split(strings.TrimSpace(string(output)), "\n")
@@ -159,7 +160,7 @@ func GetContainerTasks(containerName string) (*ContainerTasks, error) {
 
 	// Read from NEW format: /home/node/.claude/tasks/
 	// Structure: tasks/{session-uuid}/{id}.json where each file is a single task
-	listCmd = exec.Command("docker", "exec", containerName,
+	listCmd = logging.Command("docker", "exec", containerName,
 		"find", "/home/node/.claude/tasks", "-mindepth", "1", "-maxdepth", "1", "-type", "d")
 	if output, err := listCmd.Output(); err == nil {
 		sessionDirs := strings.Split(strings.TrimSpace(string(output)), "\n")
@@ -191,7 +192,7 @@ func readTasksDir(containerName, sessionDir, sessionID string) (*Session, error)
 	}
 
 	// List JSON files in the session directory (exclude .lock files)
-	listCmd := exec.Command("docker", "exec", containerName,
+	listCmd := logging.Command("docker", "exec", containerName,
 		"find", sessionDir, "-maxdepth", "1", "-name", "*.json", "-type", "f")
 	output, err := listCmd.Output()
 	if err != nil {
@@ -206,7 +207,7 @@ func readTasksDir(containerName, sessionDir, sessionID string) (*Session, error)
 
 		// Get file modification time
 		var mtime time.Time
-		statCmd := exec.Command("docker", "exec", containerName, "stat", "-c", "%Y", taskFile)
+		statCmd := logging.Command("docker", "exec", containerName, "stat", "-c", "%Y", taskFile)
 		if statOutput, err := statCmd.Output(); err == nil {
 			if ts, err := strconv.ParseInt(strings.TrimSpace(string(statOutput)), 10, 64); err == nil {
 				mtime = time.Unix(ts, 0)
@@ -214,7 +215,7 @@ func readTasksDir(containerName, sessionDir, sessionID string) (*Session, error)
 		}
 
 		// Read file contents
-		catCmd := exec.Command("docker", "exec", containerName, "cat", taskFile)
+		catCmd := logging.Command("docker", "exec", containerName, "cat", taskFile)
 		content, err := catCmd.Output()
 		if err != nil {
 			continue
@@ -258,7 +259,7 @@ func readTodoFile(containerName, filePath string) (*Session, error) {
 	}
 
 	// Get file modification time
-	statCmd := exec.Command("docker", "exec", containerName,
+	statCmd := logging.Command("docker", "exec", containerName,
 		"stat", "-c", "%Y", filePath)
 	output, err := statCmd.Output()
 	if err == nil {
@@ -268,7 +269,7 @@ func readTodoFile(containerName, filePath string) (*Session, error) {
 	}
 
 	// Read file contents
-	catCmd := exec.Command("docker", "exec", containerName, "cat", filePath)
+	catCmd := logging.Command("docker", "exec", containerName, "cat", filePath)
 	output, err = catCmd.Output()
 	if err != nil {
 		return nil, err
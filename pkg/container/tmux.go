@@ -0,0 +1,322 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+const (
+	// DefaultTmuxSession is the tmux session name used when none is configured
+	DefaultTmuxSession = "main"
+
+	// DefaultTmuxPrefix is tmux's own default prefix key
+	DefaultTmuxPrefix = "C-b"
+
+	// TmuxSessionLabel records the tmux session name a container was created
+	// with, so later operations keep working if tmux.default_session changes
+	TmuxSessionLabel = "maestro.tmux_session"
+
+	// TmuxSessionEnv passes the session name to maestro-agent in the container
+	TmuxSessionEnv = "MAESTRO_TMUX_SESSION"
+
+	// NoTmuxLabel marks containers created with --no-tmux, where Claude runs
+	// directly under docker exec instead of inside a tmux session
+	NoTmuxLabel = "maestro.no_tmux"
+
+	// DirectClaudeScript launches Claude in --no-tmux containers. It sends the
+	// bootstrap prompt on first use and continues the conversation afterwards.
+	DirectClaudeScript = "/home/node/.maestro/claude-direct.sh"
+)
+
+// ValidTmuxSessionName reports whether name can be used as a tmux session
+// name. tmux reserves ':' and '.' as target separators, and names end up in
+// shell command lines, so only a conservative character set is accepted.
+func ValidTmuxSessionName(name string) bool {
+	if name == "" || len(name) > 64 {
+		return false
+	}
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// TmuxPrefix returns the prefix key to use for a tmux.prefix value:
+// DefaultTmuxPrefix when unset or when the value cannot be a single tmux key.
+// valid is false only for the latter.
+func TmuxPrefix(configured string) (prefix string, valid bool) {
+	prefix = strings.TrimSpace(configured)
+	if prefix == "" {
+		return DefaultTmuxPrefix, true
+	}
+	if strings.ContainsAny(prefix, " \t\n'\"") {
+		return DefaultTmuxPrefix, false
+	}
+	return prefix, true
+}
+
+// FormatTmuxKey renders a tmux key name for humans, e.g. "C-a" -> "Ctrl+a"
+// and "M-a" -> "Alt+a".
+func FormatTmuxKey(key string) string {
+	modifiers := map[byte]string{'C': "Ctrl", 'M': "Alt", 'S': "Shift"}
+
+	var parts []string
+	for len(key) > 2 && key[1] == '-' {
+		mod, ok := modifiers[key[0]]
+		if !ok {
+			break
+		}
+		parts = append(parts, mod)
+		key = key[2:]
+	}
+	return strings.Join(append(parts, key), "+")
+}
+
+// UsesTmux reports whether the container runs Claude in a tmux session, i.e.
+// it was not created with --no-tmux.
+func UsesTmux(containerName string) bool {
+	return GetLabel(containerName, NoTmuxLabel) != "true"
+}
+
+// TmuxSession returns the tmux session name for a container, read from its
+// maestro.tmux_session label. Containers created before the label existed
+// use DefaultTmuxSession.
+func TmuxSession(containerName string) string {
+	cmd := logging.Command("docker", "inspect", "-f",
+		fmt.Sprintf("{{index .Config.Labels %q}}", TmuxSessionLabel), containerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return DefaultTmuxSession
+	}
+	session := strings.TrimSpace(string(output))
+	if !ValidTmuxSessionName(session) {
+		return DefaultTmuxSession
+	}
+	return session
+}
+
+// HasTmuxSession reports whether the container's tmux session exists
+func HasTmuxSession(containerName string) bool {
+	exists, _ := CheckTmuxSession(containerName)
+	return exists
+}
+
+// CheckTmuxSession reports whether the container's tmux session exists.
+// When it does not, the returned reason explains why in terms suitable for
+// showing to the user.
+func CheckTmuxSession(containerName string) (bool, string) {
+	session := TmuxSession(containerName)
+	checkCmd := logging.Command("docker", "exec", "-u", "node", containerName, "tmux", "has-session", "-t", session)
+	output, err := checkCmd.CombinedOutput()
+	if err == nil {
+		return true, ""
+	}
+	return false, describeMissingTmuxSession(string(output))
+}
+
+// describeMissingTmuxSession turns tmux has-session output into a short
+// explanation of why the session is gone.
+func describeMissingTmuxSession(output string) string {
+	switch {
+	case strings.Contains(output, "no server running"), strings.Contains(output, "error connecting to"):
+		return "the tmux server is not running (container restarted, crashed, or ran out of memory)"
+	case strings.Contains(output, "can't find session"), strings.Contains(output, "session not found"):
+		return "the tmux session has exited"
+	default:
+		return "the tmux session is unavailable"
+	}
+}
+
+// EnsureTmuxSession recreates the container's tmux session (Claude in window
+// 0, a shell in window 1) if it is missing. Returns true if the session had
+// to be recreated. The tmux config written at creation time lives on the
+// container filesystem and is picked up again by the new server. Containers
+// created with --no-tmux have no session and are left alone.
+func EnsureTmuxSession(containerName string) (bool, error) {
+	if !UsesTmux(containerName) || HasTmuxSession(containerName) {
+		return false, nil
+	}
+	if err := CreateTmuxSession(containerName, TmuxSessionOptions{LaunchClaude: true}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TmuxSessionOptions says what a recreated tmux session runs.
+type TmuxSessionOptions struct {
+	LaunchClaude bool   // Claude in window 0 and a shell in window 1; otherwise a single bare shell
+	Prompt       string // With LaunchClaude: sent to Claude as its first message ("" sends nothing)
+}
+
+// resumePromptPath holds the prompt a recreated Claude session starts with.
+const resumePromptPath = "/tmp/maestro-resume.txt"
+
+// agentServiceScript starts the maestro-agent service (idle wake-up,
+// heartbeat, clear timer) unless its PID file names a live process.
+const agentServiceScript = `kill -0 "$(cat /home/node/.maestro/state/maestro-agent.pid 2>/dev/null)" 2>/dev/null || HOME=/home/node exec maestro-agent service`
+
+// ResumePrompt asks a relaunched Claude to pick a task back up after its
+// session was lost, checking the workspace for what is already done.
+func ResumePrompt(task string) string {
+	return fmt.Sprintf(`Your previous session in this container ended unexpectedly. You were working on this task:
+
+%s
+
+Check the workspace (git status, git log, any notes or plans you left) to see what is already done, then continue from there.`, strings.TrimSpace(task))
+}
+
+// CreateTmuxSession starts a new tmux session in the container, laid out
+// like the one 'maestro new' creates, and makes sure the maestro-agent
+// service is running again for Claude sessions.
+func CreateTmuxSession(containerName string, opts TmuxSessionOptions) error {
+	session := TmuxSession(containerName)
+	workspace := WorkspaceRoot(containerName)
+	shell := Shell(containerName)
+	window := "-n shell " + shell
+	if opts.LaunchClaude {
+		window = "-n claude 'claude --dangerously-skip-permissions'"
+		if opts.Prompt != "" {
+			writePrompt := logging.Command("docker", "exec", "-i", "-u", "node", containerName, "sh", "-c", "cat > "+resumePromptPath)
+			writePrompt.Stdin = strings.NewReader(opts.Prompt)
+			if err := logging.Run(writePrompt); err != nil {
+				return fmt.Errorf("failed to write the task prompt: %w", err)
+			}
+			window = fmt.Sprintf("-n claude 'cat %s | claude --dangerously-skip-permissions'", resumePromptPath)
+		}
+	}
+	startCmd := logging.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
+		InWorkspace(workspace, fmt.Sprintf("HOME=/home/node tmux new-session -d -s %s %s", session, window)))
+	if err := logging.Run(startCmd); err != nil {
+		return fmt.Errorf("failed to recreate tmux session: %w", err)
+	}
+
+	// Wait for the session to come up before adding windows
+	for i := 0; i < 10; i++ {
+		if HasTmuxSession(containerName) {
+			break
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	if opts.LaunchClaude {
+		// Add shell window (best effort - Claude window is what matters)
+		logging.Command("docker", "exec", "-u", "node", containerName,
+			"tmux", "new-window", "-t", session+":1", "-n", "shell", "-c", workspace, shell).Run()
+		logging.Command("docker", "exec", "-u", "node", containerName,
+			"tmux", "select-window", "-t", session+":0").Run()
+
+		// The agent service dies with the container or an OOM kill too
+		agentCmd := logging.Command("docker", "exec", "-d", "-u", "node", containerName, "sh", "-c", agentServiceScript)
+		if err := logging.Run(agentCmd); err != nil {
+			logging.Warnf("Failed to start maestro-agent service: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// CaptureClaudePane returns up to the last lines of Claude's tmux window as
+// plain text, without the blank lines below the cursor.
+func CaptureClaudePane(containerName string, lines int) (string, error) {
+	if !UsesTmux(containerName) {
+		return "", fmt.Errorf("claude runs without tmux in this container")
+	}
+	session := TmuxSession(containerName)
+	output, err := logging.Command("docker", "exec", "-u", "node", containerName,
+		"tmux", "capture-pane", "-p", "-t", session+":0").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to capture the claude window: %w", err)
+	}
+	return lastLines(string(output), lines), nil
+}
+
+// lastLines returns the last n lines of s after dropping trailing blank lines.
+func lastLines(s string, n int) string {
+	all := strings.Split(strings.TrimRight(s, " \t\r\n"), "\n")
+	if len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return strings.Join(all, "\n")
+}
+
+// TmuxWindowIndex turns a window choice (claude, shell or a window number)
+// into the tmux window index. Claude runs in window 0 and the shell in
+// window 1. Empty means no choice and stays empty.
+func TmuxWindowIndex(window string) (string, error) {
+	switch window {
+	case "":
+		return "", nil
+	case "claude":
+		return "0", nil
+	case "shell":
+		return "1", nil
+	}
+	if n, err := strconv.Atoi(window); err != nil || n < 0 {
+		return "", fmt.Errorf("invalid window %q: use claude, shell or a window number", window)
+	}
+	return window, nil
+}
+
+// SelectTmuxWindow makes window (claude, shell or a number) the active
+// window of the container's tmux session, so attaching lands on it. A
+// window that doesn't exist is reported along with the ones that do.
+func SelectTmuxWindow(containerName, window string) error {
+	index, err := TmuxWindowIndex(window)
+	if err != nil || index == "" {
+		return err
+	}
+	session := TmuxSession(containerName)
+	output, err := logging.Command("docker", "exec", "-u", "node", containerName,
+		"tmux", "list-windows", "-t", session, "-F", "#{window_index}\t#{window_name}").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+	windows, found := parseTmuxWindows(string(output), index)
+	if !found {
+		return fmt.Errorf("window %s doesn't exist in %s; its windows are %s", window, containerName, strings.Join(windows, ", "))
+	}
+	if err := logging.Command("docker", "exec", "-u", "node", containerName,
+		"tmux", "select-window", "-t", session+":"+index).Run(); err != nil {
+		return fmt.Errorf("failed to select window %s: %w", window, err)
+	}
+	return nil
+}
+
+// parseTmuxWindows reads 'tmux list-windows' output in
+// "#{window_index}\t#{window_name}" format into "0 (claude)" descriptions,
+// and reports whether index is among them.
+func parseTmuxWindows(output, index string) ([]string, bool) {
+	var windows []string
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		idx, name, _ := strings.Cut(line, "\t")
+		if idx == "" {
+			continue
+		}
+		windows = append(windows, fmt.Sprintf("%s (%s)", idx, name))
+		if idx == index {
+			found = true
+		}
+	}
+	return windows, found
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribeMissingTmuxSession(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"no server running on /tmp/tmux-1000/default\n", "the tmux server is not running (container restarted, crashed, or ran out of memory)"},
+		{"error connecting to /tmp/tmux-1000/default (No such file or directory)\n", "the tmux server is not running (container restarted, crashed, or ran out of memory)"},
+		{"can't find session: main\n", "the tmux session has exited"},
+		{"session not found: main\n", "the tmux session has exited"},
+		{"", "the tmux session is unavailable"},
+	}
+	for _, tc := range tests {
+		if got := describeMissingTmuxSession(tc.output); got != tc.want {
+			t.Errorf("describeMissingTmuxSession(%q) = %q, want %q", tc.output, got, tc.want)
+		}
+	}
+}
+
+func TestValidTmuxSessionName(t *testing.T) {
+	valid := []string{"main", "maestro", "work_1", "A-b"}
+	for _, name := range valid {
+		if !ValidTmuxSessionName(name) {
+			t.Errorf("ValidTmuxSessionName(%q) should be valid", name)
+		}
+	}
+
+	invalid := []string{"", "main:0", "a.b", "has space", "quote'd", "$(cmd)", strings.Repeat("x", 65)}
+	for _, name := range invalid {
+		if ValidTmuxSessionName(name) {
+			t.Errorf("ValidTmuxSessionName(%q) should be invalid", name)
+		}
+	}
+}
+
+func TestFormatTmuxKey(t *testing.T) {
+	tests := map[string]string{
+		"C-b":   "Ctrl+b",
+		"C-a":   "Ctrl+a",
+		"M-a":   "Alt+a",
+		"C-M-x": "Ctrl+Alt+x",
+		"`":     "`",
+		"F12":   "F12",
+	}
+	for key, want := range tests {
+		if got := FormatTmuxKey(key); got != want {
+			t.Errorf("FormatTmuxKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestTmuxPrefix(t *testing.T) {
+	tests := []struct {
+		configured string
+		want       string
+		valid      bool
+	}{
+		{"", "C-b", true},
+		{" C-a ", "C-a", true},
+		{"`", "`", true},
+		{"C-a C-b", "C-b", false},
+		{"'", "C-b", false},
+	}
+	for _, tt := range tests {
+		if got, valid := TmuxPrefix(tt.configured); got != tt.want || valid != tt.valid {
+			t.Errorf("TmuxPrefix(%q) = %q, %v, want %q, %v", tt.configured, got, valid, tt.want, tt.valid)
+		}
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"a\nb\nc\n\n\n", 2, "b\nc"},
+		{"a\nb\n", 5, "a\nb"},
+		{"  indented\n   \n", 3, "  indented"},
+		{"", 3, ""},
+	}
+	for _, tt := range tests {
+		if got := lastLines(tt.in, tt.n); got != tt.want {
+			t.Errorf("lastLines(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTmuxWindowIndex(t *testing.T) {
+	for window, want := range map[string]string{"": "", "claude": "0", "shell": "1", "3": "3"} {
+		if got, err := TmuxWindowIndex(window); err != nil || got != want {
+			t.Errorf("TmuxWindowIndex(%q) = %q, %v; want %q", window, got, err, want)
+		}
+	}
+	for _, window := range []string{"Shell", "-1", "main"} {
+		if _, err := TmuxWindowIndex(window); err == nil {
+			t.Errorf("TmuxWindowIndex(%q) should fail", window)
+		}
+	}
+}
+
+func TestParseTmuxWindows(t *testing.T) {
+	windows, found := parseTmuxWindows("0\tclaude\n1\tshell\n", "1")
+	if !found || len(windows) != 2 || windows[0] != "0 (claude)" || windows[1] != "1 (shell)" {
+		t.Errorf("parseTmuxWindows() = %v, %v", windows, found)
+	}
+	if _, found := parseTmuxWindows("0\tclaude\n", "4"); found {
+		t.Error("window 4 should not be found")
+	}
+}
+
+func TestResumePrompt(t *testing.T) {
+	prompt := ResumePrompt("  add user auth\n")
+	if !strings.Contains(prompt, "\n\nadd user auth\n\n") || !strings.Contains(prompt, "continue") {
+		t.Errorf("ResumePrompt should quote the task and ask to continue:\n%s", prompt)
+	}
+}
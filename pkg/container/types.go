@@ -29,21 +29,30 @@ type Credentials struct {
 
 // Info holds information about a container
 type Info struct {
-	Name          string
-	ShortName     string
-	Status        string
-	StatusDetails string
-	Branch        string
-	AgentState    string                       // maestro-agent state (starting, active, waiting, idle, question, clearing, connected)
-	IsDormant     bool                         // Claude process not running
-	HasWeb        bool                         // Container has web/browser support (Playwright)
-	AuthStatus    string                       // Token expiration status
-	LastActivity  string                       // Time since last activity
-	GitStatus     string                       // Git status indicators
-	CreatedAt     time.Time                    // Container creation time
-	CurrentTask   string                       // Current task being worked on (from Claude Code task management)
-	TaskProgress  string                       // Task progress (e.g., "2/5")
-	Contacts      map[string]map[string]string // Contact overrides from maestro.contacts label
+	Name            string
+	ShortName       string
+	Status          string
+	StatusDetails   string
+	Branch          string
+	AgentState      string                       // maestro-agent state (starting, active, waiting, idle, question, clearing, connected)
+	AgentStateSince time.Time                    // When the agent entered AgentState (zero if unknown)
+	IsDormant       bool                         // Claude process not running
+	HasWeb          bool                         // Container has web/browser support (Playwright)
+	NoFirewall      bool                         // Created with --no-firewall and not enabled since
+	HostNetwork     bool                         // Uses the host's network, where the firewall can't run
+	Project         string                       // Project name from the maestro.project label
+	Task            string                       // Task description from the maestro.task label
+	AuthStatus      string                       // Token expiration status
+	LastActivity    string                       // Time since last activity
+	GitStatus       string                       // Git status indicators
+	Git             GitState                     // Counts behind GitStatus (zero for stopped containers)
+	CreatedAt       time.Time                    // Container creation time
+	StartedAt       time.Time                    // When a running container was last started (zero otherwise)
+	CurrentTask     string                       // Current task being worked on (from Claude Code task management)
+	TaskProgress    string                       // Task progress (e.g., "2/5")
+	Contacts        map[string]map[string]string // Contact overrides from maestro.contacts label
+	Image           string                       // Image the container was created from
+	ImageOutdated   bool                         // Image differs from the one new containers use (see MarkOutdatedImages)
 }
 
 // DisplayOptions configures how containers are displayed
@@ -59,15 +68,24 @@ type ContainerDetails struct {
 	Status        string
 	StatusDetails string
 	Branch        string
+	Task          string // Task description from the maestro.task label
 	GitStatus     string
 	AuthStatus    string
 	LastActivity  string
 	Uptime        string
+	CreatedAt     time.Time // Zero if unknown
+	StartedAt     time.Time // Last start, also set for stopped containers
 	CPUs          string
 	Memory        string
+	NetworkMode   string // bridge, host or a Docker network name
+	Image         string // Image the container was created from
+	ImageOutdated bool   // Set by callers from Info.ImageOutdated
 	IPAddress     string
 	Ports         []string
 	Volumes       []string
+	SyncedFolders []SyncedFolder
+	NoFirewall    bool // Created with --no-firewall and not enabled since
 	Environment   []string
+	DirtyFiles    []string // Uncommitted files in the workspace, at most MaxDirtyFiles
 	RecentLogs    string
 }
@@ -0,0 +1,201 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/logging"
+)
+
+// CacheVolumes returns the named volumes holding a container's npm, uv and
+// shell history caches. They outlive the container unless removed explicitly,
+// so the next container for the same branch starts warm.
+func CacheVolumes(containerName string) []string {
+	return []string{
+		containerName + "-npm",
+		containerName + "-uv",
+		containerName + "-history",
+	}
+}
+
+// cacheVolumeTargets are where the CacheVolumes are mounted, in the same
+// order.
+var cacheVolumeTargets = []string{
+	"/home/node/.npm",
+	"/home/node/.cache/uv",
+	"/commandhistory",
+}
+
+// CacheVolumeArgs returns the docker run arguments mounting a container's
+// cache volumes. With a volume driver or driver options
+// (containers.volume_driver, containers.volume_opts) the volumes are mounted
+// with --mount so Docker creates them with that driver. A volume that already
+// exists keeps the driver and options it was created with.
+func CacheVolumeArgs(containerName, driver string, opts map[string]string) []string {
+	var args []string
+	for i, volume := range CacheVolumes(containerName) {
+		target := cacheVolumeTargets[i]
+		if driver == "" && len(opts) == 0 {
+			args = append(args, "-v", volume+":"+target)
+			continue
+		}
+		fields := []string{"type=volume", "source=" + volume, "target=" + target}
+		if driver != "" {
+			fields = append(fields, "volume-driver="+driver)
+		}
+		for _, key := range slices.Sorted(maps.Keys(opts)) {
+			fields = append(fields, "volume-opt="+key+"="+opts[key])
+		}
+		args = append(args, "--mount", mountSpec(fields))
+	}
+	return args
+}
+
+// mountSpec joins --mount fields. Docker parses the value as CSV, so fields
+// containing commas, like NFS options (o=addr=10.0.0.1,rw), are quoted.
+func mountSpec(fields []string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(fields)
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// volumeDriverPattern matches volume driver and plugin names, such as local,
+// nfs or vieux/sshfs:latest.
+var volumeDriverPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_./:-]*$`)
+
+// ValidateVolumeDriver checks a containers.volume_driver value. Empty means
+// Docker's default, local.
+func ValidateVolumeDriver(driver string) error {
+	if driver != "" && !volumeDriverPattern.MatchString(driver) {
+		return fmt.Errorf("invalid volume_driver %q: use a Docker volume driver or plugin name", driver)
+	}
+	return nil
+}
+
+// VolumeDriverInstalled reports whether Docker has the volume driver, either
+// built in or as an enabled plugin.
+func VolumeDriverInstalled(driver string) (bool, error) {
+	if driver == "" || driver == "local" {
+		return true, nil
+	}
+	out, err := logging.Command("docker", "info", "--format", "{{json .Plugins.Volume}}").Output()
+	if err != nil {
+		return false, fmt.Errorf("docker info failed: %w", err)
+	}
+	var drivers []string
+	if err := json.Unmarshal(out, &drivers); err != nil {
+		return false, fmt.Errorf("failed to parse docker info: %w", err)
+	}
+	return volumeDriverListed(drivers, driver), nil
+}
+
+// volumeDriverListed reports whether driver is among the volume drivers
+// docker info lists. Plugins are listed with their tag, which defaults to
+// latest when the config leaves it out.
+func volumeDriverListed(drivers []string, driver string) bool {
+	if slices.Contains(drivers, driver) {
+		return true
+	}
+	return !strings.Contains(driver, ":") && slices.Contains(drivers, driver+":latest")
+}
+
+// removeVolume removes a docker volume. A volume that does not exist is not
+// an error; removed reports whether there was one.
+func removeVolume(name string) (removed bool, err error) {
+	output, err := logging.Command("docker", "volume", "rm", name).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "no such volume") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to remove volume %s: %s", name, strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
+
+// CacheVolumesSize returns the disk space used by a container's cache
+// volumes. docker system df walks every volume, so this can take a while.
+func CacheVolumesSize(containerName string) (int64, error) {
+	out, err := logging.Command("docker", "system", "df", "-v", "--format", "json").Output()
+	if err != nil {
+		return 0, fmt.Errorf("docker system df failed: %w", err)
+	}
+	sizes, err := ParseVolumeSizes(out)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, name := range CacheVolumes(containerName) {
+		total += sizes[name]
+	}
+	return total, nil
+}
+
+// ParseVolumeSizes maps volume names to sizes in the output of
+// 'docker system df -v --format json'.
+func ParseVolumeSizes(output []byte) (map[string]int64, error) {
+	var df struct {
+		Volumes []struct {
+			Name string
+			Size string
+		}
+	}
+	if err := json.Unmarshal(output, &df); err != nil {
+		return nil, fmt.Errorf("failed to parse docker system df: %w", err)
+	}
+	sizes := make(map[string]int64, len(df.Volumes))
+	for _, v := range df.Volumes {
+		size, err := ParseDockerSize(v.Size)
+		if err != nil {
+			return nil, err
+		}
+		sizes[v.Name] = size
+	}
+	return sizes, nil
+}
+
+// ParseDockerSize parses sizes as docker prints them ("0B", "12.3kB",
+// "1.5GB"), which use decimal units.
+func ParseDockerSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "N/A" {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3}, {"KB", 1e3}, {"B", 1},
+	}
+	for _, u := range units {
+		if num, ok := strings.CutSuffix(s, u.suffix); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q", s)
+			}
+			return int64(f * u.scale), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid size %q", s)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDockerSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0B", 0},
+		{"512B", 512},
+		{"12.5kB", 12500},
+		{"1.5MB", 1500000},
+		{"2GB", 2000000000},
+		{"1.2TB", 1200000000000},
+		{"N/A", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseDockerSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDockerSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "lots", "1.2XB"} {
+		if _, err := ParseDockerSize(bad); err == nil {
+			t.Errorf("ParseDockerSize(%q): expected error", bad)
+		}
+	}
+}
+
+func TestCacheVolumeArgs(t *testing.T) {
+	got := strings.Join(CacheVolumeArgs("maestro-feat-1", "", nil), " ")
+	want := "-v maestro-feat-1-npm:/home/node/.npm -v maestro-feat-1-uv:/home/node/.cache/uv -v maestro-feat-1-history:/commandhistory"
+	if got != want {
+		t.Errorf("default driver:\n got %s\nwant %s", got, want)
+	}
+
+	args := CacheVolumeArgs("maestro-feat-1", "nfs", map[string]string{"type": "nfs", "o": "addr=10.0.0.1,rw"})
+	if len(args) != 6 || args[0] != "--mount" {
+		t.Fatalf("expected three --mount flags, got %q", args)
+	}
+	want = `type=volume,source=maestro-feat-1-npm,target=/home/node/.npm,volume-driver=nfs,"volume-opt=o=addr=10.0.0.1,rw",volume-opt=type=nfs`
+	if args[1] != want {
+		t.Errorf("mount spec:\n got %s\nwant %s", args[1], want)
+	}
+}
+
+func TestVolumeDriver(t *testing.T) {
+	for _, driver := range []string{"", "local", "nfs", "vieux/sshfs:latest"} {
+		if err := ValidateVolumeDriver(driver); err != nil {
+			t.Errorf("ValidateVolumeDriver(%q): %v", driver, err)
+		}
+	}
+	for _, driver := range []string{"-nfs", "my driver", "nfs,rw"} {
+		if err := ValidateVolumeDriver(driver); err == nil {
+			t.Errorf("ValidateVolumeDriver(%q): expected error", driver)
+		}
+	}
+
+	drivers := []string{"local", "nfs", "vieux/sshfs:latest"}
+	for driver, want := range map[string]bool{"nfs": true, "vieux/sshfs": true, "vieux/sshfs:latest": true, "vieux/sshfs:1.0": false, "glusterfs": false} {
+		if got := volumeDriverListed(drivers, driver); got != want {
+			t.Errorf("volumeDriverListed(%q) = %v, want %v", driver, got, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"path"
+)
+
+const (
+	// DefaultWorkspace is the in-container project root used when none is
+	// configured, and for containers created before it was configurable
+	DefaultWorkspace = "/workspace"
+
+	// WorkspaceRootLabel records the project root a container was created with
+	WorkspaceRootLabel = "maestro.workspace_root"
+
+	// WorkspaceEnv passes the project root to maestro-agent in the container
+	WorkspaceEnv = "MAESTRO_WORKSPACE"
+
+	// DefaultShell is the interactive shell used when none is configured
+	DefaultShell = "zsh"
+
+	// ShellLabel records the interactive shell a container was created with
+	ShellLabel = "maestro.shell"
+)
+
+// shellRCFiles maps each supported shell to its rc file in the container.
+// sh reads no rc file for non-login shells, so it gets no prompt setup.
+var shellRCFiles = map[string]string{
+	"zsh":  "/home/node/.zshrc",
+	"bash": "/home/node/.bashrc",
+	"sh":   "",
+}
+
+// SupportedShell reports whether shell is installed in the maestro image.
+func SupportedShell(shell string) bool {
+	_, ok := shellRCFiles[shell]
+	return ok
+}
+
+// ShellRCFile returns the rc file for shell, or "" if it has none.
+func ShellRCFile(shell string) string {
+	return shellRCFiles[shell]
+}
+
+// ValidWorkspacePath reports whether p can be used as the in-container
+// project root. It must be a clean absolute path below / and, because it is
+// interpolated into shell command lines, use only a conservative character set.
+func ValidWorkspacePath(p string) bool {
+	if p == "" || p == "/" || !path.IsAbs(p) || path.Clean(p) != p {
+		return false
+	}
+	for _, c := range p {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '/' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// WorkspaceRoot returns the project root recorded on the container, falling
+// back to /workspace for containers created without the label.
+func WorkspaceRoot(containerName string) string {
+	if ws := GetLabel(containerName, WorkspaceRootLabel); ws != "" {
+		return ws
+	}
+	return DefaultWorkspace
+}
+
+// Shell returns the interactive shell recorded on the container, falling
+// back to zsh for containers created without the label.
+func Shell(containerName string) string {
+	if shell := GetLabel(containerName, ShellLabel); SupportedShell(shell) {
+		return shell
+	}
+	return DefaultShell
+}
+
+// InWorkspace prefixes a shell command line so it runs from dir.
+func InWorkspace(dir, command string) string {
+	return fmt.Sprintf("cd %s && %s", dir, command)
+}
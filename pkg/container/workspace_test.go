@@ -0,0 +1,64 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestValidWorkspacePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/workspace", true},
+		{"/home/node/src", true},
+		{"/srv/my-app_v2.0", true},
+		{"", false},
+		{"/", false},
+		{"workspace", false},
+		{"/workspace/", false},
+		{"/a/../b", false},
+		{"/my project", false},
+		{"/ws;rm -rf", false},
+		{"/ws$(id)", false},
+	}
+	for _, tt := range tests {
+		if got := ValidWorkspacePath(tt.path); got != tt.want {
+			t.Errorf("ValidWorkspacePath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSupportedShell(t *testing.T) {
+	for _, shell := range []string{"zsh", "bash", "sh"} {
+		if !SupportedShell(shell) {
+			t.Errorf("SupportedShell(%q) = false, want true", shell)
+		}
+	}
+	for _, shell := range []string{"", "fish", "/bin/zsh"} {
+		if SupportedShell(shell) {
+			t.Errorf("SupportedShell(%q) = true, want false", shell)
+		}
+	}
+	if got := ShellRCFile("sh"); got != "" {
+		t.Errorf("ShellRCFile(sh) = %q, want empty", got)
+	}
+}
+
+func TestInWorkspace(t *testing.T) {
+	got := InWorkspace("/srv/app", "git status")
+	if want := "cd /srv/app && git status"; got != want {
+		t.Errorf("InWorkspace() = %q, want %q", got, want)
+	}
+}
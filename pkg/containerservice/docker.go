@@ -29,13 +29,16 @@ func (s *dockerService) StopContainer(ctx context.Context, name string, stateHas
 
 func (s *dockerService) CleanupContainers(ctx context.Context, names []string, stateHash string, opts *CleanupOptions) (*CleanupResult, error) {
 	result := &CleanupResult{}
+	removeVolumes := opts != nil && opts.RemoveVolumes
 
 	for _, name := range names {
-		if err := container.DeleteContainer(name); err != nil {
+		volumes, err := container.DeleteContainer(name, removeVolumes)
+		if err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("failed to remove %s: %v", name, err))
 			continue
 		}
 		result.Removed = append(result.Removed, name)
+		result.VolumesRemoved += volumes
 
 		// Remove the claude-debug volume (not covered by container.DeleteContainer)
 		vol := fmt.Sprintf("%s-claude-debug", name)
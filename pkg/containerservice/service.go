@@ -23,6 +23,10 @@ type CleanupOptions struct {
 	// Use this when making multiple sequential cleanup calls and refreshing
 	// once at the end via RefreshCache.
 	SkipRefresh bool
+
+	// RemoveVolumes also removes the containers' npm/uv/history cache
+	// volumes, which are otherwise kept for the next container on the branch.
+	RemoveVolumes bool
 }
 
 // ContainerService abstracts container operations. When the daemon is running,
@@ -116,10 +120,12 @@ func (s *daemonService) StopContainer(ctx context.Context, name string, stateHas
 
 func (s *daemonService) CleanupContainers(ctx context.Context, names []string, stateHash string, opts *CleanupOptions) (*CleanupResult, error) {
 	skipRefresh := opts != nil && opts.SkipRefresh
+	removeVolumes := opts != nil && opts.RemoveVolumes
 	resp, err := api.Call(ctx, s.client, api.CleanupContainers, &api.CleanupContainersRequest{
-		Names:       names,
-		StateHash:   stateHash,
-		SkipRefresh: skipRefresh,
+		Names:         names,
+		StateHash:     stateHash,
+		SkipRefresh:   skipRefresh,
+		RemoveVolumes: removeVolumes,
 	})
 	if err != nil {
 		return nil, err
@@ -155,21 +161,33 @@ func toContainerInfoSlice(apiInfos []api.ContainerInfo) []container.Info {
 	result := make([]container.Info, len(apiInfos))
 	for i, a := range apiInfos {
 		result[i] = container.Info{
-			Name:          a.Name,
-			ShortName:     a.ShortName,
-			Status:        a.Status,
-			StatusDetails: a.StatusDetails,
-			Branch:        a.Branch,
-			AgentState:    a.AgentState,
-			IsDormant:     a.IsDormant,
-			HasWeb:        a.HasWeb,
-			AuthStatus:    a.AuthStatus,
-			LastActivity:  a.LastActivity,
-			GitStatus:     a.GitStatus,
-			CreatedAt:     a.CreatedAt,
-			CurrentTask:   a.CurrentTask,
-			TaskProgress:  a.TaskProgress,
-			Contacts:      a.Contacts,
+			Name:            a.Name,
+			ShortName:       a.ShortName,
+			Status:          a.Status,
+			StatusDetails:   a.StatusDetails,
+			Branch:          a.Branch,
+			AgentState:      a.AgentState,
+			AgentStateSince: a.AgentStateSince,
+			IsDormant:       a.IsDormant,
+			HasWeb:          a.HasWeb,
+			NoFirewall:      a.NoFirewall,
+			Project:         a.Project,
+			Task:            a.Task,
+			AuthStatus:      a.AuthStatus,
+			LastActivity:    a.LastActivity,
+			GitStatus:       a.GitStatus,
+			Git: container.GitState{
+				Repo:        a.GitRepo,
+				Uncommitted: a.GitUncommitted,
+				Unpushed:    a.GitUnpushed,
+				Behind:      a.GitBehind,
+			},
+			CreatedAt:    a.CreatedAt,
+			StartedAt:    a.StartedAt,
+			CurrentTask:  a.CurrentTask,
+			TaskProgress: a.TaskProgress,
+			Contacts:     a.Contacts,
+			Image:        a.Image,
 		}
 	}
 	return result
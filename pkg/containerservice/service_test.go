@@ -248,6 +248,8 @@ func TestToContainerInfoSlice(t *testing.T) {
 			AuthStatus:    "ok",
 			LastActivity:  "2m ago",
 			GitStatus:     "clean",
+			GitRepo:       true,
+			GitUnpushed:   4,
 			CreatedAt:     now,
 			CurrentTask:   "Implementing auth",
 			TaskProgress:  "3/5",
@@ -297,6 +299,9 @@ func TestToContainerInfoSlice(t *testing.T) {
 	if c.GitStatus != "clean" {
 		t.Errorf("GitStatus: got %s", c.GitStatus)
 	}
+	if want := (container.GitState{Repo: true, Unpushed: 4}); c.Git != want {
+		t.Errorf("Git: got %+v, want %+v", c.Git, want)
+	}
 	if !c.CreatedAt.Equal(now) {
 		t.Errorf("CreatedAt: got %v", c.CreatedAt)
 	}
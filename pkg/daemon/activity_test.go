@@ -0,0 +1,68 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordPaneActivity(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	state := &ContainerState{LastActivity: start}
+
+	// The first capture only sets the baseline
+	recordPaneActivity(state, "> waiting", start.Add(time.Minute))
+	if !state.LastActivity.Equal(start) || !state.ActivityTracked {
+		t.Fatalf("first capture: LastActivity = %v, tracked = %v", state.LastActivity, state.ActivityTracked)
+	}
+
+	// An unchanged pane is not activity
+	recordPaneActivity(state, "> waiting", start.Add(2*time.Minute))
+	if !state.LastActivity.Equal(start) {
+		t.Errorf("unchanged pane moved LastActivity to %v", state.LastActivity)
+	}
+
+	changed := start.Add(3 * time.Minute)
+	recordPaneActivity(state, "> working", changed)
+	if !state.LastActivity.Equal(changed) {
+		t.Errorf("changed pane: LastActivity = %v, want %v", state.LastActivity, changed)
+	}
+}
+
+func TestIgnoreIdle(t *testing.T) {
+	now := time.Now()
+	d := &Daemon{config: Config{IgnoreIdleAfter: 48 * time.Hour}}
+
+	stale := &ContainerState{Name: "mcl-old-1", LastActivity: now.Add(-72 * time.Hour), ActivityTracked: true}
+	if !d.ignoreIdle("mcl-old-1", stale, now) {
+		t.Error("container inactive for 72h should be ignored")
+	}
+
+	recent := &ContainerState{Name: "mcl-new-1", LastActivity: now.Add(-time.Hour), ActivityTracked: true}
+	if d.ignoreIdle("mcl-new-1", recent, now) {
+		t.Error("recently active container should not be ignored")
+	}
+
+	untracked := &ContainerState{Name: "mcl-notmux-1", LastActivity: now.Add(-72 * time.Hour)}
+	if d.ignoreIdle("mcl-notmux-1", untracked, now) {
+		t.Error("containers whose pane can't be captured should not be ignored")
+	}
+
+	d.config.IgnoreIdleAfter = 0
+	if d.ignoreIdle("mcl-old-1", stale, now) {
+		t.Error("ignore_idle_after unset should never ignore")
+	}
+}
@@ -23,6 +23,10 @@ type ContainerCache struct {
 	// Replaceable in tests to inject a controllable function.
 	refreshFn func(prefix string) ([]container.Info, error)
 
+	// activityFn reports when a container's Claude pane last changed, if the
+	// daemon has been able to track it. Optional; overrides LastActivity.
+	activityFn func(name string) (time.Time, bool)
+
 	mu        sync.Mutex
 	data      []api.ContainerInfo
 	stateHash string
@@ -150,6 +154,7 @@ func (c *ContainerCache) doRefreshLocked() ([]api.ContainerInfo, string, error)
 
 	// Convert to API types and compute hash
 	apiContainers := toAPIContainers(containers)
+	c.applyActivity(apiContainers, time.Now())
 	hash := computeStateHash(apiContainers)
 
 	c.data = apiContainers
@@ -167,26 +172,49 @@ func toAPIContainers(infos []container.Info) []api.ContainerInfo {
 	result := make([]api.ContainerInfo, len(infos))
 	for i, c := range infos {
 		result[i] = api.ContainerInfo{
-			Name:          c.Name,
-			ShortName:     c.ShortName,
-			Status:        c.Status,
-			StatusDetails: c.StatusDetails,
-			Branch:        c.Branch,
-			AgentState:    c.AgentState,
-			IsDormant:     c.IsDormant,
-			HasWeb:        c.HasWeb,
-			AuthStatus:    c.AuthStatus,
-			LastActivity:  c.LastActivity,
-			GitStatus:     c.GitStatus,
-			CreatedAt:     c.CreatedAt,
-			CurrentTask:   c.CurrentTask,
-			TaskProgress:  c.TaskProgress,
-			Contacts:      c.Contacts,
+			Name:            c.Name,
+			ShortName:       c.ShortName,
+			Status:          c.Status,
+			StatusDetails:   c.StatusDetails,
+			Branch:          c.Branch,
+			AgentState:      c.AgentState,
+			AgentStateSince: c.AgentStateSince,
+			IsDormant:       c.IsDormant,
+			HasWeb:          c.HasWeb,
+			NoFirewall:      c.NoFirewall,
+			Project:         c.Project,
+			Task:            c.Task,
+			AuthStatus:      c.AuthStatus,
+			LastActivity:    c.LastActivity,
+			GitStatus:       c.GitStatus,
+			GitRepo:         c.Git.Repo,
+			GitUncommitted:  c.Git.Uncommitted,
+			GitUnpushed:     c.Git.Unpushed,
+			GitBehind:       c.Git.Behind,
+			CreatedAt:       c.CreatedAt,
+			StartedAt:       c.StartedAt,
+			CurrentTask:     c.CurrentTask,
+			TaskProgress:    c.TaskProgress,
+			Contacts:        c.Contacts,
+			Image:           c.Image,
 		}
 	}
 	return result
 }
 
+// applyActivity replaces LastActivity with the daemon's tracked activity for
+// the containers it has, which is more reliable than asking tmux.
+func (c *ContainerCache) applyActivity(containers []api.ContainerInfo, now time.Time) {
+	if c.activityFn == nil {
+		return
+	}
+	for i := range containers {
+		if last, ok := c.activityFn(containers[i].Name); ok {
+			containers[i].LastActivity = container.FormatDuration(now.Sub(last))
+		}
+	}
+}
+
 // computeStateHash produces a deterministic hash of the container list.
 // Used for optimistic concurrency — clients send the hash back with action
 // requests, and the daemon rejects if state has changed.
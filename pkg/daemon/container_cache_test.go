@@ -354,6 +354,7 @@ func TestToAPIContainers_AllFields(t *testing.T) {
 			AuthStatus:    "ok",
 			LastActivity:  "2m ago",
 			GitStatus:     "clean",
+			Git:           container.GitState{Repo: true, Uncommitted: 2, Unpushed: 1},
 			CreatedAt:     now,
 			CurrentTask:   "building",
 			TaskProgress:  "3/5",
@@ -368,8 +369,36 @@ func TestToAPIContainers_AllFields(t *testing.T) {
 	if r.Name != "test-1" || r.ShortName != "t-1" || r.Status != "running" ||
 		r.StatusDetails != "Up 2 hours" || r.Branch != "main" || r.AgentState != "active" ||
 		!r.IsDormant || r.AuthStatus != "ok" || r.LastActivity != "2m ago" ||
-		r.GitStatus != "clean" || !r.CreatedAt.Equal(now) ||
+		r.GitStatus != "clean" || !r.GitRepo || r.GitUncommitted != 2 || r.GitUnpushed != 1 || !r.CreatedAt.Equal(now) ||
 		r.CurrentTask != "building" || r.TaskProgress != "3/5" {
 		t.Errorf("field mismatch in toAPIContainers conversion: %+v", r)
 	}
 }
+
+func TestContainerCache_AppliesTrackedActivity(t *testing.T) {
+	now := time.Now()
+	cache := NewContainerCache("maestro-")
+	cache.refreshFn = func(prefix string) ([]container.Info, error) {
+		return []container.Info{
+			{Name: "maestro-a-1", Status: "running", LastActivity: "-"},
+			{Name: "maestro-b-1", Status: "running", LastActivity: "-"},
+		}, nil
+	}
+	cache.activityFn = func(name string) (time.Time, bool) {
+		if name == "maestro-a-1" {
+			return now.Add(-5 * time.Minute), true
+		}
+		return time.Time{}, false
+	}
+
+	data, _, err := cache.ForceRefresh()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0].LastActivity != "5m" {
+		t.Errorf("tracked container LastActivity = %q, want 5m", data[0].LastActivity)
+	}
+	if data[1].LastActivity != "-" {
+		t.Errorf("untracked container LastActivity = %q, want the value from docker", data[1].LastActivity)
+	}
+}
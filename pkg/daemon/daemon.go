@@ -16,6 +16,7 @@ package daemon
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
@@ -47,10 +49,16 @@ type Config struct {
 	NotifyOn            []string
 	QuietHoursStart     string
 	QuietHoursEnd       string
+	QuietHoursAllow     []string                 // Notification types delivered during quiet hours
+	NotificationIcon    string                   // Linux notification icon override: theme icon name or file path
+	RateLimit           time.Duration            // Minimum time between defaultRateLimited notifications per container (0 disables)
+	RateLimits          map[string]time.Duration // Per-type rate limits, overriding RateLimit
 	ContainerPrefix     string
 	CreateContainer     func(opts CreateContainerOpts) (string, error) // Callback for IPC child creation
 	UpdateCheckEnabled  bool                                           // Whether to check for updates periodically
 	UpdateCheckInterval time.Duration                                  // How often to check (default: 6h)
+	MaxContainers       int                                            // Running container limit for warnings (0 disables)
+	IgnoreIdleAfter     time.Duration                                  // Skip attention notifications after this long without activity (0 disables)
 }
 
 // CreateContainerOpts holds parameters for creating a child container via the daemon callback.
@@ -78,7 +86,8 @@ type Daemon struct {
 	stopOnce            sync.Once
 	mu                  sync.Mutex // protects containerStates
 	containerStates     map[string]*ContainerState
-	iconPath            string // Cached icon path for notifications
+	iconPath            string // Icon for notifications: cached PNG path, or a theme icon name on Linux
+	iconSource          string // Where iconPath came from, for the logs
 	hasTerminalNotifier bool   // Whether terminal-notifier is available
 	ipcServer           *IPCServer
 	startTime           time.Time
@@ -96,6 +105,7 @@ type Daemon struct {
 	containerCache      *ContainerCache // lazy cache for API v1 endpoints
 	alarms              *AlarmStore
 	updateChecker       *update.Checker
+	nearLimitWarned     bool // whether the max_containers warning has been logged for the current excursion
 }
 
 // ContainerState tracks container monitoring state
@@ -103,8 +113,11 @@ type ContainerState struct {
 	mu                     sync.Mutex // protects all fields below
 	Name                   string
 	AttentionStarted       *time.Time
-	LastIdleNotified       *time.Time // Last time an idle/attention notification was sent (scoped rate limit)
-	LastActivity           time.Time
+	LastNotified           map[string]time.Time // Last notification sent per type, for the rate limit
+	LastActivity           time.Time            // Last time the Claude pane changed (or the state was created)
+	LastPaneHash           string               // Hash of the Claude pane at the last check
+	ActivityTracked        bool                 // Whether the Claude pane could be captured, so LastActivity is meaningful
+	IdleIgnored            bool                 // Whether attention is currently ignored for inactivity
 	LastTokenCheck         time.Time
 	NotificationSent       bool
 	LastTaskCheck          time.Time
@@ -121,6 +134,31 @@ type ContainerState struct {
 	AlarmsLoaded           bool   // Whether we've loaded alarms from this container
 }
 
+// CleanupStaleIPCFile removes daemon-ipc.json when the daemon that wrote it
+// is no longer alive, e.g. after a crash or SIGKILL skipped Stop(). It reports
+// whether a file was removed. A file whose PID is still alive is left alone;
+// Start() decides whether that daemon is actually responsive.
+func CleanupStaleIPCFile(configDir string) (bool, error) {
+	ipcFilePath := filepath.Join(configDir, "daemon-ipc.json")
+	data, err := os.ReadFile(ipcFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", ipcFilePath, err)
+	}
+
+	var info api.DaemonIPCInfo
+	if json.Unmarshal(data, &info) == nil && info.PID > 0 && ProcessAlive(info.PID) {
+		return false, nil
+	}
+
+	if err := os.Remove(ipcFilePath); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to remove stale %s: %w", ipcFilePath, err)
+	}
+	return true, nil
+}
+
 // New creates a new daemon instance
 func New(config Config, configDir string, iconData []byte) (*Daemon, error) {
 	logPath := filepath.Join(configDir, "daemon.log")
@@ -156,31 +194,49 @@ func New(config Config, configDir string, iconData []byte) (*Daemon, error) {
 		containerCache:   NewContainerCache(prefix),
 		alarms:           NewAlarmStore(),
 	}
+	d.containerCache.activityFn = d.trackedActivity
 
-	// Check for terminal-notifier on macOS
-	if runtime.GOOS == "darwin" {
-		cmd := exec.Command("which", "terminal-notifier")
-		if err := cmd.Run(); err == nil {
-			d.hasTerminalNotifier = true
-		}
+	d.hasTerminalNotifier = TerminalNotifierAvailable()
+	d.iconPath, d.iconSource = NotificationIcon(configDir, iconData, config.NotificationIcon)
+
+	return d, nil
+}
+
+// TerminalNotifierAvailable reports whether macOS notifications can go
+// through terminal-notifier, which supports subtitles and icons.
+func TerminalNotifierAvailable() bool {
+	if runtime.GOOS != "darwin" {
+		return false
 	}
+	return exec.Command("which", "terminal-notifier").Run() == nil
+}
 
-	// Cache icon to temp location for platforms that support it
-	if runtime.GOOS == "darwin" || runtime.GOOS == "linux" {
-		if len(iconData) > 0 {
-			iconPath := filepath.Join(configDir, "notification-icon.png")
-			if err := os.WriteFile(iconPath, iconData, 0644); err == nil {
-				// Ensure path is absolute
-				if absPath, err := filepath.Abs(iconPath); err == nil {
-					d.iconPath = absPath
-				} else {
-					d.iconPath = iconPath
-				}
-			}
-		}
+// CacheNotificationIcon writes the notification icon to configDir on
+// platforms that can show it, returning its absolute path or "" if there is
+// none.
+func CacheNotificationIcon(configDir string, iconData []byte) string {
+	if runtime.GOOS != "darwin" && runtime.GOOS != "linux" || len(iconData) == 0 {
+		return ""
+	}
+	iconPath := filepath.Join(configDir, "notification-icon.png")
+	if err := os.WriteFile(iconPath, iconData, 0644); err != nil {
+		return ""
 	}
+	if absPath, err := filepath.Abs(iconPath); err == nil {
+		return absPath
+	}
+	return iconPath
+}
 
-	return d, nil
+// NotificationIcon returns the icon desktop notifications show and where it
+// came from. On Linux that is daemon.notifications.icon if set, else the
+// cached PNG, else a theme icon (see notify.LinuxIcon).
+func NotificationIcon(configDir string, iconData []byte, configured string) (icon, source string) {
+	iconPath := CacheNotificationIcon(configDir, iconData)
+	if runtime.GOOS == "linux" {
+		return notify.LinuxIcon(configured, iconPath)
+	}
+	return iconPath, "bundled PNG"
 }
 
 // SetEngine configures the notification engine and local provider.
@@ -245,9 +301,7 @@ func (d *Daemon) Start() error {
 				}
 			case "linux":
 				d.logInfo("Using notify-send for notifications")
-				if d.iconPath != "" {
-					d.logInfo("Custom icon path: %s", d.iconPath)
-				}
+				d.logInfo("Notification icon: %s (%s)", d.iconPath, d.iconSource)
 			}
 
 			// Send welcome notification
@@ -366,6 +420,8 @@ func (d *Daemon) check() {
 		return
 	}
 
+	d.checkContainerLimit(len(containers))
+
 	// Batch token sync: find freshest token and distribute to expired containers
 	d.syncTokensAcrossContainers(containers)
 
@@ -408,6 +464,7 @@ func (d *Daemon) check() {
 					Contacts:      d.getContainerContacts(container),
 				}
 				d.sendNotification(event)
+				d.recordNotified("dormant", state)
 			}
 		}
 
@@ -417,8 +474,12 @@ func (d *Daemon) check() {
 		// Check token expiry
 		d.checkTokenExpiry(container, state)
 
-		// Check attention status (idle notifications — gated by threshold + rate limit)
-		d.checkAttentionStatus(container, state)
+		// Check attention status (idle notifications — gated by threshold + rate limit),
+		// except for containers nobody has touched in daemon.ignore_idle_after
+		d.checkActivity(container, state)
+		if !d.ignoreIdle(container, state, time.Now()) {
+			d.checkAttentionStatus(container, state)
+		}
 
 		// Check task completion status
 		d.checkTaskStatus(container, state)
@@ -441,6 +502,20 @@ func (d *Daemon) check() {
 	d.cleanupStates(containers)
 }
 
+// checkContainerLimit logs a warning once when the running container count
+// climbs above 80% of daemon.max_containers, and re-arms once it drops back.
+func (d *Daemon) checkContainerLimit(count int) {
+	if !container.NearContainerLimit(count, d.config.MaxContainers) {
+		d.nearLimitWarned = false
+		return
+	}
+	if d.nearLimitWarned {
+		return
+	}
+	d.nearLimitWarned = true
+	log.Printf("[WARN] %d of %d allowed containers are running (daemon.max_containers)\n", count, d.config.MaxContainers)
+}
+
 // checkQuestionStatus checks for pending questions every cycle with no gating.
 // Questions are time-sensitive (interactive Q&A) and should fire within one check cycle.
 func (d *Daemon) checkQuestionStatus(containerName string, state *ContainerState) {
@@ -667,12 +742,78 @@ func (d *Daemon) checkTokenExpiry(containerName string, state *ContainerState) {
 			Contacts:      d.getContainerContacts(containerName),
 		}
 		d.sendNotification(event)
+		d.recordNotified("token_expiring", state)
 		state.mu.Lock()
 		state.TokenExpiryNotified = true
 		state.mu.Unlock()
 	}
 }
 
+// checkActivity updates the container's LastActivity when its Claude pane has
+// changed since the last check. Containers without tmux can't be captured
+// and are left untracked.
+func (d *Daemon) checkActivity(containerName string, state *ContainerState) {
+	pane, err := container.CaptureClaudePane(containerName, paneActivityLines)
+	if err != nil {
+		return
+	}
+	recordPaneActivity(state, pane, time.Now())
+}
+
+// paneActivityLines is how much of the Claude pane is compared between checks.
+const paneActivityLines = 200
+
+// recordPaneActivity stores the pane's hash, moving LastActivity to now if
+// the pane changed since the last check.
+func recordPaneActivity(state *ContainerState, pane string, now time.Time) {
+	sum := sha256.Sum256([]byte(pane))
+	hash := hex.EncodeToString(sum[:])
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.LastPaneHash != "" && state.LastPaneHash != hash {
+		state.LastActivity = now
+	}
+	state.LastPaneHash = hash
+	state.ActivityTracked = true
+}
+
+// trackedActivity returns when the container's Claude pane last changed, if
+// the daemon has been able to capture it.
+func (d *Daemon) trackedActivity(containerName string) (time.Time, bool) {
+	d.mu.Lock()
+	state := d.containerStates[containerName]
+	d.mu.Unlock()
+	if state == nil {
+		return time.Time{}, false
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.LastActivity, state.ActivityTracked
+}
+
+// ignoreIdle reports whether attention checks should be skipped because the
+// container's pane hasn't changed in daemon.ignore_idle_after, logging when
+// a container starts or stops being ignored.
+func (d *Daemon) ignoreIdle(containerName string, state *ContainerState, now time.Time) bool {
+	if d.config.IgnoreIdleAfter <= 0 {
+		return false
+	}
+	state.mu.Lock()
+	idle := now.Sub(state.LastActivity)
+	ignore := state.ActivityTracked && idle >= d.config.IgnoreIdleAfter
+	changed := ignore != state.IdleIgnored
+	state.IdleIgnored = ignore
+	state.mu.Unlock()
+
+	if changed && ignore {
+		d.logInfo("Container %s inactive for %s, ignoring attention until it is active again", d.getShortName(containerName), container.FormatDuration(idle))
+	} else if changed {
+		d.logInfo("Container %s active again, resuming attention checks", d.getShortName(containerName))
+	}
+	return ignore
+}
+
 // checkAttentionStatus monitors container idle/attention state via agent state.
 // Questions are handled separately by checkQuestionStatus(); this only sends
 // EventAttentionNeeded notifications, gated by the attention threshold and a
@@ -697,9 +838,8 @@ func (d *Daemon) checkAttentionStatus(containerName string, state *ContainerStat
 		// Check if we should notify (threshold + idle-specific rate limit)
 		// Skip if a question is already pending — the question notification is sufficient
 		attentionDuration := time.Since(*state.AttentionStarted)
-		idleRateLimited := state.LastIdleNotified != nil && time.Since(*state.LastIdleNotified) < 30*time.Minute
 		hasQuestion := state.QuestionNotified
-		shouldSend := !state.NotificationSent && !hasQuestion && attentionDuration >= d.config.AttentionThreshold && !idleRateLimited
+		shouldSend := !state.NotificationSent && !hasQuestion && attentionDuration >= d.config.AttentionThreshold
 		state.mu.Unlock()
 
 		if newAttention {
@@ -713,27 +853,27 @@ func (d *Daemon) checkAttentionStatus(containerName string, state *ContainerStat
 				ContainerName: containerName,
 				ShortName:     shortName,
 				Title:         "Needs Attention",
-				Message:       fmt.Sprintf("Has needed attention for %s", formatDuration(attentionDuration)),
+				Message:       fmt.Sprintf("Has needed attention for %s", container.FormatDuration(attentionDuration)),
 				Type:          notify.EventAttentionNeeded,
 				Timestamp:     time.Now(),
 				Contacts:      d.getContainerContacts(containerName),
 			}
 
 			d.sendNotification(event)
+			d.recordNotified("attention_needed", state)
 
 			state.mu.Lock()
 			state.NotificationSent = true
-			state.LastIdleNotified = timeNow()
 			state.mu.Unlock()
 		}
 	} else {
-		// Clear attention state — also clear LastIdleNotified so the next
-		// idle event can fire immediately after attention is resolved.
+		// Clear attention state — also clear the attention rate limit so the
+		// next idle event can fire immediately after attention is resolved.
 		wasAttending := state.AttentionStarted != nil
 		state.AttentionStarted = nil
 		state.NotificationSent = false
 		if wasAttending {
-			state.LastIdleNotified = nil
+			delete(state.LastNotified, "attention_needed")
 		}
 		state.mu.Unlock()
 
@@ -778,7 +918,7 @@ func (d *Daemon) checkTaskStatus(containerName string, state *ContainerState) {
 	if shouldNotifyCompletion {
 		d.logInfo("Container %s completed all tasks (%s)", d.getShortName(containerName), summary.Progress)
 
-		// Send notification if type is enabled (no rate limit — TaskCompletionNotified dedup is sufficient)
+		// Send notification if type is enabled (TaskCompletionNotified dedups each completion)
 		if d.shouldNotify("tasks_completed", state) {
 			shortName := d.getShortName(containerName)
 			event := notify.Event{
@@ -792,6 +932,7 @@ func (d *Daemon) checkTaskStatus(containerName string, state *ContainerState) {
 				Contacts:      d.getContainerContacts(containerName),
 			}
 			d.sendNotification(event)
+			d.recordNotified("tasks_completed", state)
 			state.mu.Lock()
 			state.TaskCompletionNotified = true
 			state.mu.Unlock()
@@ -808,8 +949,9 @@ func (d *Daemon) checkTaskStatus(containerName string, state *ContainerState) {
 	state.mu.Unlock()
 }
 
-// shouldNotify checks if a notification type is enabled and not in quiet hours.
-// Rate limiting is handled per-notification-type by the caller.
+// shouldNotify checks if a notification type is enabled, not rate limited for
+// the container and not in quiet hours. Callers record what they send with
+// recordNotified.
 func (d *Daemon) shouldNotify(notifyType string, state *ContainerState) bool {
 	if !d.config.NotificationsOn {
 		return false
@@ -827,12 +969,54 @@ func (d *Daemon) shouldNotify(notifyType string, state *ContainerState) bool {
 		return false
 	}
 
-	// Check quiet hours — blockers bypass quiet hours
-	if notifyType != "blocker" && d.isQuietHours() {
-		return false
+	if state != nil {
+		state.mu.Lock()
+		last, sent := state.LastNotified[notifyType]
+		state.mu.Unlock()
+		if sent && time.Since(last) < d.rateLimit(notifyType) {
+			return false
+		}
 	}
 
-	return true
+	return !d.quietFor(notifyType)
+}
+
+// defaultRateLimited lists the notification types daemon.notifications.rate_limit
+// applies to. Other types are only limited by a rate_limits entry.
+var defaultRateLimited = []string{"attention_needed"}
+
+// rateLimit returns the minimum time between notifications of a type for
+// one container: daemon.notifications.rate_limits[type] if set, otherwise
+// daemon.notifications.rate_limit for defaultRateLimited types and none for
+// the rest.
+func (d *Daemon) rateLimit(notifyType string) time.Duration {
+	if limit, ok := d.config.RateLimits[notifyType]; ok {
+		return limit
+	}
+	if slices.Contains(defaultRateLimited, notifyType) {
+		return d.config.RateLimit
+	}
+	return 0
+}
+
+// recordNotified notes that a notification of a type was sent for the
+// container, starting its rate limit.
+func (d *Daemon) recordNotified(notifyType string, state *ContainerState) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.LastNotified == nil {
+		state.LastNotified = make(map[string]time.Time)
+	}
+	state.LastNotified[notifyType] = time.Now()
+}
+
+// quietFor reports whether quiet hours hold back a notification type.
+// Blockers and the types in the quiet hours allow list always come through.
+func (d *Daemon) quietFor(notifyType string) bool {
+	if notifyType == "blocker" || slices.Contains(d.config.QuietHoursAllow, notifyType) {
+		return false
+	}
+	return d.isQuietHours()
 }
 
 // notify sends a desktop notification.
@@ -895,17 +1079,11 @@ func (d *Daemon) notify(title, subtitle, message string) {
 	case "linux":
 		// Linux notification via notify-send (no subtitle support)
 		// Prepend subtitle to message if present
-		var args []string
-		if d.iconPath != "" {
-			args = append(args, "--icon", d.iconPath)
-		}
 		displayMsg := message
 		if subtitle != "" {
 			displayMsg = fmt.Sprintf("[%s] %s", subtitle, message)
 		}
-		args = append(args, fmt.Sprintf("Maestro - %s", title), displayMsg)
-		cmd := exec.Command("notify-send", args...)
-		if err := cmd.Run(); err != nil {
+		if err := notify.NotifySend(d.iconPath, fmt.Sprintf("Maestro - %s", title), displayMsg); err != nil {
 			d.logError("Failed to send Linux notification: %v", err)
 		}
 	default:
@@ -1145,7 +1323,7 @@ func (d *Daemon) createChildContainer(opts CreateContainerOpts) (string, error)
 
 // getUptime returns formatted daemon uptime
 func (d *Daemon) getUptime() string {
-	return formatDuration(time.Since(d.startTime))
+	return container.FormatDuration(time.Since(d.startTime))
 }
 
 // Credentials represents OAuth credentials
@@ -1196,18 +1374,3 @@ func (d *Daemon) getContainerContacts(containerName string) map[string]map[strin
 	}
 	return contacts
 }
-
-func timeNow() *time.Time {
-	t := time.Now()
-	return &t
-}
-
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%.0fs", d.Seconds())
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%.0fm", d.Minutes())
-	}
-	return fmt.Sprintf("%.1fh", d.Hours())
-}
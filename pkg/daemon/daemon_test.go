@@ -0,0 +1,135 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/api"
+)
+
+func TestShouldNotify_QuietHoursAllow(t *testing.T) {
+	d := &Daemon{config: Config{
+		NotificationsOn: true,
+		NotifyOn:        []string{"attention_needed", "token_expiring", "blocker"},
+		// Equal start and end wrap around midnight, so it is always quiet
+		QuietHoursStart: "00:00",
+		QuietHoursEnd:   "00:00",
+		QuietHoursAllow: []string{"token_expiring"},
+	}}
+
+	if d.shouldNotify("attention_needed", nil) {
+		t.Error("attention_needed should be muted during quiet hours")
+	}
+	if !d.shouldNotify("token_expiring", nil) {
+		t.Error("token_expiring is allowed and should come through quiet hours")
+	}
+	if !d.shouldNotify("blocker", nil) {
+		t.Error("blockers should always come through quiet hours")
+	}
+	if !d.quietFor("container_notification") {
+		t.Error("container notifications should be muted unless allowed")
+	}
+}
+
+func TestShouldNotify_RateLimit(t *testing.T) {
+	d := &Daemon{config: Config{
+		NotificationsOn: true,
+		NotifyOn:        []string{"attention_needed", "token_expiring", "tasks_completed", "dormant"},
+		RateLimit:       30 * time.Minute,
+		RateLimits:      map[string]time.Duration{"token_expiring": 6 * time.Hour, "tasks_completed": 0},
+	}}
+	state := &ContainerState{LastNotified: map[string]time.Time{
+		"attention_needed": time.Now().Add(-time.Hour),
+		"token_expiring":   time.Now().Add(-time.Hour),
+	}}
+
+	if !d.shouldNotify("attention_needed", state) {
+		t.Error("attention_needed was sent an hour ago, past the 30m default")
+	}
+	if d.shouldNotify("token_expiring", state) {
+		t.Error("token_expiring has a 6h override and was sent an hour ago")
+	}
+
+	d.recordNotified("attention_needed", state)
+	d.recordNotified("tasks_completed", state)
+	if d.shouldNotify("attention_needed", state) {
+		t.Error("attention_needed was just sent and should be rate limited")
+	}
+	if !d.shouldNotify("tasks_completed", state) {
+		t.Error("a 0 override should disable the rate limit")
+	}
+
+	// rate_limit only applies to attention_needed by default
+	d.recordNotified("dormant", state)
+	if !d.shouldNotify("dormant", state) {
+		t.Error("dormant has no rate limit without a rate_limits entry")
+	}
+}
+
+func writeIPCFile(t *testing.T, dir string, pid int) string {
+	t.Helper()
+	data, err := json.Marshal(api.DaemonIPCInfo{Port: 1, Token: "t", PID: pid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "daemon-ipc.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCleanupStaleIPCFile(t *testing.T) {
+	dir := t.TempDir()
+	// Far above any kernel pid_max, so nothing can be running with it
+	path := writeIPCFile(t, dir, 99999999)
+
+	removed, err := CleanupStaleIPCFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removed {
+		t.Error("expected the stale IPC file to be removed")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("daemon-ipc.json still exists: %v", err)
+	}
+
+	// Nothing left to clean up
+	if removed, err := CleanupStaleIPCFile(dir); err != nil || removed {
+		t.Errorf("second cleanup = %v, %v; want false, nil", removed, err)
+	}
+}
+
+func TestCleanupStaleIPCFile_LiveDaemon(t *testing.T) {
+	dir := t.TempDir()
+	path := writeIPCFile(t, dir, os.Getpid())
+
+	removed, err := CleanupStaleIPCFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed {
+		t.Error("IPC file of a live process should be kept")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("daemon-ipc.json should still exist: %v", err)
+	}
+}
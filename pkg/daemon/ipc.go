@@ -309,7 +309,7 @@ func (s *IPCServer) handleNotify(w http.ResponseWriter, req IPCRequest) {
 	// Container notifications are always delivered — the agent explicitly asked to
 	// notify the user. The shouldNotify rate-limiting only applies to daemon-generated
 	// alerts (attention_needed, token_expiring), not explicit IPC requests.
-	if s.daemon.config.NotificationsOn && !s.daemon.isQuietHours() {
+	if s.daemon.config.NotificationsOn && !s.daemon.quietFor("container_notification") {
 		if s.daemon.notifyEngine != nil {
 			event := notify.Event{
 				ID:            fmt.Sprintf("ipc-%s-%d", req.Parent, time.Now().UnixMilli()),
@@ -624,7 +624,7 @@ func (s *IPCServer) checkPendingRequests(containerName string, state *ContainerS
 
 		case IPCActionNotify:
 			containerShort := s.daemon.getShortName(containerName)
-			if s.daemon.config.NotificationsOn && !s.daemon.isQuietHours() {
+			if s.daemon.config.NotificationsOn && !s.daemon.quietFor("container_notification") {
 				s.daemon.notify(reqFile.Title, containerShort, reqFile.Message)
 			}
 			s.updateRequestFile(containerName, reqFile.ID, IPCRequestStatusFulfilled, "", "")
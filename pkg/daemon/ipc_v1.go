@@ -164,11 +164,13 @@ func (s *IPCServer) handleCleanupContainersV1(r *http.Request, req api.CleanupCo
 		}
 
 		// Remove container
-		if err := container.DeleteContainer(name); err != nil {
+		volumes, err := container.DeleteContainer(name, req.RemoveVolumes)
+		if err != nil {
 			errors = append(errors, fmt.Sprintf("failed to remove %s: %v", name, err))
 			continue
 		}
 		removed = append(removed, name)
+		totalVolumes += volumes
 
 		// Remove claude-debug volume (npm/uv/history handled by DeleteContainer)
 		vol := fmt.Sprintf("%s-claude-debug", name)
@@ -0,0 +1,162 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging is the leveled console logger for maestro's CLI output.
+// Progress messages and warnings go to stdout as they always have; debug
+// output, including every docker command run, goes to stderr.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Level controls which messages are printed
+type Level int32
+
+const (
+	LevelQuiet  Level = iota // Warnings and errors only
+	LevelNormal              // Progress messages (default)
+	LevelDebug               // Everything, including docker commands
+)
+
+// DebugEnv enables debug output when set to a non-empty value other than
+// "0" or "false"
+const DebugEnv = "MAESTRO_DEBUG"
+
+var (
+	level  atomic.Int32
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+func init() {
+	level.Store(int32(LevelNormal))
+}
+
+// SetLevel sets the current log level
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+// GetLevel returns the current log level
+func GetLevel() Level {
+	return Level(level.Load())
+}
+
+// DebugFromEnv reports whether MAESTRO_DEBUG asks for debug output
+func DebugFromEnv() bool {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv(DebugEnv)))
+	return v != "" && v != "0" && v != "false"
+}
+
+// Debugf prints a debug message to stderr in debug mode
+func Debugf(format string, args ...any) {
+	if GetLevel() >= LevelDebug {
+		fmt.Fprintf(stderr, "[debug] "+format+"\n", args...)
+	}
+}
+
+// Infof prints a progress message unless quiet
+func Infof(format string, args ...any) {
+	if GetLevel() >= LevelNormal {
+		fmt.Fprintf(stdout, format+"\n", args...)
+	}
+}
+
+// Warnf prints a "Warning: " message at every level
+func Warnf(format string, args ...any) {
+	fmt.Fprintf(stdout, "Warning: "+format+"\n", args...)
+}
+
+// Command is exec.Command that echoes the command line in debug mode
+func Command(name string, args ...string) *exec.Cmd {
+	if GetLevel() >= LevelDebug {
+		Debugf("+ %s", FormatCommand(name, args...))
+	}
+	return exec.Command(name, args...)
+}
+
+// CommandContext is exec.CommandContext that echoes the command line in debug
+// mode
+func CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	if GetLevel() >= LevelDebug {
+		Debugf("+ %s", FormatCommand(name, args...))
+	}
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// maxStderrLines bounds how much captured stderr Run adds to an error
+const maxStderrLines = 5
+
+// Run is cmd.Run that captures stderr when the caller would otherwise discard
+// it, so a failure carries the command's own error message (e.g. docker cp's
+// "no such container") instead of a bare exit status. In debug mode failures
+// are also logged.
+func Run(cmd *exec.Cmd) error {
+	if cmd.Stderr != nil {
+		err := cmd.Run()
+		if err != nil {
+			Debugf("  failed: %v", err)
+		}
+		return err
+	}
+
+	var stderrBuf bytes.Buffer
+	cmd.Stderr = &stderrBuf
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+	Debugf("  failed: %v", err)
+	if msg := lastLines(stderrBuf.String(), maxStderrLines); msg != "" {
+		Debugf("  stderr: %s", msg)
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return err
+}
+
+// lastLines returns the last n non-empty lines of s joined with "; "
+func lastLines(s string, n int) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "; ")
+}
+
+// FormatCommand renders a command line for display, quoting arguments that
+// would not survive copy-pasting into a shell as-is
+func FormatCommand(name string, args ...string) string {
+	parts := make([]string, 0, len(args)+1)
+	for _, a := range append([]string{name}, args...) {
+		if a == "" || strings.ContainsAny(a, " \t\n\"'\\$`|&;<>()*?[]{}#~") {
+			a = strconv.Quote(a)
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
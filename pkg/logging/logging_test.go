@@ -0,0 +1,115 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func capture(t *testing.T) (*bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	oldOut, oldErr, oldLevel := stdout, stderr, GetLevel()
+	stdout, stderr = &out, &errOut
+	t.Cleanup(func() {
+		stdout, stderr = oldOut, oldErr
+		SetLevel(oldLevel)
+	})
+	return &out, &errOut
+}
+
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		level               Level
+		wantInfo, wantDebug bool
+	}{
+		{LevelQuiet, false, false},
+		{LevelNormal, true, false},
+		{LevelDebug, true, true},
+	}
+	for _, tt := range tests {
+		out, errOut := capture(t)
+		SetLevel(tt.level)
+
+		Infof("copying %s", "x")
+		Warnf("disk %d%% full", 90)
+		Debugf("detail")
+
+		if got := bytes.Contains(out.Bytes(), []byte("copying x\n")); got != tt.wantInfo {
+			t.Errorf("level %d: info printed = %v, want %v", tt.level, got, tt.wantInfo)
+		}
+		if !bytes.Contains(out.Bytes(), []byte("Warning: disk 90% full\n")) {
+			t.Errorf("level %d: warning missing from %q", tt.level, out.String())
+		}
+		if got := bytes.Contains(errOut.Bytes(), []byte("[debug] detail\n")); got != tt.wantDebug {
+			t.Errorf("level %d: debug printed = %v, want %v", tt.level, got, tt.wantDebug)
+		}
+	}
+}
+
+func TestCommandEchoesInDebug(t *testing.T) {
+	_, errOut := capture(t)
+	SetLevel(LevelDebug)
+
+	cmd := Command("docker", "exec", "c1", "sh", "-c", "echo hi")
+	if cmd.Args[0] != "docker" || len(cmd.Args) != 6 {
+		t.Fatalf("unexpected args %v", cmd.Args)
+	}
+	want := `[debug] + docker exec c1 sh -c "echo hi"` + "\n"
+	if errOut.String() != want {
+		t.Errorf("debug output = %q, want %q", errOut.String(), want)
+	}
+}
+
+func TestDebugFromEnv(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "false": false, "1": true, "yes": true} {
+		t.Setenv(DebugEnv, value)
+		if got := DebugFromEnv(); got != want {
+			t.Errorf("%s=%q: DebugFromEnv() = %v, want %v", DebugEnv, value, got, want)
+		}
+	}
+}
+
+func TestRunCapturesStderr(t *testing.T) {
+	_, errOut := capture(t)
+	SetLevel(LevelNormal)
+
+	err := Run(Command("sh", "-c", "echo 'Error: No such container: c1' >&2; exit 1"))
+	if err == nil || err.Error() != "exit status 1: Error: No such container: c1" {
+		t.Errorf("Run() error = %v, want stderr appended", err)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Error("Run() should wrap the *exec.ExitError")
+	}
+
+	if err := Run(Command("sh", "-c", "echo ok >&2")); err != nil {
+		t.Errorf("Run() of successful command = %v", err)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("nothing should be logged outside debug mode, got %q", errOut.String())
+	}
+
+	// A caller-provided Stderr is left alone
+	var own bytes.Buffer
+	cmd := Command("sh", "-c", "echo mine >&2; exit 2")
+	cmd.Stderr = &own
+	if err := Run(cmd); err == nil || err.Error() != "exit status 2" || own.String() != "mine\n" {
+		t.Errorf("Run() with own Stderr = %v, stderr %q", err, own.String())
+	}
+}
@@ -17,8 +17,10 @@ package notify
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 )
 
 // DesktopProvider sends macOS/Linux desktop notifications.
@@ -38,6 +40,13 @@ func NewDesktopProvider(iconPath string, hasTerminalNotifier bool) *DesktopProvi
 func (d *DesktopProvider) Name() string { return "desktop" }
 
 func (d *DesktopProvider) Send(_ context.Context, event Event) error {
+	_, err := d.Deliver(event)
+	return err
+}
+
+// Deliver sends the notification like Send and also reports the backend
+// that showed it: terminal-notifier, osascript or notify-send.
+func (d *DesktopProvider) Deliver(event Event) (string, error) {
 	title := event.Title
 	subtitle := event.ShortName
 	message := event.Message
@@ -46,9 +55,9 @@ func (d *DesktopProvider) Send(_ context.Context, event Event) error {
 	case "darwin":
 		return d.sendDarwin(title, subtitle, message)
 	case "linux":
-		return d.sendLinux(title, subtitle, message)
+		return "notify-send", d.sendLinux(title, subtitle, message)
 	default:
-		return fmt.Errorf("desktop notifications not supported on %s", runtime.GOOS)
+		return "", fmt.Errorf("desktop notifications not supported on %s", runtime.GOOS)
 	}
 }
 
@@ -62,7 +71,7 @@ func (d *DesktopProvider) Available() bool {
 
 func (d *DesktopProvider) Close() error { return nil }
 
-func (d *DesktopProvider) sendDarwin(title, subtitle, message string) error {
+func (d *DesktopProvider) sendDarwin(title, subtitle, message string) (string, error) {
 	if d.hasTerminalNotifier {
 		args := []string{
 			"-title", fmt.Sprintf("Maestro - %s", title),
@@ -76,7 +85,7 @@ func (d *DesktopProvider) sendDarwin(title, subtitle, message string) error {
 		}
 		cmd := exec.Command("terminal-notifier", args...)
 		if err := cmd.Run(); err == nil {
-			return nil
+			return "terminal-notifier", nil
 		}
 		// Fall through to osascript
 	}
@@ -89,7 +98,7 @@ func (d *DesktopProvider) sendDarwin(title, subtitle, message string) error {
 			"--",
 			message, title, subtitle,
 		)
-		return cmd.Run()
+		return "osascript", cmd.Run()
 	}
 
 	cmd := exec.Command("osascript",
@@ -99,19 +108,57 @@ func (d *DesktopProvider) sendDarwin(title, subtitle, message string) error {
 		"--",
 		message, title,
 	)
-	return cmd.Run()
+	return "osascript", cmd.Run()
 }
 
 func (d *DesktopProvider) sendLinux(title, subtitle, message string) error {
-	var args []string
-	if d.iconPath != "" {
-		args = append(args, "--icon", d.iconPath)
-	}
 	displayMsg := message
 	if subtitle != "" {
 		displayMsg = fmt.Sprintf("[%s] %s", subtitle, message)
 	}
-	args = append(args, fmt.Sprintf("Maestro - %s", title), displayMsg)
-	cmd := exec.Command("notify-send", args...)
-	return cmd.Run()
+	return NotifySend(d.iconPath, fmt.Sprintf("Maestro - %s", title), displayMsg)
+}
+
+// FallbackIcon is the freedesktop theme icon used on Linux when maestro's
+// own icon is missing or notify-send rejects it.
+const FallbackIcon = "dialog-information"
+
+// LinuxIcon picks the notify-send icon and describes where it came from for
+// the logs. A configured icon (daemon.notifications.icon) is used as is, as
+// a theme icon name or a file path; otherwise the cached PNG, if it exists;
+// otherwise FallbackIcon.
+func LinuxIcon(configured, cachedPath string) (icon, source string) {
+	if configured != "" {
+		if strings.Contains(configured, "/") {
+			return configured, "configured file"
+		}
+		return configured, "configured theme icon"
+	}
+	if cachedPath != "" {
+		if _, err := os.Stat(cachedPath); err == nil {
+			return cachedPath, "bundled PNG"
+		}
+	}
+	return FallbackIcon, "theme icon"
+}
+
+// NotifySend shows a notification with notify-send. Some notification
+// daemons only accept theme icon names or fail on the icon, so if it fails
+// the notification is sent again with FallbackIcon.
+func NotifySend(icon, title, message string) error {
+	run := func(icon string) error {
+		var args []string
+		if icon != "" {
+			args = append(args, "--icon", icon)
+		}
+		args = append(args, title, message)
+		return exec.Command("notify-send", args...).Run()
+	}
+	err := run(icon)
+	if err != nil && icon != FallbackIcon {
+		if fallbackErr := run(FallbackIcon); fallbackErr == nil {
+			return nil
+		}
+	}
+	return err
 }
@@ -0,0 +1,44 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinuxIcon(t *testing.T) {
+	cached := filepath.Join(t.TempDir(), "notification-icon.png")
+	os.WriteFile(cached, []byte("png"), 0644)
+	missing := filepath.Join(t.TempDir(), "missing.png")
+
+	tests := []struct {
+		configured, cached string
+		wantIcon, wantSrc  string
+	}{
+		{"utilities-terminal", cached, "utilities-terminal", "configured theme icon"},
+		{"/usr/share/icons/maestro.svg", cached, "/usr/share/icons/maestro.svg", "configured file"},
+		{"", cached, cached, "bundled PNG"},
+		{"", missing, FallbackIcon, "theme icon"},
+		{"", "", FallbackIcon, "theme icon"},
+	}
+	for _, tt := range tests {
+		icon, src := LinuxIcon(tt.configured, tt.cached)
+		if icon != tt.wantIcon || src != tt.wantSrc {
+			t.Errorf("LinuxIcon(%q, %q) = %q, %q; want %q, %q", tt.configured, tt.cached, icon, src, tt.wantIcon, tt.wantSrc)
+		}
+	}
+}
@@ -70,6 +70,37 @@ func CertificatesDir() string {
 	return filepath.Join(GetConfigDir(), "certificates")
 }
 
+// AppsCacheDir returns the directory where apps downloaded from URLs are cached.
+// Unix/macOS: ~/.maestro/apps
+// Windows: %APPDATA%\maestro\apps
+func AppsCacheDir() string {
+	return filepath.Join(GetConfigDir(), "apps")
+}
+
+// StateDir returns the directory for small state files maestro keeps between
+// runs, such as when each container was last connected to.
+// Unix/macOS: ~/.maestro/state
+// Windows: %APPDATA%\maestro\state
+func StateDir() string {
+	return filepath.Join(GetConfigDir(), "state")
+}
+
+// TemplateCacheDir returns the directory where PR templates downloaded for
+// 'maestro new --pr-template' are cached.
+// Unix/macOS: ~/.maestro/template-cache
+// Windows: %APPDATA%\maestro\template-cache
+func TemplateCacheDir() string {
+	return filepath.Join(GetConfigDir(), "template-cache")
+}
+
+// CacheDir returns the directory for caches maestro can rebuild, such as the
+// container list the TUI shows while it loads the real one.
+// Unix/macOS: ~/.maestro/cache
+// Windows: %APPDATA%\maestro\cache
+func CacheDir() string {
+	return filepath.Join(GetConfigDir(), "cache")
+}
+
 // LegacyConfigFile returns the old config file path for migration detection.
 // Returns empty string on Windows (no legacy path on Windows).
 func LegacyConfigFile() string {
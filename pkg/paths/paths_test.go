@@ -99,6 +99,32 @@ func TestGitHubAuthDir(t *testing.T) {
 	}
 }
 
+func TestAppsCacheDir(t *testing.T) {
+	dir := AppsCacheDir()
+	configDir := GetConfigDir()
+
+	if !strings.HasPrefix(dir, configDir) {
+		t.Errorf("AppsCacheDir() = %q, should be inside %q", dir, configDir)
+	}
+	if !strings.HasSuffix(dir, "apps") {
+		t.Errorf("AppsCacheDir() = %q, should end with 'apps'", dir)
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	dir := CacheDir()
+	if !strings.HasPrefix(dir, GetConfigDir()) || filepath.Base(dir) != "cache" {
+		t.Errorf("CacheDir() = %q, want cache inside %q", dir, GetConfigDir())
+	}
+}
+
+func TestTemplateCacheDir(t *testing.T) {
+	dir := TemplateCacheDir()
+	if !strings.HasPrefix(dir, GetConfigDir()) || filepath.Base(dir) != "template-cache" {
+		t.Errorf("TemplateCacheDir() = %q, want template-cache inside %q", dir, GetConfigDir())
+	}
+}
+
 func TestLegacyPaths(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		// No legacy paths on Windows
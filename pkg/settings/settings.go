@@ -0,0 +1,423 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package settings is the single definition of maestro's config keys: their
+// defaults (registered with viper) and the commented config.yml rendered by
+// 'maestro config init'.
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrConfigExists is returned by WriteDefault when the file already exists.
+var ErrConfigExists = errors.New("config file already exists")
+
+// Setting is one config key.
+type Setting struct {
+	Key     string // Dotted viper key, e.g. "containers.image"
+	Default any    // Registered with viper.SetDefault; nil for optional keys
+	Example string // YAML value shown commented out for optional keys
+	Comment string // Explanation rendered above the key
+}
+
+// Section groups the settings under one top-level key.
+type Section struct {
+	Comment  string
+	Settings []Setting
+}
+
+// Sections returns every supported config key in config.yml order. Defaults
+// that depend on the platform (paths) are computed on each call.
+func Sections() []Section {
+	return []Section{
+		{
+			Comment: "Claude Code",
+			Settings: []Setting{
+				{Key: "claude.config_path", Default: "~/.claude", Comment: "Your host Claude configuration directory (for reference only)"},
+				{Key: "claude.auth_path", Default: paths.AuthDir(), Comment: "Credentials shared by all containers; run 'maestro auth' once to fill it"},
+				{Key: "claude.default_mode", Default: "yolo", Comment: "yolo runs Claude with --dangerously-skip-permissions"},
+			},
+		},
+		{
+			Comment: "Containers",
+			Settings: []Setting{
+				{Key: "containers.prefix", Default: container.DefaultPrefix, Comment: "Prefix for container names, ending in '-', '_' or '.' ('-' is added otherwise)"},
+				{Key: "containers.image", Default: "ghcr.io/uprockcom/maestro:latest", Comment: "Docker image (maestro:latest when building from source)"},
+				{Key: "containers.resources.memory", Default: "4g", Comment: "Memory limit per container"},
+				{Key: "containers.resources.cpus", Default: "2", Comment: "CPU limit per container"},
+				{Key: "containers.security_opts", Example: "[\"seccomp=~/.maestro/seccomp.json\", \"no-new-privileges\"]", Comment: "Docker --security-opt values: seccomp=, apparmor=, label= or no-new-privileges"},
+				{Key: "containers.read_only_rootfs", Default: false, Comment: "Run containers with a read-only root filesystem"},
+				{Key: "containers.ulimits", Example: "[\"nofile=65536:65536\", \"nproc=4096\"]", Comment: "Docker ulimits as <type>=<soft>[:<hard>] (nofile, nproc, stack, core, memlock)"},
+				{Key: "containers.default_return_to_tui", Default: false, Comment: "Pre-check \"Return to TUI\" when creating containers from the TUI"},
+				{Key: "containers.default_model", Default: "opus", Comment: "Claude model for new containers: opus, sonnet or haiku"},
+				{Key: "containers.shell", Default: container.DefaultShell, Comment: "Interactive shell for the tmux shell window: zsh, bash or sh"},
+				{Key: "containers.workspace", Default: container.DefaultWorkspace, Comment: "Project root inside new containers (absolute path)"},
+				{Key: "containers.default_no_firewall", Default: false, Comment: "Create containers without the outbound firewall (unrestricted network)"},
+				{Key: "containers.network_mode", Default: container.NetworkBridge, Comment: "Docker network for new containers: bridge, host (bypasses the firewall) or a network name (created if missing)"},
+				{Key: "containers.volume_driver", Example: "nfs", Comment: "Docker volume driver for the npm, uv and history cache volumes (default local)"},
+				{Key: "containers.volume_opts", Example: "{type: nfs, o: \"addr=10.0.0.1,rw\", device: \":/exports/maestro\"}", Comment: "Driver options for the cache volumes, passed as volume-opt when they are created"},
+				{Key: "containers.shared_volumes", Example: "[\"~/.cargo:/home/node/.cargo\"]", Comment: "host_path:container_path[:options] directories copied once into a named volume mounted in every new container"},
+				{Key: "containers.max_context_tokens", Default: container.DefaultMaxContextTokens, Comment: "Truncate planning prompts estimated above this many tokens (0 disables)"},
+				{Key: "containers.dockerfile", Example: "~/maestro/Dockerfile", Comment: "Dockerfile extending the image (FROM ${BASE_IMAGE}); built locally and rebuilt when it or build_args change"},
+				{Key: "containers.build_args", Example: "{NODE_VERSION: \"22\"}", Comment: "Build args for containers.dockerfile and local builds of docker/"},
+				{Key: "containers.image_pull_policy", Default: "if-not-present", Comment: "When to pull the maestro image: if-not-present, always (before every new container) or never"},
+				{Key: "containers.offline_mode", Default: false, Comment: "Never pull images (image_pull_policy: never); the TUI shows [OFFLINE]"},
+				{Key: "containers.init_commands", Example: "[\"git -C /workspace config pull.rebase false\"]", Comment: "Shell commands run as node in new containers once the project is copied; {{.ContainerName}} and {{.BranchName}} are expanded"},
+				{Key: "containers.post_copy_script", Example: "~/maestro/post-copy.sh", Comment: "Script (host path or inline) run as node in the workspace after the project is copied, before the branch is created"},
+			},
+		},
+		{
+			Comment: "tmux inside containers",
+			Settings: []Setting{
+				{Key: "tmux.default_session", Default: "main", Comment: "Session name for new containers (letters, digits, - and _)"},
+				{Key: "tmux.prefix", Default: "C-b", Comment: "Prefix key, e.g. C-a when nesting tmux locally"},
+			},
+		},
+		{
+			Comment: "Outbound firewall",
+			Settings: []Setting{
+				{Key: "firewall.allowed_domains", Default: []string{
+					"registry.npmjs.org",
+					"api.anthropic.com",
+					"github.com",
+					"pypi.org",
+					"files.pythonhosted.org",
+					"sentry.io",
+					"statsig.anthropic.com",
+					"statsig.com",
+					// AWS Bedrock domains
+					"sts.amazonaws.com",
+					"bedrock.amazonaws.com",
+					"bedrock-runtime.amazonaws.com",
+				}, Comment: "Domains containers may reach; the project's package registries are added automatically"},
+				{Key: "firewall.internal_dns", Default: "", Comment: "DNS server for internal domains (corporate networks/VPN)"},
+				{Key: "firewall.internal_domains", Default: []string{}, Comment: "Domains resolved through internal_dns"},
+			},
+		},
+		{
+			Comment: "Copying into containers",
+			Settings: []Setting{
+				{Key: "sync.additional_folders", Default: []string{}, Comment: "Extra folders to copy: a path, or {source, dest, exclude}"},
+				{Key: "sync.compress", Example: "true", Comment: "Compress copies with gzip (default true)"},
+			},
+		},
+		{
+			Comment: "SSH agent forwarding for git (keys stay on the host)",
+			Settings: []Setting{
+				{Key: "ssh.enabled", Default: false, Comment: "Forward the host SSH agent into containers"},
+				{Key: "ssh.known_hosts_path", Default: "~/.ssh/known_hosts", Comment: "known_hosts file mounted into containers"},
+			},
+		},
+		{
+			Comment: "SSL certificates for corporate HTTPS inspection",
+			Settings: []Setting{
+				{Key: "ssl.certificates_path", Default: paths.CertificatesDir(), Comment: "Directory of .crt/.pem files installed in containers"},
+				{Key: "ssl.mount_host_certs", Default: true, Comment: "Mount the host's /etc/ssl/certs into containers"},
+			},
+		},
+		{
+			Comment: "Android SDK",
+			Settings: []Setting{
+				{Key: "android.sdk_path", Default: "", Comment: "Host SDK mounted into containers (empty disables)"},
+			},
+		},
+		{
+			Comment: "Git identity inside containers",
+			Settings: []Setting{
+				{Key: "git.user_name", Default: "", Comment: "git config user.name"},
+				{Key: "git.user_email", Default: "", Comment: "git config user.email"},
+			},
+		},
+		{
+			Comment: "GitHub CLI integration",
+			Settings: []Setting{
+				{Key: "github.enabled", Default: false, Comment: "Copy gh credentials into containers"},
+				{Key: "github.config_path", Default: paths.GitHubAuthDir(), Comment: "gh configuration directory"},
+				{Key: "github.hostname", Example: "github.example.com", Comment: "GitHub Enterprise hostname"},
+			},
+		},
+		{
+			Comment: "AWS",
+			Settings: []Setting{
+				{Key: "aws.enabled", Default: false, Comment: "Mount AWS credentials into containers"},
+				{Key: "aws.profile", Default: "", Comment: "AWS profile to use"},
+				{Key: "aws.region", Default: "", Comment: "AWS region"},
+			},
+		},
+		{
+			Comment: "AWS Bedrock instead of the Anthropic API",
+			Settings: []Setting{
+				{Key: "bedrock.enabled", Default: false, Comment: "Authenticate Claude through Bedrock (requires aws)"},
+				{Key: "bedrock.model", Default: "", Comment: "Bedrock model ID (empty uses Claude's default)"},
+			},
+		},
+		{
+			Comment: "Browser support (Playwright + headless Chromium)",
+			Settings: []Setting{
+				{Key: "web.enabled", Default: false, Comment: "Use the web image for all new containers (or pass --web)"},
+				{Key: "web.image", Default: "", Comment: "Web image (empty uses the one matching this maestro version)"},
+				{Key: "web.shm_size", Default: "256m", Comment: "Shared memory for Chromium"},
+			},
+		},
+		{
+			Comment: "Background daemon",
+			Settings: []Setting{
+				{Key: "daemon.check_interval", Default: "30s", Comment: "How often containers are checked"},
+				{Key: "daemon.show_nag", Default: true, Comment: "Remind you when the daemon is not running"},
+				{Key: "daemon.update_check", Default: true, Comment: "Check GitHub for new maestro releases"},
+				{Key: "daemon.update_check_interval", Default: "6h", Comment: "How often to check for releases"},
+				{Key: "daemon.max_containers", Default: container.DefaultMaxContainers, Comment: "Refuse 'maestro new' past this many running containers (0 disables)"},
+				{Key: "daemon.ignore_idle_after", Example: "72h", Comment: "No attention notifications for containers whose Claude screen hasn't changed in this long (unset: always notify)"},
+				{Key: "daemon.token_refresh.enabled", Default: true, Comment: "Refresh container auth tokens automatically"},
+				{Key: "daemon.token_refresh.threshold", Default: "6h", Comment: "Refresh when less than this remains"},
+				{Key: "daemon.notifications.enabled", Default: true, Comment: "Send notifications"},
+				{Key: "daemon.notifications.attention_threshold", Default: "5m", Comment: "Notify once a container has waited this long"},
+				{Key: "daemon.notifications.notify_on", Default: []string{"attention_needed", "token_expiring", "tasks_completed", "container_notification"}, Comment: "Events that trigger notifications"},
+				{Key: "daemon.notifications.rate_limit", Default: "30m", Comment: "Minimum time between attention notifications for a container (0 disables)"},
+				{Key: "daemon.notifications.rate_limits", Example: "{token_expiring: 6h, dormant: 24h}", Comment: "Per-event-type rate limits; only attention_needed has one by default"},
+				{Key: "daemon.notifications.icon", Example: "dialog-information", Comment: "Linux notification icon, a theme icon name or file path (default: maestro's icon)"},
+				{Key: "daemon.notifications.quiet_hours.start", Default: "", Comment: "Quiet hours start, 24-hour format (e.g. \"22:00\")"},
+				{Key: "daemon.notifications.quiet_hours.end", Default: "", Comment: "Quiet hours end (e.g. \"08:00\")"},
+				{Key: "daemon.notifications.quiet_hours.allow", Example: "[token_expiring]", Comment: "Event types still notified during quiet hours"},
+				{Key: "daemon.notifications.providers.desktop.enabled", Default: true, Comment: "Desktop notifications"},
+				{Key: "daemon.notifications.providers.desktop.notify_on", Example: "[attention_needed]", Comment: "Per-provider event filter (default: notifications.notify_on)"},
+				{Key: "daemon.notifications.providers.local.enabled", Default: true, Comment: "Questions and notifications answered from the TUI"},
+				{Key: "daemon.notifications.providers.slack.enabled", Default: false, Comment: "Slack notifications and replies"},
+				{Key: "daemon.notifications.providers.slack.app_token", Example: "xapp-...", Comment: "Slack app-level token (Socket Mode)"},
+				{Key: "daemon.notifications.providers.slack.bot_token", Example: "xoxb-...", Comment: "Slack bot token"},
+				{Key: "daemon.notifications.providers.slack.user_id", Example: "U0123456789", Comment: "Slack user to message"},
+				{Key: "daemon.notifications.providers.signal.enabled", Default: false, Comment: "Signal notifications via signal-cli-rest-api"},
+				{Key: "daemon.notifications.providers.signal.container_port", Default: 8080, Comment: "Port of the Signal relay container"},
+				{Key: "daemon.notifications.providers.signal.url", Default: "", Comment: "Signal REST API URL (set by Signal setup)"},
+				{Key: "daemon.notifications.providers.signal.api_key", Default: "", Comment: "Signal REST API key"},
+				{Key: "daemon.notifications.providers.signal.number", Example: "\"+15550000000\"", Comment: "Number the notifications are sent from"},
+				{Key: "daemon.notifications.providers.signal.recipient", Example: "\"+15551111111\"", Comment: "Your number"},
+			},
+		},
+		{
+			Comment: "Custom binaries copied into containers: a host path, an http(s) URL\n(append #sha256:<hex> to verify), or per-architecture url_amd64/url_arm64",
+			Settings: []Setting{
+				{Key: "apps", Default: map[string]string{}, Comment: "name -> source"},
+			},
+		},
+		{
+			Comment: "Named projects for 'maestro new --project'",
+			Settings: []Setting{
+				{Key: "projects", Example: "myapp:\n  path: ~/code/myapp\nfullstack:\n  paths: [~/code/api, ~/code/web]\n  primary: ~/code/api", Comment: "name -> path, or paths with an optional primary"},
+			},
+		},
+		{
+			Comment: "Contact profiles for 'maestro new --contact-profile'",
+			Settings: []Setting{
+				{Key: "contacts", Example: "alice:\n  signal:\n    recipient: \"+15552222222\"", Comment: "name -> notification routing overrides"},
+			},
+		},
+		{
+			Comment: "Text UI",
+			Settings: []Setting{
+				{Key: "tui.ascii_fallback", Default: false, Comment: "Plain ASCII banner, spinners and indicators for terminals without full Unicode"},
+				{Key: "tui.color", Default: "auto", Comment: "auto colors output unless NO_COLOR is set; always or never force it on or off"},
+				{Key: "tui.pin_attention", Default: false, Comment: "List containers waiting on you (idle, waiting or asking a question) first"},
+				{Key: "tui.home_order", Default: "default", Comment: "Container list order: default, recent for the last connected first, or git for the most unpushed commits first (cycle with l)"},
+				{Key: "tui.columns", Example: "[name, status, branch, task, git, uptime, created]", Comment: "Home view columns in order: name, status, branch, task, git, auth, activity, uptime, created"},
+				{Key: "tui.cache_ttl", Default: "24h", Comment: "Show the container list saved on exit at startup if it is newer than this (0 disables)"},
+				{Key: "tui.confirm_quit", Default: true, Comment: "Ask before quitting while containers are running (--no-confirm-quit skips it once)"},
+				{Key: "tui.connect_window", Example: "shell", Comment: "tmux window the TUI connects to: claude, shell or a window number (default: the last active one)"},
+			},
+		},
+		{
+			Comment: "Onboarding wizard",
+			Settings: []Setting{
+				{Key: "wizard.always_run", Default: false, Comment: "Run the wizard on every start"},
+				{Key: "wizard.resume_after_auth", Default: false, Comment: "Resume the wizard after 'maestro auth' (set by the wizard)"},
+			},
+		},
+	}
+}
+
+// ApplyDefaults registers every default with viper.
+func ApplyDefaults() {
+	for _, sec := range Sections() {
+		for _, s := range sec.Settings {
+			if s.Default != nil {
+				viper.SetDefault(s.Key, s.Default)
+			}
+		}
+	}
+}
+
+// Render returns a config.yml containing every key with its default value
+// and a comment. Optional keys without a default are commented out.
+func Render() (string, error) {
+	var b strings.Builder
+	b.WriteString("# Maestro configuration, generated by 'maestro config init'.\n")
+	b.WriteString("# Every supported key is listed with its default value. Commented-out keys\n")
+	b.WriteString("# are optional and unset by default.\n")
+
+	for _, sec := range Sections() {
+		b.WriteString("\n")
+		writeComment(&b, "", sec.Comment)
+
+		var open []string // map keys already written for the current setting's parents
+		for _, s := range sec.Settings {
+			parts := strings.Split(s.Key, ".")
+			parents := parts[:len(parts)-1]
+			n := 0
+			for n < len(open) && n < len(parents) && open[n] == parents[n] {
+				n++
+			}
+			open = open[:n]
+			for _, p := range parents[n:] {
+				fmt.Fprintf(&b, "%s%s:\n", indent(len(open)), p)
+				open = append(open, p)
+			}
+
+			ind := indent(len(parents))
+			name := parts[len(parts)-1]
+			writeComment(&b, ind, s.Comment)
+			if s.Default == nil {
+				writeExample(&b, ind, name, s.Example)
+				continue
+			}
+			if err := writeValue(&b, ind, name, s.Default); err != nil {
+				return "", fmt.Errorf("%s: %w", s.Key, err)
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// WriteDefault writes the rendered config to path, refusing to replace an
+// existing file unless force is set.
+func WriteDefault(path string, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return ErrConfigExists
+	}
+	content, err := Render()
+	if err != nil {
+		return fmt.Errorf("rendering config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+func indent(level int) string {
+	return strings.Repeat("  ", level)
+}
+
+func writeComment(b *strings.Builder, ind, comment string) {
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintf(b, "%s# %s\n", ind, line)
+	}
+}
+
+// writeValue writes "name: value", putting lists on their own lines.
+func writeValue(b *strings.Builder, ind, name string, value any) error {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	if len(lines) == 1 && !strings.HasPrefix(lines[0], "- ") {
+		fmt.Fprintf(b, "%s%s: %s\n", ind, name, lines[0])
+		return nil
+	}
+	fmt.Fprintf(b, "%s%s:\n", ind, name)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s  %s\n", ind, line)
+	}
+	return nil
+}
+
+// writeExample writes an optional key commented out.
+func writeExample(b *strings.Builder, ind, name, example string) {
+	if !strings.Contains(example, "\n") {
+		fmt.Fprintf(b, "%s# %s: %s\n", ind, name, example)
+		return
+	}
+	fmt.Fprintf(b, "%s# %s:\n", ind, name)
+	for _, line := range strings.Split(example, "\n") {
+		fmt.Fprintf(b, "%s#   %s\n", ind, line)
+	}
+}
+
+// Save sets values in the running config and writes them to the global
+// config file. The file is re-read first, so values that didn't come from
+// it, like a project .maestro.yml merged over it, aren't written back.
+func Save(values map[string]any) error {
+	gv, file, err := readGlobal()
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		viper.Set(key, value)
+		gv.Set(key, value)
+	}
+	if err := gv.WriteConfigAs(file); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// GlobalStringSlice returns key from the global config file, or its default
+// when the file doesn't set it. Unlike viper.GetStringSlice it leaves out
+// values merged over the file, so an editor can Save the result back.
+func GlobalStringSlice(key string) ([]string, error) {
+	gv, _, err := readGlobal()
+	if err != nil {
+		return nil, err
+	}
+	for _, sec := range Sections() {
+		for _, s := range sec.Settings {
+			if s.Key == key && s.Default != nil {
+				gv.SetDefault(key, s.Default)
+			}
+		}
+	}
+	return gv.GetStringSlice(key), nil
+}
+
+// readGlobal reads the global config file, if it exists, into a fresh viper
+// instance, returning it with the file's path.
+func readGlobal() (*viper.Viper, string, error) {
+	file := viper.ConfigFileUsed()
+	if file == "" {
+		file = paths.ConfigFile()
+	}
+
+	gv := viper.New()
+	gv.SetConfigFile(file)
+	gv.SetConfigType("yaml")
+	if _, err := os.Stat(file); err == nil {
+		if err := gv.ReadInConfig(); err != nil {
+			return nil, "", fmt.Errorf("failed to read %s: %w", file, err)
+		}
+	}
+	return gv, file, nil
+}
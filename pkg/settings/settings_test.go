@@ -0,0 +1,173 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestRender_RoundTrip reads the rendered file back and checks that every
+// key comes out with its default, so the template cannot drift.
+func TestRender_RoundTrip(t *testing.T) {
+	content, err := Render()
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(content)); err != nil {
+		t.Fatalf("rendered config is not valid YAML: %v\n%s", err, content)
+	}
+
+	seen := map[string]bool{}
+	for _, sec := range Sections() {
+		for _, s := range sec.Settings {
+			if seen[s.Key] {
+				t.Errorf("duplicate key %s", s.Key)
+			}
+			seen[s.Key] = true
+
+			if s.Comment == "" {
+				t.Errorf("%s has no comment", s.Key)
+			}
+			if s.Default == nil {
+				if v.IsSet(s.Key) {
+					t.Errorf("optional key %s should be commented out", s.Key)
+				}
+				if !strings.Contains(content, "# "+s.Key[strings.LastIndex(s.Key, ".")+1:]+":") {
+					t.Errorf("optional key %s missing from rendered config", s.Key)
+				}
+				continue
+			}
+			if !v.IsSet(s.Key) {
+				t.Errorf("%s missing from rendered config", s.Key)
+				continue
+			}
+			got := fmt.Sprint(v.Get(s.Key))
+			if want := fmt.Sprint(s.Default); got != want {
+				t.Errorf("%s = %s, want %s", s.Key, got, want)
+			}
+		}
+	}
+}
+
+func TestWriteDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maestro", "config.yml")
+
+	if err := WriteDefault(path, false); err != nil {
+		t.Fatalf("WriteDefault() error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("custom: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteDefault(path, false); !errors.Is(err, ErrConfigExists) {
+		t.Errorf("expected ErrConfigExists, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "custom: true\n" {
+		t.Error("existing config was modified without force")
+	}
+
+	if err := WriteDefault(path, true); err != nil {
+		t.Fatalf("WriteDefault(force) error: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "containers:") {
+		t.Errorf("forced write did not replace config:\n%s", data)
+	}
+}
+
+// TestSave checks that values merged over the config file in memory aren't
+// written back to it.
+func TestSave(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("containers:\n  image: maestro:latest\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if err := viper.MergeConfigMap(map[string]any{"containers": map[string]any{"shell": "bash"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Save(map[string]any{"daemon.show_nag": false}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if viper.GetBool("daemon.show_nag") || viper.GetString("containers.shell") != "bash" {
+		t.Error("Save() should update the running config and keep merged values")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if v.GetString("containers.image") != "maestro:latest" {
+		t.Errorf("containers.image = %q, want the file's value kept", v.GetString("containers.image"))
+	}
+	if !v.IsSet("daemon.show_nag") || v.GetBool("daemon.show_nag") {
+		t.Error("daemon.show_nag should be written as false")
+	}
+	if v.IsSet("containers.shell") {
+		t.Error("merged containers.shell should not be written to the file")
+	}
+}
+
+func TestGlobalStringSlice(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("firewall:\n  allowed_domains:\n    - github.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	// As a project .maestro.yml would be merged
+	if err := viper.MergeConfigMap(map[string]any{"firewall": map[string]any{"allowed_domains": []string{"github.com", "example.com"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GlobalStringSlice("firewall.allowed_domains")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "github.com" {
+		t.Errorf("GlobalStringSlice() = %v, want the file's [github.com]", got)
+	}
+
+	if err := os.WriteFile(path, []byte("containers:\n  image: maestro:latest\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = GlobalStringSlice("firewall.allowed_domains")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) == 0 || got[0] != "registry.npmjs.org" {
+		t.Errorf("GlobalStringSlice() = %v, want the defaults when the file doesn't set it", got)
+	}
+}
@@ -15,38 +15,177 @@
 package system
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/update"
 )
 
-// IsDockerAvailable checks if Docker is installed and the daemon is running
-func IsDockerAvailable() (bool, string) {
-	// Check if docker command exists
-	_, err := exec.LookPath("docker")
+const (
+	// MinClaudeVersion is the oldest Claude CLI maestro works with on the
+	// host, where it generates branch names with --print and --model
+	MinClaudeVersion = "1.0.0"
+
+	// MinFreeDisk is the space the maestro image needs to be pulled and
+	// unpacked
+	MinFreeDisk = 5_000_000_000
+
+	// checkTimeout bounds each command run by the checks, so a hung Docker
+	// daemon doesn't stall the wizard
+	checkTimeout = 10 * time.Second
+)
+
+// Check is the outcome of one prerequisite check.
+type Check struct {
+	Name   string // What was checked, e.g. "Docker"
+	OK     bool
+	Warn   bool   // Failed, but not badly enough to stop setup
+	Detail string // What was found
+	Fix    string // What to do about a failed check
+}
+
+// Prerequisites checks the Claude CLI, Docker and free disk space.
+func Prerequisites() []Check {
+	docker, rootDir := checkDocker()
+	return []Check{checkClaude(), docker, checkDiskSpace(rootDir)}
+}
+
+// PrerequisitesMet reports whether setup can go ahead: every check passed or
+// only warned.
+func PrerequisitesMet(checks []Check) bool {
+	for _, c := range checks {
+		if !c.OK && !c.Warn {
+			return false
+		}
+	}
+	return true
+}
+
+// runCheck runs a command with checkTimeout, returning its combined output.
+func runCheck(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	return strings.TrimSpace(string(out)), err
+}
+
+// checkClaude checks the Claude CLI is installed and recent enough.
+func checkClaude() Check {
+	c := Check{Name: "Claude CLI"}
+	if _, err := exec.LookPath("claude"); err != nil {
+		c.Detail = "not found in PATH"
+		c.Fix = "Install Claude Code from https://claude.ai/download"
+		return c
+	}
+	out, err := runCheck("claude", "--version")
 	if err != nil {
-		return false, "Docker command not found in PATH"
+		c.Detail = "found but 'claude --version' failed"
+		c.Fix = "Reinstall Claude Code from https://claude.ai/download"
+		return c
 	}
+	return claudeVersionCheck(c, out)
+}
 
-	// Check if docker daemon is running
-	cmd := exec.Command("docker", "ps")
-	if err := cmd.Run(); err != nil {
-		return false, "Docker daemon not running"
+// claudeVersionCheck fills in c from 'claude --version' output, such as
+// "2.0.14 (Claude Code)". An old version is only a warning.
+func claudeVersionCheck(c Check, out string) Check {
+	version := ""
+	if fields := strings.Fields(out); len(fields) > 0 {
+		version = strings.TrimPrefix(fields[0], "v")
+	}
+	switch {
+	case version == "":
+		c.OK = true
+		c.Detail = "installed (unknown version)"
+	case update.CompareSemver(version, MinClaudeVersion) < 0:
+		c.Warn = true
+		c.Detail = fmt.Sprintf("version %s is older than %s", version, MinClaudeVersion)
+		c.Fix = "Update it with 'claude update'"
+	default:
+		c.OK = true
+		c.Detail = "version " + version
 	}
+	return c
+}
 
-	return true, "Docker is available"
+// checkDocker checks the Docker daemon is reachable, not just installed. It
+// also returns Docker's data directory, for the disk space check.
+func checkDocker() (Check, string) {
+	c := Check{Name: "Docker"}
+	if _, err := exec.LookPath("docker"); err != nil {
+		c.Detail = "not found in PATH"
+		c.Fix = "Install Docker from https://docker.com/get-started"
+		return c, ""
+	}
+	out, err := runCheck("docker", "info", "--format", "{{.ServerVersion}}\t{{.OSType}}/{{.Architecture}}\t{{.DockerRootDir}}")
+	fields := strings.Split(out, "\t")
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		c.Detail = fmt.Sprintf("installed but not responding after %s", checkTimeout)
+		c.Fix = "Docker may still be starting. Wait for it to finish, then Re-check"
+		return c, ""
+	case err != nil && strings.Contains(strings.ToLower(out), "permission denied"):
+		c.Detail = "installed but you don't have access to the Docker socket"
+		c.Fix = "Add yourself to the docker group (sudo usermod -aG docker $USER), log in again, then Re-check"
+		return c, ""
+	case err != nil || len(fields) != 3 || fields[0] == "":
+		c.Detail = "installed but not running"
+		c.Fix = dockerStartFix()
+		return c, ""
+	}
+	c.OK = true
+	c.Detail = fmt.Sprintf("server %s (%s)", fields[0], fields[1])
+	return c, fields[2]
 }
 
-// IsClaudeAvailable checks if Claude CLI is installed
-func IsClaudeAvailable() (bool, string) {
-	_, err := exec.LookPath("claude")
-	if err != nil {
-		return false, "Claude CLI not found in PATH"
+// dockerStartFix says how to start Docker on this OS.
+func dockerStartFix() string {
+	if runtime.GOOS == "linux" {
+		return "Start the Docker daemon (sudo systemctl start docker), then Re-check"
 	}
+	return "Start Docker Desktop, wait for it to finish starting, then Re-check"
+}
 
-	// Verify it's actually executable
-	cmd := exec.Command("claude", "--version")
-	if err := cmd.Run(); err != nil {
-		return false, "Found but not executable"
+// checkDiskSpace checks there is room to pull the image. Docker's data
+// directory is only on the host with a native Docker daemon; Docker Desktop
+// keeps its disk image under the home directory.
+func checkDiskSpace(dockerRoot string) Check {
+	c := Check{Name: "Disk space"}
+	dir := dockerRoot
+	if _, err := os.Stat(dir); dir == "" || err != nil {
+		if dir, err = os.UserHomeDir(); err != nil {
+			c.Warn = true
+			c.Detail = "couldn't find the home directory"
+			return c
+		}
+	}
+	free, err := freeDiskSpace(dir)
+	if err != nil {
+		c.Warn = true
+		c.Detail = fmt.Sprintf("couldn't check %s: %v", dir, err)
+		return c
 	}
+	return diskSpaceCheck(c, dir, free)
+}
 
-	return true, "Claude CLI is available"
+// diskSpaceCheck fills in c for free bytes available in dir. Too little space
+// is a warning, since the image may already be pulled.
+func diskSpaceCheck(c Check, dir string, free uint64) Check {
+	c.Detail = fmt.Sprintf("%.1f GB free in %s", float64(free)/1e9, dir)
+	if free < MinFreeDisk {
+		c.Warn = true
+		c.Fix = fmt.Sprintf("The maestro image needs about %d GB. Free some space (e.g. 'docker system prune'), then Re-check", MinFreeDisk/1_000_000_000)
+		return c
+	}
+	c.OK = true
+	return c
 }
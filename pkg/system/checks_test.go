@@ -0,0 +1,55 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import "testing"
+
+func TestClaudeVersionCheck(t *testing.T) {
+	tests := []struct {
+		out      string
+		ok, warn bool
+	}{
+		{"2.0.14 (Claude Code)", true, false},
+		{"v1.0.0", true, false},
+		{"0.2.9 (Claude Code)", false, true},
+		{"", true, false},
+	}
+	for _, tt := range tests {
+		c := claudeVersionCheck(Check{Name: "Claude CLI"}, tt.out)
+		if c.OK != tt.ok || c.Warn != tt.warn {
+			t.Errorf("%q: OK=%v Warn=%v, want %v %v (%s)", tt.out, c.OK, c.Warn, tt.ok, tt.warn, c.Detail)
+		}
+	}
+}
+
+func TestDiskSpaceCheck(t *testing.T) {
+	c := diskSpaceCheck(Check{}, "/var/lib/docker", 12_300_000_000)
+	if !c.OK || c.Detail != "12.3 GB free in /var/lib/docker" {
+		t.Errorf("plenty of space: %+v", c)
+	}
+	c = diskSpaceCheck(Check{}, "/home/me", 1_000_000_000)
+	if c.OK || !c.Warn || c.Fix == "" {
+		t.Errorf("low space should warn with a fix: %+v", c)
+	}
+}
+
+func TestPrerequisitesMet(t *testing.T) {
+	if !PrerequisitesMet([]Check{{OK: true}, {Warn: true}}) {
+		t.Error("warnings should not block setup")
+	}
+	if PrerequisitesMet([]Check{{OK: true}, {Detail: "not running"}}) {
+		t.Error("a failed check should block setup")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectType identifies a project's primary language ecosystem
+type ProjectType int
+
+const (
+	ProjectUnknown ProjectType = iota
+	ProjectNode
+	ProjectGo
+	ProjectPython
+	ProjectRust
+	ProjectRuby
+)
+
+// String returns the display name of the project type
+func (t ProjectType) String() string {
+	switch t {
+	case ProjectNode:
+		return "Node"
+	case ProjectGo:
+		return "Go"
+	case ProjectPython:
+		return "Python"
+	case ProjectRust:
+		return "Rust"
+	case ProjectRuby:
+		return "Ruby"
+	default:
+		return "Unknown"
+	}
+}
+
+// projectDetector maps marker files to a project type and the package
+// registry domains that type needs through the firewall
+type projectDetector struct {
+	Type    ProjectType
+	Markers []string
+	Domains []string
+}
+
+// projectDetectors are checked in priority order; the first match wins
+var projectDetectors = []projectDetector{
+	{ProjectNode, []string{"package.json"}, []string{"registry.npmjs.org", "registry.yarnpkg.com", "nodejs.org"}},
+	{ProjectGo, []string{"go.mod"}, []string{"proxy.golang.org", "sum.golang.org", "go.googlesource.com"}},
+	{ProjectPython, []string{"requirements.txt", "pyproject.toml", "setup.py"}, []string{"pypi.org", "files.pythonhosted.org"}},
+	{ProjectRust, []string{"Cargo.toml"}, []string{"crates.io", "index.crates.io", "static.crates.io", "static.rust-lang.org"}},
+	{ProjectRuby, []string{"Gemfile"}, []string{"rubygems.org", "index.rubygems.org"}},
+}
+
+// DetectProjectType inspects the top level of dir for well-known manifest
+// files and returns the project type with the firewall domains it needs.
+// A directory with no recognized manifest is ProjectUnknown with no domains.
+func DetectProjectType(dir string) (ProjectType, []string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return ProjectUnknown, nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+	if !info.IsDir() {
+		return ProjectUnknown, nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	for _, d := range projectDetectors {
+		for _, marker := range d.Markers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return d.Type, append([]string(nil), d.Domains...), nil
+			}
+		}
+	}
+	return ProjectUnknown, nil, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectProjectType(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		want    ProjectType
+		domains bool
+	}{
+		{"node", []string{"package.json"}, ProjectNode, true},
+		{"go", []string{"go.mod"}, ProjectGo, true},
+		{"python pyproject", []string{"pyproject.toml"}, ProjectPython, true},
+		{"python setup.py", []string{"setup.py"}, ProjectPython, true},
+		{"rust", []string{"Cargo.toml"}, ProjectRust, true},
+		{"ruby", []string{"Gemfile"}, ProjectRuby, true},
+		{"node wins over go", []string{"go.mod", "package.json"}, ProjectNode, true},
+		{"unknown", []string{"README.md"}, ProjectUnknown, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, f), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, domains, err := DetectProjectType(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectProjectType() = %v, want %v", got, tt.want)
+			}
+			if (len(domains) > 0) != tt.domains {
+				t.Errorf("domains = %v, want non-empty %v", domains, tt.domains)
+			}
+		})
+	}
+
+	if _, _, err := DetectProjectType(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
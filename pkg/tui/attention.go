@@ -0,0 +1,119 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/settings"
+	"github.com/uprockcom/maestro/pkg/tui/views"
+)
+
+// homeContainers orders containers for the home view: most recently
+// connected first when tui.home_order is recent, most unmerged work first
+// when it is git, then pinning those that need attention to the top when
+// tui.pin_attention is set.
+func homeContainers(containers []container.Info) []container.Info {
+	switch viper.GetString("tui.home_order") {
+	case "recent":
+		containers = container.SortByLastConnect(containers, container.LastConnects())
+	case "git":
+		containers = container.SortByUnmergedWork(containers)
+	}
+	if viper.GetBool("tui.pin_attention") {
+		return container.PinAttention(containers)
+	}
+	return containers
+}
+
+// toggleHomeOrder cycles tui.home_order through default, recent and git,
+// saves it, and reorders the list keeping the selected container selected.
+func (m *Model) toggleHomeOrder() tea.Cmd {
+	order, label := "recent", "Recently connected first"
+	switch viper.GetString("tui.home_order") {
+	case "recent":
+		order, label = "git", "Most unmerged work first"
+	case "git":
+		order, label = "default", "Default order"
+	}
+	cmd := m.alert.NewAlertCmd("Info", label)
+	if err := settings.Save(map[string]any{"tui.home_order": order}); err != nil {
+		cmd = m.alert.NewAlertCmd("Warning", fmt.Sprintf("%s (not saved: %v)", label, err))
+	}
+	if m.homeView == nil {
+		return cmd
+	}
+
+	var selected string
+	if cursor, containers := m.homeView.GetCursor(), m.homeView.GetContainers(); cursor >= 0 && cursor < len(containers) {
+		selected = containers[cursor].Name
+	}
+	ordered := homeContainers(m.loadedContainers)
+	m.homeView.RefreshContainers(ordered, false)
+	for i, c := range ordered {
+		if c.Name == selected {
+			m.homeView.SetCursor(i)
+			break
+		}
+	}
+	return cmd
+}
+
+// attentionContainers returns the containers waiting on the user, longest
+// waiting first.
+func attentionContainers(containers []container.Info) []container.Info {
+	var waiting []container.Info
+	for _, c := range container.PinAttention(containers) {
+		if !container.NeedsAttention(c) {
+			break
+		}
+		waiting = append(waiting, c)
+	}
+	return waiting
+}
+
+// attentionStateLabel describes why a container needs attention.
+func attentionStateLabel(c container.Info) string {
+	switch c.AgentState {
+	case "question":
+		return "asking a question"
+	case "waiting":
+		return "waiting for input"
+	default:
+		return "idle"
+	}
+}
+
+// createAttentionModal lists the containers waiting on the user; choosing
+// one connects to it.
+func createAttentionModal(waiting []container.Info, now time.Time) *Modal {
+	rows := make([]string, len(waiting))
+	for i, c := range waiting {
+		since := "—"
+		if d := container.WaitingFor(c, now); d > 0 {
+			since = container.FormatDuration(d)
+		}
+		rows[i] = fmt.Sprintf("%-32s %-18s %6s", c.ShortName, attentionStateLabel(c), since)
+	}
+	content := fmt.Sprintf("%d container(s) waiting on you, longest first:", len(waiting))
+	return NewListModal("Needs Attention", content, rows, "Connect", func(i int) tea.Msg {
+		return views.ConnectRequestMsg{ContainerName: waiting[i].Name}
+	})
+}
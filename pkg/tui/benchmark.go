@@ -0,0 +1,90 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// benchmarkEvery is how many renders each timing report covers.
+const benchmarkEvery = 10
+
+// benchmarkModel wraps the TUI model to time its View calls, reporting to
+// out after every benchmarkEvery renders (maestro --benchmark).
+type benchmarkModel struct {
+	tea.Model
+	out   io.Writer
+	stats *renderStats
+}
+
+// renderStats accumulates render timings between reports.
+type renderStats struct {
+	renders int // Renders since startup
+	total   time.Duration
+	max     time.Duration
+}
+
+func newBenchmarkModel(model tea.Model, out io.Writer) benchmarkModel {
+	return benchmarkModel{Model: model, out: out, stats: &renderStats{}}
+}
+
+// Update passes messages to the wrapped model and keeps it wrapped.
+func (b benchmarkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	b.Model, cmd = b.Model.Update(msg)
+	return b, cmd
+}
+
+// View renders the wrapped model and records how long it took.
+func (b benchmarkModel) View() string {
+	start := time.Now()
+	view := b.Model.View()
+	b.stats.record(time.Since(start), b.out)
+	return view
+}
+
+// record adds a render's duration, writing a report line such as
+// "tui_render renders=20 avg_us=812 max_us=2140" and starting over every
+// benchmarkEvery renders.
+func (s *renderStats) record(d time.Duration, out io.Writer) {
+	s.renders++
+	s.total += d
+	s.max = max(s.max, d)
+	if s.renders%benchmarkEvery != 0 {
+		return
+	}
+	fmt.Fprintf(out, "tui_render renders=%d avg_us=%d max_us=%d\n",
+		s.renders, (s.total / benchmarkEvery).Microseconds(), s.max.Microseconds())
+	s.total, s.max = 0, 0
+}
+
+// Screenshot renders one frame of the TUI at the given size, once the
+// container list has loaded, as plain text without ANSI styling (maestro
+// --screenshot).
+func Screenshot(containerPrefix string, width, height int) string {
+	zone.NewGlobal()
+	var model tea.Model = *NewWithCache(containerPrefix, nil)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	if m := model.(Model); !m.wizardMode {
+		model, _ = model.Update(m.loadContainers()())
+	}
+	return ansi.Strip(model.View())
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// errNoPorts means a container has no ports reachable from the host.
+var errNoPorts = errors.New("no ports exposed")
+
+// browserOpenedMsg reports the outcome of opening a container in the browser.
+type browserOpenedMsg struct {
+	url string
+	err error
+}
+
+// openInBrowser looks up the container's host ports in the background and
+// opens the most web-like one in the default browser.
+func openInBrowser(containerName, prefix string) tea.Cmd {
+	return func() tea.Msg {
+		mappings, err := container.HostPorts(containerName, prefix)
+		if err != nil {
+			return browserOpenedMsg{err: err}
+		}
+		port, ok := container.BrowserPort(mappings)
+		if !ok {
+			return browserOpenedMsg{err: errNoPorts}
+		}
+		url := fmt.Sprintf("http://localhost:%d", port.HostPort)
+		return browserOpenedMsg{url: url, err: openURL(url)}
+	}
+}
+
+// openURL opens url in the default browser without waiting for it.
+func openURL(url string) error {
+	opener := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		opener = "open"
+	}
+	if err := exec.Command(opener, url).Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", opener, err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/branchname"
+)
+
+// Field indexes of the create container form, as used by Modal.focusedField.
+const (
+	createFieldTask = iota
+	createFieldBranch
+	createFieldModel
+	createFieldNoConnect
+	createFieldExact
+	createFieldWeb
+)
+
+// maxBranchNameLength is the longest branch name the form accepts:
+// branchname.MaxLength, which 'maestro new' also cuts generated and typed
+// branch names to.
+const maxBranchNameLength = branchname.MaxLength
+
+// createFormInput is what the create container form submits.
+type createFormInput struct {
+	task   string
+	branch string
+	exact  bool
+	web    bool
+}
+
+// validateBranchName checks a branch name against git's ref format rules
+// (see git check-ref-format) and the length cap, returning why it is invalid
+// or "" if it is fine.
+func validateBranchName(name string) string {
+	switch {
+	case len(name) > maxBranchNameLength:
+		return fmt.Sprintf("Branch name is too long (%d/%d characters)", len(name), maxBranchNameLength)
+	case strings.IndexFunc(name, unicode.IsSpace) >= 0:
+		return "Branch name can't contain spaces"
+	case strings.IndexFunc(name, unicode.IsControl) >= 0 || strings.ContainsAny(name, "~^:?*[\\"):
+		return "Branch name can't contain ~ ^ : ? * [ or \\"
+	case strings.Contains(name, ".."):
+		return "Branch name can't contain .."
+	case strings.Contains(name, "@{") || name == "@":
+		return "Branch name can't be @ or contain @{"
+	case strings.HasPrefix(name, "-"):
+		return "Branch name can't start with -"
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") || strings.Contains(name, "//"):
+		return "Branch name can't start or end with / or contain //"
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, ".lock"):
+		return "Branch name can't end with . or .lock"
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") || strings.HasSuffix(part, ".lock") {
+			return "Branch name parts can't start with . or end with .lock"
+		}
+	}
+	return ""
+}
+
+// createFormErrors validates the create container form, returning inline
+// errors keyed by field index.
+func createFormErrors(in createFormInput) map[int]string {
+	errs := make(map[int]string)
+	if strings.TrimSpace(in.task) == "" {
+		errs[createFieldTask] = "Enter a task description"
+	}
+	if branch := strings.TrimSpace(in.branch); branch != "" {
+		if msg := validateBranchName(branch); msg != "" {
+			errs[createFieldBranch] = msg
+		}
+	}
+	return errs
+}
+
+// createFormHints explains how the checkboxes combine with the other fields,
+// keyed by field index.
+func createFormHints(in createFormInput) map[int]string {
+	hints := make(map[int]string)
+	hasBranch := strings.TrimSpace(in.branch) != ""
+	switch {
+	case hasBranch && !in.exact:
+		hints[createFieldExact] = "  With a branch name given, the description is sent as written anyway"
+	case in.exact && !hasBranch:
+		hints[createFieldExact] = "  The branch name is still generated from the description"
+	}
+	if in.web {
+		hints[createFieldWeb] = "  Uses the larger web image (Playwright + Chromium)"
+	}
+	return hints
+}
+
+// branchPreview is the create form's "Preview branch" state. The suggestion
+// is kept per description so previewing again doesn't repeat the AI call.
+type branchPreview struct {
+	task    string // Description the suggestion was generated for
+	branch  string // Suggested branch name
+	pending bool   // A suggestion is being generated
+}
+
+// branchPreviewRequestMsg asks the model to generate a branch name for the
+// create form. It is handled before the modal sees messages, since the form
+// stays open.
+type branchPreviewRequestMsg struct {
+	modal   *Modal
+	preview *branchPreview
+	task    string
+}
+
+// branchPreviewMsg carries a generated branch name back to the create form.
+// offlineErr is set when the AI failed and the offline heuristic was used.
+type branchPreviewMsg struct {
+	modal      *Modal
+	preview    *branchPreview
+	task       string
+	branch     string
+	offlineErr error
+}
+
+// requestBranchPreview fills the branch field from a cached suggestion, or
+// starts generating one. It is the create form's "Preview branch" action.
+func requestBranchPreview(modal *Modal, preview *branchPreview) tea.Msg {
+	task := strings.TrimSpace(modal.textarea.Value())
+	switch {
+	case task == "":
+		modal.setFieldErrors(map[int]string{createFieldTask: "Enter a task description to preview its branch name"})
+		return nil
+	case preview.pending:
+		return nil
+	case task == preview.task:
+		modal.textinputs[0].SetValue(preview.branch)
+		modal.setFieldErrors(nil)
+		return nil
+	}
+	preview.pending = true
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	modal.spinner = &s
+	return branchPreviewRequestMsg{modal: modal, preview: preview, task: task}
+}
+
+// generateBranchPreview generates a branch name in the background, falling
+// back to the offline heuristic if the AI fails.
+func generateBranchPreview(req branchPreviewRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg := branchPreviewMsg{modal: req.modal, preview: req.preview, task: req.task}
+		msg.branch, msg.offlineErr = branchname.Generate(req.task, branchname.DefaultModel)
+		if msg.offlineErr != nil {
+			msg.branch = branchname.Simple(req.task)
+		}
+		return msg
+	}
+}
+
+// applyBranchPreview records a generated branch name and fills it into the
+// form's branch field.
+func applyBranchPreview(msg branchPreviewMsg) {
+	msg.preview.pending = false
+	msg.preview.task = msg.task
+	msg.preview.branch = msg.branch
+	msg.modal.spinner = nil
+	msg.modal.textinputs[0].SetValue(msg.branch)
+	msg.modal.setFieldErrors(nil)
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+func TestValidateBranchName(t *testing.T) {
+	valid := []string{"feat/add-auth", "fix/bug-123", "Feature_X", "release/v1.2"}
+	for _, name := range valid {
+		if msg := validateBranchName(name); msg != "" {
+			t.Errorf("validateBranchName(%q) = %q, want valid", name, msg)
+		}
+	}
+	invalid := []string{
+		"add auth", "feat:auth", "a..b", "feat/", "/feat", "feat//x", "-feat",
+		"feat.lock", "feat/.hidden", "ends.", "x@{1}", "@", "what?",
+		strings.Repeat("a", maxBranchNameLength+1),
+	}
+	for _, name := range invalid {
+		if validateBranchName(name) == "" {
+			t.Errorf("validateBranchName(%q) should be invalid", name)
+		}
+	}
+}
+
+func TestCreateFormErrors(t *testing.T) {
+	errs := createFormErrors(createFormInput{task: "  \n ", branch: "my branch"})
+	if errs[createFieldTask] == "" || errs[createFieldBranch] == "" {
+		t.Errorf("errors = %v, want task and branch errors", errs)
+	}
+	if errs := createFormErrors(createFormInput{task: "fix it"}); len(errs) != 0 {
+		t.Errorf("an empty branch name is generated, got errors %v", errs)
+	}
+}
+
+func TestCreateFormHints(t *testing.T) {
+	if h := createFormHints(createFormInput{branch: "feat/x"}); h[createFieldExact] == "" {
+		t.Error("a branch name without exact should explain the prompt is sent as written")
+	}
+	if h := createFormHints(createFormInput{exact: true}); h[createFieldExact] == "" {
+		t.Error("exact without a branch name should explain the branch is still generated")
+	}
+	if h := createFormHints(createFormInput{branch: "feat/x", exact: true}); len(h) != 0 {
+		t.Errorf("hints = %v, want none", h)
+	}
+}
+
+func TestCreateModal_ValidatesOnSubmit(t *testing.T) {
+	zone.NewGlobal()
+	modal := createContainerCreateModal()
+	modal.textinputs[0].SetValue("has space")
+
+	// Empty task: the modal stays open with the task focused and an error shown
+	next, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if next == nil || cmd != nil {
+		t.Fatal("submitting an invalid form should keep the modal open")
+	}
+	if modal.focusedField != createFieldTask {
+		t.Errorf("focus = %d, want the task field", modal.focusedField)
+	}
+	view := modal.View(120, 60)
+	if !strings.Contains(view, "Enter a task description") || !strings.Contains(view, "can't contain spaces") {
+		t.Errorf("errors should be shown under the fields:\n%s", view)
+	}
+
+	// Fix the task: focus moves to the branch name, still invalid
+	modal.textarea.SetValue("add auth")
+	modal.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if modal.focusedField != createFieldBranch {
+		t.Errorf("focus = %d, want the branch field", modal.focusedField)
+	}
+
+	modal.textinputs[0].SetValue("feat/auth")
+	next, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if next != nil || cmd == nil {
+		t.Fatal("a valid form should submit")
+	}
+	msg, ok := cmd().(createContainerMsg)
+	if !ok || msg.taskDescription != "add auth" || msg.branchName != "feat/auth" {
+		t.Errorf("submitted %#v", cmd())
+	}
+}
+
+func TestCreateModal_CharacterCounter(t *testing.T) {
+	zone.NewGlobal()
+	modal := createContainerCreateModal()
+	modal.textarea.SetValue("hello")
+	if view := modal.View(120, 60); !strings.Contains(view, "5/2000") {
+		t.Errorf("view should count the task's characters:\n%s", view)
+	}
+}
+
+func TestCreateModal_PreviewBranch(t *testing.T) {
+	zone.NewGlobal()
+	modal := createContainerCreateModal()
+
+	// No task: nothing to name the branch after
+	next, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if next == nil || cmd != nil {
+		t.Fatal("previewing without a task should keep the modal open with an error")
+	}
+	if modal.fieldErrors[createFieldTask] == "" {
+		t.Error("previewing without a task should ask for one")
+	}
+
+	modal.textarea.SetValue("add user login")
+	next, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if next == nil || cmd == nil {
+		t.Fatal("previewing should keep the modal open and request a branch name")
+	}
+	req, ok := cmd().(branchPreviewRequestMsg)
+	if !ok || req.task != "add user login" || !req.preview.pending {
+		t.Fatalf("requested %#v", req)
+	}
+	if view := modal.View(120, 60); !strings.Contains(view, "Generating branch name") {
+		t.Errorf("the branch field should show progress:\n%s", view)
+	}
+
+	applyBranchPreview(branchPreviewMsg{modal: modal, preview: req.preview, task: req.task, branch: "feat/user-login"})
+	if got := modal.textinputs[0].Value(); got != "feat/user-login" {
+		t.Errorf("branch field = %q, want the suggestion", got)
+	}
+
+	// Previewing the same task again reuses the suggestion
+	modal.textinputs[0].SetValue("")
+	if _, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyCtrlG}); cmd != nil {
+		t.Error("previewing an unchanged task should not generate again")
+	}
+	if got := modal.textinputs[0].Value(); got != "feat/user-login" {
+		t.Errorf("branch field = %q, want the cached suggestion", got)
+	}
+}
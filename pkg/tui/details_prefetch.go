@@ -0,0 +1,212 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+const (
+	// detailsPrefetchDelay is how long the cursor must rest on a row before
+	// its details are fetched in the background
+	detailsPrefetchDelay = 500 * time.Millisecond
+
+	// detailsCacheTTL is how long prefetched details may be shown while a
+	// fresh copy loads
+	detailsCacheTTL = 30 * time.Second
+
+	// maxDetailsFetches caps the details fetches running at once; each one is
+	// a handful of docker execs
+	maxDetailsFetches = 2
+)
+
+// detailsPrefetch fetches the selected container's details in the background
+// so the details modal ('d') can open from cache. The zero value prefetches
+// nothing.
+type detailsPrefetch struct {
+	target string                   // Container the cursor rests on
+	gen    int                      // Bumped when target changes so older ticks stop
+	cancel context.CancelFunc       // Cancels the fetch in flight, nil if none
+	cache  map[string]cachedDetails // Fetched details, by container name
+	slots  chan struct{}            // Semaphore bounding concurrent fetches
+	modal  *Modal                   // Open details modal, refreshed when its container's fetch lands
+	shown  string                   // Container the open details modal is for
+	fetch  func(ctx context.Context, name, prefix string) (*container.ContainerDetails, error)
+}
+
+// cachedDetails is a fetched ContainerDetails and when it was fetched.
+type cachedDetails struct {
+	details   *container.ContainerDetails
+	fetchedAt time.Time
+}
+
+// detailsPrefetchTickMsg fires once the cursor has rested on a row.
+type detailsPrefetchTickMsg struct {
+	gen int
+}
+
+// detailsFetchedMsg carries a background details fetch.
+type detailsFetchedMsg struct {
+	container string
+	details   *container.ContainerDetails
+	err       error
+	at        time.Time
+}
+
+func newDetailsPrefetch() detailsPrefetch {
+	return detailsPrefetch{
+		cache: make(map[string]cachedDetails),
+		slots: make(chan struct{}, maxDetailsFetches),
+		fetch: container.GetContainerDetailsContext,
+	}
+}
+
+// cached returns the details fetched for name within detailsCacheTTL.
+func (p detailsPrefetch) cached(name string, now time.Time) (*container.ContainerDetails, bool) {
+	entry, ok := p.cache[name]
+	if !ok || now.Sub(entry.fetchedAt) > detailsCacheTTL {
+		return nil, false
+	}
+	return entry.details, true
+}
+
+// scheduleDetailsPrefetch starts the debounce for the selected container when
+// the selection has changed, cancelling the fetch for the previous one.
+func (m *Model) scheduleDetailsPrefetch() tea.Cmd {
+	selected, _ := m.selectedContainer()
+	if m.details.fetch == nil || selected.Name == m.details.target {
+		return nil
+	}
+	m.stopDetailsFetch()
+	m.details.target = selected.Name
+	m.details.gen++
+	if selected.Name == "" {
+		return nil
+	}
+	gen := m.details.gen
+	return tea.Tick(detailsPrefetchDelay, func(time.Time) tea.Msg {
+		return detailsPrefetchTickMsg{gen: gen}
+	})
+}
+
+// stopDetailsFetch cancels the fetch in flight, if any.
+func (m *Model) stopDetailsFetch() {
+	if m.details.cancel != nil {
+		m.details.cancel()
+		m.details.cancel = nil
+	}
+}
+
+// prefetchDetails fetches the target's details unless a fresh copy is cached.
+func (m *Model) prefetchDetails(msg detailsPrefetchTickMsg) tea.Cmd {
+	if msg.gen != m.details.gen || m.details.target == "" {
+		return nil
+	}
+	if _, ok := m.details.cached(m.details.target, time.Now()); ok {
+		return nil
+	}
+	return m.fetchDetails(m.details.target)
+}
+
+// fetchDetails fetches name's details in the background, replacing any fetch
+// in flight. The fetch waits for a free slot and gives up if cancelled first.
+func (m *Model) fetchDetails(name string) tea.Cmd {
+	m.stopDetailsFetch()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.details.cancel = cancel
+	slots, fetch, prefix := m.details.slots, m.details.fetch, m.containerPrefix
+	return func() tea.Msg {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-ctx.Done():
+			return detailsFetchedMsg{container: name, err: ctx.Err()}
+		}
+		details, err := fetch(ctx, name, prefix)
+		return detailsFetchedMsg{container: name, details: details, err: err, at: time.Now()}
+	}
+}
+
+// setFetchedDetails caches a fetch and refreshes the details modal if it is
+// open for that container.
+func (m *Model) setFetchedDetails(msg detailsFetchedMsg) {
+	if msg.err != nil || m.details.cache == nil {
+		return
+	}
+	if m.homeView != nil {
+		// The image check is made when the container list loads
+		for _, c := range m.homeView.GetContainers() {
+			if c.Name == msg.container {
+				msg.details.ImageOutdated = c.ImageOutdated
+			}
+		}
+	}
+	m.details.cache[msg.container] = cachedDetails{details: msg.details, fetchedAt: msg.at}
+	if m.modal != nil && m.modal == m.details.modal && m.details.shown == msg.container {
+		refreshDetailsModal(m.modal, msg.details)
+	}
+}
+
+// showDetails opens the details modal for selected, straight from cache when
+// a recent copy exists, refreshing it in the background.
+func (m *Model) showDetails(selected container.Info) tea.Cmd {
+	var cmd tea.Cmd
+	details, ok := m.details.cached(selected.Name, time.Now())
+	if ok {
+		cmd = m.fetchDetails(selected.Name)
+	} else {
+		var err error
+		details, err = container.GetContainerDetails(selected.Name, m.containerPrefix)
+		if err != nil {
+			m.modal = NewErrorModal("Error", fmt.Sprintf("Failed to fetch container details:\n\n%v", err))
+			return nil
+		}
+		if m.details.cache != nil {
+			m.details.cache[selected.Name] = cachedDetails{details: details, fetchedAt: time.Now()}
+		}
+		details.ImageOutdated = selected.ImageOutdated
+	}
+	m.modal = createContainerDetailsModal(details)
+	m.details.modal = m.modal
+	m.details.shown = selected.Name
+	return cmd
+}
+
+// refreshDetailsModal replaces the details modal's content, keeping its
+// scroll position and whether secrets are revealed.
+func refreshDetailsModal(modal *Modal, details *container.ContainerDetails) {
+	content, hasSecrets := containerDetailsContent(details, false)
+	modal.Content = content
+	modal.revealContent = ""
+	if hasSecrets {
+		modal.revealContent, _ = containerDetailsContent(details, true)
+	}
+	if modal.revealContent == "" {
+		modal.revealed = false
+	}
+	offset := modal.viewport.YOffset
+	if modal.revealed {
+		modal.viewport.SetContent(modal.revealContent)
+	} else {
+		modal.viewport.SetContent(modal.Content)
+	}
+	modal.viewport.SetYOffset(offset)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/views"
+)
+
+// prefetchTestModel returns a model over two containers whose details fetches
+// are counted instead of run.
+func prefetchTestModel(fetches *int) Model {
+	zone.NewGlobal()
+	containers := []container.Info{
+		{Name: "mcl-a-1", ShortName: "a-1", Status: "running"},
+		{Name: "mcl-b-1", ShortName: "b-1", Status: "running"},
+	}
+	m := Model{homeView: views.NewHomeModel(containers, false, false), details: newDetailsPrefetch()}
+	m.homeView.SetSize(120, 20)
+	m.details.fetch = func(ctx context.Context, name, prefix string) (*container.ContainerDetails, error) {
+		*fetches++
+		return &container.ContainerDetails{Name: name, ShortName: strings.TrimPrefix(name, "mcl-"), Status: "running"}, nil
+	}
+	return m
+}
+
+func TestDetailsPrefetch_Debounce(t *testing.T) {
+	var fetches int
+	m := prefetchTestModel(&fetches)
+
+	if m.scheduleDetailsPrefetch() == nil {
+		t.Fatal("expected a prefetch to be scheduled for the first row")
+	}
+	stale := detailsPrefetchTickMsg{gen: m.details.gen}
+
+	// Moving on before the delay replaces the pending prefetch
+	m.homeView.SetCursor(1)
+	if m.scheduleDetailsPrefetch() == nil {
+		t.Fatal("expected a prefetch to be scheduled for the second row")
+	}
+	if m.scheduleDetailsPrefetch() != nil {
+		t.Error("an unchanged selection should not reschedule")
+	}
+	if m.prefetchDetails(stale) != nil {
+		t.Error("a tick for an earlier selection should be ignored")
+	}
+
+	cmd := m.prefetchDetails(detailsPrefetchTickMsg{gen: m.details.gen})
+	if cmd == nil {
+		t.Fatal("expected a fetch once the cursor rested")
+	}
+	msg, ok := cmd().(detailsFetchedMsg)
+	if !ok || msg.container != "mcl-b-1" || fetches != 1 {
+		t.Fatalf("fetched %#v after %d fetches", msg, fetches)
+	}
+	m.setFetchedDetails(msg)
+	if _, ok := m.details.cached("mcl-b-1", time.Now()); !ok {
+		t.Error("expected mcl-b-1 details to be cached")
+	}
+	if m.prefetchDetails(detailsPrefetchTickMsg{gen: m.details.gen}) != nil {
+		t.Error("fresh cached details should not be fetched again")
+	}
+	if _, ok := m.details.cached("mcl-b-1", time.Now().Add(detailsCacheTTL+time.Second)); ok {
+		t.Error("details older than the TTL should not be used")
+	}
+}
+
+func TestDetailsPrefetch_CancelWhileWaiting(t *testing.T) {
+	var fetches int
+	m := prefetchTestModel(&fetches)
+	for range maxDetailsFetches {
+		m.details.slots <- struct{}{}
+	}
+
+	cmd := m.fetchDetails("mcl-a-1")
+	done := make(chan tea.Msg)
+	go func() { done <- cmd() }()
+
+	// Moving the cursor cancels the fetch stuck waiting for a slot
+	m.homeView.SetCursor(1)
+	m.scheduleDetailsPrefetch()
+	select {
+	case msg := <-done:
+		if fetched := msg.(detailsFetchedMsg); fetched.err == nil || fetches != 0 {
+			t.Errorf("expected a cancelled fetch, got %#v after %d fetches", fetched, fetches)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelled fetch did not return")
+	}
+}
+
+func TestDetailsPrefetch_ModalRefreshesInPlace(t *testing.T) {
+	var fetches int
+	m := prefetchTestModel(&fetches)
+	m.details.cache["mcl-a-1"] = cachedDetails{
+		details:   &container.ContainerDetails{Name: "mcl-a-1", ShortName: "a-1", Status: "exited"},
+		fetchedAt: time.Now(),
+	}
+
+	selected, _ := m.selectedContainer()
+	cmd := m.showDetails(selected)
+	if m.modal == nil || !strings.Contains(m.modal.Content, "exited") {
+		t.Fatal("expected the modal to open from cache")
+	}
+	if cmd == nil {
+		t.Fatal("expected a refresh of the cached details")
+	}
+	modal := m.modal
+
+	m.setFetchedDetails(cmd().(detailsFetchedMsg))
+	if m.modal != modal || !strings.Contains(modal.viewport.View(), "running") {
+		t.Errorf("expected the open modal to show the fresh details:\n%s", modal.viewport.View())
+	}
+}
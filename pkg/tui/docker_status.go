@@ -0,0 +1,62 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// dockerDesktopStartedMsg reports the outcome of launching Docker Desktop.
+type dockerDesktopStartedMsg struct {
+	err error
+}
+
+// dockerStatusText describes why Docker can't be used, for the statusbar.
+// The background refresh keeps retrying, so an unreachable daemon says so.
+func dockerStatusText(err error) string {
+	switch {
+	case errors.Is(err, container.ErrDockerNotInstalled):
+		return "Docker not installed"
+	case errors.Is(err, container.ErrDockerPermission):
+		return "Docker permission denied"
+	case errors.Is(err, container.ErrDockerUnreachable):
+		return "Docker unreachable, retrying..."
+	}
+	return "Is Docker running?"
+}
+
+// canOpenDockerDesktop reports whether the TUI can offer to start Docker
+// Desktop: on macOS, when the daemon isn't answering.
+func canOpenDockerDesktop(err error) bool {
+	return runtime.GOOS == "darwin" && errors.Is(err, container.ErrDockerUnreachable)
+}
+
+// openDockerDesktop launches Docker Desktop without waiting for it; the
+// background refresh picks the containers up once the daemon answers.
+func openDockerDesktop() tea.Cmd {
+	return func() tea.Msg {
+		if err := exec.Command("open", "-a", "Docker").Start(); err != nil {
+			return dockerDesktopStartedMsg{err: fmt.Errorf("failed to run open: %w", err)}
+		}
+		return dockerDesktopStartedMsg{}
+	}
+}
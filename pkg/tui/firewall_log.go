@@ -0,0 +1,86 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// firewallLogMsg carries a container's firewall log for the log modal.
+type firewallLogMsg struct {
+	containerName string
+	events        []container.FirewallEvent
+	err           error
+}
+
+// clearFirewallLogMsg asks for a container's firewall log to be cleared.
+type clearFirewallLogMsg struct {
+	containerName string
+}
+
+// loadFirewallLog reads a container's firewall log in the background.
+func loadFirewallLog(containerName string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := container.GetFirewallLog(containerName)
+		return firewallLogMsg{containerName: containerName, events: events, err: err}
+	}
+}
+
+// clearFirewallLog clears a container's firewall log and reloads it.
+func clearFirewallLog(containerName string) tea.Cmd {
+	return func() tea.Msg {
+		if err := container.ClearFirewallLog(containerName); err != nil {
+			return firewallLogMsg{containerName: containerName, err: err}
+		}
+		events, err := container.GetFirewallLog(containerName)
+		return firewallLogMsg{containerName: containerName, events: events, err: err}
+	}
+}
+
+// firewallLogContent renders firewall events one per line, oldest first.
+func firewallLogContent(events []container.FirewallEvent) string {
+	if len(events) == 0 {
+		return "No blocked connections logged.\n\nConnections the firewall rejects show up here. Allow a domain with 'f'.\n\nIf nothing ever shows up, the Docker host may need net.netfilter.nf_log_all_netns=1."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-19s  %-5s  %s\n", "TIME", "PROTO", "DESTINATION")
+	for _, e := range events {
+		dest := e.DestIP
+		if e.DestPort != 0 {
+			dest += ":" + strconv.Itoa(e.DestPort)
+		}
+		fmt.Fprintf(&b, "%-19s  %-5s  %s\n", e.Timestamp.Local().Format("2006-01-02 15:04:05"), e.Protocol, dest)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// createFirewallLogModal shows the connections a container's firewall
+// rejected, with an action to clear the log.
+func createFirewallLogModal(msg firewallLogMsg, shortName string) *Modal {
+	modal := NewScrollableInfoModalWide("Firewall Log: "+shortName, firewallLogContent(msg.events), 20, 70)
+	modal.Actions = []ModalAction{
+		{Label: "Close", Key: "enter", IsPrimary: true},
+		{Label: "Clear Log", Key: "c", OnSelect: func() tea.Msg {
+			return clearFirewallLogMsg{containerName: msg.containerName}
+		}},
+	}
+	return modal
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+)
+
+// fontCheckGlyphs are the Unicode indicators the TUI draws, each of which has
+// an ASCII replacement in tui.ascii_fallback mode.
+var fontCheckGlyphs = []string{"●", "○", "◌", "◷", "↻", "⋯", "◆", "▲", "▼", "▸", "•", "↵", "⬆", "⚠", "✓", "✗", "☐", "☑", "🔔"}
+
+// FontCheckSample renders every kind of Unicode character the TUI uses, for
+// 'maestro --font-check' to ask whether the terminal shows them all.
+func FontCheckSample() string {
+	var b strings.Builder
+	for _, line := range blockBanner {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("Indicators: " + strings.Join(fontCheckGlyphs, " ") + "\n")
+	b.WriteString("Spinners:   " + strings.Join(spinner.Dot.Frames, " ") + "  " + strings.Join(operationSpinnerFrames, " ") + "\n")
+	b.WriteString("Borders:    ╭──╮ ┌──┐ │ ╰──╯ └──┘\n")
+	return b.String()
+}
@@ -19,11 +19,17 @@ import (
 
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/notify"
+	"github.com/uprockcom/maestro/pkg/system"
+	"github.com/uprockcom/maestro/pkg/update"
 )
 
 // tickMsg is sent on each animation tick (750ms for daemon pulsing)
 type tickMsg time.Time
 
+// clockTickMsg is sent at the start of each minute to keep the statusbar
+// clock current
+type clockTickMsg time.Time
+
 // refreshTickMsg is sent on each refresh interval (30s)
 type refreshTickMsg time.Time
 
@@ -48,6 +54,16 @@ type saveWizardConfigMsg struct {
 	cpus       string
 	domains    []string
 	runAuthNow bool // If true, exit TUI to run maestro auth
+
+	startDaemon   bool // Start the daemon now
+	installDaemon bool // Install the daemon as a login service
+}
+
+// daemonSetupResultMsg reports the outcome of one of the wizard's daemon
+// options
+type daemonSetupResultMsg struct {
+	action string // "started" or "installed"
+	err    error
 }
 
 // updateWizardConfigMsg is sent to update wizard config fields and advance
@@ -58,18 +74,20 @@ type updateWizardConfigMsg struct {
 
 // prerequisiteCheckResult contains the results of prerequisite checks
 type prerequisiteCheckResult struct {
-	claudeAvailable bool
-	claudeMessage   string
-	dockerAvailable bool
-	dockerMessage   string
+	checks []system.Check
 }
 
+// recheckPrerequisitesMsg re-runs the prerequisite checks after the user
+// fixed something
+type recheckPrerequisitesMsg struct{}
+
 // containersLoadedMsg is sent when container data is loaded
 type containersLoadedMsg struct {
 	containers       []container.Info
 	err              error
 	dockerResponsive bool
-	daemonConnected  bool // true when data came from daemon cache
+	dockerErr        error // Why Docker isn't responding (nil when it is)
+	daemonConnected  bool  // true when data came from daemon cache
 }
 
 // daemonStatusMsg is sent when daemon status is checked
@@ -78,6 +96,23 @@ type daemonStatusMsg struct {
 	err     error
 }
 
+// enableFirewallMsg asks the CLI to enable the firewall in a container
+// created with --no-firewall
+type enableFirewallMsg struct {
+	ContainerName string
+}
+
+// recreateContainerMsg asks the CLI to recreate a container on the current
+// image
+type recreateContainerMsg struct {
+	ContainerName string
+}
+
+// updateAvailableMsg is sent when the cached update check found a newer release
+type updateAvailableMsg struct {
+	result *update.Result
+}
+
 // errorMsg wraps an error for display
 type errorMsg struct {
 	err error
@@ -181,6 +216,7 @@ type dockerOperationResult struct {
 type TUIResult struct {
 	Action          ActionType
 	ContainerName   string
+	Window          string // For ActionConnect: tmux window to open ("" = configured default)
 	FilePath        string
 	TaskDescription string // For ActionCreate
 	BranchName      string // For ActionCreate
@@ -196,9 +232,11 @@ type ActionType int
 const (
 	ActionNone ActionType = iota
 	ActionQuit
-	ActionConnect    // Connect to a container
-	ActionEditConfig // Edit config file
-	ActionRunCommand // Run a CLI command
-	ActionCreate     // Create a new container
-	ActionRunAuth    // Run maestro auth command
+	ActionConnect        // Connect to a container
+	ActionEditConfig     // Edit config file
+	ActionRunCommand     // Run a CLI command
+	ActionCreate         // Create a new container
+	ActionRunAuth        // Run maestro auth command
+	ActionEnableFirewall // Enable the firewall in a --no-firewall container
+	ActionRecreate       // Recreate a container on the current image
 )
@@ -17,6 +17,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
@@ -45,6 +46,7 @@ const (
 	ModalContainerDetails           // Container info (i key)
 	ModalLoading                    // Loading with progress bar
 	ModalForm                       // Interactive form with multiple fields
+	ModalList                       // Selectable list of rows
 )
 
 // Modal represents a modal dialog
@@ -61,13 +63,26 @@ type Modal struct {
 	viewport       *viewport.Model // Viewport for scrollable content (nil if not used)
 	useViewport    bool            // Whether to use viewport for content
 	DisableEsc     bool            // Disable Esc key for modal dismissal (for wizard)
+	revealContent  string          // Alternate viewport content toggled with "r" (empty = no toggle)
+	revealed       bool            // Whether revealContent is currently shown
 
 	// Form fields (for ModalForm)
-	textarea     *textarea.Model   // Multiline text input
-	textinputs   []textinput.Model // Text input fields
-	checkboxes   []bool            // Checkbox states
-	focusedField int               // Currently focused field index
-	fieldLabels  []string          // Labels for form fields
+	textarea     *textarea.Model       // Multiline text input
+	textinputs   []textinput.Model     // Text input fields
+	checkboxes   []bool                // Checkbox states
+	focusedField int                   // Currently focused field index
+	fieldLabels  []string              // Labels for form fields
+	fieldErrors  map[int]string        // Inline errors under form fields, by field index
+	fieldHints   func() map[int]string // Optional live notes under form fields, by field index
+
+	// Selectable rows (for ModalList)
+	listItems    []string          // Rows, one per line
+	listCursor   int               // Highlighted row
+	onListSelect func(int) tea.Msg // Called with the chosen row
+
+	// Optional checkbox below a ModalConfirm's content
+	confirmCheckLabel string // Checkbox label ("" = no checkbox)
+	confirmChecked    bool   // Checkbox state
 
 	// Mouse click state for textarea scroll tracking
 	lastTextareaLine  int  // Cursor line after last click
@@ -81,6 +96,31 @@ type ModalAction struct {
 	Key       string // Keyboard shortcut (e.g., "y", "n", "enter")
 	IsPrimary bool   // Primary actions highlighted
 	OnSelect  func() tea.Msg
+	Validate  func() bool // Optional: returning false keeps the modal open without calling OnSelect
+	KeepOpen  bool        // Leave the modal open after OnSelect (form helpers like a preview)
+}
+
+// runAction runs action i, returning the modal to show afterwards (nil once
+// the action has run) and a command delivering the action's message.
+func (m *Modal) runAction(i int) (*Modal, tea.Cmd) {
+	action := m.Actions[i]
+	if action.Validate != nil && !action.Validate() {
+		return m, nil
+	}
+	if action.KeepOpen {
+		if action.OnSelect != nil {
+			if msg := action.OnSelect(); msg != nil {
+				return m, func() tea.Msg { return msg }
+			}
+		}
+		return m, nil
+	}
+	if action.OnSelect != nil {
+		if msg := action.OnSelect(); msg != nil {
+			return nil, func() tea.Msg { return msg }
+		}
+	}
+	return nil, nil
 }
 
 // NewInfoModal creates an info modal
@@ -124,6 +164,38 @@ func NewConfirmModal(title, content string, onConfirm, onCancel func() tea.Msg)
 	}
 }
 
+// NewConfirmCheckboxModal creates a confirmation modal with a checkbox below
+// the content, unchecked by default. Space or a click toggles it; onConfirm
+// is called with its state.
+func NewConfirmCheckboxModal(title, content, checkLabel string, onConfirm func(checked bool) tea.Msg) *Modal {
+	m := NewConfirmModal(title, content, nil, nil)
+	m.confirmCheckLabel = checkLabel
+	m.Actions[0].OnSelect = func() tea.Msg { return onConfirm(m.confirmChecked) }
+	return m
+}
+
+// SetCheckLabel changes the label of a confirmation modal's checkbox, which
+// is the last form field once the modal asks for typed confirmation.
+func (m *Modal) SetCheckLabel(label string) {
+	if m.Type == ModalForm && len(m.checkboxes) > 0 {
+		m.fieldLabels[len(m.fieldLabels)-1] = label
+		return
+	}
+	m.confirmCheckLabel = label
+}
+
+// renderConfirmCheckbox renders the checkbox line of a ModalConfirm.
+func (m *Modal) renderConfirmCheckbox(width int, bg lipgloss.Color) string {
+	icon, color := style.Glyph("☐", "[ ]"), style.SilverMist
+	if m.confirmChecked {
+		icon, color = style.Glyph("☑", "[x]"), style.OceanTide
+	}
+	box := lipgloss.NewStyle().Foreground(color).Background(bg).Render(icon)
+	label := lipgloss.NewStyle().Foreground(style.GhostWhite).Background(bg).Render(" " + m.confirmCheckLabel)
+	line := lipgloss.NewStyle().Background(bg).Width(width).Render(box + label)
+	return zone.Mark("modal-confirm-checkbox", line)
+}
+
 // NewLoadingModal creates a loading modal with progress or spinner
 func NewLoadingModal(title, message string, determinate bool) *Modal {
 	m := &Modal{
@@ -152,6 +224,59 @@ func NewLoadingModal(title, message string, determinate bool) *Modal {
 	return m
 }
 
+// NewListModal creates a modal listing items, one per row, above a primary
+// action. Up/down move between rows; enter or a click on a row calls
+// onSelect with its index.
+func NewListModal(title, content string, items []string, actionLabel string, onSelect func(int) tea.Msg) *Modal {
+	m := &Modal{
+		Type:         ModalList,
+		Title:        title,
+		Content:      content,
+		Width:        80,
+		listItems:    items,
+		onListSelect: onSelect,
+	}
+	m.Actions = []ModalAction{
+		{Label: actionLabel, Key: "enter", IsPrimary: true, OnSelect: m.selectListItem},
+		{Label: "Close", Key: "esc"},
+	}
+	return m
+}
+
+// selectListItem reports the highlighted row of a ModalList.
+func (m *Modal) selectListItem() tea.Msg {
+	if m.onListSelect == nil || m.listCursor >= len(m.listItems) {
+		return nil
+	}
+	return m.onListSelect(m.listCursor)
+}
+
+// renderList renders a ModalList's content and rows.
+func (m *Modal) renderList(width int, bg lipgloss.Color) string {
+	var parts []string
+	if m.Content != "" {
+		parts = append(parts, lipgloss.NewStyle().
+			Foreground(style.GhostWhite).
+			Background(bg).
+			Width(width).
+			Render(m.Content), "")
+	}
+	for i, item := range m.listItems {
+		rowStyle := lipgloss.NewStyle().
+			Foreground(style.GhostWhite).
+			Background(bg).
+			Width(width)
+		prefix := "  "
+		if i == m.listCursor {
+			rowStyle = rowStyle.Foreground(style.OceanSurge).Bold(true)
+			prefix = style.Glyph("▸ ", "> ")
+		}
+		row := ansi.Truncate(prefix+item, width, "…")
+		parts = append(parts, zone.Mark(fmt.Sprintf("modal-list-%d", i), rowStyle.Render(row)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
 // NewScrollableInfoModal creates an info modal with scrollable content
 func NewScrollableInfoModal(title, content string, contentHeight int) *Modal {
 	vp := viewport.New(56, contentHeight) // Width slightly less than modal width
@@ -251,8 +376,21 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			return m, nil
 		}
 
+		// Check if a list row was clicked
+		for i := range m.listItems {
+			if zone.Get(fmt.Sprintf("modal-list-%d", i)).InBounds(msg) {
+				m.listCursor = i
+				return nil, m.selectListItem
+			}
+		}
+
+		if m.confirmCheckLabel != "" && zone.Get("modal-confirm-checkbox").InBounds(msg) {
+			m.confirmChecked = !m.confirmChecked
+			return m, nil
+		}
+
 		// Check if a button was clicked
-		for i, action := range m.Actions {
+		for i := range m.Actions {
 			if zone.Get(fmt.Sprintf("modal-action-%d", i)).InBounds(msg) {
 				m.SelectedAction = i
 				if m.Type == ModalForm {
@@ -261,14 +399,7 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 					m.blurFocused()
 					m.focusedField = actionsStartIdx + i
 				}
-				// Execute the action
-				if action.OnSelect != nil {
-					cmd := action.OnSelect()
-					if cmd != nil {
-						return nil, func() tea.Msg { return cmd }
-					}
-				}
-				return nil, nil
+				return m.runAction(i)
 			}
 		}
 
@@ -405,6 +536,13 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			onTextarea := m.focusedField == 0
 			onTextinput := m.focusedField > 0 && m.focusedField < checkboxStartIdx
 
+			// Actions that keep the form open answer to their own key from any field
+			for i, action := range m.Actions {
+				if action.KeepOpen && action.Key == msg.String() {
+					return m.runAction(i)
+				}
+			}
+
 			switch msg.String() {
 			case "tab":
 				// Tab: move to next field (including action buttons)
@@ -425,11 +563,8 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 				return m, nil
 			case "ctrl+s":
 				// Ctrl+S: submit form (works from any field)
-				if len(m.Actions) > 0 && m.Actions[0].OnSelect != nil {
-					cmd := m.Actions[0].OnSelect()
-					if cmd != nil {
-						return nil, func() tea.Msg { return cmd }
-					}
+				if len(m.Actions) > 0 {
+					return m.runAction(0)
 				}
 				return nil, nil
 			case "esc":
@@ -457,21 +592,14 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 				// Enter: execute focused action button OR newline in textarea
 				if onActionButton {
 					actionIdx := m.focusedField - actionsStartIdx
-					if actionIdx < len(m.Actions) && m.Actions[actionIdx].OnSelect != nil {
-						cmd := m.Actions[actionIdx].OnSelect()
-						if cmd != nil {
-							return nil, func() tea.Msg { return cmd }
-						}
+					if actionIdx < len(m.Actions) {
+						return m.runAction(actionIdx)
 					}
 					return nil, nil
 				}
 				// On a single-line textinput, Enter submits the form
-				if onTextinput && len(m.Actions) > 0 && m.Actions[0].OnSelect != nil {
-					cmd := m.Actions[0].OnSelect()
-					if cmd != nil {
-						return nil, func() tea.Msg { return cmd }
-					}
-					return nil, nil
+				if onTextinput && len(m.Actions) > 0 {
+					return m.runAction(0)
 				}
 				// Not on action button or textinput, fall through to textarea
 			case " ":
@@ -504,9 +632,36 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			return m, nil
 		}
 
+		// List rows take the up/down keys
+		if m.Type == ModalList {
+			switch msg.String() {
+			case "up", "k":
+				if m.listCursor > 0 {
+					m.listCursor--
+				}
+				return m, nil
+			case "down", "j":
+				if m.listCursor < len(m.listItems)-1 {
+					m.listCursor++
+				}
+				return m, nil
+			}
+		}
+
+		// Space toggles a confirmation checkbox
+		if m.confirmCheckLabel != "" && msg.String() == " " {
+			m.confirmChecked = !m.confirmChecked
+			return m, nil
+		}
+
 		// If viewport is active, delegate scroll keys to it
 		if m.useViewport && m.viewport != nil {
 			switch msg.String() {
+			case "r":
+				if m.revealContent != "" {
+					m.toggleReveal()
+					return m, nil
+				}
 			case "up", "k":
 				m.viewport.LineUp(1)
 				return m, nil
@@ -539,13 +694,7 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 		case "enter":
 			// Execute selected action
 			if len(m.Actions) > 0 {
-				action := m.Actions[m.SelectedAction]
-				if action.OnSelect != nil {
-					cmd := action.OnSelect()
-					if cmd != nil {
-						return nil, func() tea.Msg { return cmd }
-					}
-				}
+				return m.runAction(m.SelectedAction)
 			}
 			return nil, nil
 
@@ -570,13 +719,7 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			for i, action := range m.Actions {
 				if msg.String() == action.Key {
 					m.SelectedAction = i
-					if action.OnSelect != nil {
-						cmd := action.OnSelect()
-						if cmd != nil {
-							return nil, func() tea.Msg { return cmd }
-						}
-					}
-					return nil, nil
+					return m.runAction(i)
 				}
 			}
 		}
@@ -585,6 +728,68 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 	return m, nil
 }
 
+// toggleReveal swaps the viewport between Content and revealContent,
+// keeping the scroll position
+func (m *Modal) toggleReveal() {
+	m.revealed = !m.revealed
+	offset := m.viewport.YOffset
+	if m.revealed {
+		m.viewport.SetContent(m.revealContent)
+	} else {
+		m.viewport.SetContent(m.Content)
+	}
+	m.viewport.SetYOffset(offset)
+}
+
+// setFieldErrors records inline errors for form fields, keyed by field index
+// (0 = textarea, then text inputs, then checkboxes), and focuses the first
+// invalid field. Reports whether there were no errors.
+func (m *Modal) setFieldErrors(errs map[int]string) bool {
+	m.fieldErrors = errs
+	if len(errs) == 0 {
+		return true
+	}
+	first := -1
+	for idx := range errs {
+		if first < 0 || idx < first {
+			first = idx
+		}
+	}
+	m.blurFocused()
+	m.focusedField = first
+	m.focusField()
+	return false
+}
+
+// renderFieldNote renders the line under a form field: its error if it has
+// one, otherwise its hint, otherwise nothing.
+func (m *Modal) renderFieldNote(idx, width int, bg lipgloss.Color) []string {
+	note, color := m.fieldErrors[idx], style.CrimsonPulse
+	if note == "" && m.fieldHints != nil {
+		note, color = m.fieldHints()[idx], style.SilverMist
+	}
+	if note == "" {
+		return nil
+	}
+	return []string{lipgloss.NewStyle().Foreground(color).Background(bg).Width(width).Render(note)}
+}
+
+// renderTextareaCounter renders the textarea's character count against its
+// limit, right-aligned; it turns amber near the limit.
+func (m *Modal) renderTextareaCounter(width int, bg lipgloss.Color) string {
+	count := utf8.RuneCountInString(m.textarea.Value())
+	color := style.SilverMist
+	if count*10 >= m.textarea.CharLimit*9 {
+		color = style.SunsetGlow
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Background(bg).
+		Width(width).
+		Align(lipgloss.Right).
+		Render(fmt.Sprintf("%d/%d", count, m.textarea.CharLimit))
+}
+
 // blurFocused removes focus from the currently focused form field
 func (m *Modal) blurFocused() {
 	if m.focusedField == 0 && m.textarea != nil {
@@ -616,7 +821,41 @@ func (m *Modal) GetContextHelp() []key.Binding {
 		return nil
 	}
 
-	// Only ModalForm currently supports context-specific help
+	if m.Type == ModalList {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("up", "down"),
+				key.WithHelp("↑/↓", "select"),
+			),
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("↵", strings.ToLower(m.Actions[0].Label)),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "close"),
+			),
+		}
+	}
+
+	if m.confirmCheckLabel != "" {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys(" "),
+				key.WithHelp("space", "toggle"),
+			),
+			key.NewBinding(
+				key.WithKeys("y", "n"),
+				key.WithHelp("y/n", "confirm/cancel"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "cancel"),
+			),
+		}
+	}
+
+	// Otherwise only ModalForm supports context-specific help
 	if m.Type != ModalForm {
 		return nil
 	}
@@ -713,6 +952,21 @@ func (m *Modal) GetContextHelp() []key.Binding {
 		)
 	}
 
+	// Actions that keep the form open work from any field; list them before esc
+	if !onActionButton && len(bindings) > 0 {
+		esc := bindings[len(bindings)-1]
+		bindings = bindings[:len(bindings)-1]
+		for _, action := range m.Actions {
+			if action.KeepOpen {
+				bindings = append(bindings, key.NewBinding(
+					key.WithKeys(action.Key),
+					key.WithHelp(action.Key, strings.ToLower(action.Label)),
+				))
+			}
+		}
+		bindings = append(bindings, esc)
+	}
+
 	return bindings
 }
 
@@ -794,6 +1048,10 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 				Align(lipgloss.Left)
 			// Mark textarea zone for mouse click detection
 			formParts = append(formParts, zone.Mark("modal-textarea", textareaStyle.Render(m.textarea.View())))
+			if m.textarea.CharLimit > 0 {
+				formParts = append(formParts, m.renderTextareaCounter(modalWidth-4, modalBg))
+			}
+			formParts = append(formParts, m.renderFieldNote(0, modalWidth-4, modalBg)...)
 			formParts = append(formParts, "") // Spacing after textarea
 			fieldIdx++
 		}
@@ -822,6 +1080,7 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 					Align(lipgloss.Left)
 				// Mark textinput zone for mouse click detection
 				formParts = append(formParts, zone.Mark(fmt.Sprintf("modal-textinput-%d", i), textinputStyle.Render(ti.View())))
+				formParts = append(formParts, m.renderFieldNote(1+i, modalWidth-4, modalBg)...)
 				formParts = append(formParts, "") // Spacing after text input
 				fieldIdx++
 			}
@@ -864,6 +1123,7 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 					Align(lipgloss.Left)
 				// Mark textinput zone for mouse click detection
 				formParts = append(formParts, zone.Mark(fmt.Sprintf("modal-textinput-%d", i), textinputStyle.Render(ti.View())))
+				formParts = append(formParts, m.renderFieldNote(1+i, modalWidth-4, modalBg)...)
 				formParts = append(formParts, "") // Spacing after text input
 				fieldIdx++
 			}
@@ -904,6 +1164,7 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 				checkboxLine := checkboxPart + labelPart
 				// Mark checkbox zone for mouse click detection
 				formParts = append(formParts, zone.Mark(fmt.Sprintf("modal-checkbox-%d", i), checkboxLineStyle.Render(checkboxLine)))
+				formParts = append(formParts, m.renderFieldNote(1+len(m.textinputs)+i, modalWidth-4, modalBg)...)
 				fieldIdx++
 			}
 		}
@@ -927,22 +1188,24 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 				Background(modalBg).
 				Width(modalWidth - 4).
 				Align(lipgloss.Center).
-				Render("▲ Scroll ▼")
+				Render(style.Glyph("▲", "^") + " Scroll " + style.Glyph("▼", "v"))
 		} else if !m.viewport.AtTop() {
 			scrollIndicators = lipgloss.NewStyle().
 				Foreground(style.SilverMist).
 				Background(modalBg).
 				Width(modalWidth - 4).
 				Align(lipgloss.Center).
-				Render("▲ Scroll up for more")
+				Render(style.Glyph("▲", "^") + " Scroll up for more")
 		} else if !m.viewport.AtBottom() {
 			scrollIndicators = lipgloss.NewStyle().
 				Foreground(style.SilverMist).
 				Background(modalBg).
 				Width(modalWidth - 4).
 				Align(lipgloss.Center).
-				Render("▼ Scroll down for more")
+				Render(style.Glyph("▼", "v") + " Scroll down for more")
 		}
+	} else if m.Type == ModalList {
+		content = m.renderList(modalWidth-4, modalBg)
 	} else {
 		// Normal content rendering
 		contentStyle := lipgloss.NewStyle().
@@ -951,14 +1214,18 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 			Width(modalWidth - 4).
 			Align(lipgloss.Left)
 		content = contentStyle.Render(m.Content)
+		if m.confirmCheckLabel != "" {
+			blank := lipgloss.NewStyle().Background(modalBg).Width(modalWidth - 4).Render("")
+			content = lipgloss.JoinVertical(lipgloss.Left, content, blank, m.renderConfirmCheckbox(modalWidth-4, modalBg))
+		}
 	}
 
 	// Add progress bar or spinner for loading modals
 	var loadingIndicator string
 	if m.progress != nil {
 		loadingIndicator = m.progress.View()
-	} else if m.spinner != nil {
-		// Center the spinner
+	} else if m.spinner != nil && m.Type != ModalForm {
+		// Center the spinner (forms show theirs in a field note)
 		spinnerStyle := lipgloss.NewStyle().
 			Background(modalBg).
 			Width(modalWidth - 4).
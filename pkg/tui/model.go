@@ -16,6 +16,7 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,6 +31,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/mistakenelf/teacup/statusbar"
 	"github.com/spf13/viper"
@@ -39,9 +41,11 @@ import (
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/containerservice"
 	"github.com/uprockcom/maestro/pkg/notify"
+	"github.com/uprockcom/maestro/pkg/settings"
 	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/uprockcom/maestro/pkg/tui/style"
 	"github.com/uprockcom/maestro/pkg/tui/views"
+	"github.com/uprockcom/maestro/pkg/update"
 )
 
 // Model is the main TUI model
@@ -56,18 +60,32 @@ type Model struct {
 	help                help.Model          // Help component for keybindings
 	keys                keyMap              // Keybindings
 	cachedCursorPos     int                 // Cursor position to restore from cache
+	pendingSelection    string              // Container to pre-select once it is in the list (--container)
 	spinner             spinner.Model       // Loading spinner
 	loading             bool                // Whether we're currently loading
 	alert               bubbleup.AlertModel // Toast notifications
 	statusbar           statusbar.Model     // Status bar for persistent state
+	loadedContainers    []container.Info    // Containers as loaded, before homeContainers orders them
 	containerCount      int                 // Number of containers
+	attentionCount      int                 // Number of containers waiting on the user
+	runningCount        int                 // Number of running containers
+	maxContainers       int                 // daemon.max_containers (0 disables the limit warning)
 	operationStatus     string              // Current operation status
 	daemonRunning       bool                // Whether daemon is running
 	dockerResponsive    bool                // Whether Docker daemon is responding
+	confirmQuit         bool                // Ask before quitting while containers run
+	dockerErr           error               // Why Docker isn't responding, from the last load
 	workingDir          string              // Current working directory (relative to ~)
 	animationFrame      int                 // Animation frame counter for pulsing effects
-	operationInProgress bool                // Whether an operation is currently running
+	containerOperations pendingOperations   // Operations running, by container name
 	operationSpinner    spinner.Model       // Spinner for operations in statusbar
+	preview             previewState        // Claude screen preview below the table ('p')
+	details             detailsPrefetch     // Details of the selected container, fetched ahead of 'd'
+	loadInFlight        bool                // Whether a refresh of the container list is running
+	lastLoaded          time.Time           // When the container list was last loaded
+	currentImages       []string            // Images new containers use (RunOptions.CurrentImages)
+	startDaemon         func() error        // Starts the daemon (RunOptions.StartDaemon)
+	installDaemon       func() error        // Installs the login service, nil if unsupported
 
 	// Container service (daemon-backed or direct Docker)
 	containerService containerservice.ContainerService
@@ -98,17 +116,25 @@ type Model struct {
 // keyMap defines keybindings for different contexts
 type keyMap struct {
 	// Normal view keys
-	Up        key.Binding
-	Down      key.Binding
-	Connect   key.Binding
-	Actions   key.Binding
-	Info      key.Binding
-	New       key.Binding
-	Settings  key.Binding
-	Firewall  key.Binding
-	Questions key.Binding
-	Help      key.Binding
-	Quit      key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	Connect      key.Binding
+	ConnectShell key.Binding
+	FirewallLog  key.Binding
+	Actions      key.Binding
+	Info         key.Binding
+	Preview      key.Binding
+	Browser      key.Binding
+	Refresh      key.Binding
+	New          key.Binding
+	Settings     key.Binding
+	Firewall     key.Binding
+	Questions    key.Binding
+	Attention    key.Binding
+	Order        key.Binding
+	Quick        key.Binding
+	Help         key.Binding
+	Quit         key.Binding
 
 	// Modal keys (set dynamically based on modal type)
 	ModalSelect   key.Binding
@@ -123,10 +149,13 @@ type keyMap struct {
 
 // ShortHelp returns keybindings to be shown in the mini help view
 func (k keyMap) ShortHelp() []key.Binding {
-	bindings := []key.Binding{k.Up, k.Connect, k.Actions, k.Info, k.New, k.Settings, k.Firewall}
+	bindings := []key.Binding{k.Up, k.Connect, k.Actions, k.Info, k.Preview, k.Browser, k.Refresh, k.New, k.Settings, k.Firewall}
 	if k.Questions.Enabled() {
 		bindings = append(bindings, k.Questions)
 	}
+	if k.Attention.Enabled() {
+		bindings = append(bindings, k.Attention)
+	}
 	bindings = append(bindings, k.Help, k.Quit)
 	return bindings
 }
@@ -134,7 +163,7 @@ func (k keyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the expanded help view
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Connect, k.Actions, k.Info, k.New, k.Settings, k.Firewall, k.Questions},
+		{k.Up, k.Down, k.Connect, k.ConnectShell, k.Quick, k.Actions, k.Info, k.Preview, k.Browser, k.Refresh, k.Order, k.New, k.Settings, k.Firewall, k.FirewallLog, k.Questions, k.Attention},
 		{k.Help, k.Quit},
 	}
 }
@@ -189,46 +218,56 @@ func isFirstRun() bool {
 	return false
 }
 
+// operationSpinnerFrames animate the statusbar's operation spinner.
+var operationSpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
 // NewWithCache creates a new TUI model with optional cached state
 func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 	// Initialize spinner with Ocean Tide color
 	s := spinner.New()
 	s.Spinner = spinner.Dot
+	if style.IsASCIIMode() {
+		s.Spinner = spinner.Line
+	}
 	s.Style = lipgloss.NewStyle().Foreground(style.OceanTide)
 
 	// Initialize operation spinner for statusbar (braille characters for subtle animation)
 	opSpinner := spinner.New()
 	opSpinner.Spinner = spinner.Spinner{
-		Frames: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		Frames: operationSpinnerFrames,
 		FPS:    time.Second / 10, // 100ms per frame
 	}
+	if style.IsASCIIMode() {
+		opSpinner.Spinner.Frames = spinner.Line.Frames
+	}
 	opSpinner.Style = lipgloss.NewStyle().
 		Foreground(style.OceanTide).
 		Background(style.PurpleHaze) // Match Column 3 background
 
-	// Initialize alert/toast system with Ocean Tide colors
+	// Initialize alert/toast system with Ocean Tide colors, and ASCII
+	// prefixes in ASCII mode
 	alertModel := bubbleup.NewAlertModel(80, false) // width=80, useNerdFont=false
 
 	// Register custom Ocean Tide alert types
 	alertModel.RegisterNewAlertType(bubbleup.AlertDefinition{
 		Key:       "Success",
 		ForeColor: string(style.NeonGreen), // #00FF41
-		Prefix:    "✓",
+		Prefix:    style.Check(),
 	})
 	alertModel.RegisterNewAlertType(bubbleup.AlertDefinition{
 		Key:       "Info",
 		ForeColor: string(style.OceanTide), // #00BCD4
-		Prefix:    "ℹ",
+		Prefix:    style.Glyph("ℹ", "INFO"),
 	})
 	alertModel.RegisterNewAlertType(bubbleup.AlertDefinition{
 		Key:       "Warning",
 		ForeColor: string(style.SunsetGlow), // #FCC451
-		Prefix:    "⚠",
+		Prefix:    style.Warning(),
 	})
 	alertModel.RegisterNewAlertType(bubbleup.AlertDefinition{
 		Key:       "Error",
 		ForeColor: string(style.CrimsonPulse), // #C52735
-		Prefix:    "✗",
+		Prefix:    style.Cross(),
 	})
 
 	// Initialize statusbar with Ocean Tide 4-column layout
@@ -285,18 +324,20 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 	m := &Model{
 		containerPrefix:     containerPrefix,
 		containerService:    svc,
+		details:             newDetailsPrefetch(),
 		help:                help.New(),
 		spinner:             s,
 		loading:             cached == nil || len(cached.Containers) == 0, // Loading if no cache
 		alert:               *alertModel,
 		statusbar:           sb,
 		containerCount:      0,
+		maxContainers:       viper.GetInt("daemon.max_containers"),
 		operationStatus:     "Ready",
 		daemonRunning:       svc.IsDaemonConnected(),
 		dockerResponsive:    true, // Assume true until first check completes
 		workingDir:          relPath,
 		animationFrame:      0,
-		operationInProgress: false,
+		containerOperations: make(pendingOperations),
 		operationSpinner:    opSpinner,
 		daemonClient:        daemonClient,
 		daemonConfigDir:     authDir,
@@ -313,6 +354,14 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 				key.WithKeys("enter"),
 				key.WithHelp("↵", "connect"),
 			),
+			ConnectShell: key.NewBinding(
+				key.WithKeys("S"),
+				key.WithHelp("S", "shell"),
+			),
+			FirewallLog: key.NewBinding(
+				key.WithKeys("L"),
+				key.WithHelp("L", "firewall log"),
+			),
 			Actions: key.NewBinding(
 				key.WithKeys("a"),
 				key.WithHelp("a", "actions"),
@@ -321,30 +370,55 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 				key.WithKeys("d"),
 				key.WithHelp("d", "details"),
 			),
+			Preview: key.NewBinding(
+				key.WithKeys("p"),
+				key.WithHelp("p", "preview"),
+			),
+			Browser: key.NewBinding(
+				key.WithKeys("o"),
+				key.WithHelp("o", "open"),
+			),
+			Refresh: key.NewBinding(
+				key.WithKeys("r", "f5"),
+				key.WithHelp("r/F5", "refresh"),
+			),
 			New: key.NewBinding(
 				key.WithKeys("n"),
 				key.WithHelp("n", "new"),
 			),
 			Settings: key.NewBinding(
-				key.WithKeys("s"),
-				key.WithHelp("s", "settings"),
+				key.WithKeys("s", "f2"),
+				key.WithHelp("s/F2", "settings"),
 			),
 			Firewall: key.NewBinding(
-				key.WithKeys("f"),
-				key.WithHelp("f", "firewall"),
+				key.WithKeys("f", "f3"),
+				key.WithHelp("f/F3", "firewall"),
 			),
 			Questions: key.NewBinding(
 				key.WithKeys("i"),
 				key.WithHelp("i", "questions"),
 				key.WithDisabled(),
 			),
+			Attention: key.NewBinding(
+				key.WithKeys("!"),
+				key.WithHelp("!", "attention"),
+				key.WithDisabled(),
+			),
+			Order: key.NewBinding(
+				key.WithKeys("l"),
+				key.WithHelp("l", "order"),
+			),
+			Quick: key.NewBinding(
+				key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+				key.WithHelp("1-9", "connect to row"),
+			),
 			Help: key.NewBinding(
-				key.WithKeys("?"),
-				key.WithHelp("?", "help"),
+				key.WithKeys("?", "f1"),
+				key.WithHelp("?/F1", "help"),
 			),
 			Quit: key.NewBinding(
-				key.WithKeys("q", "ctrl+c"),
-				key.WithHelp("q", "quit"),
+				key.WithKeys("q", "ctrl+c", "f10"),
+				key.WithHelp("q/F10", "quit"),
 			),
 		},
 	}
@@ -381,7 +455,8 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 			m.wizardCPUs = "2" // Default from viper defaults
 		}
 
-		m.wizardDomains = viper.GetStringSlice("firewall.allowed_domains")
+		// The global list only: the wizard saves it back to the global file
+		m.wizardDomains, _ = settings.GlobalStringSlice("firewall.allowed_domains")
 		if len(m.wizardDomains) == 0 {
 			// Use default domains from viper defaults
 			m.wizardDomains = []string{
@@ -400,17 +475,59 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 	} else {
 		// Normal mode: If we have cached state, initialize with it for instant render
 		if cached != nil && len(cached.Containers) > 0 {
-			m.homeView = views.NewHomeModel(cached.Containers, false, viper.GetBool("bedrock.enabled"))
+			m.loadedContainers = cached.Containers
+			m.homeView = views.NewHomeModel(homeContainers(cached.Containers), false, viper.GetBool("bedrock.enabled"))
 			m.ready = true // Skip "Loading..."
 			m.cachedCursorPos = cached.CursorPos
+			m.lastLoaded = cached.LoadedAt // The statusbar shows how old the list is
 		} else {
 			m.cachedCursorPos = -1 // No cached cursor
 		}
+		if cached != nil {
+			m.pendingSelection = cached.SelectedContainerName
+		}
 	}
 
 	return m
 }
 
+// connect exits the TUI to attach to a container on the given tmux window.
+func (m Model) connect(containerName, window string) (tea.Model, tea.Cmd) {
+	m.result = &TUIResult{
+		Action:        ActionConnect,
+		ContainerName: containerName,
+		Window:        window,
+	}
+	return m, tea.Quit
+}
+
+// findContainerIndex returns the index of the container matching name, which
+// may be a full container name or a short name without the prefix, or -1.
+func findContainerIndex(containers []container.Info, name, prefix string) int {
+	short := strings.TrimPrefix(name, prefix)
+	for i, c := range containers {
+		if c.Name == name || c.Name == prefix+short || c.ShortName == short {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyPendingSelection moves the cursor to the --container selection if it
+// is in the list, reporting whether it was found.
+func (m *Model) applyPendingSelection() bool {
+	if m.pendingSelection == "" || m.homeView == nil {
+		return false
+	}
+	idx := findContainerIndex(m.homeView.GetContainers(), m.pendingSelection, m.containerPrefix)
+	if idx < 0 {
+		return false
+	}
+	m.homeView.SetCursor(idx)
+	m.pendingSelection = ""
+	return true
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	// Wizard mode: just initialize alert
@@ -437,6 +554,11 @@ func (m Model) Init() tea.Cmd {
 	// Start background refresh ticker (30s)
 	cmds = append(cmds, refreshTick())
 
+	// Keep the statusbar clock on the minute
+	cmds = append(cmds, clockTick())
+
+	cmds = append(cmds, checkUpdateToast())
+
 	return tea.Batch(cmds...)
 }
 
@@ -454,9 +576,18 @@ func animationTick() tea.Cmd {
 	})
 }
 
-// refreshTick creates a command that sends refresh tick messages every 30 seconds
+// clockTick creates a command that fires at the start of the next minute, so
+// the statusbar clock changes when the wall clock does
+func clockTick() tea.Cmd {
+	now := time.Now()
+	return tea.Tick(now.Truncate(time.Minute).Add(time.Minute).Sub(now), func(t time.Time) tea.Msg {
+		return clockTickMsg(t)
+	})
+}
+
+// refreshTick creates a command that sends refresh tick messages every refreshInterval
 func refreshTick() tea.Cmd {
-	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
 		return refreshTickMsg(t)
 	})
 }
@@ -468,6 +599,29 @@ func daemonReconnectTick() tea.Cmd {
 	})
 }
 
+// updateToastDelay keeps the update toast from replacing the initial
+// "Loaded N containers" toast.
+const updateToastDelay = 3 * time.Second
+
+// updateToastShown limits the update toast to once per process, since the TUI
+// is rebuilt each time the user returns from a container.
+var updateToastShown bool
+
+// checkUpdateToast reads the update check cache (kept fresh by the daemon or
+// 'maestro version --check') and reports a newer release, if any.
+func checkUpdateToast() tea.Cmd {
+	if updateToastShown || !viper.GetBool("daemon.update_check") {
+		return nil
+	}
+	return tea.Tick(updateToastDelay, func(t time.Time) tea.Msg {
+		result := update.NewChecker(paths.GetConfigDir(), 0, nil).LoadCachedResult()
+		if result == nil || !result.UpdateAvail {
+			return nil
+		}
+		return updateAvailableMsg{result: result}
+	})
+}
+
 // GetState exports the current state for caching
 func (m Model) GetState() *CachedState {
 	if m.homeView == nil {
@@ -477,6 +631,7 @@ func (m Model) GetState() *CachedState {
 	return &CachedState{
 		Containers: m.homeView.GetContainers(),
 		CursorPos:  m.homeView.GetCursor(),
+		LoadedAt:   m.lastLoaded,
 	}
 }
 
@@ -490,19 +645,22 @@ func (m Model) loadContainers() tea.Cmd {
 				// Daemon-backed service failed — daemon may have gone down.
 				// Report as disconnected so the TUI can start reconnection.
 				// Also check Docker since the daemon failure may not mean Docker is healthy.
+				dockerErr := container.CheckDocker()
 				return containersLoadedMsg{
 					containers:       []container.Info{},
 					err:              nil,
-					dockerResponsive: container.IsDockerResponsive(),
+					dockerResponsive: dockerErr == nil,
+					dockerErr:        dockerErr,
 					daemonConnected:  false,
 				}
 			}
 			// Direct Docker fallback: check if Docker is responsive
-			dockerResponsive := container.IsDockerResponsive()
+			dockerErr := container.CheckDocker()
 			return containersLoadedMsg{
 				containers:       []container.Info{},
-				err:              nil,
-				dockerResponsive: dockerResponsive,
+				err:              err,
+				dockerResponsive: dockerErr == nil,
+				dockerErr:        dockerErr,
 				daemonConnected:  false,
 			}
 		}
@@ -515,8 +673,20 @@ func (m Model) loadContainers() tea.Cmd {
 	}
 }
 
-// Update handles messages and updates state
+// Update handles messages and updates state, then rebuilds the statusbar from
+// the resulting state so View only has to render it
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok {
+		nm.updateStatusBar()
+		nm.statusbar.SetSize(nm.width)
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+// update applies a message to the model; see Update
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Always update alert model for lifecycle management (even when modal is active)
 	outAlert, alertCmd := m.alert.Update(msg)
 	m.alert = outAlert.(bubbleup.AlertModel)
@@ -529,6 +699,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Schedule next animation tick and continue processing
 		return m, tea.Batch(animationTick(), alertCmd)
 
+	case clockTickMsg:
+		// Nothing to change; Update redraws the statusbar clock
+		return m, tea.Batch(clockTick(), alertCmd)
+
 	case wizardAnimationTickMsg:
 		// Wizard opening animation tick (80ms per column)
 		if m.wizardMode && !m.animationComplete {
@@ -545,14 +719,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, alertCmd
 
+	case cacheVolumesSizeMsg:
+		// Arrives while the delete confirmation is open, so handle it before
+		// the modal takes the message; label only the modal it was fetched for
+		sizeMsg := msg.(cacheVolumesSizeMsg)
+		if sizeMsg.err == nil && m.modal != nil && m.modal == sizeMsg.modal {
+			m.modal.SetCheckLabel(cacheVolumesLabel(sizeMsg.size))
+		}
+		return m, alertCmd
+
+	case unsavedWorkMsg:
+		// Also arrives while the delete confirmation is open, which can't be
+		// confirmed until it does. Work the delete would lose, or a state
+		// that couldn't be read, turns it into a typed confirmation.
+		workMsg := msg.(unsavedWorkMsg)
+		if m.modal == nil || m.modal != workMsg.modal {
+			return m, alertCmd
+		}
+		if workMsg.err != nil || hasUnsavedWork(workMsg.state) {
+			shortName := container.GetShortName(workMsg.containerName, m.containerPrefix)
+			requireTypedDelete(m.modal, workMsg.containerName, shortName, workMsg.state, workMsg.canPull, workMsg.err)
+			return m, tea.Batch(alertCmd, textinput.Blink)
+		}
+		m.modal.Content = deleteConfirmContent(workMsg.containerName)
+		m.modal.Actions[0].Validate = nil
+		return m, alertCmd
+
+	case branchPreviewRequestMsg:
+		// The create form stays open while its branch name is generated
+		req := msg.(branchPreviewRequestMsg)
+		return m, tea.Batch(generateBranchPreview(req), req.modal.spinner.Tick, alertCmd)
+
+	case branchPreviewMsg:
+		previewMsg := msg.(branchPreviewMsg)
+		if m.modal == nil || m.modal != previewMsg.modal {
+			return m, alertCmd
+		}
+		applyBranchPreview(previewMsg)
+		if previewMsg.offlineErr != nil {
+			return m, tea.Batch(alertCmd, m.alert.NewAlertCmd("Info", "AI branch naming failed, so the offline heuristic was used"))
+		}
+		return m, alertCmd
+
 	case refreshTickMsg:
 		// Background refresh tick (30s)
 		// Skip refresh if modal is active or operation in progress
 		cmds := []tea.Cmd{refreshTick(), alertCmd}
-		if m.modal == nil && !m.operationInProgress {
+		if m.modal == nil && !m.operationInProgress() {
 			// Set syncing status and reload containers in background
-			m.operationStatus = "Syncing..."
-			cmds = append(cmds, m.loadContainers())
+			cmds = append(cmds, m.refresh())
 		}
 		// Always poll for pending questions (even with modal open)
 		cmds = append(cmds, m.fetchPendingQuestions())
@@ -595,7 +810,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.daemonDisconnected = false
 		m.reconnectActive = false
 		m.operationStatus = "Syncing..."
-		m.updateStatusBar()
 
 		// Reload containers through daemon and fetch questions
 		cmds = append(cmds, m.loadContainers(), m.fetchPendingQuestions())
@@ -607,14 +821,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// If config doesn't exist, create default config so app can function
 		configPath := paths.ConfigFile()
 		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			// No config exists - create minimal default config
-			defaultConfig := saveWizardConfigMsg{
-				memory:     m.wizardMemory,
-				cpus:       m.wizardCPUs,
-				domains:    m.wizardDomains,
-				runAuthNow: false,
-			}
-			if err := m.saveWizardConfig(defaultConfig); err != nil {
+			// No config exists - write the commented default config
+			if err := settings.WriteDefault(configPath, false); err != nil {
 				// Show error but continue anyway
 				m.modal = NewErrorModal("Warning", "Could not create default config: "+err.Error())
 				return m, alertCmd
@@ -664,70 +872,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case prerequisiteCheckResult:
 		// Update prerequisite modal with check results
-		result := msg.(prerequisiteCheckResult)
-
-		// Use plain text indicators without colors
-		// TODO: Find way to add colors without background conflicts (see backlog)
-		claudeStatus := "✗"
-		if result.claudeAvailable {
-			claudeStatus = "✓"
-		}
-		dockerStatus := "✗"
-		if result.dockerAvailable {
-			dockerStatus = "✓"
-		}
-
-		content := fmt.Sprintf(`Prerequisite Check Complete
-
-• Claude CLI: %s %s
-• Docker: %s %s
-
-`, claudeStatus, result.claudeMessage, dockerStatus, result.dockerMessage)
-
-		// Add help text based on what's missing
-		allPassed := result.claudeAvailable && result.dockerAvailable
-		if !allPassed {
-			content += "Please install missing prerequisites before continuing:\n\n"
-			if !result.claudeAvailable {
-				content += "• Install Claude CLI from https://claude.ai/download\n"
-			}
-			if !result.dockerAvailable {
-				content += "• Install Docker from https://docker.com/get-started\n"
-			}
-			content += "\nStep 1 of 6"
-		} else {
-			content += "All prerequisites are installed! You're ready to continue.\n\nStep 1 of 6"
-		}
-
-		// Create updated modal with results
-		m.modal = &Modal{
-			Type:       ModalInfo,
-			Title:      "System Requirements",
-			Content:    content,
-			Width:      70,
-			DisableEsc: true,
-			Actions:    []ModalAction{},
-		}
-
-		// Add appropriate actions based on results
-		if allPassed {
-			m.modal.Actions = []ModalAction{
-				{Label: "Continue", Key: "enter", IsPrimary: true},
-			}
-			m.modal.Actions[0].OnSelect = func() tea.Msg {
-				return wizardNextStepMsg{}
-			}
-		} else {
-			m.modal.Actions = []ModalAction{
-				{Label: "Exit", Key: "enter", IsPrimary: false},
-			}
-			m.modal.Actions[0].OnSelect = func() tea.Msg {
-				return exitWizardMsg{}
-			}
-		}
-
+		m.modal = prerequisiteResultModal(msg.(prerequisiteCheckResult).checks)
 		return m, alertCmd
 
+	case recheckPrerequisitesMsg:
+		m.modal = createPrerequisiteCheckModal()
+		return m, tea.Batch(alertCmd, checkPrerequisites())
+
 	case saveWizardConfigMsg:
 		// Save wizard configuration to file and exit wizard
 		configMsg := msg.(saveWizardConfigMsg)
@@ -738,10 +889,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, alertCmd
 		}
 
+		daemonCmds := m.daemonSetupCmds(configMsg)
+
 		// If runAuthNow is set, exit TUI to run auth command
 		if configMsg.runAuthNow {
 			m.result = &TUIResult{Action: ActionRunAuth}
-			return m, tea.Quit
+			return m, tea.Sequence(append(daemonCmds, tea.Quit)...)
 		}
 
 		// Otherwise, exit wizard and start normal operation
@@ -756,13 +909,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Show success toast
 		toastCmd := m.alert.NewAlertCmd("Success", "Configuration saved!")
-		return m, tea.Batch(append(cmds, toastCmd)...)
+		cmds = append(cmds, toastCmd)
+		return m, tea.Batch(append(cmds, daemonCmds...)...)
 	}
 
-	// Check for 'q' to quit even when modal is active (only in wizard mode)
+	// Check for 'q' (or F10) to quit even when modal is active (only in wizard mode)
 	if m.wizardMode {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			if keyMsg.String() == "q" || keyMsg.String() == "ctrl+c" {
+			if key.Matches(keyMsg, m.keys.Quit) {
 				m.result = &TUIResult{Action: ActionQuit}
 				return m, tea.Quit
 			}
@@ -807,18 +961,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Pass size to home view if it exists
-		// Subtract 9 lines: title banner (6) + help (1) + blank line (1) + statusbar (1)
 		if m.homeView != nil {
-			m.homeView.SetSize(msg.Width, msg.Height-9)
+			m.homeView.SetSize(msg.Width, m.homeHeight())
 
 			// Restore cursor position from cache after sizing
 			if m.cachedCursorPos >= 0 {
 				m.homeView.SetCursor(m.cachedCursorPos)
 				m.cachedCursorPos = -1 // Clear after restoring
 			}
+			// A --container selection takes precedence over the cached cursor
+			m.applyPendingSelection()
 		}
 		return m, wizardCheckCmd
 
+	case previewTickMsg:
+		if !m.preview.enabled || msg.gen != m.preview.gen {
+			return m, nil
+		}
+		return m, tea.Batch(m.refreshPreview(), previewTick(msg.gen))
+
+	case previewCapturedMsg:
+		m.setPreviewCapture(msg)
+		return m, nil
+
+	case detailsPrefetchTickMsg:
+		return m, m.prefetchDetails(msg)
+
+	case detailsFetchedMsg:
+		m.setFetchedDetails(msg)
+		return m, nil
+
+	case daemonSetupResultMsg:
+		if msg.err != nil {
+			verb := map[string]string{"started": "start", "installed": "install"}[msg.action]
+			return m, m.alert.NewAlertCmd("Error", fmt.Sprintf("Failed to %s daemon: %v", verb, msg.err))
+		}
+		if msg.action == "started" {
+			return m, m.alert.NewAlertCmd("Success", "Daemon started")
+		}
+		return m, m.alert.NewAlertCmd("Success", "Daemon installed as a login service")
+
+	case browserOpenedMsg:
+		switch {
+		case errors.Is(msg.err, errNoPorts):
+			return m, m.alert.NewAlertCmd("Info", "No ports exposed. Use maestro expose first.")
+		case msg.err != nil:
+			return m, m.alert.NewAlertCmd("Error", "Failed to open browser: "+msg.err.Error())
+		}
+		return m, m.alert.NewAlertCmd("Success", "Opened "+msg.url)
+
+	case dockerDesktopStartedMsg:
+		if msg.err != nil {
+			return m, m.alert.NewAlertCmd("Error", "Failed to start Docker Desktop: "+msg.err.Error())
+		}
+		return m, m.alert.NewAlertCmd("Success", "Docker Desktop is starting, the list reloads once it answers")
+
 	case spinner.TickMsg:
 		// Update loading spinner animation if loading
 		var cmds []tea.Cmd
@@ -827,49 +1024,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.spinner, cmd = m.spinner.Update(msg)
 			cmds = append(cmds, cmd)
 		}
-		// Also update operation spinner if operation in progress
-		if m.operationInProgress {
+		// Also update operation spinner, and the rows showing it, while
+		// operations are running
+		if m.operationInProgress() {
 			var cmd tea.Cmd
 			m.operationSpinner, cmd = m.operationSpinner.Update(msg)
+			m.syncPendingRows()
 			cmds = append(cmds, cmd)
 		}
 		return m, tea.Batch(cmds...)
 
 	case containersLoadedMsg:
-		// Save currently selected container name for cursor preservation
-		var selectedContainerName string
-		if m.homeView != nil && len(m.homeView.GetContainers()) > 0 {
-			cursor := m.homeView.GetCursor()
-			containers := m.homeView.GetContainers()
-			if cursor >= 0 && cursor < len(containers) {
-				selectedContainerName = containers[cursor].Name
+		// A failed refresh keeps the containers already listed, rather than
+		// emptying the list; the statusbar shows Docker's state and, with
+		// lastLoaded left alone, how old the list is
+		keepList := (msg.err != nil || !msg.dockerResponsive) && m.homeView != nil && len(m.loadedContainers) > 0
+		var selectionCmd tea.Cmd
+		if !keepList {
+			container.MarkOutdatedImages(msg.containers, m.currentImages)
+			m.loadedContainers = msg.containers
+			msg.containers = homeContainers(msg.containers)
+
+			// Save currently selected container name for cursor preservation
+			var selectedContainerName string
+			if m.homeView != nil && len(m.homeView.GetContainers()) > 0 {
+				cursor := m.homeView.GetCursor()
+				containers := m.homeView.GetContainers()
+				if cursor >= 0 && cursor < len(containers) {
+					selectedContainerName = containers[cursor].Name
+				}
 			}
-		}
 
-		// Initialize home view with loaded data
-		m.homeView = views.NewHomeModel(msg.containers, false, viper.GetBool("bedrock.enabled"))
-		if m.width > 0 && m.height > 0 {
-			// Subtract 9 lines: title banner (6) + help (1) + blank line (1) + statusbar (1)
-			m.homeView.SetSize(m.width, m.height-9)
-		}
+			// Initialize home view with loaded data
+			m.homeView = views.NewHomeModel(msg.containers, false, viper.GetBool("bedrock.enabled"))
+			if len(msg.containers) == 0 {
+				empty := views.EmptyState{DockerDown: !msg.dockerResponsive, DockerErr: msg.dockerErr, RecentDirs: container.RecentProjectDirs(3)}
+				empty.OpenDocker = canOpenDockerDesktop(msg.dockerErr)
+				if msg.err != nil {
+					empty.LoadError = msg.err.Error()
+				}
+				m.homeView.SetEmptyState(empty)
+			}
+			m.syncPendingRows()
+			if m.width > 0 && m.height > 0 {
+				m.homeView.SetSize(m.width, m.homeHeight())
+			}
 
-		// Restore cursor to same container if it still exists
-		if selectedContainerName != "" {
-			for i, c := range msg.containers {
-				if c.Name == selectedContainerName {
-					m.homeView.SetCursor(i)
-					break
+			// Restore cursor to same container if it still exists
+			if selectedContainerName != "" {
+				for i, c := range msg.containers {
+					if c.Name == selectedContainerName {
+						m.homeView.SetCursor(i)
+						break
+					}
+				}
+			}
+
+			// Pre-select the --container container; give up after the first load
+			if m.pendingSelection != "" && !m.applyPendingSelection() {
+				selectionCmd = m.alert.NewAlertCmd("Warning", fmt.Sprintf("Container %s not found", m.pendingSelection))
+				m.pendingSelection = ""
+			}
+
+			// Update container counts
+			m.containerCount = len(msg.containers)
+			m.runningCount = 0
+			for _, c := range msg.containers {
+				if c.Status == "running" {
+					m.runningCount++
 				}
 			}
+			m.attentionCount = len(attentionContainers(msg.containers))
+			m.keys.Attention.SetEnabled(m.attentionCount > 0)
+			m.lastLoaded = time.Now()
 		}
 
 		// Stop loading and reset operation status to Ready
 		m.loading = false
+		m.loadInFlight = false
 		m.operationStatus = "Ready"
 
-		// Update container count and Docker status
-		m.containerCount = len(msg.containers)
+		// Docker status
 		m.dockerResponsive = msg.dockerResponsive
+		m.dockerErr = msg.dockerErr
 
 		// Detect daemon disconnection and manage reconnect polling
 		var reconnectCmd tea.Cmd
@@ -912,7 +1149,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.reconnectActive = false
 			}
 		}
-		m.updateStatusBar()
 
 		// Only show toast for initial load, not background refreshes
 		var toastCmd tea.Cmd
@@ -925,11 +1161,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Mark as ready now that initial load is complete
 			m.ready = true
 		}
+		if selectionCmd != nil {
+			toastCmd = selectionCmd
+		}
 		if reconnectCmd != nil {
 			return m, tea.Batch(toastCmd, reconnectCmd)
 		}
 		return m, toastCmd
 
+	case updateAvailableMsg:
+		if updateToastShown {
+			return m, nil
+		}
+		updateToastShown = true
+		return m, m.alert.NewAlertCmd("Info", fmt.Sprintf("maestro %s is available — run 'maestro self-update'", msg.result.LatestVersion))
+
 	case pendingQuestionsMsg:
 		if msg.err == nil {
 			m.pendingQuestions = msg.questions
@@ -938,7 +1184,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.keys.Questions.SetEnabled(false)
 			}
-			m.updateStatusBar()
 		} else {
 			// Connection failed — attempt reconnect
 			if m.daemonClient != nil {
@@ -965,7 +1210,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					_ = m.containerService.Close()
 				}
 				m.containerService = containerservice.NewDocker(m.containerPrefix)
-				m.updateStatusBar()
 				// Immediately reload containers via Docker so the UI stays populated
 				cmds = append(cmds, m.loadContainers())
 			}
@@ -1083,7 +1327,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(m.pendingQuestions) == 0 {
 			m.keys.Questions.SetEnabled(false)
 		}
-		m.updateStatusBar()
 		return m, nil
 
 	case answerQuestionMsg:
@@ -1098,18 +1341,101 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
-		m.updateStatusBar()
 		toastCmd := m.alert.NewAlertCmd("Success", "Answer submitted")
 		return m, toastCmd
 
-	case views.ConnectRequestMsg:
-		// User pressed Enter to connect to a container
+	case enableFirewallMsg:
+		// Firewall setup prints progress and verification, so the CLI runs it
+		m.result = &TUIResult{
+			Action:        ActionEnableFirewall,
+			ContainerName: msg.ContainerName,
+		}
+		return m, tea.Quit
+
+	case recreateContainerMsg:
+		// Recreating copies the workspace and starts Claude, so the CLI runs it
 		m.result = &TUIResult{
-			Action:        ActionConnect,
+			Action:        ActionRecreate,
 			ContainerName: msg.ContainerName,
 		}
 		return m, tea.Quit
 
+	case views.ConnectRequestMsg:
+		// User pressed Enter to connect to a container. Check a running
+		// container's tmux session first, so a dead one can be recreated
+		// here rather than after the TUI has exited
+		if i := findContainerIndex(m.loadedContainers, msg.ContainerName, m.containerPrefix); i >= 0 && m.loadedContainers[i].Status == "running" {
+			return m, checkTmuxSession(msg.ContainerName, msg.Window, m.loadedContainers[i].Task)
+		}
+		return m.connect(msg.ContainerName, msg.Window)
+
+	case firewallLogMsg:
+		if msg.err != nil {
+			m.modal = NewErrorModal("Firewall Log", msg.err.Error())
+			return m, nil
+		}
+		m.modal = createFirewallLogModal(msg, container.GetShortName(msg.containerName, m.containerPrefix))
+		return m, nil
+
+	case clearFirewallLogMsg:
+		return m, clearFirewallLog(msg.containerName)
+
+	case tmuxSessionMsg:
+		if msg.missing {
+			m.modal = createTmuxRepairModal(msg, container.GetShortName(msg.containerName, m.containerPrefix))
+			return m, nil
+		}
+		return m.connect(msg.containerName, msg.window)
+
+	case repairTmuxSessionMsg:
+		return m, tea.Batch(
+			m.alert.NewAlertCmd("Info", "Recreating tmux session..."),
+			repairTmuxSession(msg),
+		)
+
+	case tmuxRepairedMsg:
+		if msg.err != nil {
+			m.modal = NewErrorModal("Error", "Failed to recreate the tmux session: "+msg.err.Error())
+			return m, nil
+		}
+		return m.connect(msg.containerName, msg.window)
+
+	case views.OpenInBrowserMsg:
+		return m, tea.Batch(
+			m.alert.NewAlertCmd("Info", "Looking up ports..."),
+			openInBrowser(msg.ContainerName, m.containerPrefix),
+		)
+
+	case views.OpenDockerRequestMsg:
+		return m, tea.Batch(
+			m.alert.NewAlertCmd("Info", "Starting Docker Desktop..."),
+			openDockerDesktop(),
+		)
+
+	case quitMsg:
+		m.result = &TUIResult{Action: ActionQuit}
+		return m, tea.Quit
+
+	case stopAllAndQuitMsg:
+		names := m.runningContainerNames()
+		return m, tea.Batch(
+			m.alert.NewAlertCmd("Info", fmt.Sprintf("Stopping %d containers...", len(names))),
+			stopAllContainers(names),
+		)
+
+	case allStoppedMsg:
+		if msg.err != nil {
+			m.modal = NewErrorModal("Error", "Failed to stop all containers:\n\n"+msg.err.Error())
+			return m, m.refresh()
+		}
+		m.result = &TUIResult{Action: ActionQuit}
+		return m, tea.Quit
+
+	case views.CreateRequestMsg:
+		// Enter on the empty list
+		m.modal = createContainerCreateModal()
+		return m, nil
+
 	case views.ShowActionsMenuMsg:
 		// Show actions menu for container
 		m.modal = createActionsModal(msg.Container)
@@ -1200,8 +1526,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case updateResourcesMsg:
 		m.modal = nil
-		m.operationInProgress = true
-		m.operationStatus = "Updating resources..."
+		if cmd, busy := m.operationPending(msg.containerName); busy {
+			return m, cmd
+		}
+		tickCmd := m.startOperation(msg.containerName, container.OperationUpdateResources)
 
 		toastCmd := m.alert.NewAlertCmd("Info", fmt.Sprintf("Updating resources for %s...", msg.containerName))
 		operationCmd := func() tea.Msg {
@@ -1213,7 +1541,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				err:           err,
 			}
 		}
-		return m, tea.Batch(toastCmd, operationCmd, m.operationSpinner.Tick)
+		return m, tea.Batch(toastCmd, operationCmd, tickCmd)
 
 	case createContainerMsg:
 		// User submitted create container form - exit TUI and return to CLI
@@ -1233,33 +1561,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.modal = nil // Close settings modal
 
 		// Update container resource defaults
+		values := map[string]any{
+			"daemon.show_nag":              msg.showNag,
+			"daemon.token_refresh.enabled": msg.autoRefreshTokens,
+			"daemon.notifications.enabled": msg.enableNotifications,
+		}
 		if msg.memory != "" {
-			viper.Set("containers.resources.memory", msg.memory)
+			values["containers.resources.memory"] = msg.memory
 		}
 		if msg.cpus != "" {
-			viper.Set("containers.resources.cpus", msg.cpus)
+			values["containers.resources.cpus"] = msg.cpus
 		}
 		if msg.defaultModel != "" {
 			normalizedModel := strings.ToLower(msg.defaultModel)
 			validModels := map[string]bool{"opus": true, "sonnet": true, "haiku": true}
 			if validModels[normalizedModel] {
-				viper.Set("containers.default_model", normalizedModel)
+				values["containers.default_model"] = normalizedModel
 			}
 			// Invalid values are silently ignored; the field keeps its previous value
 		}
 
-		// Update daemon settings
-		viper.Set("daemon.show_nag", msg.showNag)
-		viper.Set("daemon.token_refresh.enabled", msg.autoRefreshTokens)
-		viper.Set("daemon.notifications.enabled", msg.enableNotifications)
-
 		// Write config to file
-		if err := viper.WriteConfig(); err != nil {
-			// If config file doesn't exist, create it
-			if err := viper.SafeWriteConfig(); err != nil {
-				toastCmd := m.alert.NewAlertCmd("Error", "Failed to save settings: "+err.Error())
-				return m, toastCmd
-			}
+		if err := settings.Save(values); err != nil {
+			toastCmd := m.alert.NewAlertCmd("Error", "Failed to save settings: "+err.Error())
+			return m, toastCmd
 		}
 
 		toastCmd := m.alert.NewAlertCmd("Success", "Settings saved successfully")
@@ -1280,14 +1605,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Update config with new domains
-		viper.Set("firewall.allowed_domains", newDomains)
-
-		// Write config to file
-		if err := viper.WriteConfig(); err != nil {
-			if err := viper.SafeWriteConfig(); err != nil {
-				toastCmd := m.alert.NewAlertCmd("Error", "Failed to save firewall: "+err.Error())
-				return m, toastCmd
-			}
+		if err := settings.Save(map[string]any{"firewall.allowed_domains": newDomains}); err != nil {
+			toastCmd := m.alert.NewAlertCmd("Error", "Failed to save firewall: "+err.Error())
+			return m, toastCmd
 		}
 
 		// If "apply to running" is checked, sync all domains to running containers.
@@ -1318,20 +1638,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleContainerAction(msg)
 
 	case ConfirmActionMsg:
-		// Mark operation in progress and update status
-		m.operationInProgress = true
-		if msg.Action == container.OperationDelete {
-			m.operationStatus = "Deleting..."
-		} else if msg.Action == container.OperationStop {
-			m.operationStatus = "Stopping..."
+		// Mark the container's operation in progress; another may have
+		// started while the confirmation was open
+		if cmd, busy := m.operationPending(msg.ContainerName); busy {
+			return m, cmd
 		}
+		tickCmd := m.startOperation(msg.ContainerName, msg.Action)
 
 		// Execute confirmed action asynchronously
-		return m, tea.Batch(m.performDockerOperation(msg.Action, msg.ContainerName), m.operationSpinner.Tick)
+		if msg.PullFirst {
+			return m, tea.Batch(m.pullThenDelete(msg.ContainerName, msg.RemoveVolumes), tickCmd)
+		}
+		return m, tea.Batch(m.performDockerOperation(msg.Action, msg.ContainerName, msg.RemoveVolumes), tickCmd)
 
 	case dockerOperationResult:
-		// Clear operation in progress flag
-		m.operationInProgress = false
+		// Clear the container's operation
+		m.finishOperation(msg.containerName)
 
 		// Handle result of Docker operation
 		if msg.success {
@@ -1351,11 +1673,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			toastCmd := m.alert.NewAlertCmd("Success", fmt.Sprintf("Container %s %s", msg.containerName, actionVerb))
 
 			// Reload container list immediately for all operations (to update auth status, state changes, etc.)
-			m.operationStatus = "Syncing..."
+			if !m.operationInProgress() {
+				m.operationStatus = "Syncing..."
+			}
 			return m, tea.Batch(toastCmd, m.loadContainers())
 		} else {
 			// Error - reset to Ready and show modal
-			m.operationStatus = "Ready"
+			if !m.operationInProgress() {
+				m.operationStatus = "Ready"
+			}
 			m.modal = NewErrorModal("Operation Failed", fmt.Sprintf("Failed to %s container %s:\n\n%v", msg.action, msg.containerName, msg.err))
 			return m, nil
 		}
@@ -1373,10 +1699,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch msg.String() {
-		case "q", "ctrl+c":
-			m.result = &TUIResult{Action: ActionQuit}
-			return m, tea.Quit
-		case "?":
+		case "q", "ctrl+c", "f10":
+			return m.quit()
+		case "?", "f1":
 			// Show help modal (skip in wizard mode)
 			if !m.wizardMode {
 				m.modal = createHelpModal()
@@ -1384,18 +1709,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "d":
 			// Show container details for selected container
-			if m.homeView != nil && len(m.homeView.GetContainers()) > 0 {
-				selectedIdx := m.homeView.GetCursor()
-				containers := m.homeView.GetContainers()
-				if selectedIdx >= 0 && selectedIdx < len(containers) {
-					selected := containers[selectedIdx]
-					details, err := container.GetContainerDetails(selected.Name, m.containerPrefix)
-					if err != nil {
-						m.modal = NewErrorModal("Error", fmt.Sprintf("Failed to fetch container details:\n\n%v", err))
-					} else {
-						m.modal = createContainerDetailsModal(details)
-					}
-				}
+			if selected, ok := m.selectedContainer(); ok {
+				return m, m.showDetails(selected)
 			}
 			return m, nil
 		case "i":
@@ -1407,29 +1722,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.activeQuestionEvent = m.pendingQuestions[0].Event.ID
 			}
 			return m, nil
+		case "!":
+			// List the containers waiting on the user
+			if m.homeView != nil {
+				if waiting := attentionContainers(m.homeView.GetContainers()); len(waiting) > 0 {
+					m.modal = createAttentionModal(waiting, time.Now())
+					return m, nil
+				}
+			}
+			return m, m.alert.NewAlertCmd("Info", "No containers need attention")
 		case "n":
 			// Show create container form
 			m.modal = createContainerCreateModal()
 			return m, nil
-		case "s":
+		case "s", "f2":
 			// Show settings form
 			m.modal = createSettingsModal()
 			return m, nil
-		case "f":
+		case "f", "f3":
 			// Show firewall configuration form
 			m.modal = createFirewallModal()
 			return m, nil
+		case "p":
+			// Toggle the Claude screen preview for the selected container
+			return m, m.togglePreview()
+		case "L":
+			// Show the connections the selected container's firewall rejected
+			selected, ok := m.selectedContainer()
+			if !ok {
+				return m, nil
+			}
+			if selected.Status != "running" {
+				return m, m.alert.NewAlertCmd("Info", "Start the container to read its firewall log")
+			}
+			return m, tea.Batch(
+				m.alert.NewAlertCmd("Info", "Reading firewall log..."),
+				loadFirewallLog(selected.Name),
+			)
+		case "l":
+			// Cycle through the default, last-connected and unmerged-work orders
+			return m, m.toggleHomeOrder()
+		case "r", "f5":
+			// Reload the container list now instead of waiting for the next tick
+			if m.operationInProgress() {
+				return m, nil
+			}
+			return m, m.refresh()
 		}
 	}
 
 	// Route to home view if ready
-	var homeCmd tea.Cmd
+	var homeCmd, previewCmd, detailsCmd tea.Cmd
 	if m.homeView != nil {
 		_, homeCmd = m.homeView.Update(msg)
+		if m.previewSelectionChanged() {
+			previewCmd = m.refreshPreview()
+		}
+		detailsCmd = m.scheduleDetailsPrefetch()
 	}
 
 	// Batch home and alert commands (alert already updated at top of Update)
-	return m, tea.Batch(homeCmd, alertCmd)
+	return m, tea.Batch(homeCmd, previewCmd, detailsCmd, alertCmd)
 }
 
 // createHelpModal creates the help/keybindings modal
@@ -1437,18 +1790,27 @@ func createHelpModal() *Modal {
 	helpText := `Navigation:
   ↑/↓ or j/k    Navigate list
   Enter         Connect to container
+  S             Connect to the container's shell window
+  1-9           Connect to the container in that row
 
 Actions:
   a             Container actions menu
   d             View container details
+  p             Preview Claude's screen for the selected container
+  o             Open the container's web server in a browser
+                (with Docker down on macOS, start Docker Desktop)
+  L             Show connections the container's firewall blocked
+  r / F5        Refresh the container list now
+  l             Toggle listing recently connected containers first
+  !             List containers waiting on you; Enter connects
   i             View pending questions
-  ?             Show this help
-  q             Quit Maestro
+  s / F2        Settings
+  f / F3        Firewall domains
+  ? / F1        Show this help
+  q / F10       Quit Maestro
 
 Container Connection:
-  Ctrl+b d      Detach from container
-  Ctrl+b 0      Switch to Claude window
-  Ctrl+b 1      Switch to shell window
+%s
 
 Scrolling in Modals:
   ↑/↓ or j/k    Scroll line by line
@@ -1456,10 +1818,18 @@ Scrolling in Modals:
   Space         Scroll down one page
   Home          Jump to top
   End           Jump to bottom
+  r             Reveal/hide secrets (container details)
 
 This is scrollable content - try scrolling if you see
 the scroll indicators (▲/▼) below this text!`
 
+	// Render tmux keys with the configured prefix (tmux.prefix)
+	prefix, _ := container.TmuxPrefix(viper.GetString("tmux.prefix"))
+	prefixKey := container.FormatTmuxKey(prefix)
+	connectionKeys := fmt.Sprintf("  %-13s Detach from container\n  %-13s Switch to Claude window\n  %-13s Switch to shell window\n  %-13s Exit Claude to disconnect (--no-tmux containers)",
+		prefixKey+" d", prefixKey+" 0", prefixKey+" 1", "/exit")
+	helpText = fmt.Sprintf(helpText, connectionKeys)
+
 	// Use scrollable modal with 10 lines visible
 	return NewScrollableHelpModal("Maestro Keybindings", helpText, 10)
 }
@@ -1471,6 +1841,7 @@ func createPrerequisiteCheckModal() *Modal {
 
 • Claude CLI: Checking...
 • Docker: Checking...
+• Disk space: Checking...
 
 Please wait while we verify your system requirements.`
 
@@ -1489,16 +1860,63 @@ Please wait while we verify your system requirements.`
 // checkPrerequisites returns a command that performs prerequisite checks asynchronously
 func checkPrerequisites() tea.Cmd {
 	return func() tea.Msg {
-		result := prerequisiteCheckResult{}
+		return prerequisiteCheckResult{checks: system.Prerequisites()}
+	}
+}
 
-		// Check Claude CLI using shared utility
-		result.claudeAvailable, result.claudeMessage = system.IsClaudeAvailable()
+// prerequisiteResultModal shows each prerequisite check with how to fix the
+// failed ones. Setup continues once nothing has failed outright; otherwise
+// the user can fix things and re-check without leaving the wizard.
+func prerequisiteResultModal(checks []system.Check) *Modal {
+	// Use plain text indicators without colors
+	// TODO: Find way to add colors without background conflicts (see backlog)
+	pass, warn, fail, bullet := style.Check(), style.Warning(), style.Cross(), style.Glyph("•", "-")
 
-		// Check Docker using shared utility
-		result.dockerAvailable, result.dockerMessage = system.IsDockerAvailable()
+	var content strings.Builder
+	content.WriteString("Prerequisite Check Complete\n\n")
+	for _, c := range checks {
+		status := fail
+		switch {
+		case c.OK:
+			status = pass
+		case c.Warn:
+			status = warn
+		}
+		fmt.Fprintf(&content, "%s %s: %s %s\n", bullet, c.Name, status, c.Detail)
+		if !c.OK && c.Fix != "" {
+			fix := ansi.Wrap(c.Fix, 60, "")
+			content.WriteString("  " + strings.ReplaceAll(fix, "\n", "\n  ") + "\n")
+		}
+	}
 
-		return result
+	met := system.PrerequisitesMet(checks)
+	if met {
+		content.WriteString("\nYou're ready to continue.\n\nStep 1 of 6")
+	} else {
+		content.WriteString("\nFix the problems above, then Re-check.\n\nStep 1 of 6")
 	}
+
+	recheck := ModalAction{Label: "Re-check", Key: "r", OnSelect: func() tea.Msg { return recheckPrerequisitesMsg{} }}
+	modal := &Modal{
+		Type:       ModalInfo,
+		Title:      "System Requirements",
+		Content:    content.String(),
+		Width:      70,
+		DisableEsc: true,
+	}
+	if met {
+		modal.Actions = []ModalAction{
+			{Label: "Continue", Key: "enter", IsPrimary: true, OnSelect: func() tea.Msg { return wizardNextStepMsg{} }},
+			recheck,
+		}
+	} else {
+		recheck.IsPrimary = true
+		modal.Actions = []ModalAction{
+			recheck,
+			{Label: "Exit", Key: "x", OnSelect: func() tea.Msg { return exitWizardMsg{} }},
+		}
+	}
+	return modal
 }
 
 // createWizardWelcomeModal creates the welcome screen for the wizard
@@ -1549,7 +1967,7 @@ Step 2 of 6`
 func (m Model) createWizardAuthModal(hasCredentials bool) *Modal {
 	var content string
 	if hasCredentials {
-		content = `Authentication: ✓ Already configured
+		content = `Authentication: ` + style.Check() + ` Already configured
 
 Your Claude credentials are already set up and ready to use.
 
@@ -1724,7 +2142,9 @@ Step 5 of 6`, m.wizardMemory, m.wizardCPUs)
 	return modal
 }
 
-// createWizardCompletionModal creates the completion screen for the wizard
+// createWizardCompletionModal creates the completion screen for the wizard.
+// It offers to start the daemon now and, where supported, to install it as a
+// login service, so token refresh and notifications work from day one.
 func (m Model) createWizardCompletionModal() *Modal {
 	var content strings.Builder
 
@@ -1736,28 +2156,48 @@ func (m Model) createWizardCompletionModal() *Modal {
 	content.WriteString("You're ready to start using Maestro!\n\n")
 	content.WriteString("On the main screen, press 'n' to create your first container.\n")
 	content.WriteString("Use 's' to adjust settings and 'f' to modify firewall rules.\n\n")
+	content.WriteString("The background daemon refreshes tokens before they expire and\n")
+	content.WriteString("notifies you when a container needs attention.\n\n")
 	content.WriteString("Step 6 of 6")
 
+	checkboxes := []bool{true}
+	labels := []string{"Start the maestro daemon now"}
+	if m.installDaemon != nil {
+		checkboxes = append(checkboxes, false)
+		labels = append(labels, "Install as a login service")
+	}
+
 	modal := &Modal{
-		Type:       ModalInfo,
-		Title:      "Welcome Complete",
-		Content:    content.String(),
-		Width:      70,
-		DisableEsc: true, // Disable Esc during wizard
+		Type:        ModalForm,
+		Title:       "Welcome Complete",
+		Content:     content.String(),
+		Width:       70,
+		DisableEsc:  true, // Disable Esc during wizard
+		checkboxes:  checkboxes,
+		fieldLabels: labels,
 		Actions: []ModalAction{
-			{Label: "Finish", Key: "enter", IsPrimary: true},
-			{Label: "Back", Key: "b", IsPrimary: false},
+			{Label: "Finish", Key: "ctrl+s", IsPrimary: true},
+			// Back replaces the modal, so keeping it open only lets its key
+			// work from any field
+			{Label: "Back", Key: "ctrl+b", IsPrimary: false, KeepOpen: true},
 		},
 	}
+	// Start on Finish so Enter completes the wizard with the defaults
+	modal.focusedField = 1 + len(checkboxes)
 
 	// Finish button - save config and exit wizard
 	modal.Actions[0].OnSelect = func() tea.Msg {
-		return saveWizardConfigMsg{
-			memory:     m.wizardMemory,
-			cpus:       m.wizardCPUs,
-			domains:    m.wizardDomains,
-			runAuthNow: m.wizardRunAuthNow,
+		msg := saveWizardConfigMsg{
+			memory:      m.wizardMemory,
+			cpus:        m.wizardCPUs,
+			domains:     m.wizardDomains,
+			runAuthNow:  m.wizardRunAuthNow,
+			startDaemon: modal.checkboxes[0],
+		}
+		if len(modal.checkboxes) > 1 {
+			msg.installDaemon = modal.checkboxes[1]
 		}
+		return msg
 	}
 
 	// Back button
@@ -1793,52 +2233,89 @@ func (m *Model) getWizardModal() *Modal {
 
 // saveWizardConfig saves the wizard configuration to the config file
 func (m *Model) saveWizardConfig(msg saveWizardConfigMsg) error {
-	// Get config file path
-	configPath := paths.ConfigFile()
-
-	// Check if config file exists
-	fileExists := false
-	if _, err := os.Stat(configPath); err == nil {
-		fileExists = true
-	}
-
-	if fileExists {
-		// Config file exists - update only the wizard keys
-		// Re-read the config to ensure we have the latest values
-		viper.SetConfigFile(configPath)
-		if err := viper.ReadInConfig(); err != nil {
-			return fmt.Errorf("failed to read existing config: %w", err)
-		}
+	// Update only the wizard-specific keys. If running auth now, the wizard
+	// resumes after auth completes (they still need to complete remaining
+	// wizard steps: firewall, defaults, completion); finishing clears it.
+	values := map[string]any{
+		"containers.resources.memory": msg.memory,
+		"containers.resources.cpus":   msg.cpus,
+		"firewall.allowed_domains":    msg.domains,
+		"wizard.resume_after_auth":    msg.runAuthNow,
+	}
+	// Users who opted into the daemon don't need the reminder to start it
+	if !msg.runAuthNow {
+		values["daemon.show_nag"] = !msg.startDaemon && !msg.installDaemon
+	}
+	if err := settings.Save(values); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	return nil
+}
 
-	// Update only the wizard-specific keys
-	viper.Set("containers.resources.memory", msg.memory)
-	viper.Set("containers.resources.cpus", msg.cpus)
-	viper.Set("firewall.allowed_domains", msg.domains)
+// daemonSetupCmds runs the daemon options picked on the wizard's completion
+// step, each reporting back with a daemonSetupResultMsg.
+func (m *Model) daemonSetupCmds(msg saveWizardConfigMsg) []tea.Cmd {
+	var cmds []tea.Cmd
+	if msg.startDaemon && m.startDaemon != nil {
+		start := m.startDaemon
+		cmds = append(cmds, func() tea.Msg {
+			return daemonSetupResultMsg{action: "started", err: start()}
+		})
+	}
+	if msg.installDaemon && m.installDaemon != nil {
+		install := m.installDaemon
+		cmds = append(cmds, func() tea.Msg {
+			return daemonSetupResultMsg{action: "installed", err: install()}
+		})
+	}
+	return cmds
+}
 
-	// If running auth now, enable wizard to continue after auth completes
-	// (they still need to complete remaining wizard steps: firewall, defaults, completion)
-	if msg.runAuthNow {
-		viper.Set("wizard.resume_after_auth", true)
-	} else {
-		// Wizard is completing normally (Finish button) - clear resume flag
-		viper.Set("wizard.resume_after_auth", false)
-	}
+// secretEnvPatterns are substrings of environment variable names whose
+// values are masked in the container details modal
+var secretEnvPatterns = []string{"TOKEN", "KEY", "SECRET", "PASSWORD"}
 
-	// Write the config file
-	if err := viper.WriteConfig(); err != nil {
-		// If WriteConfig fails (file doesn't exist), use WriteConfigAs
-		if err := viper.WriteConfigAs(configPath); err != nil {
-			return fmt.Errorf("failed to write config file: %w", err)
+// isSecretEnv reports whether an environment variable name looks like it
+// holds a credential
+func isSecretEnv(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, pattern := range secretEnvPatterns {
+		if strings.Contains(upper, pattern) {
+			return true
 		}
 	}
+	return false
+}
 
-	return nil
+// redactEnv masks the value of a NAME=value entry if the name looks secret.
+// The second result reports whether anything was masked.
+func redactEnv(env string) (string, bool) {
+	name, value, ok := strings.Cut(env, "=")
+	if !ok || value == "" || !isSecretEnv(name) {
+		return env, false
+	}
+	return name + "=****", true
 }
 
-// createContainerDetailsModal creates a scrollable modal showing comprehensive container information
+// createContainerDetailsModal creates a scrollable modal showing comprehensive container information.
+// Secret-looking environment values are masked; "r" toggles revealing them.
 func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
+	content, hasSecrets := containerDetailsContent(details, false)
+
+	// Use scrollable info modal with 20 lines visible and 100 character width
+	modal := NewScrollableInfoModalWide("Container Details", content, 20, 100)
+	if hasSecrets {
+		modal.revealContent, _ = containerDetailsContent(details, true)
+	}
+	return modal
+}
+
+// containerDetailsContent renders the details modal body. Unless reveal is
+// set, secret environment values are masked; the second result reports
+// whether any were found.
+func containerDetailsContent(details *container.ContainerDetails, reveal bool) (string, bool) {
 	var content strings.Builder
+	hasSecrets := false
 
 	// Header section
 	content.WriteString(fmt.Sprintf("Container: %s\n", details.ShortName))
@@ -1853,11 +2330,49 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	content.WriteString(fmt.Sprintf("Git Status:   %s\n", strings.TrimSpace(details.GitStatus)))
 	content.WriteString(fmt.Sprintf("Auth Status:  %s\n", details.AuthStatus))
 	content.WriteString(fmt.Sprintf("Last Activity: %s\n", details.LastActivity))
+	if !details.CreatedAt.IsZero() {
+		content.WriteString(fmt.Sprintf("Created:      %s (%s ago)\n", details.CreatedAt.Local().Format("2006-01-02 15:04:05"), container.ShortDuration(time.Since(details.CreatedAt))))
+	}
+	if !details.StartedAt.IsZero() {
+		content.WriteString(fmt.Sprintf("Started:      %s\n", details.StartedAt.Local().Format("2006-01-02 15:04:05")))
+	}
 	if details.Uptime != "" {
 		content.WriteString(fmt.Sprintf("Uptime:       %s\n", details.Uptime))
 	}
+	if details.Image != "" {
+		content.WriteString(fmt.Sprintf("Image:        %s\n", details.Image))
+	}
+	if details.ImageOutdated {
+		content.WriteString(style.Glyph("⬆", "^") + " Outdated: new containers use a newer image. Recreate from the actions menu (a).\n")
+	}
+	if details.NoFirewall {
+		content.WriteString("\n" + noFirewallWarning() + "\n")
+	}
 	content.WriteString("\n")
 
+	// Task description the container was created for
+	content.WriteString("Task:\n")
+	content.WriteString(strings.Repeat("─", 96) + "\n")
+	task := strings.TrimSpace(details.Task)
+	if task == "" {
+		task = container.UnknownTask
+	}
+	content.WriteString(ansi.Wrap(task, 96, "") + "\n\n")
+
+	// Uncommitted files, fetched with the details when the workspace is dirty
+	if len(details.DirtyFiles) > 0 {
+		title := "Uncommitted Files:"
+		if len(details.DirtyFiles) == container.MaxDirtyFiles {
+			title = fmt.Sprintf("Uncommitted Files (first %d):", container.MaxDirtyFiles)
+		}
+		content.WriteString(title + "\n")
+		content.WriteString(strings.Repeat("─", 96) + "\n")
+		for _, f := range details.DirtyFiles {
+			content.WriteString(fmt.Sprintf("  %s\n", f))
+		}
+		content.WriteString("\n")
+	}
+
 	// Resources
 	content.WriteString("Resources:\n")
 	content.WriteString(strings.Repeat("─", 96) + "\n")
@@ -1868,6 +2383,11 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	// Network
 	content.WriteString("Network:\n")
 	content.WriteString(strings.Repeat("─", 96) + "\n")
+	if details.NetworkMode == container.NetworkHost {
+		content.WriteString("Mode:         host (bypasses the firewall)\n")
+	} else {
+		content.WriteString(fmt.Sprintf("Mode:         %s\n", details.NetworkMode))
+	}
 	if details.IPAddress != "" {
 		content.WriteString(fmt.Sprintf("IP Address:   %s\n", details.IPAddress))
 	} else {
@@ -1895,13 +2415,38 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	}
 	content.WriteString("\n")
 
+	// Synced folders (sync.additional_folders copied at creation)
+	if len(details.SyncedFolders) > 0 {
+		content.WriteString("Synced Folders:\n")
+		content.WriteString(strings.Repeat("─", 96) + "\n")
+		for _, f := range details.SyncedFolders {
+			content.WriteString(fmt.Sprintf("  %s -> %s\n", f.Source, f.Dest))
+		}
+		content.WriteString("\n")
+	}
+
 	// Environment Variables
-	content.WriteString("Environment Variables:\n")
+	var envLines strings.Builder
+	for _, env := range details.Environment {
+		if masked, secret := redactEnv(env); secret {
+			hasSecrets = true
+			if !reveal {
+				env = masked
+			}
+		}
+		envLines.WriteString(fmt.Sprintf("  %s\n", env))
+	}
+	switch {
+	case hasSecrets && reveal:
+		content.WriteString("Environment Variables (secrets shown, r to hide):\n")
+	case hasSecrets:
+		content.WriteString("Environment Variables (secrets hidden, r to reveal):\n")
+	default:
+		content.WriteString("Environment Variables:\n")
+	}
 	content.WriteString(strings.Repeat("─", 96) + "\n")
 	if len(details.Environment) > 0 {
-		for _, env := range details.Environment {
-			content.WriteString(fmt.Sprintf("  %s\n", env))
-		}
+		content.WriteString(envLines.String())
 	} else {
 		content.WriteString("(none)\n")
 	}
@@ -1912,8 +2457,7 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	content.WriteString(strings.Repeat("─", 96) + "\n")
 	content.WriteString(details.RecentLogs)
 
-	// Use scrollable info modal with 20 lines visible and 100 character width
-	return NewScrollableInfoModalWide("Container Details", content.String(), 20, 100)
+	return content.String(), hasSecrets
 }
 
 // createContainerCreateModal creates the interactive form for creating a new container
@@ -1981,10 +2525,36 @@ func createContainerCreateModal() *Modal {
 		},
 		Actions: []ModalAction{
 			{Label: "Create", Key: "ctrl+s", IsPrimary: true},
+			{Label: "Preview branch", Key: "ctrl+g", IsPrimary: false, KeepOpen: true},
 			{Label: "Cancel", Key: "esc", IsPrimary: false},
 		},
 	}
 
+	// Validate before exiting the TUI, since creation errors would otherwise
+	// only show up in the CLI flow afterwards
+	formInput := func() createFormInput {
+		return createFormInput{
+			task:   modal.textarea.Value(),
+			branch: modal.textinputs[0].Value(),
+			exact:  modal.checkboxes[1],
+			web:    modal.checkboxes[2],
+		}
+	}
+	modal.Actions[0].Validate = func() bool {
+		return modal.setFieldErrors(createFormErrors(formInput()))
+	}
+	preview := &branchPreview{}
+	modal.fieldHints = func() map[int]string {
+		hints := createFormHints(formInput())
+		if preview.pending && modal.spinner != nil {
+			hints[createFieldBranch] = "  " + modal.spinner.View() + " Generating branch name..."
+		}
+		return hints
+	}
+	modal.Actions[1].OnSelect = func() tea.Msg {
+		return requestBranchPreview(modal, preview)
+	}
+
 	// Set OnSelect handler after modal is created (to avoid closure issues)
 	modal.Actions[0].OnSelect = func() tea.Msg {
 		// Extract form values and create message
@@ -1995,7 +2565,7 @@ func createContainerCreateModal() *Modal {
 
 		branchName := ""
 		if len(modal.textinputs) > 0 {
-			branchName = modal.textinputs[0].Value()
+			branchName = strings.TrimSpace(modal.textinputs[0].Value())
 		}
 
 		model := ""
@@ -2141,8 +2711,9 @@ func createSettingsModal() *Modal {
 
 // createFirewallModal creates the firewall domain management modal
 func createFirewallModal() *Modal {
-	// Load current domains from viper
-	domains := viper.GetStringSlice("firewall.allowed_domains")
+	// Load the global config's domains, which saving writes back, leaving out
+	// any a project .maestro.yml adds
+	domains, _ := settings.GlobalStringSlice("firewall.allowed_domains")
 
 	// Create textarea with all domains (one per line)
 	domainsText := strings.Join(domains, "\n")
@@ -2209,8 +2780,11 @@ func createFirewallModal() *Modal {
 // createActionsModal creates the container actions menu modal
 func createActionsModal(containerInfo container.Info) *Modal {
 	content := "Select an action for: " + containerInfo.ShortName
+	if containerInfo.NoFirewall {
+		content += "\n\n" + noFirewallWarning()
+	}
 
-	return &Modal{
+	modal := &Modal{
 		Type:    ModalActions,
 		Title:   "Container Actions",
 		Content: content,
@@ -2224,6 +2798,14 @@ func createActionsModal(containerInfo container.Info) *Modal {
 					return views.ConnectRequestMsg{ContainerName: containerInfo.Name}
 				},
 			},
+			{
+				Label:     "Connect (shell)",
+				Key:       "S",
+				IsPrimary: false,
+				OnSelect: func() tea.Msg {
+					return views.ConnectRequestMsg{ContainerName: containerInfo.Name, Window: "shell"}
+				},
+			},
 			{
 				Label:     "Stop",
 				Key:       "s",
@@ -2273,6 +2855,44 @@ func createActionsModal(containerInfo container.Info) *Modal {
 		},
 		SelectedAction: 0,
 	}
+
+	// Offer to lock down containers created with --no-firewall, but not host
+	// networked ones, where the firewall would rewrite the host's rules
+	if containerInfo.NoFirewall && !containerInfo.HostNetwork && containerInfo.Status == "running" {
+		enable := ModalAction{
+			Label:     "Enable Firewall",
+			Key:       "f",
+			IsPrimary: false,
+			OnSelect: func() tea.Msg {
+				return enableFirewallMsg{ContainerName: containerInfo.Name}
+			},
+		}
+		last := len(modal.Actions) - 1
+		modal.Actions = append(modal.Actions[:last], enable, modal.Actions[last])
+	}
+
+	// Offer to move containers on an old image to the current one
+	if containerInfo.ImageOutdated && containerInfo.Status == "running" {
+		recreate := ModalAction{
+			Label:     "Recreate with current image",
+			Key:       "i",
+			IsPrimary: false,
+			OnSelect: func() tea.Msg {
+				return recreateContainerMsg{ContainerName: containerInfo.Name}
+			},
+		}
+		last := len(modal.Actions) - 1
+		modal.Actions = append(modal.Actions[:last], recreate, modal.Actions[last])
+	}
+	return modal
+}
+
+// noFirewallWarning is the red warning shown for containers without a firewall.
+func noFirewallWarning() string {
+	return lipgloss.NewStyle().
+		Foreground(style.CrimsonPulse).
+		Bold(true).
+		Render("⚠ Firewall disabled: this container has unrestricted network access")
 }
 
 // ContainerActionMsg signals a container action should be performed
@@ -2284,20 +2904,34 @@ type ContainerActionMsg struct {
 // handleContainerAction processes container action requests
 func (m Model) handleContainerAction(msg ContainerActionMsg) (tea.Model, tea.Cmd) {
 	switch msg.Action {
-	case container.OperationStop, container.OperationDelete:
-		// Destructive actions - show confirmation
-		actionVerb := string(msg.Action)
-		if msg.Action == container.OperationDelete {
-			actionVerb = "remove"
-		}
+	case container.OperationDelete:
+		// Destructive action - show confirmation, offering to remove the
+		// cache volumes too. Their size is filled in once docker reports it.
+		// It can't be confirmed until the unsaved work check is back.
+		containerName := msg.ContainerName
+		m.modal = NewConfirmCheckboxModal(
+			"Confirm Delete",
+			deleteConfirmContent(containerName)+"\n\nChecking for unsaved work...",
+			cacheVolumesLabel(-1),
+			func(removeVolumes bool) tea.Msg {
+				return ConfirmActionMsg{
+					Action:        container.OperationDelete,
+					ContainerName: containerName,
+					RemoveVolumes: removeVolumes,
+				}
+			},
+		)
+		m.modal.Actions[0].Validate = func() bool { return false }
+		return m, tea.Batch(fetchCacheVolumesSize(m.modal, containerName), checkUnsavedWork(m.modal, containerName))
 
-		// Store action info for confirmation callback
+	case container.OperationStop:
+		// Destructive action - show confirmation
 		action := msg.Action
 		containerName := msg.ContainerName
 
 		m.modal = NewConfirmModal(
 			"Confirm "+strings.Title(string(msg.Action)),
-			fmt.Sprintf("Are you sure you want to %s container '%s'?", actionVerb, msg.ContainerName),
+			fmt.Sprintf("Are you sure you want to %s container '%s'?", msg.Action, msg.ContainerName),
 			func() tea.Msg {
 				return ConfirmActionMsg{
 					Action:        action,
@@ -2309,24 +2943,28 @@ func (m Model) handleContainerAction(msg ContainerActionMsg) (tea.Model, tea.Cmd
 		return m, nil
 
 	case container.OperationRestart:
-		// Mark operation in progress and update status
-		m.operationInProgress = true
-		m.operationStatus = "Restarting..."
+		// Mark the container's operation in progress
+		if cmd, busy := m.operationPending(msg.ContainerName); busy {
+			return m, cmd
+		}
+		tickCmd := m.startOperation(msg.ContainerName, msg.Action)
 
 		// Show info toast and perform restart asynchronously
 		toastCmd := m.alert.NewAlertCmd("Info", fmt.Sprintf("Restarting container %s...", msg.ContainerName))
-		operationCmd := m.performDockerOperation(msg.Action, msg.ContainerName)
-		return m, tea.Batch(toastCmd, operationCmd, m.operationSpinner.Tick)
+		operationCmd := m.performDockerOperation(msg.Action, msg.ContainerName, false)
+		return m, tea.Batch(toastCmd, operationCmd, tickCmd)
 
 	case container.OperationRefreshTokens:
-		// Mark operation in progress and update status
-		m.operationInProgress = true
-		m.operationStatus = "Refreshing tokens..."
+		// Mark the container's operation in progress
+		if cmd, busy := m.operationPending(msg.ContainerName); busy {
+			return m, cmd
+		}
+		tickCmd := m.startOperation(msg.ContainerName, msg.Action)
 
 		// Show info toast and perform token refresh asynchronously
 		toastCmd := m.alert.NewAlertCmd("Info", fmt.Sprintf("Refreshing tokens for %s...", msg.ContainerName))
-		operationCmd := m.performDockerOperation(msg.Action, msg.ContainerName)
-		return m, tea.Batch(toastCmd, operationCmd, m.operationSpinner.Tick)
+		operationCmd := m.performDockerOperation(msg.Action, msg.ContainerName, false)
+		return m, tea.Batch(toastCmd, operationCmd, tickCmd)
 
 	case container.OperationUpdateResources:
 		// Handled by updateResourcesMsg — should not reach here via ContainerActionMsg
@@ -2343,12 +2981,183 @@ func (m Model) handleContainerAction(msg ContainerActionMsg) (tea.Model, tea.Cmd
 type ConfirmActionMsg struct {
 	Action        container.OperationType
 	ContainerName string
+	RemoveVolumes bool // Delete only: also remove the cache volumes
+	PullFirst     bool // Delete only: pull the branch to the host first
+}
+
+// cacheVolumesSizeMsg carries the size of a container's cache volumes for
+// the delete confirmation modal that asked for it.
+type cacheVolumesSizeMsg struct {
+	modal *Modal
+	size  int64
+	err   error
+}
+
+// fetchCacheVolumesSize measures a container's cache volumes in the
+// background; docker system df can take several seconds.
+func fetchCacheVolumesSize(modal *Modal, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		size, err := container.CacheVolumesSize(containerName)
+		return cacheVolumesSizeMsg{modal: modal, size: size, err: err}
+	}
+}
+
+// cacheVolumesLabel labels the delete confirmation's volume checkbox with
+// the space it frees, or without it while size is unknown (negative).
+func cacheVolumesLabel(size int64) string {
+	const label = "Also delete cached volumes"
+	switch {
+	case size < 0:
+		return label
+	case size >= 1e9:
+		return fmt.Sprintf("%s (frees ~%.1f GB)", label, float64(size)/1e9)
+	default:
+		return fmt.Sprintf("%s (frees ~%d MB)", label, (size+5e5)/1e6)
+	}
+}
+
+// deleteConfirmContent is the question the delete confirmation asks.
+func deleteConfirmContent(containerName string) string {
+	return fmt.Sprintf("Are you sure you want to remove container '%s'?", containerName)
+}
+
+// unsavedWorkMsg carries the git state of a container for the delete
+// confirmation modal that asked for it.
+type unsavedWorkMsg struct {
+	modal         *Modal
+	containerName string
+	state         container.GitState
+	canPull       bool // The container records the host repository it came from
+	err           error
+}
+
+// checkUnsavedWork reads a container's git state in the background, so the
+// delete confirmation can ask for more when work would be lost.
+func checkUnsavedWork(modal *Modal, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		state, err := container.GetUnsavedWork(containerName)
+		return unsavedWorkMsg{
+			modal:         modal,
+			containerName: containerName,
+			state:         state,
+			canPull:       container.GetLabel(containerName, container.SourceDirLabel) != "",
+			err:           err,
+		}
+	}
+}
+
+// hasUnsavedWork reports whether deleting a container in this git state
+// loses commits or uncommitted changes.
+func hasUnsavedWork(state container.GitState) bool {
+	return state.Repo && (state.Unpushed > 0 || state.Uncommitted > 0)
+}
+
+// unsavedWorkSummary describes the work a delete would lose ("3 unpushed
+// commits, 1 uncommitted file").
+func unsavedWorkSummary(state container.GitState) string {
+	plural := func(n int, word string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", word)
+		}
+		return fmt.Sprintf("%d %ss", n, word)
+	}
+	var parts []string
+	if state.Unpushed > 0 {
+		parts = append(parts, plural(state.Unpushed, "unpushed commit"))
+	}
+	if state.Uncommitted > 0 {
+		parts = append(parts, plural(state.Uncommitted, "uncommitted file"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// requireTypedDelete turns a delete confirmation into a form that only
+// deletes once the container's short name is typed, keeping the cache
+// volume checkbox. With canPull it also offers to pull the branch to the
+// host before deleting. A non-nil checkErr means the git state couldn't be
+// read, for example because the container is stopped, and is treated as
+// unsaved work that can't be pulled. The modal is changed in place so
+// messages addressed to it, like the volume size, still find it.
+func requireTypedDelete(modal *Modal, containerName, shortName string, state container.GitState, canPull bool, checkErr error) {
+	input := textinput.New()
+	input.Placeholder = shortName
+	input.Width = 40
+	input.CharLimit = len(containerName)
+	input.PromptStyle = lipgloss.NewStyle().Foreground(style.OceanTide)
+	input.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	input.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	input.Cursor.Style = lipgloss.NewStyle().Foreground(style.OceanSurge)
+	input.Focus()
+
+	summary := "Unsaved work: " + unsavedWorkSummary(state)
+	consequence := fmt.Sprintf("Deleting container '%s' loses this work.", containerName)
+	if checkErr != nil {
+		summary = "Couldn't check for unsaved work"
+		consequence = fmt.Sprintf("Deleting container '%s' loses any work in it (is it stopped?).", containerName)
+		canPull = false
+	}
+	warning := lipgloss.NewStyle().
+		Foreground(style.CrimsonPulse).
+		Bold(true).
+		Render(style.Glyph("⚠", "!") + " " + summary)
+	content := warning + "\n\n" + consequence
+	if canPull && state.Uncommitted > 0 {
+		content += "\nPulling to the host copies commits only, not uncommitted files."
+	}
+
+	modal.Type = ModalForm
+	modal.Content = content
+	modal.textinputs = []textinput.Model{input}
+	modal.checkboxes = []bool{modal.confirmChecked}
+	modal.fieldLabels = []string{fmt.Sprintf("Type %s to confirm:", shortName), modal.confirmCheckLabel}
+	modal.confirmCheckLabel = ""
+	modal.focusedField = 1
+
+	typed := func() bool {
+		if strings.TrimSpace(modal.textinputs[0].Value()) == shortName {
+			return modal.setFieldErrors(nil)
+		}
+		return modal.setFieldErrors(map[int]string{1: fmt.Sprintf("Type %s to delete the container", shortName)})
+	}
+	confirm := func(pullFirst bool) func() tea.Msg {
+		return func() tea.Msg {
+			return ConfirmActionMsg{
+				Action:        container.OperationDelete,
+				ContainerName: containerName,
+				RemoveVolumes: modal.checkboxes[0],
+				PullFirst:     pullFirst,
+			}
+		}
+	}
+
+	modal.Actions = []ModalAction{{Label: "Delete", Key: "ctrl+s", IsPrimary: true, Validate: typed, OnSelect: confirm(false)}}
+	if canPull {
+		modal.Actions = append(modal.Actions, ModalAction{Label: "Pull branch to host first", Key: "ctrl+p", Validate: typed, OnSelect: confirm(true)})
+	}
+	modal.Actions = append(modal.Actions, ModalAction{Label: "Cancel", Key: "esc"})
+	modal.SelectedAction = 0
+}
+
+// pullThenDelete pulls a container's branch to the host and deletes the
+// container only if that worked.
+func (m Model) pullThenDelete(containerName string, removeVolumes bool) tea.Cmd {
+	deleteCmd := m.performDockerOperation(container.OperationDelete, containerName, removeVolumes)
+	return func() tea.Msg {
+		if _, err := container.PullBranch(containerName); err != nil {
+			return dockerOperationResult{
+				action:        container.OperationDelete,
+				containerName: containerName,
+				err:           fmt.Errorf("pulling the branch to the host failed, so the container was kept: %w", err),
+			}
+		}
+		return deleteCmd()
+	}
 }
 
 // performDockerOperation executes a Docker operation asynchronously.
 // Stop and delete route through ContainerService so the daemon's cache
 // is invalidated and state hash validation works.
-func (m Model) performDockerOperation(action container.OperationType, containerName string) tea.Cmd {
+func (m Model) performDockerOperation(action container.OperationType, containerName string, removeVolumes bool) tea.Cmd {
 	return func() tea.Msg {
 		var err error
 		ctx := context.Background()
@@ -2359,7 +3168,9 @@ func (m Model) performDockerOperation(action container.OperationType, containerN
 		case container.OperationRestart:
 			err = container.RestartContainer(containerName)
 		case container.OperationDelete:
-			_, err = m.containerService.CleanupContainers(ctx, []string{containerName}, "", nil)
+			_, err = m.containerService.CleanupContainers(ctx, []string{containerName}, "", &containerservice.CleanupOptions{
+				RemoveVolumes: removeVolumes,
+			})
 		case container.OperationRefreshTokens:
 			err = container.RefreshTokens(containerName)
 		default:
@@ -2629,14 +3440,43 @@ func (c rgbColor) toANSI256() lipgloss.Color {
 	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b))
 }
 
+// blockBanner is the MAESTRO logo drawn with Unicode shade blocks.
+var blockBanner = []string{
+	"░  ░░░░  ░░░      ░░░        ░░░      ░░░        ░░       ░░░░      ░░",
+	"▒   ▒▒   ▒▒  ▒▒▒▒  ▒▒  ▒▒▒▒▒▒▒▒  ▒▒▒▒▒▒▒▒▒▒▒  ▒▒▒▒▒  ▒▒▒▒  ▒▒  ▒▒▒▒  ▒",
+	"▓        ▓▓  ▓▓▓▓  ▓▓      ▓▓▓▓▓      ▓▓▓▓▓▓  ▓▓▓▓▓       ▓▓▓  ▓▓▓▓  ▓",
+	"█  █  █  ██        ██  ██████████████  █████  █████  ███  ███  ████  █",
+	"█  ████  ██  ████  ██        ███      ██████  █████  ████  ███      ██",
+}
+
+// asciiBanner replaces blockBanner in ASCII mode.
+var asciiBanner = []string{
+	` __  __     _     _____  ____   _____  ____    ___`,
+	`|  \/  |   / \   | ____|/ ___| |_   _||  _ \  / _ \`,
+	`| |\/| |  / _ \  |  _|  \___ \   | |  | |_) || | | |`,
+	`| |  | | / ___ \ | |___  ___) |  | |  |  _ < | |_| |`,
+	`|_|  |_|/_/   \_\|_____||____/   |_|  |_| \_\ \___/`,
+}
+
+// bannerLines returns the logo for the current mode.
+func bannerLines() []string {
+	if style.IsASCIIMode() {
+		return asciiBanner
+	}
+	return blockBanner
+}
+
 // renderTitleBanner creates the ASCII art title with horizontal smooth gradient
 func (m Model) renderTitleBanner() string {
-	banner := []string{
-		"░  ░░░░  ░░░      ░░░        ░░░      ░░░        ░░       ░░░░      ░░",
-		"▒   ▒▒   ▒▒  ▒▒▒▒  ▒▒  ▒▒▒▒▒▒▒▒  ▒▒▒▒▒▒▒▒▒▒▒  ▒▒▒▒▒  ▒▒▒▒  ▒▒  ▒▒▒▒  ▒",
-		"▓        ▓▓  ▓▓▓▓  ▓▓      ▓▓▓▓▓      ▓▓▓▓▓▓  ▓▓▓▓▓       ▓▓▓  ▓▓▓▓  ▓",
-		"█  █  █  ██        ██  ██████████████  █████  █████  ███  ███  ████  █",
-		"█  ████  ██  ████  ██        ███      ██████  █████  ████  ███      ██",
+	banner := bannerLines()
+
+	if !style.ColorEnabled() {
+		// No color: skip the gradient and center the plain text
+		var lines []string
+		for _, line := range banner {
+			lines = append(lines, lipgloss.Place(m.width, 1, lipgloss.Center, lipgloss.Center, line))
+		}
+		return strings.Join(append(lines, ""), "\n")
 	}
 
 	// Define gradient stops (left to right)
@@ -2729,13 +3569,7 @@ func (m Model) renderTitleBanner() string {
 
 // renderWizardAnimation renders the opening animation (column-by-column reveal)
 func (m Model) renderWizardAnimation() string {
-	banner := []string{
-		"░  ░░░░  ░░░      ░░░        ░░░      ░░░        ░░       ░░░░      ░░",
-		"▒   ▒▒   ▒▒  ▒▒▒▒  ▒▒  ▒▒▒▒▒▒▒▒  ▒▒▒▒▒▒▒▒▒▒▒  ▒▒▒▒▒  ▒▒▒▒  ▒▒  ▒▒▒▒  ▒",
-		"▓        ▓▓  ▓▓▓▓  ▓▓      ▓▓▓▓▓      ▓▓▓▓▓▓  ▓▓▓▓▓       ▓▓▓  ▓▓▓▓  ▓",
-		"█  █  █  ██        ██  ██████████████  █████  █████  ███  ███  ████  █",
-		"█  ████  ██  ████  ██        ███      ██████  █████  ████  ███      ██",
-	}
+	banner := bannerLines()
 
 	// Same gradient as normal title
 	stops := []struct {
@@ -2765,6 +3599,10 @@ func (m Model) renderWizardAnimation() string {
 			visibleLen = len(runes)
 		}
 		visibleLine := string(runes[:visibleLen])
+		if !style.ColorEnabled() {
+			renderedLines = append(renderedLines, visibleLine)
+			continue
+		}
 
 		// Apply gradient
 		var coloredLine strings.Builder
@@ -2814,7 +3652,7 @@ func (m Model) renderWizardAnimation() string {
 	if m.animationComplete {
 		helpText = lipgloss.NewStyle().
 			Foreground(style.OceanTide).
-			Render("↵ begin")
+			Render(style.Glyph("↵", "Enter") + " begin")
 	}
 
 	// Combine banner and help
@@ -2851,19 +3689,42 @@ func (m *Model) updateStatusBar() {
 			shade = cycleLength - step
 		}
 		daemonColor := style.GetDaemonShade(shade)
-		daemonIndicator = lipgloss.NewStyle().Foreground(daemonColor).Render("●")
+		daemonIndicator = lipgloss.NewStyle().Foreground(daemonColor).Render(style.Glyph("●", "*"))
 	} else {
-		daemonIndicator = "○" // Not running
+		daemonIndicator = style.Glyph("○", "o") // Not running
 	}
 	containerText := fmt.Sprintf("%d containers", m.containerCount)
 	if m.containerCount == 1 {
 		containerText = "1 container"
 	}
 	col1Text := fmt.Sprintf("%s %s", daemonIndicator, containerText)
+	if !style.ColorEnabled() {
+		// Without the green pulse the dot alone is easy to misread
+		col1Text = containerText + ", daemon off"
+		if m.daemonRunning {
+			col1Text = containerText + ", daemon on"
+		}
+	}
 	col1 := lipgloss.NewStyle().
 		Foreground(style.GhostWhite).
 		Background(style.DeepSpace).
 		Render(col1Text)
+	if viper.GetBool("containers.offline_mode") {
+		// Image pulls are disabled
+		col1 += lipgloss.NewStyle().
+			Foreground(style.DeepSpace).
+			Background(style.SilverMist).
+			Bold(true).
+			Render(" [OFFLINE] ")
+	}
+	if container.NearContainerLimit(m.runningCount, m.maxContainers) {
+		// Approaching daemon.max_containers - new containers will soon be refused
+		col1 += lipgloss.NewStyle().
+			Foreground(style.DeepSpace).
+			Background(style.SunsetGlow).
+			Bold(true).
+			Render(fmt.Sprintf(" %d/%d running ", m.runningCount, m.maxContainers))
+	}
 
 	// Column 2: Current path (DimGray background)
 	pathText := m.workingDir
@@ -2876,6 +3737,14 @@ func (m *Model) updateStatusBar() {
 		Foreground(style.GhostWhite).
 		Background(style.DimGray).
 		Render(pathText)
+	if m.attentionCount > 0 {
+		// Containers waiting on the user take precedence over the path
+		col2 = lipgloss.NewStyle().
+			Foreground(style.DeepSpace).
+			Background(style.SunsetGlow).
+			Bold(true).
+			Render(fmt.Sprintf(" %d need attention (! key) ", m.attentionCount))
+	}
 
 	// Column 3: Operation status / question badge (PurpleHaze background)
 	// Shows warning in red if Docker is unresponsive
@@ -2905,15 +3774,26 @@ func (m *Model) updateStatusBar() {
 			Foreground(style.GhostWhite).
 			Background(style.CrimsonPulse).
 			Bold(true).
-			Render(" Is Docker running? ")
-	} else if m.operationInProgress {
+			Render(" " + dockerStatusText(m.dockerErr) + " ")
+	} else if m.operationInProgress() {
 		// Style both spinner and text with matching background
 		spinnerPart := m.operationSpinner.View()
 		textPart := lipgloss.NewStyle().
 			Foreground(style.GhostWhite).
 			Background(style.PurpleHaze).
-			Render(" " + m.operationStatus)
+			Render(" " + operationsSummary(m.containerOperations))
 		col3 = spinnerPart + textPart
+	} else if m.operationStatus == "Ready" && !m.lastLoaded.IsZero() {
+		// Idle: show how fresh the list is, in amber once refreshes are missed
+		text, stale := updatedAgo(m.lastLoaded, time.Now())
+		fg := style.GhostWhite
+		if stale {
+			fg = style.SunsetGlow
+		}
+		col3 = lipgloss.NewStyle().
+			Foreground(fg).
+			Background(style.PurpleHaze).
+			Render(text)
 	} else {
 		col3 = lipgloss.NewStyle().
 			Foreground(style.GhostWhite).
@@ -2923,9 +3803,9 @@ func (m *Model) updateStatusBar() {
 
 	// Column 4: Time + Mode indicator (OceanAbyss background)
 	timeText := time.Now().Format("15:04")
-	modeIndicator := "●" // Normal mode
+	modeIndicator := style.Glyph("●", "*") // Normal mode
 	if m.modal != nil {
-		modeIndicator = "◆" // Modal active
+		modeIndicator = style.Glyph("◆", "#") // Modal active
 	}
 	col4Text := fmt.Sprintf("%s %s", timeText, modeIndicator)
 	col4 := lipgloss.NewStyle().
@@ -2986,6 +3866,9 @@ func (m Model) View() string {
 	titleBanner := m.renderTitleBanner()
 
 	baseView := m.homeView.View()
+	if m.preview.enabled {
+		baseView = lipgloss.JoinVertical(lipgloss.Left, baseView, m.renderPreview(m.width, previewHeight(m.height-9)))
+	}
 
 	// Combine title and main view for modal background
 	combinedView := titleBanner + "\n" + baseView
@@ -3016,12 +3899,9 @@ func (m Model) View() string {
 		helpView = m.help.View(activeKeys)
 	}
 
-	// Update and render statusbar at bottom (always visible, not dimmed by modal)
-	// Create a mutable copy for updateStatusBar
-	mCopy := m
-	mCopy.updateStatusBar()
-	mCopy.statusbar.SetSize(m.width)
-	statusView := mCopy.statusbar.View()
+	// Render statusbar at bottom (always visible, not dimmed by modal); its
+	// content is kept current by Update
+	statusView := m.statusbar.View()
 
 	// Combine main view with help and statusbar
 	// Layout: Title → Content → Help → (blank line) → Statusbar
@@ -0,0 +1,83 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/views"
+)
+
+// benchContainers is a fixture of n containers in a mix of states.
+func benchContainers(n int) []container.Info {
+	states := []string{"active", "waiting", "idle", "question", ""}
+	created := time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)
+	containers := make([]container.Info, n)
+	for i := range containers {
+		name := fmt.Sprintf("feat-bench-%d", i)
+		containers[i] = container.Info{
+			Name:         "maestro-" + name,
+			ShortName:    name,
+			Status:       "running",
+			Branch:       "feat/bench-" + fmt.Sprint(i),
+			AgentState:   states[i%len(states)],
+			Task:         "Benchmark the home view with a long task description",
+			CurrentTask:  "Rendering rows",
+			TaskProgress: "2/5",
+			GitStatus:    "+3 ~1",
+			AuthStatus:   "✓ 147.2h",
+			CreatedAt:    created,
+		}
+	}
+	return containers
+}
+
+func BenchmarkTUIView(b *testing.B) {
+	zone.NewGlobal()
+	containers := benchContainers(100)
+	m := NewWithCache("maestro-", &CachedState{Containers: containers})
+	// Without a config the model starts in the first run wizard
+	m.wizardMode = false
+	m.homeView = views.NewHomeModel(containers, false, false)
+	var model tea.Model = *m
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+
+	if view := model.View(); !strings.Contains(view, "feat-bench-0") {
+		b.Fatalf("home view not rendered:\n%s", view)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = model.View()
+	}
+}
+
+func TestRenderStats_ReportsEveryTenRenders(t *testing.T) {
+	var out strings.Builder
+	stats := &renderStats{}
+	for i := 0; i < 2*benchmarkEvery-1; i++ {
+		stats.record(time.Millisecond, &out)
+	}
+	if got, want := out.String(), "tui_render renders=10 avg_us=1000 max_us=1000\n"; got != want {
+		t.Errorf("report = %q, want %q", got, want)
+	}
+}
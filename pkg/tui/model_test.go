@@ -0,0 +1,885 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+	"github.com/uprockcom/maestro/pkg/tui/views"
+	"go.dalton.dog/bubbleup"
+)
+
+func TestRedactEnv(t *testing.T) {
+	tests := []struct {
+		env    string
+		want   string
+		secret bool
+	}{
+		{"API_KEY=abc123", "API_KEY=****", true},
+		{"GITHUB_TOKEN=ghp_x", "GITHUB_TOKEN=****", true},
+		{"db_password=hunter2", "db_password=****", true},
+		{"CLIENT_SECRET=s", "CLIENT_SECRET=****", true},
+		{"PATH=/usr/bin", "PATH=/usr/bin", false},
+		{"API_KEY=", "API_KEY=", false},
+		{"NO_VALUE", "NO_VALUE", false},
+	}
+	for _, tt := range tests {
+		got, secret := redactEnv(tt.env)
+		if got != tt.want || secret != tt.secret {
+			t.Errorf("redactEnv(%q) = %q, %v; want %q, %v", tt.env, got, secret, tt.want, tt.secret)
+		}
+	}
+}
+
+func TestContainerDetailsModal_RevealToggle(t *testing.T) {
+	details := &container.ContainerDetails{
+		ShortName:   "demo",
+		Environment: []string{"ANTHROPIC_API_KEY=sk-ant-secret", "HOME=/home/node"},
+	}
+	m := createContainerDetailsModal(details)
+	m.viewport.Height = 100 // show the whole body
+
+	if strings.Contains(m.viewport.View(), "sk-ant-secret") || !strings.Contains(m.Content, "ANTHROPIC_API_KEY=****") {
+		t.Fatal("secret should be masked by default")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if m == nil || !strings.Contains(m.viewport.View(), "sk-ant-secret") {
+		t.Fatal("r should reveal secrets and keep the modal open")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if strings.Contains(m.viewport.View(), "sk-ant-secret") {
+		t.Error("second r should hide secrets again")
+	}
+
+	plain := createContainerDetailsModal(&container.ContainerDetails{Environment: []string{"HOME=/home/node"}})
+	if plain.revealContent != "" {
+		t.Error("no reveal toggle expected without secrets")
+	}
+}
+
+func TestContainerDetailsModal_Task(t *testing.T) {
+	task := strings.Repeat("implement the feature ", 10)
+	content, _ := containerDetailsContent(&container.ContainerDetails{Task: task}, false)
+	if !strings.Contains(content, "Task:\n") || !strings.Contains(content, "implement the feature") {
+		t.Fatalf("task section missing:\n%s", content)
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if len(line) > 96 && strings.Contains(line, "implement") {
+			t.Errorf("task not wrapped to the modal width: %q", line)
+		}
+	}
+
+	content, _ = containerDetailsContent(&container.ContainerDetails{}, false)
+	if !strings.Contains(content, "Task:\n"+strings.Repeat("─", 96)+"\n"+container.UnknownTask) {
+		t.Errorf("expected %s for a container without a task", container.UnknownTask)
+	}
+}
+
+func TestContainerDetailsModal_DirtyFiles(t *testing.T) {
+	content, _ := containerDetailsContent(&container.ContainerDetails{DirtyFiles: []string{" M main.go", "?? notes.txt"}}, false)
+	if !strings.Contains(content, "Uncommitted Files:\n") || !strings.Contains(content, "  ?? notes.txt\n") {
+		t.Errorf("uncommitted files missing:\n%s", content)
+	}
+
+	content, _ = containerDetailsContent(&container.ContainerDetails{}, false)
+	if strings.Contains(content, "Uncommitted Files") {
+		t.Error("no uncommitted files section expected for a clean workspace")
+	}
+}
+
+func TestHomeContainers_GitOrder(t *testing.T) {
+	viper.Set("tui.home_order", "git")
+	defer viper.Set("tui.home_order", "")
+
+	ordered := homeContainers([]container.Info{
+		{Name: "clean", Git: container.GitState{Repo: true}},
+		{Name: "ahead", Git: container.GitState{Repo: true, Unpushed: 11}},
+	})
+	if ordered[0].Name != "ahead" {
+		t.Errorf("git order should put the most unpushed work first, got %s", ordered[0].Name)
+	}
+}
+
+func TestOutdatedImage(t *testing.T) {
+	content, _ := containerDetailsContent(&container.ContainerDetails{Image: "ghcr.io/uprockcom/maestro:1.3.2", ImageOutdated: true}, false)
+	if !strings.Contains(content, "Image:        ghcr.io/uprockcom/maestro:1.3.2") || !strings.Contains(content, "Outdated:") {
+		t.Errorf("image lines missing:\n%s", content)
+	}
+
+	hasRecreate := func(c container.Info) bool {
+		for _, a := range createActionsModal(c).Actions {
+			if a.Label == "Recreate with current image" {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasRecreate(container.Info{Name: "maestro-a-1", Status: "running", ImageOutdated: true}) {
+		t.Error("no recreate action for an outdated container")
+	}
+	if hasRecreate(container.Info{Name: "maestro-a-1", Status: "running"}) {
+		t.Error("recreate offered for a current container")
+	}
+}
+
+func TestEnableFirewallAction(t *testing.T) {
+	hasEnable := func(c container.Info) bool {
+		for _, a := range createActionsModal(c).Actions {
+			if a.Label == "Enable Firewall" {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasEnable(container.Info{Name: "maestro-a-1", Status: "running", NoFirewall: true}) {
+		t.Error("no Enable Firewall action for a --no-firewall container")
+	}
+	if hasEnable(container.Info{Name: "maestro-a-1", Status: "running", NoFirewall: true, HostNetwork: true}) {
+		t.Error("Enable Firewall offered for a host networked container")
+	}
+}
+
+func TestPrerequisiteResultModal(t *testing.T) {
+	checks := []system.Check{
+		{Name: "Claude CLI", OK: true, Detail: "version 2.0.14"},
+		{Name: "Docker", Detail: "installed but not running", Fix: "Start Docker Desktop, wait for it to finish starting, then Re-check"},
+	}
+	modal := prerequisiteResultModal(checks)
+	if !strings.Contains(modal.Content, "Docker: ") || !strings.Contains(modal.Content, "  Start Docker Desktop") {
+		t.Errorf("missing per-check remediation:\n%s", modal.Content)
+	}
+	if len(modal.Actions) != 2 || modal.Actions[0].Label != "Re-check" {
+		t.Fatalf("failed checks should offer Re-check first, got %+v", modal.Actions)
+	}
+	if _, ok := modal.Actions[0].OnSelect().(recheckPrerequisitesMsg); !ok {
+		t.Error("Re-check should re-run the checks")
+	}
+
+	checks[1] = system.Check{Name: "Docker", OK: true, Detail: "server 27.3.1 (linux/x86_64)"}
+	if modal := prerequisiteResultModal(checks); modal.Actions[0].Label != "Continue" {
+		t.Errorf("passing checks should continue, got %+v", modal.Actions)
+	}
+}
+
+func TestWizardCompletionModal_DaemonOptions(t *testing.T) {
+	m := Model{startDaemon: func() error { return nil }}
+	modal := m.createWizardCompletionModal()
+	if len(modal.checkboxes) != 1 || !modal.checkboxes[0] {
+		t.Fatalf("without an installer only a checked start option should show, got %v", modal.checkboxes)
+	}
+
+	m.installDaemon = func() error { return errors.New("no systemd") }
+	modal = m.createWizardCompletionModal()
+	if len(modal.checkboxes) != 2 || modal.checkboxes[1] {
+		t.Fatalf("install option should show unchecked, got %v", modal.checkboxes)
+	}
+	modal.checkboxes[0] = false
+	modal.checkboxes[1] = true
+	msg, ok := modal.Actions[0].OnSelect().(saveWizardConfigMsg)
+	if !ok || msg.startDaemon || !msg.installDaemon {
+		t.Fatalf("Finish should carry the checkboxes, got %+v", msg)
+	}
+
+	cmds := m.daemonSetupCmds(msg)
+	if len(cmds) != 1 {
+		t.Fatalf("want one daemon command, got %d", len(cmds))
+	}
+	result, ok := cmds[0]().(daemonSetupResultMsg)
+	if !ok || result.action != "installed" || result.err == nil {
+		t.Errorf("install failure should be reported, got %+v", result)
+	}
+}
+
+func TestFindContainerIndex(t *testing.T) {
+	containers := []container.Info{
+		{Name: "maestro-feat-a-1", ShortName: "feat-a-1"},
+		{Name: "maestro-feat-b-1", ShortName: "feat-b-1"},
+	}
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"maestro-feat-b-1", 1},
+		{"feat-b-1", 1},
+		{"feat-a-1", 0},
+		{"feat-c-1", -1},
+	}
+	for _, tt := range tests {
+		if got := findContainerIndex(containers, tt.name, "maestro-"); got != tt.want {
+			t.Errorf("findContainerIndex(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBannerLines_ASCIIMode(t *testing.T) {
+	defer viper.Set("tui.ascii_fallback", false)
+
+	viper.Set("tui.ascii_fallback", true)
+	banner := bannerLines()
+	if len(banner) != len(blockBanner) {
+		t.Errorf("ASCII banner has %d lines, want %d to keep the layout", len(banner), len(blockBanner))
+	}
+	for _, line := range banner {
+		for _, r := range line {
+			if r > 127 {
+				t.Fatalf("non-ASCII %q in banner line %q", r, line)
+			}
+		}
+	}
+
+	viper.Set("tui.ascii_fallback", false)
+	if bannerLines()[0] != blockBanner[0] {
+		t.Error("Unicode banner expected by default")
+	}
+}
+
+func TestToast_ASCIIMode(t *testing.T) {
+	defer viper.Set("tui.ascii_fallback", false)
+	viper.Set("tui.ascii_fallback", true)
+
+	m := New("mcl-")
+	alert, _ := m.alert.Update(m.alert.NewAlertCmd("Success", "Configuration saved!")())
+	view := alert.(bubbleup.AlertModel).Render(strings.Repeat(strings.Repeat(" ", 100)+"\n", 10))
+	if !strings.Contains(view, "OK") || strings.Contains(view, "✓") {
+		t.Errorf("toast should use the ASCII prefix in ASCII mode:\n%s", view)
+	}
+}
+
+func TestPreview_HeightMath(t *testing.T) {
+	m := Model{height: 50}
+	if got := m.homeHeight(); got != 41 {
+		t.Errorf("homeHeight without preview = %d, want 41", got)
+	}
+	m.preview.enabled = true
+	if got, want := m.homeHeight()+previewHeight(41), 41; got != want {
+		t.Errorf("home view and preview take %d lines, want %d", got, want)
+	}
+	if got := previewHeight(60); got != previewLines+1 {
+		t.Errorf("previewHeight(60) = %d, want %d", got, previewLines+1)
+	}
+	if got := previewHeight(10); got != 5 {
+		t.Errorf("previewHeight(10) = %d, want half the space", got)
+	}
+	if got := previewHeight(-3); got != 0 {
+		t.Errorf("previewHeight(-3) = %d, want 0", got)
+	}
+}
+
+func TestPreview_Render(t *testing.T) {
+	m := Model{homeView: views.NewHomeModel([]container.Info{
+		{Name: "mcl-fix-1", ShortName: "fix-1", Status: "exited"},
+	}, false, false)}
+	m.preview.enabled = true
+
+	if cmd := m.refreshPreview(); cmd != nil {
+		t.Error("stopped container should not be captured")
+	}
+	out := m.renderPreview(40, 8)
+	if lines := strings.Split(out, "\n"); len(lines) != 8 {
+		t.Errorf("placeholder render has %d lines, want 8", len(lines))
+	}
+	if !strings.Contains(out, "Container is not running") || !strings.Contains(out, "fix-1") {
+		t.Errorf("unexpected placeholder render:\n%s", out)
+	}
+
+	m.setPreviewCapture(previewCapturedMsg{container: "mcl-fix-1", content: "a\nb\nc\nd\ne\nf\ng\nh\ni"})
+	lines := strings.Split(m.renderPreview(40, 5), "\n")
+	if len(lines) != 5 || lines[1] != "f" || lines[4] != "i" {
+		t.Errorf("render should show the last 4 lines under the header, got %q", lines)
+	}
+
+	// Captures for a container no longer shown are dropped
+	m.setPreviewCapture(previewCapturedMsg{container: "mcl-other-1", content: "stale"})
+	if strings.Contains(m.renderPreview(40, 5), "stale") {
+		t.Error("capture for another container was shown")
+	}
+}
+
+func TestAttentionModal(t *testing.T) {
+	now := time.Now()
+	containers := []container.Info{
+		{Name: "mcl-a-1", ShortName: "a-1", Status: "running", AgentState: "active"},
+		{Name: "mcl-b-1", ShortName: "b-1", Status: "running", AgentState: "idle", AgentStateSince: now.Add(-2 * time.Minute)},
+		{Name: "mcl-c-1", ShortName: "c-1", Status: "running", AgentState: "question", AgentStateSince: now.Add(-10 * time.Minute)},
+	}
+	waiting := attentionContainers(containers)
+	if len(waiting) != 2 || waiting[0].Name != "mcl-c-1" {
+		t.Fatalf("attentionContainers = %v, want c-1 then b-1", waiting)
+	}
+
+	zone.NewGlobal()
+	modal := createAttentionModal(waiting, now)
+	view := modal.View(120, 40)
+	if !strings.Contains(view, "10m") || !strings.Contains(view, "asking a question") {
+		t.Errorf("modal should show how long each container has waited:\n%s", view)
+	}
+
+	// Down then enter connects to the second row
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should connect")
+	}
+	msg, ok := cmd().(views.ConnectRequestMsg)
+	if !ok || msg.ContainerName != "mcl-b-1" {
+		t.Errorf("enter sent %#v, want a connect to mcl-b-1", cmd())
+	}
+}
+
+func TestDeleteModal_VolumeCheckbox(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{}
+	result, _ := m.handleContainerAction(ContainerActionMsg{Action: container.OperationDelete, ContainerName: "mcl-feat-1"})
+	m = result.(Model)
+	modal := m.modal
+	if !strings.Contains(modal.View(100, 40), "Also delete cached volumes") {
+		t.Fatal("delete confirmation should offer to delete the cache volumes")
+	}
+
+	// A size fetched for another modal is ignored
+	result, _ = m.Update(cacheVolumesSizeMsg{modal: &Modal{}, size: 5e6})
+	m = result.(Model)
+	if strings.Contains(m.modal.View(100, 40), "frees") {
+		t.Error("size for another modal was shown")
+	}
+	result, _ = m.Update(cacheVolumesSizeMsg{modal: modal, size: 123456789})
+	m = result.(Model)
+	if view := m.modal.View(100, 40); !strings.Contains(view, "frees ~123 MB") {
+		t.Errorf("size should be shown once fetched:\n%s", view)
+	}
+
+	// Confirming waits for the unsaved work check
+	if _, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}); cmd != nil {
+		t.Fatal("delete should wait for the unsaved work check")
+	}
+	result, _ = m.Update(unsavedWorkMsg{modal: modal, containerName: "mcl-feat-1", state: container.GitState{Repo: true}})
+	m = result.(Model)
+	if strings.Contains(m.modal.View(100, 40), "Checking for unsaved work") {
+		t.Error("the pending check should be cleared once it's back")
+	}
+
+	// Unchecked by default; space checks it
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if msg := cmd().(ConfirmActionMsg); msg.RemoveVolumes {
+		t.Error("volumes should be kept by default")
+	}
+	modal.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	_, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if msg := cmd().(ConfirmActionMsg); !msg.RemoveVolumes || msg.ContainerName != "mcl-feat-1" {
+		t.Errorf("confirm sent %#v, want volumes removed for mcl-feat-1", msg)
+	}
+}
+
+func TestDeleteModal_UnsavedWork(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{containerPrefix: "mcl-"}
+	result, _ := m.handleContainerAction(ContainerActionMsg{Action: container.OperationDelete, ContainerName: "mcl-feat-1"})
+	m = result.(Model)
+	modal := m.modal
+
+	// A clean container keeps the yes/no confirmation
+	result, _ = m.Update(unsavedWorkMsg{modal: modal, containerName: "mcl-feat-1", state: container.GitState{Repo: true}})
+	m = result.(Model)
+	if m.modal.Type != ModalConfirm {
+		t.Fatal("a clean container should keep the simple confirmation")
+	}
+
+	dirty := container.GitState{Repo: true, Unpushed: 2, Uncommitted: 1}
+	result, _ = m.Update(unsavedWorkMsg{modal: modal, containerName: "mcl-feat-1", state: dirty, canPull: true})
+	m = result.(Model)
+	if m.modal != modal || modal.Type != ModalForm {
+		t.Fatal("unsaved work should turn the same modal into a typed confirmation")
+	}
+	view := modal.View(100, 40)
+	for _, want := range []string{"2 unpushed commits, 1 uncommitted file", "Type feat-1 to confirm", "Pull branch to host first", "Also delete cached volumes"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("typed confirmation should show %q:\n%s", want, view)
+		}
+	}
+
+	// The volume size still reaches the upgraded modal
+	result, _ = m.Update(cacheVolumesSizeMsg{modal: modal, size: 123456789})
+	m = result.(Model)
+	if !strings.Contains(modal.View(100, 40), "frees ~123 MB") {
+		t.Error("size should be shown on the typed confirmation")
+	}
+
+	// Enter does nothing until the name is typed
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("delete should need the typed name")
+	}
+	modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("feat-1")})
+	next, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if next != nil || cmd == nil {
+		t.Fatal("typing the name should allow the delete")
+	}
+	if msg := cmd().(ConfirmActionMsg); msg.ContainerName != "mcl-feat-1" || msg.PullFirst {
+		t.Errorf("delete sent %#v", msg)
+	}
+
+	// The pull action confirms with PullFirst
+	_, cmd = modal.runAction(1)
+	if msg := cmd().(ConfirmActionMsg); !msg.PullFirst {
+		t.Errorf("pull action sent %#v, want PullFirst", msg)
+	}
+}
+
+func TestDeleteModal_UnknownWork(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{containerPrefix: "mcl-"}
+	result, _ := m.handleContainerAction(ContainerActionMsg{Action: container.OperationDelete, ContainerName: "mcl-feat-1"})
+	m = result.(Model)
+	modal := m.modal
+
+	// A stopped container can't be checked, so it counts as unsaved work
+	result, _ = m.Update(unsavedWorkMsg{modal: modal, containerName: "mcl-feat-1", canPull: true, err: errors.New("container is not running")})
+	m = result.(Model)
+	if modal.Type != ModalForm {
+		t.Fatal("a failed check should require the typed confirmation")
+	}
+	view := modal.View(100, 40)
+	if !strings.Contains(view, "Couldn't check for unsaved work") {
+		t.Errorf("typed confirmation should say the check failed:\n%s", view)
+	}
+	if strings.Contains(view, "Pull branch to host first") {
+		t.Error("a container that can't be checked can't be pulled from either")
+	}
+}
+
+func TestUnsavedWorkSummary(t *testing.T) {
+	if got := unsavedWorkSummary(container.GitState{Repo: true, Unpushed: 1, Uncommitted: 3}); got != "1 unpushed commit, 3 uncommitted files" {
+		t.Errorf("unsavedWorkSummary() = %q", got)
+	}
+	if hasUnsavedWork(container.GitState{Repo: true}) || hasUnsavedWork(container.GitState{Unpushed: 1}) {
+		t.Error("clean containers and ones without a repository have no unsaved work")
+	}
+}
+
+func TestCacheVolumesLabel(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{-1, "Also delete cached volumes"},
+		{0, "Also delete cached volumes (frees ~0 MB)"},
+		{1600000, "Also delete cached volumes (frees ~2 MB)"},
+		{2500000000, "Also delete cached volumes (frees ~2.5 GB)"},
+	}
+	for _, tt := range tests {
+		if got := cacheVolumesLabel(tt.size); got != tt.want {
+			t.Errorf("cacheVolumesLabel(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestRefresh_SkipsWhileLoading(t *testing.T) {
+	m := Model{}
+	if cmd := m.refresh(); cmd == nil || !m.loadInFlight {
+		t.Fatal("refresh should start a load")
+	}
+	if cmd := m.refresh(); cmd != nil {
+		t.Error("refresh should be a no-op while a load is in flight")
+	}
+}
+
+func TestUpdatedAgo(t *testing.T) {
+	now := time.Now()
+	if text, stale := updatedAgo(now.Add(-12*time.Second), now); text != "updated 12s ago" || stale {
+		t.Errorf("updatedAgo(12s) = %q, %v", text, stale)
+	}
+	if text, stale := updatedAgo(now.Add(-staleAfter), now); text != "updated 1m ago" || !stale {
+		t.Errorf("updatedAgo(staleAfter) = %q, %v; want stale", text, stale)
+	}
+}
+
+func TestContainerOperations_PerRow(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{
+		homeView: views.NewHomeModel([]container.Info{
+			{Name: "mcl-a-1", ShortName: "a-1", Status: "running", GitStatus: "+3"},
+			{Name: "mcl-b-1", ShortName: "b-1", Status: "running"},
+		}, false, false),
+		containerOperations: make(pendingOperations),
+		operationSpinner:    spinner.New(spinner.WithSpinner(spinner.Line)),
+	}
+	m.homeView.SetSize(160, 20)
+
+	if cmd := m.startOperation("mcl-a-1", container.OperationRestart); cmd == nil {
+		t.Error("first operation should start the spinner")
+	}
+	if cmd := m.startOperation("mcl-b-1", container.OperationDelete); cmd != nil {
+		t.Error("spinner is already ticking for the second operation")
+	}
+	if got := operationsSummary(m.containerOperations); got != "2 operations running" {
+		t.Errorf("summary = %q", got)
+	}
+	view := m.homeView.View()
+	if !strings.Contains(view, "Restarting") || !strings.Contains(view, "Deleting") || strings.Contains(view, "+3") {
+		t.Errorf("rows should show their operations:\n%s", view)
+	}
+	if _, busy := m.operationPending("mcl-a-1"); !busy {
+		t.Error("a second operation on a busy container should be refused")
+	}
+
+	m.finishOperation("mcl-a-1")
+	if got := operationsSummary(m.containerOperations); got != "Deleting..." {
+		t.Errorf("summary = %q, want the remaining operation", got)
+	}
+	if view := m.homeView.View(); strings.Contains(view, "Restarting") || !strings.Contains(view, "+3") {
+		t.Errorf("finished row should render normally:\n%s", view)
+	}
+}
+
+func TestStatusBar_BuiltInUpdate(t *testing.T) {
+	zone.NewGlobal()
+	m := NewWithCache("maestro-", &CachedState{})
+	m.wizardMode = false
+	var model tea.Model = *m
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// The clock tick alone is enough to put the current time in the bar
+	model, cmd := model.Update(clockTickMsg(time.Now()))
+	if cmd == nil {
+		t.Error("clock tick should schedule the next one")
+	}
+	bar := model.(Model).statusbar.View()
+	if now := time.Now().Format("15:04"); !strings.Contains(bar, now) {
+		t.Errorf("statusbar should show %s after a clock tick:\n%s", now, bar)
+	}
+	if !strings.Contains(bar, "0 containers") {
+		t.Errorf("statusbar should show the container count:\n%s", bar)
+	}
+}
+
+func TestQuickConnectKeys(t *testing.T) {
+	zone.NewGlobal()
+	containers := []container.Info{
+		{Name: "mcl-a-1", ShortName: "a-1", Status: "running"},
+		{Name: "mcl-b-1", ShortName: "b-1", Status: "running"},
+	}
+	m := Model{homeView: views.NewHomeModel(containers, false, false)}
+	m.homeView.SetSize(120, 20)
+	if view := m.homeView.View(); !strings.Contains(view, "1 a-1") || !strings.Contains(view, "2 b-1") {
+		t.Errorf("rows should be numbered:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if cmd == nil {
+		t.Fatal("2 should connect")
+	}
+	if connect, ok := cmd().(views.ConnectRequestMsg); !ok || connect.ContainerName != "mcl-b-1" {
+		t.Errorf("2 should connect to mcl-b-1, got %#v", cmd())
+	}
+
+	// No row 3, and no quick connect while a modal is open
+	_, cmd = m.homeView.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if cmd != nil {
+		t.Error("3 should do nothing with two containers")
+	}
+	m.modal = createContainerCreateModal()
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if cmd != nil {
+		if _, ok := cmd().(views.ConnectRequestMsg); ok {
+			t.Error("1 should not connect while the create form is open")
+		}
+	}
+}
+
+func TestConnectShellKey(t *testing.T) {
+	zone.NewGlobal()
+	containers := []container.Info{{Name: "mcl-a-1", ShortName: "a-1", Status: "running"}}
+	m := Model{homeView: views.NewHomeModel(containers, false, false)}
+	m.homeView.SetSize(120, 20)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if cmd == nil {
+		t.Fatal("S should connect")
+	}
+	msg, ok := cmd().(views.ConnectRequestMsg)
+	if !ok || msg.ContainerName != "mcl-a-1" || msg.Window != "shell" {
+		t.Fatalf("S sent %#v, want a shell connect to mcl-a-1", cmd())
+	}
+
+	result, _ := m.Update(msg)
+	if r := result.(Model).result; r == nil || r.Action != ActionConnect || r.Window != "shell" {
+		t.Errorf("result = %#v, want a connect to the shell window", r)
+	}
+}
+
+func TestConnect_TmuxSessionLost(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{containerPrefix: "mcl-", loadedContainers: []container.Info{
+		{Name: "mcl-a-1", ShortName: "a-1", Status: "running", Task: "add user auth"},
+	}}
+
+	// Running containers get their session checked before the TUI exits
+	result, cmd := m.Update(views.ConnectRequestMsg{ContainerName: "mcl-a-1"})
+	if result.(Model).result != nil || cmd == nil {
+		t.Fatal("connecting to a running container should check its tmux session first")
+	}
+
+	result, _ = m.Update(tmuxSessionMsg{containerName: "mcl-a-1", window: "shell", task: "add user auth", missing: true, reason: "the tmux session has exited"})
+	m = result.(Model)
+	if m.modal == nil || len(m.modal.Actions) != 4 || !strings.Contains(m.modal.Content, "has exited") {
+		t.Fatalf("a lost session should offer to recreate it, got %#v", m.modal)
+	}
+
+	result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if cmd == nil {
+		t.Fatal("t should recreate the session")
+	}
+	m = result.(Model)
+	repair, ok := cmd().(repairTmuxSessionMsg)
+	if !ok || !repair.opts.LaunchClaude || !strings.Contains(repair.opts.Prompt, "add user auth") || repair.window != "shell" {
+		t.Fatalf("t sent %#v, want Claude re-prompted with the task", repair)
+	}
+
+	result, _ = m.Update(tmuxRepairedMsg{containerName: "mcl-a-1", window: "shell"})
+	if r := result.(Model).result; r == nil || r.ContainerName != "mcl-a-1" || r.Window != "shell" {
+		t.Errorf("after the repair the TUI should connect, got %#v", r)
+	}
+
+	// Without a task there is nothing to re-prompt with
+	modal := createTmuxRepairModal(tmuxSessionMsg{containerName: "mcl-a-1", missing: true}, "a-1")
+	if len(modal.Actions) != 3 {
+		t.Errorf("want 3 actions without a task, got %d", len(modal.Actions))
+	}
+}
+
+func TestFirewallLogModal(t *testing.T) {
+	zone.NewGlobal()
+	events := []container.FirewallEvent{
+		{Timestamp: time.Date(2026, 10, 16, 10, 0, 1, 0, time.Local), DestIP: "1.1.1.1", Protocol: "ICMP"},
+		{Timestamp: time.Date(2026, 10, 16, 10, 0, 3, 0, time.Local), DestIP: "140.82.112.3", DestPort: 22, Protocol: "TCP"},
+	}
+	content := firewallLogContent(events)
+	if !strings.Contains(content, "2026-10-16 10:00:03  TCP    140.82.112.3:22") || !strings.Contains(content, "ICMP   1.1.1.1\n") {
+		t.Errorf("unexpected firewall log content:\n%s", content)
+	}
+	if !strings.Contains(firewallLogContent(nil), "No blocked connections") {
+		t.Error("an empty log should say nothing was blocked")
+	}
+
+	m := Model{containerPrefix: "mcl-"}
+	result, _ := m.Update(firewallLogMsg{containerName: "mcl-a-1", events: events})
+	m = result.(Model)
+	if m.modal == nil || !strings.Contains(m.modal.Title, "a-1") {
+		t.Fatalf("want the firewall log modal, got %#v", m.modal)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if cmd == nil {
+		t.Fatal("c should clear the log")
+	}
+	if clear, ok := cmd().(clearFirewallLogMsg); !ok || clear.containerName != "mcl-a-1" {
+		t.Errorf("c sent %#v, want a clear for mcl-a-1", cmd())
+	}
+}
+
+func TestEmptyState(t *testing.T) {
+	zone.NewGlobal()
+	t.Setenv("HOME", t.TempDir())
+	if err := container.RecordProjectDir("/src/webapp"); err != nil {
+		t.Fatal(err)
+	}
+
+	load := func(msg containersLoadedMsg) Model {
+		m := Model{width: 120, height: 30, daemonRunning: true}
+		result, _ := m.Update(msg)
+		return result.(Model)
+	}
+
+	m := load(containersLoadedMsg{dockerResponsive: true, daemonConnected: true})
+	view := m.homeView.View()
+	if !strings.Contains(view, "No containers yet") || !strings.Contains(view, "/src/webapp") {
+		t.Errorf("a fresh install should explain how to start and list recent projects:\n%s", view)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on the empty list should open the create form")
+	}
+	result, _ := m.Update(cmd())
+	if result.(Model).modal == nil || result.(Model).modal.Type != ModalForm {
+		t.Error("enter on the empty list should open the create form")
+	}
+
+	m = load(containersLoadedMsg{dockerResponsive: false, daemonConnected: true})
+	if view := m.homeView.View(); !strings.Contains(view, "Docker is not responding") {
+		t.Errorf("Docker being down should be shown:\n%s", view)
+	}
+
+	denied := fmt.Errorf("%w: exit status 1", container.ErrDockerPermission)
+	m = load(containersLoadedMsg{err: denied, dockerResponsive: false, dockerErr: denied, daemonConnected: true})
+	if view := m.homeView.View(); !strings.Contains(view, "Permission denied connecting to Docker") || !strings.Contains(view, "docker group") {
+		t.Errorf("a permission problem should say how to fix it:\n%s", view)
+	}
+	if got := m.statusbar.View(); !strings.Contains(got, "Docker permission denied") {
+		t.Errorf("the statusbar should name the Docker problem, got %q", got)
+	}
+	unreachable := fmt.Errorf("%w: exit status 1", container.ErrDockerUnreachable)
+	m = load(containersLoadedMsg{dockerResponsive: false, dockerErr: unreachable, daemonConnected: true})
+	if got := m.statusbar.View(); !strings.Contains(got, "Docker unreachable, retrying...") {
+		t.Errorf("a background refresh failure should show in the statusbar, got %q", got)
+	}
+	if m.modal != nil {
+		t.Error("Docker being unreachable should not open a modal")
+	}
+
+	m = load(containersLoadedMsg{err: errors.New("permission denied"), dockerResponsive: true, daemonConnected: true})
+	if view := m.homeView.View(); !strings.Contains(view, "Couldn't load containers") || !strings.Contains(view, "permission denied") {
+		t.Errorf("a load error should be shown:\n%s", view)
+	}
+	if _, cmd := m.homeView.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("enter should not offer to create a container when listing failed")
+	}
+}
+
+func TestFailedRefreshKeepsList(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{width: 120, height: 30, daemonRunning: true}
+	listed := []container.Info{{Name: "mcl-feat-1", ShortName: "feat-1", Status: "running"}}
+	result, _ := m.Update(containersLoadedMsg{containers: listed, dockerResponsive: true, daemonConnected: true})
+	m = result.(Model)
+	loaded := m.lastLoaded
+
+	unreachable := fmt.Errorf("%w: exit status 1", container.ErrDockerUnreachable)
+	result, _ = m.Update(containersLoadedMsg{err: unreachable, dockerResponsive: false, dockerErr: unreachable, daemonConnected: true})
+	m = result.(Model)
+	if got := m.homeView.GetContainers(); len(got) != 1 || got[0].Name != "mcl-feat-1" {
+		t.Errorf("a failed refresh should keep the listed containers, got %v", got)
+	}
+	if m.containerCount != 1 || !m.lastLoaded.Equal(loaded) {
+		t.Error("a failed refresh should leave the counts and last load time alone")
+	}
+	if got := m.statusbar.View(); !strings.Contains(got, "Docker unreachable, retrying...") {
+		t.Errorf("the statusbar should still report Docker, got %q", got)
+	}
+
+	// Docker answering again with no containers empties the list
+	result, _ = m.Update(containersLoadedMsg{dockerResponsive: true, daemonConnected: true})
+	m = result.(Model)
+	if got := m.homeView.GetContainers(); len(got) != 0 {
+		t.Errorf("a successful empty load should clear the list, got %v", got)
+	}
+}
+
+func TestFunctionKeys(t *testing.T) {
+	zone.NewGlobal()
+	m := Model{}
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyF1})
+	if result.(Model).modal == nil {
+		t.Error("F1 should open the help")
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyF3})
+	if result.(Model).modal == nil {
+		t.Error("F3 should open the firewall form")
+	}
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyF10})
+	if res := result.(Model).result; res == nil || res.Action != ActionQuit || cmd == nil {
+		t.Errorf("F10 should quit, got result %+v", res)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyF5}, New("maestro-").keys.Refresh) {
+		t.Error("F5 should be bound to refresh")
+	}
+}
+
+func TestHomeColumns(t *testing.T) {
+	zone.NewGlobal()
+	t.Cleanup(func() { viper.Set("tui.columns", nil) })
+	now := time.Now()
+	containers := []container.Info{
+		{Name: "mcl-a-1", ShortName: "a-1", Status: "running", Branch: "feat/a", CreatedAt: now.Add(-74 * time.Hour), StartedAt: now.Add(-7*time.Hour - time.Minute)},
+		{Name: "mcl-b-1", ShortName: "b-1", Status: "exited", CreatedAt: now.Add(-30 * time.Minute)},
+	}
+
+	// Default columns: created is relative, no uptime
+	view := views.NewHomeModel(containers, false, false).View()
+	if !strings.Contains(view, "3d ago") || !strings.Contains(view, "AUTH") || strings.Contains(view, "UPTIME") {
+		t.Errorf("unexpected default columns:\n%s", view)
+	}
+
+	// Chosen columns in order; name is added, unknown names dropped
+	viper.Set("tui.columns", []string{"uptime", "bogus", "created", "uptime"})
+	view = zone.Scan(views.NewHomeModel(containers, false, false).View())
+	header := strings.Fields(strings.Split(view, "\n")[0])
+	if strings.Join(header, " ") != "NAME UPTIME CREATED" {
+		t.Errorf("header = %v, want NAME UPTIME CREATED", header)
+	}
+	if !strings.Contains(view, "up 7h") || !strings.Contains(view, "30m ago") || strings.Contains(view, "feat/a") {
+		t.Errorf("unexpected rows:\n%s", view)
+	}
+}
+
+func TestQuitConfirm(t *testing.T) {
+	zone.NewGlobal()
+	running := []container.Info{{Name: "maestro-a-1", Status: "running"}, {Name: "maestro-b-1", Status: "exited"}}
+	load := func(confirm bool) Model {
+		m := Model{width: 120, height: 30, daemonRunning: true, confirmQuit: confirm}
+		result, _ := m.Update(containersLoadedMsg{containers: running, dockerResponsive: true, daemonConnected: true})
+		return result.(Model)
+	}
+
+	m := load(true)
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = result.(Model)
+	if cmd != nil || m.modal == nil || !strings.Contains(m.modal.Content, "1 running container") {
+		t.Fatal("q with a container running should ask before quitting")
+	}
+	if labels := [3]string{m.modal.Actions[0].Label, m.modal.Actions[1].Label, m.modal.Actions[2].Label}; labels != [3]string{"Quit", "Stop All & Quit", "Cancel"} {
+		t.Errorf("unexpected actions %v", labels)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	closed := result.(Model)
+	if closed.modal != nil || closed.result != nil {
+		t.Error("Cancel should close the dialog without quitting")
+	}
+
+	result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("Quit should be selectable with y")
+	}
+	result, _ = result.(Model).Update(cmd())
+	if r := result.(Model).result; r == nil || r.Action != ActionQuit {
+		t.Error("Quit should quit")
+	}
+	if names := closed.runningContainerNames(); len(names) != 1 || names[0] != "maestro-a-1" {
+		t.Errorf("Stop All should stop only the running containers, got %v", names)
+	}
+	result, _ = closed.Update(allStoppedMsg{err: errors.New("stop failed")})
+	if r := result.(Model); r.result != nil || r.modal == nil || r.modal.Type != ModalError {
+		t.Error("a failed stop should be reported instead of quitting")
+	}
+
+	for name, m := range map[string]Model{"disabled": load(false), "wizard": func() Model { m := load(true); m.wizardMode = true; return m }()} {
+		result, _ := m.quit()
+		if r := result.(Model).result; r == nil || r.Action != ActionQuit {
+			t.Errorf("%s: should quit without asking", name)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// pendingOperations maps container names to the operation running on them.
+type pendingOperations map[string]container.OperationType
+
+// operationInProgress reports whether any container operation is running.
+func (m Model) operationInProgress() bool {
+	return len(m.containerOperations) > 0
+}
+
+// startOperation records an operation running on a container and marks its
+// row. It returns the spinner tick when it is the first operation, since
+// the spinner keeps ticking itself while any are running.
+func (m *Model) startOperation(containerName string, action container.OperationType) tea.Cmd {
+	first := !m.operationInProgress()
+	m.containerOperations[containerName] = action
+	m.syncPendingRows()
+	if first {
+		return m.operationSpinner.Tick
+	}
+	return nil
+}
+
+// finishOperation clears a container's running operation.
+func (m *Model) finishOperation(containerName string) {
+	delete(m.containerOperations, containerName)
+	m.syncPendingRows()
+}
+
+// operationPending reports whether the container already has an operation
+// running, so a second one isn't started on top of it, with a toast saying
+// so.
+func (m Model) operationPending(containerName string) (tea.Cmd, bool) {
+	op, ok := m.containerOperations[containerName]
+	if !ok {
+		return nil, false
+	}
+	shortName := container.GetShortName(containerName, m.containerPrefix)
+	return m.alert.NewAlertCmd("Info", fmt.Sprintf("Already %s %s", strings.ToLower(op.Progress()), shortName)), true
+}
+
+// syncPendingRows passes the running operations and the spinner's current
+// frame to the home view.
+func (m *Model) syncPendingRows() {
+	if m.homeView != nil {
+		m.homeView.SetPendingOperations(m.containerOperations, m.operationSpinner.View())
+	}
+}
+
+// operationsSummary is the statusbar text while operations run: the
+// operation itself when there is one, otherwise how many there are.
+func operationsSummary(ops pendingOperations) string {
+	if len(ops) == 1 {
+		for _, op := range ops {
+			return op.Progress() + "..."
+		}
+	}
+	return fmt.Sprintf("%d operations running", len(ops))
+}
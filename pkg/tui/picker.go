@@ -0,0 +1,207 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+// pickerMaxRows is how many matches the picker lists at once.
+const pickerMaxRows = 10
+
+// pickerModel is a minimal fuzzy finder over containers, used by
+// 'maestro connect' when no name is given.
+type pickerModel struct {
+	title      string
+	containers []container.Info
+	filter     textinput.Model
+	matches    []int // Indexes into containers, best match first
+	cursor     int   // Selected row in matches
+	chosen     int   // Index of the chosen container, -1 if none
+	done       bool  // Chosen or cancelled
+	width      int
+}
+
+// newPickerModel creates a picker over containers.
+func newPickerModel(title string, containers []container.Info) pickerModel {
+	filter := textinput.New()
+	filter.Prompt = "> "
+	filter.Placeholder = "type to filter"
+	filter.Focus()
+	m := pickerModel{
+		title:      title,
+		containers: containers,
+		filter:     filter,
+		chosen:     -1,
+		width:      80,
+	}
+	m.refilter()
+	return m
+}
+
+// PickContainer shows a fuzzy picker over containers and returns the chosen
+// one. ok is false if the user cancelled.
+func PickContainer(title string, containers []container.Info) (chosen container.Info, ok bool, err error) {
+	final, err := tea.NewProgram(newPickerModel(title, containers)).Run()
+	if err != nil {
+		return container.Info{}, false, fmt.Errorf("container picker failed: %w", err)
+	}
+	m := final.(pickerModel)
+	if m.chosen < 0 {
+		return container.Info{}, false, nil
+	}
+	return m.containers[m.chosen], true, nil
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.done = true
+			return m, tea.Quit
+		case "enter":
+			if len(m.matches) > 0 {
+				m.chosen = m.matches[m.cursor]
+				m.done = true
+				return m, tea.Quit
+			}
+			return m, nil
+		case "up", "ctrl+p", "ctrl+k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n", "ctrl+j":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	query := m.filter.Value()
+	m.filter, cmd = m.filter.Update(msg)
+	if m.filter.Value() != query {
+		m.refilter()
+	}
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	// Once done, clear the picker so only the connect output remains
+	if m.done {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(style.OceanTide).Bold(true).Render(m.title))
+	b.WriteString("\n" + m.filter.View() + "\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(style.SilverMist).Render("  No matching containers") + "\n")
+	}
+	// Scroll so the cursor stays visible
+	start := max(m.cursor-pickerMaxRows+1, 0)
+	end := min(start+pickerMaxRows, len(m.matches))
+	for i := start; i < end; i++ {
+		row := "  " + m.pickerRow(m.containers[m.matches[i]])
+		rowStyle := lipgloss.NewStyle().Foreground(style.GhostWhite)
+		if i == m.cursor {
+			row = style.Glyph("▸ ", "> ") + row[2:]
+			rowStyle = rowStyle.Foreground(style.OceanSurge).Bold(true)
+		}
+		b.WriteString(rowStyle.Render(ansi.Truncate(row, m.width-1, "…")) + "\n")
+	}
+
+	b.WriteString(lipgloss.NewStyle().Foreground(style.SilverMist).Render(
+		fmt.Sprintf("%d/%d • ↑/↓ select • enter connect • esc cancel", len(m.matches), len(m.containers))))
+	return b.String()
+}
+
+// pickerRow formats a container for the picker list.
+func (m pickerModel) pickerRow(c container.Info) string {
+	state := c.AgentState
+	if state == "" {
+		state = c.Status
+	}
+	if container.NeedsAttention(c) {
+		state = style.Glyph("🔔", "!") + " " + state
+	}
+	return fmt.Sprintf("%-30s %-12s %s", c.ShortName, state, c.Branch)
+}
+
+// refilter recomputes the matches for the current query, best first.
+func (m *pickerModel) refilter() {
+	query := m.filter.Value()
+	type scored struct{ idx, score int }
+	var found []scored
+	for i, c := range m.containers {
+		if score, ok := fuzzyScore(query, c.ShortName+" "+c.Branch+" "+c.Project); ok {
+			found = append(found, scored{i, score})
+		}
+	}
+	// Higher scores first; ties keep the containers' order
+	slices.SortStableFunc(found, func(a, b scored) int { return b.score - a.score })
+	m.matches = make([]int, 0, len(found))
+	for _, f := range found {
+		m.matches = append(m.matches, f.idx)
+	}
+	m.cursor = 0
+}
+
+// fuzzyScore reports whether the characters of query appear in order in
+// target, ignoring case and spaces in the query, and scores the match:
+// consecutive characters and matches at the start of a word score higher.
+func fuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(target))
+	score, qi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == prev+1 {
+			score += 3
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 2
+		}
+		prev = ti
+		qi++
+	}
+	return score, qi == len(q)
+}
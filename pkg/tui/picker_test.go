@@ -0,0 +1,73 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("fa", "feat-auth-1"); !ok {
+		t.Error("fa should match feat-auth-1")
+	}
+	if _, ok := fuzzyScore("af", "feat-auth-1"); ok {
+		t.Error("af should not match feat-auth-1 (wrong order)")
+	}
+	if _, ok := fuzzyScore("", "anything"); !ok {
+		t.Error("empty query should match everything")
+	}
+	prefix, _ := fuzzyScore("auth", "auth-fix-1")
+	scattered, _ := fuzzyScore("auth", "a-useful-thing-1")
+	if prefix <= scattered {
+		t.Errorf("contiguous match scored %d, scattered %d; want contiguous higher", prefix, scattered)
+	}
+}
+
+func TestPicker_FilterAndChoose(t *testing.T) {
+	containers := []container.Info{
+		{Name: "mcl-fix-login-1", ShortName: "fix-login-1", Branch: "fix/login"},
+		{Name: "mcl-feat-auth-1", ShortName: "feat-auth-1", Branch: "feat/auth"},
+		{Name: "mcl-docs-1", ShortName: "docs-1", Branch: "docs"},
+	}
+	var model tea.Model = newPickerModel("Connect", containers)
+	for _, r := range "auth" {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m := model.(pickerModel)
+	if len(m.matches) != 1 || m.containers[m.matches[0]].ShortName != "feat-auth-1" {
+		t.Fatalf("matches for auth = %v, want only feat-auth-1", m.matches)
+	}
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(pickerModel)
+	if cmd == nil || m.chosen != 1 {
+		t.Errorf("enter chose %d, want 1 and quit", m.chosen)
+	}
+	if m.View() != "" {
+		t.Error("picker should clear itself once done")
+	}
+}
+
+func TestPicker_Cancel(t *testing.T) {
+	var model tea.Model = newPickerModel("Connect", []container.Info{{Name: "mcl-a-1", ShortName: "a-1"}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m := model.(pickerModel); m.chosen != -1 || !m.done {
+		t.Errorf("esc should cancel, got chosen=%d done=%v", m.chosen, m.done)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/tui/style"
+)
+
+const (
+	// previewLines is how much of Claude's screen the preview pane shows
+	previewLines = 20
+
+	// previewRefreshInterval is how often the preview is recaptured
+	previewRefreshInterval = 3 * time.Second
+)
+
+// previewState holds the Claude screen preview below the container table.
+type previewState struct {
+	enabled   bool
+	container string // Container the content belongs to
+	content   string // Captured pane text
+	message   string // Placeholder shown instead of content
+	gen       int    // Bumped on every toggle so ticks from earlier toggles stop
+}
+
+// previewTickMsg triggers a recapture of the preview.
+type previewTickMsg struct {
+	gen int
+}
+
+// previewCapturedMsg carries a capture of a container's Claude window.
+type previewCapturedMsg struct {
+	container string
+	content   string
+	err       error
+}
+
+// previewTick schedules the next preview refresh.
+func previewTick(gen int) tea.Cmd {
+	return tea.Tick(previewRefreshInterval, func(time.Time) tea.Msg {
+		return previewTickMsg{gen: gen}
+	})
+}
+
+// capturePreview captures the container's Claude window in the background.
+func capturePreview(containerName string) tea.Cmd {
+	return func() tea.Msg {
+		content, err := container.CaptureClaudePane(containerName, previewLines)
+		return previewCapturedMsg{container: containerName, content: content, err: err}
+	}
+}
+
+// previewHeight returns the lines the preview pane takes from available, the
+// space between the title banner and the help bar: a header plus up to
+// previewLines of content, never more than half the space.
+func previewHeight(available int) int {
+	return max(min(previewLines+1, available/2), 0)
+}
+
+// homeHeight returns the height for the home view: the terminal minus the
+// title banner (6), help (1), blank line (1) and statusbar (1), minus the
+// preview pane when it is shown.
+func (m Model) homeHeight() int {
+	available := m.height - 9
+	if m.preview.enabled {
+		available -= previewHeight(m.height - 9)
+	}
+	return available
+}
+
+// selectedContainer returns the container under the home view cursor.
+func (m Model) selectedContainer() (container.Info, bool) {
+	if m.homeView == nil {
+		return container.Info{}, false
+	}
+	containers := m.homeView.GetContainers()
+	cursor := m.homeView.GetCursor()
+	if cursor < 0 || cursor >= len(containers) {
+		return container.Info{}, false
+	}
+	return containers[cursor], true
+}
+
+// togglePreview shows or hides the preview pane, resizing the home view to
+// make room.
+func (m *Model) togglePreview() tea.Cmd {
+	m.preview.enabled = !m.preview.enabled
+	m.preview.gen++
+	m.preview.container = ""
+	if m.homeView != nil && m.width > 0 {
+		m.homeView.SetSize(m.width, m.homeHeight())
+	}
+	if !m.preview.enabled {
+		return nil
+	}
+	return tea.Batch(m.refreshPreview(), previewTick(m.preview.gen))
+}
+
+// refreshPreview recaptures the selected container, clearing the previous
+// capture first when the selection has changed. Stopped containers are not
+// captured.
+func (m *Model) refreshPreview() tea.Cmd {
+	selected, ok := m.selectedContainer()
+	if !ok {
+		m.preview.container = ""
+		m.preview.content = ""
+		m.preview.message = "No container selected"
+		return nil
+	}
+	if selected.Name != m.preview.container {
+		m.preview.container = selected.Name
+		m.preview.content = ""
+		m.preview.message = "Capturing..."
+	}
+	if selected.Status != "running" {
+		m.preview.content = ""
+		m.preview.message = "Container is not running"
+		return nil
+	}
+	return capturePreview(selected.Name)
+}
+
+// setPreviewCapture records a capture if it is for the container shown.
+func (m *Model) setPreviewCapture(msg previewCapturedMsg) {
+	if msg.container != m.preview.container {
+		return
+	}
+	switch {
+	case msg.err != nil:
+		m.preview.content = ""
+		m.preview.message = "Preview unavailable: " + msg.err.Error()
+	case msg.content == "":
+		m.preview.content = ""
+		m.preview.message = "Claude's screen is empty"
+	default:
+		m.preview.content = msg.content
+	}
+}
+
+// previewSelectionChanged reports whether the preview shows a container other
+// than the selected one.
+func (m Model) previewSelectionChanged() bool {
+	if !m.preview.enabled {
+		return false
+	}
+	selected, _ := m.selectedContainer()
+	return selected.Name != m.preview.container
+}
+
+// renderPreview draws the preview pane at exactly width x height.
+func (m Model) renderPreview(width, height int) string {
+	if height <= 0 {
+		return ""
+	}
+	name := "no container"
+	if selected, ok := m.selectedContainer(); ok {
+		name = selected.ShortName
+	}
+	title := " Claude: " + name + " "
+	rule := style.Glyph("─", "-")
+	header := rule + rule + title
+	if fill := width - lipgloss.Width(header); fill > 0 {
+		header += strings.Repeat(rule, fill)
+	}
+	lines := []string{lipgloss.NewStyle().Foreground(style.OceanTide).Render(ansi.Truncate(header, width, ""))}
+
+	body := height - 1
+	if m.preview.content == "" {
+		placeholder := lipgloss.NewStyle().Foreground(style.SilverMist).Render(ansi.Truncate(m.preview.message, width, "…"))
+		lines = append(lines, lipgloss.Place(width, body, lipgloss.Center, lipgloss.Center, placeholder))
+		return strings.Join(lines, "\n")
+	}
+
+	content := strings.Split(m.preview.content, "\n")
+	if len(content) > body {
+		content = content[len(content)-body:]
+	}
+	for _, line := range content {
+		lines = append(lines, ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), width, ""))
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
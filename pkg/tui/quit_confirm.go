@@ -0,0 +1,92 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// quitMsg quits the TUI once the quit confirmation is accepted.
+type quitMsg struct{}
+
+// stopAllAndQuitMsg stops the running containers, then quits.
+type stopAllAndQuitMsg struct{}
+
+// allStoppedMsg reports the outcome of stopping the running containers
+// before quitting.
+type allStoppedMsg struct {
+	err error
+}
+
+// quit exits the TUI, first asking for confirmation while containers are
+// running unless tui.confirm_quit or --no-confirm-quit turned that off. The
+// wizard always quits straight away.
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.confirmQuit && !m.wizardMode && m.runningCount > 0 {
+		m.modal = createQuitConfirmModal(m.runningCount)
+		return m, nil
+	}
+	m.result = &TUIResult{Action: ActionQuit}
+	return m, tea.Quit
+}
+
+// createQuitConfirmModal reminds the user that running containers keep
+// running after the TUI exits, and offers to stop them first.
+func createQuitConfirmModal(running int) *Modal {
+	content := fmt.Sprintf("There are %d running containers. Quit Maestro?\n\n(containers will continue running)", running)
+	if running == 1 {
+		content = "There is 1 running container. Quit Maestro?\n\n(it will continue running)"
+	}
+	return &Modal{
+		Type:    ModalConfirm,
+		Title:   "Quit Maestro",
+		Content: content,
+		Width:   60,
+		Actions: []ModalAction{
+			{Label: "Quit", Key: "y", IsPrimary: true, OnSelect: func() tea.Msg { return quitMsg{} }},
+			{Label: "Stop All & Quit", Key: "s", OnSelect: func() tea.Msg { return stopAllAndQuitMsg{} }},
+			{Label: "Cancel", Key: "esc"},
+		},
+	}
+}
+
+// runningContainerNames returns the names of the running containers listed
+// on the home view.
+func (m Model) runningContainerNames() []string {
+	if m.homeView == nil {
+		return nil
+	}
+	var names []string
+	for _, c := range m.homeView.GetContainers() {
+		if c.Status == "running" {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// stopAllContainers stops the containers in the background.
+func stopAllContainers(names []string) tea.Cmd {
+	return func() tea.Msg {
+		errs := container.BulkStop(context.Background(), names, container.DefaultBulkConcurrency)
+		return allStoppedMsg{err: errors.Join(errs...)}
+	}
+}
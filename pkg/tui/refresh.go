@@ -0,0 +1,52 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+const (
+	// refreshInterval is how often the container list reloads in the
+	// background
+	refreshInterval = 30 * time.Second
+
+	// staleAfter is when the statusbar flags the list as out of date: two
+	// background refreshes have been missed
+	staleAfter = 2 * refreshInterval
+)
+
+// refresh reloads the container list unless a load is already running, so
+// holding 'r' or a refresh tick during a slow load doesn't stack them up.
+func (m *Model) refresh() tea.Cmd {
+	if m.loading || m.loadInFlight {
+		return nil
+	}
+	m.loadInFlight = true
+	m.operationStatus = "Syncing..."
+	return m.loadContainers()
+}
+
+// updatedAgo describes how long ago the container list was loaded for the
+// statusbar, and whether that is long enough to flag it as stale.
+func updatedAgo(lastLoaded, now time.Time) (string, bool) {
+	age := now.Sub(lastLoaded).Truncate(time.Second)
+	return fmt.Sprintf("updated %s ago", container.FormatDuration(age)), age >= staleAfter
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// DefaultCacheTTL is how old a saved container list may be and still be shown
+// at startup when tui.cache_ttl is not set.
+const DefaultCacheTTL = 24 * time.Hour
+
+// stateCacheVersion is bumped whenever the saved state changes shape; files
+// with another version are ignored.
+const stateCacheVersion = 1
+
+// savedState is the CachedState written to StateCacheFile between runs.
+type savedState struct {
+	Version    int              `json:"version"`
+	LoadedAt   time.Time        `json:"loaded_at"` // When the containers were listed
+	Prefix     string           `json:"prefix"`    // Containers were listed for this prefix
+	Containers []container.Info `json:"containers"`
+	CursorPos  int              `json:"cursor_pos"`
+	HomeOrder  string           `json:"home_order"` // tui.home_order the cursor position refers to
+}
+
+// StateCacheFile is where the TUI saves its state on exit.
+func StateCacheFile() string {
+	return filepath.Join(paths.CacheDir(), "tui.json")
+}
+
+// cacheTTL returns tui.cache_ttl, DefaultCacheTTL if it is unset or invalid.
+// Zero disables the cache.
+func cacheTTL() time.Duration {
+	ttl := viper.GetString("tui.cache_ttl")
+	if ttl == "" {
+		return DefaultCacheTTL
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil || d < 0 {
+		return DefaultCacheTTL
+	}
+	return d
+}
+
+// saveStateCache writes state to path for the next run.
+func saveStateCache(path, prefix string, state *CachedState) error {
+	if state == nil || len(state.Containers) == 0 || state.LoadedAt.IsZero() {
+		return nil
+	}
+	data, err := json.Marshal(savedState{
+		Version:    stateCacheVersion,
+		LoadedAt:   state.LoadedAt.UTC(),
+		Prefix:     prefix,
+		Containers: state.Containers,
+		CursorPos:  state.CursorPos,
+		HomeOrder:  viper.GetString("tui.home_order"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode TUI state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadStateCache reads the state saved at path, or returns nil when there is
+// none, it is older than ttl, was saved for another prefix or can't be read.
+// A file from another version of maestro is ignored rather than trusted.
+func loadStateCache(path, prefix string, ttl time.Duration, now time.Time) *CachedState {
+	if ttl <= 0 {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var saved savedState
+	if err := json.Unmarshal(data, &saved); err != nil || saved.Version != stateCacheVersion {
+		return nil
+	}
+	if saved.Prefix != prefix || len(saved.Containers) == 0 || now.Sub(saved.LoadedAt) > ttl {
+		return nil
+	}
+
+	state := &CachedState{Containers: saved.Containers, CursorPos: saved.CursorPos, LoadedAt: saved.LoadedAt}
+	// The list is reordered on load; a cursor into another order points elsewhere
+	if saved.HomeOrder != viper.GetString("tui.home_order") {
+		state.CursorPos = 0
+	}
+	return state
+}
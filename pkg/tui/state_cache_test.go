@@ -0,0 +1,92 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestStateCache_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "tui.json")
+	loaded := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	state := &CachedState{
+		Containers: []container.Info{{Name: "mcl-a"}, {Name: "mcl-b"}},
+		CursorPos:  1,
+		LoadedAt:   loaded,
+	}
+	if err := saveStateCache(path, "mcl-", state); err != nil {
+		t.Fatalf("saveStateCache: %v", err)
+	}
+
+	got := loadStateCache(path, "mcl-", time.Hour, loaded.Add(time.Minute))
+	if got == nil {
+		t.Fatal("expected cached state")
+	}
+	if len(got.Containers) != 2 || got.Containers[1].Name != "mcl-b" {
+		t.Errorf("containers = %+v", got.Containers)
+	}
+	if got.CursorPos != 1 {
+		t.Errorf("CursorPos = %d, want 1", got.CursorPos)
+	}
+	if !got.LoadedAt.Equal(loaded) {
+		t.Errorf("LoadedAt = %v, want %v", got.LoadedAt, loaded)
+	}
+
+	if loadStateCache(path, "mcl-", time.Hour, loaded.Add(2*time.Hour)) != nil {
+		t.Error("expected cache older than the TTL to be ignored")
+	}
+	if loadStateCache(path, "mcl-", 0, loaded) != nil {
+		t.Error("expected a zero TTL to disable the cache")
+	}
+	if loadStateCache(path, "other-", time.Hour, loaded) != nil {
+		t.Error("expected cache for another prefix to be ignored")
+	}
+}
+
+func TestStateCache_SkipsUnloadedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tui.json")
+	if err := saveStateCache(path, "mcl-", &CachedState{Containers: []container.Info{{Name: "mcl-a"}}}); err != nil {
+		t.Fatalf("saveStateCache: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no file for a list that was never loaded, got %v", err)
+	}
+}
+
+func TestStateCache_FailsOpen(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for name, content := range map[string]string{
+		"corrupt":     "{not json",
+		"old version": `{"version":0,"prefix":"mcl-","loaded_at":"` + now.Format(time.RFC3339) + `","containers":[{"Name":"mcl-a"}]}`,
+		"wrong shape": `{"version":1,"prefix":"mcl-","containers":"mcl-a"}`,
+	} {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if got := loadStateCache(path, "mcl-", time.Hour, now); got != nil {
+			t.Errorf("%s: expected nil, got %+v", name, got)
+		}
+	}
+	if loadStateCache(filepath.Join(dir, "missing.json"), "mcl-", time.Hour, now) != nil {
+		t.Error("expected nil for a missing file")
+	}
+}
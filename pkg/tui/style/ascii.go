@@ -0,0 +1,89 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package style
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/viper"
+)
+
+// IsASCIIMode reports whether tui.ascii_fallback is set, in which case the
+// TUI avoids Unicode art and glyphs that terminals without full Unicode
+// support (some SSH clients, Windows cmd) render as garbage.
+func IsASCIIMode() bool {
+	return viper.GetBool("tui.ascii_fallback")
+}
+
+// Glyph returns unicode, or ascii in ASCII mode.
+func Glyph(unicode, ascii string) string {
+	if IsASCIIMode() {
+		return ascii
+	}
+	return unicode
+}
+
+// Check, Warning and Cross mark passes, warnings and failures in CLI and TUI
+// output.
+func Check() string   { return Glyph("✓", "OK") }
+func Warning() string { return Glyph("⚠", "WARN") }
+func Cross() string   { return Glyph("✗", "FAIL") }
+
+// asciiReplacer maps the glyphs that reach the TUI inside data, like a
+// container's auth status, to their ASCII replacements.
+var asciiReplacer = strings.NewReplacer(
+	"✓", "OK",
+	"⚠️", "WARN",
+	"⚠", "WARN",
+	"✗", "FAIL",
+	"Δ", "*",
+	"↑", "^",
+	"↓", "v",
+)
+
+// ASCII replaces marks in s with ASCII in ASCII mode.
+func ASCII(s string) string {
+	if IsASCIIMode() {
+		return asciiReplacer.Replace(s)
+	}
+	return s
+}
+
+// ColorEnabled reports whether output is colored. tui.color "always" and
+// "never" decide outright; "auto", the default, colors unless NO_COLOR is
+// set (https://no-color.org).
+func ColorEnabled() bool {
+	switch viper.GetString("tui.color") {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return os.Getenv("NO_COLOR") == ""
+}
+
+// ApplyColorMode makes lipgloss render without color when ColorEnabled is
+// false, and with color even when stdout isn't a terminal for "always".
+func ApplyColorMode() {
+	switch {
+	case !ColorEnabled():
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case viper.GetString("tui.color") == "always" && lipgloss.ColorProfile() == termenv.Ascii:
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+}
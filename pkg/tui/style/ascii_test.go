@@ -0,0 +1,55 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package style
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestColorEnabled(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	tests := []struct {
+		color, noColor string
+		want           bool
+	}{
+		{"", "", true},
+		{"auto", "1", false},
+		{"always", "1", true},
+		{"never", "", false},
+	}
+	for _, tt := range tests {
+		viper.Set("tui.color", tt.color)
+		t.Setenv("NO_COLOR", tt.noColor)
+		if got := ColorEnabled(); got != tt.want {
+			t.Errorf("tui.color=%q NO_COLOR=%q: ColorEnabled() = %v, want %v", tt.color, tt.noColor, got, tt.want)
+		}
+	}
+}
+
+func TestASCII(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	if got := ASCII("✓ 5.0h"); got != "✓ 5.0h" {
+		t.Errorf("Unicode mode should keep glyphs, got %q", got)
+	}
+	viper.Set("tui.ascii_fallback", true)
+	if got := ASCII("⚠ 0.5h"); got != "WARN 0.5h" {
+		t.Errorf("ASCII(%q) = %q", "⚠ 0.5h", got)
+	}
+	if Check() != "OK" || Cross() != "FAIL" {
+		t.Errorf("marks should be ASCII, got %q %q", Check(), Cross())
+	}
+}
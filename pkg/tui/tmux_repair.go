@@ -0,0 +1,105 @@
+// Copyright 2026 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// tmuxSessionMsg reports whether a container about to be connected to
+// still has its tmux session.
+type tmuxSessionMsg struct {
+	containerName string
+	window        string
+	task          string // Task description, offered as a re-prompt when recreating
+	missing       bool
+	reason        string // Why the session is gone, for the user
+}
+
+// repairTmuxSessionMsg asks for a lost tmux session to be recreated before
+// connecting.
+type repairTmuxSessionMsg struct {
+	containerName string
+	window        string
+	opts          container.TmuxSessionOptions
+}
+
+// tmuxRepairedMsg reports the outcome of recreating a tmux session.
+type tmuxRepairedMsg struct {
+	containerName string
+	window        string
+	err           error
+}
+
+// checkTmuxSession checks a running container's tmux session in the
+// background before connecting, so a dead session can be recreated from the
+// TUI instead of failing after it exits. Containers without tmux always pass.
+func checkTmuxSession(containerName, window, task string) tea.Cmd {
+	return func() tea.Msg {
+		msg := tmuxSessionMsg{containerName: containerName, window: window, task: task}
+		if container.UsesTmux(containerName) {
+			exists, reason := container.CheckTmuxSession(containerName)
+			msg.missing, msg.reason = !exists, reason
+		}
+		return msg
+	}
+}
+
+// repairTmuxSession recreates a container's tmux session in the background.
+func repairTmuxSession(msg repairTmuxSessionMsg) tea.Cmd {
+	return func() tea.Msg {
+		err := container.CreateTmuxSession(msg.containerName, msg.opts)
+		return tmuxRepairedMsg{containerName: msg.containerName, window: msg.window, err: err}
+	}
+}
+
+// createTmuxRepairModal explains that a container's tmux session is gone and
+// offers to recreate it with Claude, with Claude re-prompted with the task
+// when there is one, or with a shell only.
+func createTmuxRepairModal(msg tmuxSessionMsg, shortName string) *Modal {
+	repair := func(opts container.TmuxSessionOptions) func() tea.Msg {
+		return func() tea.Msg {
+			return repairTmuxSessionMsg{containerName: msg.containerName, window: msg.window, opts: opts}
+		}
+	}
+
+	content := fmt.Sprintf("Cannot attach to %s: %s.\n\nThe container's files and git state are intact. Recreate the session to connect?", shortName, msg.reason)
+	actions := []ModalAction{
+		{Label: "Relaunch Claude", Key: "c", IsPrimary: true, OnSelect: repair(container.TmuxSessionOptions{LaunchClaude: true})},
+	}
+	if msg.task != "" {
+		actions = append(actions, ModalAction{
+			Label:    "Relaunch with task",
+			Key:      "t",
+			OnSelect: repair(container.TmuxSessionOptions{LaunchClaude: true, Prompt: container.ResumePrompt(msg.task)}),
+		})
+	}
+	actions = append(actions,
+		ModalAction{Label: "Shell only", Key: "s", OnSelect: repair(container.TmuxSessionOptions{})},
+		ModalAction{Label: "Cancel", Key: "esc"},
+	)
+
+	return &Modal{
+		Type:    ModalConfirm,
+		Title:   "tmux Session Lost",
+		Content: content,
+		Width:   70,
+		Actions: actions,
+	}
+}
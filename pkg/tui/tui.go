@@ -15,25 +15,73 @@
 package tui
 
 import (
+	"io"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/spf13/viper"
 
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/logging"
 )
 
 // CachedState holds TUI state for seamless return
 type CachedState struct {
 	Containers []container.Info
 	CursorPos  int
+	LoadedAt   time.Time // When Containers were listed; older lists show as stale
+
+	// SelectedContainerName pre-selects a container (full or short name) on
+	// the first load, overriding CursorPos. Not carried across runs.
+	SelectedContainerName string
+}
+
+// RunOptions are maintainer switches for a TUI run.
+type RunOptions struct {
+	// Benchmark receives render timings when set (maestro --benchmark)
+	Benchmark io.Writer
+
+	// CurrentImages are the images new containers use; containers on any
+	// other image are marked outdated
+	CurrentImages []string
+
+	// StartDaemon and InstallDaemon back the wizard's daemon options;
+	// InstallDaemon is nil where login services aren't supported
+	StartDaemon   func() error
+	InstallDaemon func() error
+
+	// NoConfirmQuit quits without asking while containers are running
+	// (maestro --no-confirm-quit)
+	NoConfirmQuit bool
 }
 
 // Run launches the TUI and returns the result and final state
 // Pass cached state from previous run for instant rendering
-func Run(containerPrefix string, cachedState *CachedState) (*TUIResult, *CachedState, error) {
+func Run(containerPrefix string, cachedState *CachedState, opts RunOptions) (*TUIResult, *CachedState, error) {
 	// Initialize bubblezone for mouse click tracking
 	zone.NewGlobal()
 
-	model := NewWithCache(containerPrefix, cachedState)
+	// On a fresh start, show the list saved by the last run while the real
+	// one loads
+	if cachedState == nil || len(cachedState.Containers) == 0 {
+		if saved := loadStateCache(StateCacheFile(), containerPrefix, cacheTTL(), time.Now()); saved != nil {
+			if cachedState != nil {
+				saved.SelectedContainerName = cachedState.SelectedContainerName
+			}
+			cachedState = saved
+		}
+	}
+
+	m := NewWithCache(containerPrefix, cachedState)
+	m.currentImages = opts.CurrentImages
+	m.startDaemon = opts.StartDaemon
+	m.installDaemon = opts.InstallDaemon
+	m.confirmQuit = viper.GetBool("tui.confirm_quit") && !opts.NoConfirmQuit
+	var model tea.Model = m
+	if opts.Benchmark != nil {
+		model = newBenchmarkModel(model, opts.Benchmark)
+	}
 
 	// tea.WithAltScreen() enables fullscreen mode
 	// tea.WithMouseCellMotion() enables mouse support for clicks, wheel, drag
@@ -43,10 +91,17 @@ func Run(containerPrefix string, cachedState *CachedState) (*TUIResult, *CachedS
 	if err != nil {
 		return nil, nil, err
 	}
+	if b, ok := finalModel.(benchmarkModel); ok {
+		finalModel = b.Model
+	}
 
 	// Extract result and state from final model
 	if m, ok := finalModel.(Model); ok {
-		return m.GetResult(), m.GetState(), nil
+		state := m.GetState()
+		if err := saveStateCache(StateCacheFile(), containerPrefix, state); err != nil {
+			logging.Debugf("Failed to save TUI state: %v", err)
+		}
+		return m.GetResult(), state, nil
 	}
 
 	return &TUIResult{Action: ActionQuit}, nil, nil
@@ -15,46 +15,94 @@
 package views
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/spf13/viper"
 
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/tui/style"
 )
 
-// Column configuration for dynamic sizing
-type columnConfig struct {
+// homeColumn is one column of the container table
+type homeColumn struct {
+	key      string // Name used in tui.columns
 	title    string
-	baseSize int // Base width used to calculate proportions
-	minSize  int // Minimum width for this column
+	baseSize int  // Base width used to calculate proportions
+	minSize  int  // Minimum width for this column
+	expand   bool // Gets a share of any spare width
+	pending  bool // Held as a placeholder while an operation runs
+	render   func(h *HomeModel, c container.Info) string
+}
+
+// homeColumns are all the columns tui.columns can choose from
+var homeColumns = []homeColumn{
+	{key: "name", title: "NAME", baseSize: 25, minSize: 15, expand: true, render: (*HomeModel).formatName},
+	{key: "status", title: "STATUS", baseSize: 14, minSize: 12, render: (*HomeModel).formatStatus},
+	{key: "branch", title: "BRANCH", baseSize: 25, minSize: 15, expand: true, render: (*HomeModel).formatBranch},
+	{key: "task", title: "TASK", baseSize: 30, minSize: 20, pending: true, render: (*HomeModel).formatTask},
+	{key: "git", title: "GIT", baseSize: 12, minSize: 11, pending: true, render: (*HomeModel).formatGit},
+	{key: "auth", title: "AUTH", baseSize: 12, minSize: 10, pending: true, render: (*HomeModel).formatAuth},
+	{key: "activity", title: "ACTIVITY", baseSize: 10, minSize: 8, render: (*HomeModel).formatActivity},
+	{key: "uptime", title: "UPTIME", baseSize: 10, minSize: 8, render: (*HomeModel).formatUptime},
+	{key: "created", title: "CREATED", baseSize: 12, minSize: 10, render: (*HomeModel).formatCreated},
+}
+
+// DefaultColumns are the columns shown when tui.columns is not set
+var DefaultColumns = []string{"name", "status", "branch", "task", "git", "auth", "created"}
+
+// ColumnNames returns the names tui.columns accepts
+func ColumnNames() []string {
+	names := make([]string, len(homeColumns))
+	for i, col := range homeColumns {
+		names[i] = col.key
+	}
+	return names
 }
 
 // Maximum table width before centering kicks in
 const maxTableWidth = 160
 
-// getColumnConfigs returns column definitions based on whether AWS auth is used
-func getColumnConfigs(useAWSAuth bool) []columnConfig {
-	configs := []columnConfig{
-		{title: "NAME", baseSize: 25, minSize: 15},
-		{title: "STATUS", baseSize: 14, minSize: 12},
-		{title: "BRANCH", baseSize: 25, minSize: 15},
-		{title: "TASK", baseSize: 30, minSize: 20},
-		{title: "GIT", baseSize: 10, minSize: 8},
+// selectColumns returns the columns named in keys, in that order, ignoring
+// unknown names and repeats. NAME is always shown, first if not listed, and
+// AUTH is left out when AWS/Bedrock auth is used.
+func selectColumns(keys []string, useAWSAuth bool) []homeColumn {
+	if len(keys) == 0 {
+		keys = DefaultColumns
 	}
-	// Only show AUTH column when not using AWS/Bedrock auth
-	if !useAWSAuth {
-		configs = append(configs, columnConfig{title: "AUTH", baseSize: 12, minSize: 10})
+	var cols []homeColumn
+	seen := map[string]bool{}
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if seen[key] || (key == "auth" && useAWSAuth) {
+			continue
+		}
+		for _, col := range homeColumns {
+			if col.key == key {
+				cols = append(cols, col)
+				seen[key] = true
+				break
+			}
+		}
 	}
-	configs = append(configs, columnConfig{title: "CREATED", baseSize: 12, minSize: 10})
-	return configs
+	if !seen["name"] {
+		cols = append([]homeColumn{homeColumns[0]}, cols...)
+	}
+	return cols
 }
 
-// getTotalBaseWidth calculates total base width for the given column configs
-func getTotalBaseWidth(configs []columnConfig) int {
+// getTotalBaseWidth calculates total base width for the given columns
+func getTotalBaseWidth(cols []homeColumn) int {
 	total := 0
-	for _, c := range configs {
+	for _, c := range cols {
 		total += c.baseSize
 	}
 	return total
@@ -69,12 +117,27 @@ type HomeModel struct {
 	containers    []container.Info
 	daemonRunning bool
 	useAWSAuth    bool // Whether AWS/Bedrock auth is being used (hides AUTH column)
+	columns       []homeColumn
+
+	pendingOps   map[string]container.OperationType // Operations running per container name
+	pendingFrame string                             // Current frame of the operation spinner
+
+	empty EmptyState // What to show instead of the table when there are no containers
+}
+
+// EmptyState explains an empty container list: Docker not answering, the
+// list failing to load, or simply no containers yet.
+type EmptyState struct {
+	DockerDown bool
+	DockerErr  error    // Why Docker isn't responding, when known
+	OpenDocker bool     // Offer o to start Docker Desktop
+	LoadError  string   // Why the containers could not be listed ("" if they were)
+	RecentDirs []string // Project directories containers were recently created from
 }
 
 // calculateColumnWidths returns column widths scaled to fit the given width
-func calculateColumnWidths(availableWidth int, useAWSAuth bool) []table.Column {
-	columnConfigs := getColumnConfigs(useAWSAuth)
-	totalBaseWidth := getTotalBaseWidth(columnConfigs)
+func calculateColumnWidths(availableWidth int, cols []homeColumn) []table.Column {
+	totalBaseWidth := getTotalBaseWidth(cols)
 
 	// Account for table borders and padding (roughly 4 chars for borders + spacing)
 	usableWidth := availableWidth - 4
@@ -82,11 +145,12 @@ func calculateColumnWidths(availableWidth int, useAWSAuth bool) []table.Column {
 		usableWidth = totalBaseWidth
 	}
 
-	columns := make([]table.Column, len(columnConfigs))
+	columns := make([]table.Column, len(cols))
 	remainingWidth := usableWidth
+	var expandableIndices []int
 
 	// First pass: calculate proportional widths, respecting minimums
-	for i, cfg := range columnConfigs {
+	for i, cfg := range cols {
 		// Calculate proportional width
 		proportionalWidth := (cfg.baseSize * usableWidth) / totalBaseWidth
 
@@ -100,11 +164,13 @@ func calculateColumnWidths(availableWidth int, useAWSAuth bool) []table.Column {
 			Width: proportionalWidth,
 		}
 		remainingWidth -= proportionalWidth
+		if cfg.expand {
+			expandableIndices = append(expandableIndices, i)
+		}
 	}
 
 	// Distribute any remaining width to the expandable columns (NAME and BRANCH)
-	if remainingWidth > 0 {
-		expandableIndices := []int{0, 2} // NAME and BRANCH
+	if remainingWidth > 0 && len(expandableIndices) > 0 {
 		extraPerColumn := remainingWidth / len(expandableIndices)
 		for _, idx := range expandableIndices {
 			columns[idx].Width += extraPerColumn
@@ -116,11 +182,10 @@ func calculateColumnWidths(availableWidth int, useAWSAuth bool) []table.Column {
 
 // NewHomeModel creates a new home view
 func NewHomeModel(containers []container.Info, daemonRunning bool, useAWSAuth bool) *HomeModel {
-	columnConfigs := getColumnConfigs(useAWSAuth)
-	totalBaseWidth := getTotalBaseWidth(columnConfigs)
+	cols := selectColumns(viper.GetStringSlice("tui.columns"), useAWSAuth)
 
 	// Start with base column widths
-	columns := calculateColumnWidths(totalBaseWidth, useAWSAuth)
+	columns := calculateColumnWidths(getTotalBaseWidth(cols), cols)
 
 	t := table.New(
 		table.WithColumns(columns),
@@ -149,6 +214,7 @@ func NewHomeModel(containers []container.Info, daemonRunning bool, useAWSAuth bo
 		containers:    containers,
 		daemonRunning: daemonRunning,
 		useAWSAuth:    useAWSAuth,
+		columns:       cols,
 	}
 
 	h.updateTableRows()
@@ -202,6 +268,10 @@ func (h *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q", "ctrl+c":
 			return h, tea.Quit
 		case "enter":
+			// With nothing to connect to, Enter starts a new container
+			if len(h.containers) == 0 && !h.empty.DockerDown && h.empty.LoadError == "" {
+				return h, func() tea.Msg { return CreateRequestMsg{} }
+			}
 			// Get selected container
 			if len(h.containers) > 0 {
 				selectedIdx := h.table.Cursor()
@@ -214,6 +284,18 @@ func (h *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return h, nil
+		case "S":
+			// Connect straight to the selected container's shell window
+			if len(h.containers) > 0 {
+				selectedIdx := h.table.Cursor()
+				if selectedIdx >= 0 && selectedIdx < len(h.containers) {
+					selected := h.containers[selectedIdx]
+					return h, func() tea.Msg {
+						return ConnectRequestMsg{ContainerName: selected.Name, Window: "shell"}
+					}
+				}
+			}
+			return h, nil
 		case "a":
 			// Show actions menu for selected container
 			if len(h.containers) > 0 {
@@ -226,6 +308,32 @@ func (h *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return h, nil
+		case "o":
+			// With Docker down, o starts Docker Desktop where that's offered
+			if len(h.containers) == 0 && h.empty.OpenDocker {
+				return h, func() tea.Msg { return OpenDockerRequestMsg{} }
+			}
+			// Open the selected container's web server in a browser
+			if len(h.containers) > 0 {
+				selectedIdx := h.table.Cursor()
+				if selectedIdx >= 0 && selectedIdx < len(h.containers) {
+					selected := h.containers[selectedIdx]
+					return h, func() tea.Msg {
+						return OpenInBrowserMsg{ContainerName: selected.Name}
+					}
+				}
+			}
+			return h, nil
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			// Connect straight to the container in the numbered row
+			idx := int(msg.String()[0] - '1')
+			if idx < len(h.containers) {
+				selected := h.containers[idx]
+				return h, func() tea.Msg {
+					return ConnectRequestMsg{ContainerName: selected.Name}
+				}
+			}
+			return h, nil
 		case "up", "k":
 			h.table, cmd = h.table.Update(msg)
 			return h, cmd
@@ -239,9 +347,16 @@ func (h *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return h, cmd
 }
 
+// CreateRequestMsg signals that the user wants to create a container
+type CreateRequestMsg struct{}
+
+// OpenDockerRequestMsg signals that the user wants to start Docker Desktop
+type OpenDockerRequestMsg struct{}
+
 // ConnectRequestMsg signals that the user wants to connect to a container
 type ConnectRequestMsg struct {
 	ContainerName string
+	Window        string // tmux window to open ("shell"), or "" for the default
 }
 
 // ShowActionsMenuMsg signals to show the actions menu for a container
@@ -249,10 +364,25 @@ type ShowActionsMenuMsg struct {
 	Container container.Info
 }
 
+// OpenInBrowserMsg signals that the user wants to open a container's web
+// server in the browser
+type OpenInBrowserMsg struct {
+	ContainerName string
+}
+
 // View renders the home view
 func (h *HomeModel) View() string {
+	if len(h.containers) == 0 {
+		return lipgloss.Place(h.width, h.height, lipgloss.Center, lipgloss.Center, h.emptyView())
+	}
+
 	// Container table - mark for mouse detection
-	tableView := zone.Mark("container-table", h.table.View())
+	tableView := zone.Mark("container-table", h.colorGitColumn(h.table.View()))
+
+	// The selected container's task description goes on a line below
+	if line := h.selectedTaskLine(); line != "" {
+		tableView = lipgloss.JoinVertical(lipgloss.Left, tableView, taskLineStyle.Render(line))
+	}
 
 	// Center the table horizontally
 	return lipgloss.Place(
@@ -264,14 +394,97 @@ func (h *HomeModel) View() string {
 	)
 }
 
+// SetEmptyState sets what the view explains when there are no containers.
+func (h *HomeModel) SetEmptyState(state EmptyState) {
+	h.empty = state
+}
+
+var (
+	emptyTitleStyle = lipgloss.NewStyle().Foreground(style.OceanTide).Bold(true)
+	emptyErrorStyle = lipgloss.NewStyle().Foreground(style.CrimsonPulse).Bold(true)
+	emptyKeyStyle   = lipgloss.NewStyle().Foreground(style.DeepSpace).Background(style.OceanTide).Bold(true).Padding(0, 1)
+	emptyPanelStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(style.PurpleHaze).Padding(1, 3)
+)
+
+// emptyView is the panel shown in place of an empty table. Docker being
+// down and the list failing to load look different from a fresh install,
+// which gets pointed at creating its first container.
+func (h *HomeModel) emptyView() string {
+	var lines []string
+	switch {
+	case h.empty.DockerDown:
+		lines = dockerDownLines(h.empty)
+	case h.empty.LoadError != "":
+		lines = []string{
+			emptyErrorStyle.Render("Couldn't load containers"),
+			"",
+			taskLineStyle.Render(ansi.Truncate(h.empty.LoadError, 70, "…")),
+			"",
+			"Press " + emptyKeyStyle.Render("r") + " to try again.",
+		}
+	default:
+		lines = []string{
+			emptyTitleStyle.Render("No containers yet"),
+			"",
+			"Each task runs Claude in its own container, on its own branch,",
+			"with a copy of the project in the directory maestro runs from.",
+			"",
+			"Press " + emptyKeyStyle.Render("n") + " or " + emptyKeyStyle.Render("Enter") + " to describe a task and create one.",
+		}
+		if len(h.empty.RecentDirs) > 0 {
+			lines = append(lines, "", "Recent projects (run maestro from one to work on it):")
+			for _, dir := range h.empty.RecentDirs {
+				lines = append(lines, taskLineStyle.Render("  "+ansi.Truncate(dir, 66, "…")))
+			}
+		}
+	}
+	return emptyPanelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// dockerDownLines explains what is wrong with Docker and how to fix it.
+// The list keeps retrying in the background, and r retries right away.
+func dockerDownLines(empty EmptyState) []string {
+	retry := "press " + emptyKeyStyle.Render("r") + " to retry."
+	switch {
+	case errors.Is(empty.DockerErr, container.ErrDockerNotInstalled):
+		return []string{
+			emptyErrorStyle.Render("Docker is not installed"),
+			"",
+			"maestro runs each task in a Docker container. Install Docker",
+			"(docker.com/get-started), then " + retry,
+		}
+	case errors.Is(empty.DockerErr, container.ErrDockerPermission):
+		return []string{
+			emptyErrorStyle.Render("Permission denied connecting to Docker"),
+			"",
+			"Add yourself to the docker group (sudo usermod -aG docker $USER)",
+			"and log in again, then " + retry,
+		}
+	}
+
+	lines := []string{
+		emptyErrorStyle.Render("Docker is not responding"),
+		"",
+	}
+	if empty.OpenDocker {
+		lines = append(lines, "Press "+emptyKeyStyle.Render("o")+" to start Docker Desktop; the list reloads once it answers.")
+	} else {
+		lines = append(lines, "Start Docker (or Docker Desktop), then "+retry)
+	}
+	if empty.DockerErr != nil {
+		lines = append(lines, "", taskLineStyle.Render(ansi.Truncate(empty.DockerErr.Error(), 70, "…")))
+	}
+	return lines
+}
+
 // SetSize updates the view dimensions
 func (h *HomeModel) SetSize(width, height int) {
 	h.width = width
 	h.height = height
 
 	// Adjust table height to fill screen
-	// Title (1) + empty (1) + empty (1) + help bar (1) = 4 lines overhead
-	tableHeight := height - 4
+	// Title (1) + empty (1) + empty (1) + help bar (1) + task line (1) = 5 lines overhead
+	tableHeight := height - 5
 	if tableHeight < 5 {
 		tableHeight = 5
 	}
@@ -285,7 +498,7 @@ func (h *HomeModel) SetSize(width, height int) {
 	}
 
 	// Update column widths proportionally
-	columns := calculateColumnWidths(effectiveWidth, h.useAWSAuth)
+	columns := calculateColumnWidths(effectiveWidth, h.columns)
 	h.table.SetColumns(columns)
 
 	// Only set table viewport width if we're filling the space
@@ -309,32 +522,68 @@ func (h *HomeModel) RefreshContainers(containers []container.Info, daemonRunning
 	h.updateTableRows()
 }
 
+// SetPendingOperations marks the rows of containers with an operation
+// running, drawing frame (the operation spinner's current frame) beside it.
+func (h *HomeModel) SetPendingOperations(ops map[string]container.OperationType, frame string) {
+	h.pendingOps = ops
+	h.pendingFrame = ansi.Strip(frame)
+	h.updateTableRows()
+}
+
+// pendingCell stands in for columns an in-flight operation is about to change
+const pendingCell = "…"
+
 // updateTableRows converts container data to table rows
 func (h *HomeModel) updateTableRows() {
 	rows := make([]table.Row, 0, len(h.containers))
 
-	for _, c := range h.containers {
-		row := table.Row{
-			h.formatName(c),
-			h.formatStatus(c),
-			h.formatBranch(c),
-			h.formatTask(c),
-			h.formatGit(c),
-		}
-		// Only include AUTH column when not using AWS auth
-		if !h.useAWSAuth {
-			row = append(row, h.formatAuth(c))
+	for i, c := range h.containers {
+		op, pending := h.pendingOps[c.Name]
+		row := make(table.Row, len(h.columns))
+		for j, col := range h.columns {
+			switch {
+			case pending && col.key == "status":
+				// The spinner and operation in place of the status
+				row[j] = h.pendingFrame + " " + op.Progress()
+			case pending && col.pending:
+				// Columns the operation will change are held until the list reloads
+				row[j] = pendingCell
+			default:
+				row[j] = col.render(h, c)
+			}
+			if col.key == "name" {
+				row[j] = quickKey(i) + row[j]
+			}
 		}
-		row = append(row, h.formatCreated(c))
 		rows = append(rows, row)
 	}
 
 	h.table.SetRows(rows)
 }
 
-// formatName returns the container short name
+// quickKey is the number key shown before a row's name; the first nine rows
+// can be connected to with 1-9.
+func quickKey(row int) string {
+	if row < 9 {
+		return string(rune('1'+row)) + " "
+	}
+	return "  "
+}
+
+// formatName returns the container short name, flagging containers that
+// need attention, run without a firewall or run an outdated image
 func (h *HomeModel) formatName(c container.Info) string {
-	return c.ShortName
+	name := c.ShortName
+	if c.NoFirewall {
+		name = "🔓 " + name
+	}
+	if c.ImageOutdated {
+		name += " " + style.Glyph("⬆", "^")
+	}
+	if container.NeedsAttention(c) {
+		name = style.Glyph("🔔", "!") + " " + name
+	}
+	return name
 }
 
 // formatStatus returns the status indicator
@@ -343,26 +592,26 @@ func (h *HomeModel) formatStatus(c container.Info) string {
 	switch c.Status {
 	case "running":
 		if c.IsDormant {
-			return "◌ Dormant"
+			return style.Glyph("◌", "-") + " Dormant"
 		}
 		switch c.AgentState {
 		case "question":
 			return "? Question"
 		case "waiting":
-			return "◷ Waiting"
+			return style.Glyph("◷", "~") + " Waiting"
 		case "idle":
-			return "⚠ Idle"
+			return style.Warning() + " Idle"
 		case "clearing":
-			return "↻ Clearing"
+			return style.Glyph("↻", "@") + " Clearing"
 		case "starting":
-			return "⋯ Starting"
+			return style.Glyph("⋯", ".") + " Starting"
 		case "active":
-			return "● Working"
+			return style.Glyph("●", "*") + " Working"
 		default:
-			return "● Running"
+			return style.Glyph("●", "*") + " Running"
 		}
 	case "exited":
-		return "○ Stopped"
+		return style.Glyph("○", "o") + " Stopped"
 	default:
 		return "? " + c.Status
 	}
@@ -376,12 +625,103 @@ func (h *HomeModel) formatBranch(c container.Info) string {
 	return c.Branch
 }
 
-// formatGit returns git status
+// formatGit returns the workspace's git indicators: Δ files with uncommitted
+// changes, ↑ unpushed and ↓ unpulled commits, ✓ when in sync. Each count has
+// its own slot so they line up from row to row.
 func (h *HomeModel) formatGit(c container.Info) string {
+	if c.Git.Repo {
+		g := c.Git
+		if g.Uncommitted == 0 && g.Unpushed == 0 && g.Behind == 0 {
+			return style.Check()
+		}
+		slot := func(glyph, ascii string, n int) string {
+			if n == 0 {
+				return ""
+			}
+			return style.Glyph(glyph, ascii) + strconv.Itoa(n)
+		}
+		return strings.TrimRight(fmt.Sprintf("%-4s%-4s%s",
+			slot("Δ", "*", g.Uncommitted), slot("↑", "^", g.Unpushed), slot("↓", "v", g.Behind)), " ")
+	}
 	if c.GitStatus == "" {
 		return "—"
 	}
-	return c.GitStatus
+	return style.ASCII(c.GitStatus)
+}
+
+// manyUnpushed is the number of unpushed commits above which the GIT column
+// turns red.
+const manyUnpushed = 10
+
+// gitColor returns the GIT column color for a workspace: green when clean,
+// yellow with uncommitted changes or unpushed commits, red with more than
+// manyUnpushed commits not on the remote. ok is false without a repository.
+func gitColor(g container.GitState) (color lipgloss.Color, ok bool) {
+	switch {
+	case !g.Repo:
+		return "", false
+	case g.Unpushed > manyUnpushed:
+		return style.CrimsonPulse, true
+	case g.Uncommitted > 0 || g.Unpushed > 0:
+		return style.SunsetGlow, true
+	default:
+		return style.NeonGreen, true
+	}
+}
+
+// colorGitColumn colors the GIT cells of the rendered table with gitColor.
+// Cells go into the table as plain text because it truncates them by byte
+// width, which would cut escape codes, so the color is added afterwards. Rows
+// are found relative to the highlighted row, which keeps its own style; with
+// colors off there is no highlight and nothing is colored.
+func (h *HomeModel) colorGitColumn(view string) string {
+	offset, width := 0, 0
+	for i, col := range h.columns {
+		if col.key == "git" {
+			width = h.table.Columns()[i].Width
+			break
+		}
+		offset += h.table.Columns()[i].Width + 2 // cell padding
+	}
+	if width <= 0 {
+		return view
+	}
+
+	lines := strings.Split(view, "\n")
+	body := len(lines) - h.table.Height()
+	if body < 0 {
+		return view
+	}
+	selected := -1
+	for i := body; i < len(lines); i++ {
+		if strings.Contains(lines[i], "\x1b") {
+			selected = i
+			break
+		}
+	}
+	if selected < 0 {
+		return view
+	}
+
+	for i := body; i < len(lines); i++ {
+		row := h.table.Cursor() + i - selected
+		if i == selected || row < 0 || row >= len(h.containers) {
+			continue
+		}
+		c := h.containers[row]
+		if _, pending := h.pendingOps[c.Name]; pending {
+			continue
+		}
+		color, ok := gitColor(c.Git)
+		if !ok {
+			continue
+		}
+		line := lines[i]
+		start, end := offset+1, offset+1+width
+		cell := lipgloss.NewStyle().Foreground(color).Render(ansi.Cut(line, start, end))
+		lines[i] = ansi.Cut(line, 0, start) + cell + ansi.Cut(line, end, ansi.StringWidth(line))
+	}
+	return strings.Join(lines, "\n")
 }
 
 // formatTask returns the current task or progress
@@ -406,20 +746,54 @@ func (h *HomeModel) formatTask(c container.Info) string {
 	return "—"
 }
 
+// taskLineStyle renders the selected container's task description
+var taskLineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+// selectedTaskLine returns the task description of the selected container
+// collapsed onto one line and cut to the table width, or "" if nothing is
+// selected
+func (h *HomeModel) selectedTaskLine() string {
+	idx := h.table.Cursor()
+	if idx < 0 || idx >= len(h.containers) {
+		return ""
+	}
+	width := h.table.Width()
+	if width <= 0 {
+		width = maxTableWidth
+	}
+	return ansi.Truncate("Task: "+container.TaskDescriptionLine(h.containers[idx].Task), width, "…")
+}
+
 // formatAuth returns authentication status
 func (h *HomeModel) formatAuth(c container.Info) string {
 	if c.AuthStatus == "" {
 		return "—"
 	}
-	return c.AuthStatus
+	return style.ASCII(c.AuthStatus)
 }
 
-// formatCreated returns when the container was created
+// formatCreated returns how long ago the container was created
 func (h *HomeModel) formatCreated(c container.Info) string {
 	if c.CreatedAt.IsZero() {
 		return "—"
 	}
-	return c.CreatedAt.Format("Jan 2 15:04")
+	return container.ShortDuration(time.Since(c.CreatedAt)) + " ago"
+}
+
+// formatUptime returns how long a running container has been up
+func (h *HomeModel) formatUptime(c container.Info) string {
+	if c.Status != "running" || c.StartedAt.IsZero() {
+		return "—"
+	}
+	return "up " + container.ShortDuration(time.Since(c.StartedAt))
+}
+
+// formatActivity returns the time since the container's tmux pane was active
+func (h *HomeModel) formatActivity(c container.Info) string {
+	if c.LastActivity == "" || c.LastActivity == "-" {
+		return "—"
+	}
+	return c.LastActivity
 }
 
 // GetContainers returns the current container list for caching
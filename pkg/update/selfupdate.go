@@ -0,0 +1,255 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/version"
+)
+
+const (
+	// ChecksumsAssetName is the goreleaser checksum manifest attached to releases.
+	ChecksumsAssetName = "checksums.txt"
+
+	// downloadTimeout bounds a single release asset download.
+	downloadTimeout = 5 * time.Minute
+
+	// maxArchiveSize guards against unexpectedly large downloads.
+	maxArchiveSize = 200 << 20
+)
+
+// NotWritableError reports that the installed binary cannot be replaced by
+// the current user, e.g. /usr/local/bin without sudo.
+type NotWritableError struct {
+	Path string
+	Err  error
+}
+
+func (e *NotWritableError) Error() string {
+	return fmt.Sprintf("%s is not writable: %v", e.Path, e.Err)
+}
+
+func (e *NotWritableError) Unwrap() error { return e.Err }
+
+// AssetName returns the archive goreleaser publishes for a platform, e.g.
+// maestro_1.2.3_Darwin_arm64.tar.gz (see .goreleaser.yml).
+func AssetName(ver, goos, goarch string) string {
+	arch := goarch
+	if goarch == "amd64" {
+		arch = "x86_64"
+	}
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	osName := goos
+	if osName != "" {
+		osName = strings.ToUpper(osName[:1]) + osName[1:]
+	}
+	return fmt.Sprintf("maestro_%s_%s_%s.%s", strings.TrimPrefix(ver, "v"), osName, arch, ext)
+}
+
+// ChangelogExcerpt returns the first maxLines non-empty lines of release
+// notes, marking truncation with "…".
+func ChangelogExcerpt(notes string, maxLines int) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(notes, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(lines) == maxLines {
+			lines = append(lines, "…")
+			break
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsHomebrewInstall reports whether exePath is managed by Homebrew, which
+// should be upgraded with brew rather than replaced in place.
+func IsHomebrewInstall(exePath string) bool {
+	return strings.Contains(exePath, "/Cellar/") || strings.Contains(exePath, "/homebrew/")
+}
+
+// DownloadBinary fetches the release archive for goos/goarch, verifies it
+// against the release's checksums.txt and returns the maestro binary inside.
+func DownloadBinary(release *Release, goos, goarch string) ([]byte, error) {
+	name := AssetName(release.LatestVersion, goos, goarch)
+	archiveURL, ok := release.Assets[name]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no asset %s", release.LatestVersion, name)
+	}
+	checksumsURL, ok := release.Assets[ChecksumsAssetName]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s, refusing to install unverified binary", release.LatestVersion, ChecksumsAssetName)
+	}
+
+	checksums, err := download(checksumsURL)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", ChecksumsAssetName, err)
+	}
+	want, err := parseChecksums(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := download(archiveURL)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, got)
+	}
+
+	binaryName := "maestro"
+	if goos == "windows" {
+		binaryName = "maestro.exe"
+	}
+	return extractBinary(archive, binaryName, strings.HasSuffix(name, ".zip"))
+}
+
+// download fetches url into memory, honoring proxy environment variables.
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: downloadTimeout}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "maestro/"+version.Version)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxArchiveSize {
+		return nil, fmt.Errorf("download exceeds %d MB", maxArchiveSize>>20)
+	}
+	return data, nil
+}
+
+// parseChecksums finds name's sha256 in a "<hex>  <file>" manifest.
+func parseChecksums(data []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s not listed in %s", name, ChecksumsAssetName)
+}
+
+// extractBinary pulls binaryName out of a release archive.
+func extractBinary(archive []byte, binaryName string, isZip bool) ([]byte, error) {
+	if isZip {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("reading zip: %w", err)
+		}
+		for _, f := range zr.File {
+			if filepath.Base(f.Name) != binaryName || f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(io.LimitReader(rc, maxArchiveSize))
+		}
+		return nil, fmt.Errorf("%s not found in archive", binaryName)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in archive", binaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == binaryName {
+			return io.ReadAll(io.LimitReader(tr, maxArchiveSize))
+		}
+	}
+}
+
+// ReplaceExecutable atomically replaces the binary at exePath (symlinks are
+// followed) by writing a temp file beside it and renaming it into place.
+// Returns a *NotWritableError when the current user cannot do that.
+func ReplaceExecutable(exePath string, binary []byte) error {
+	target, err := filepath.EvalSymlinks(exePath)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", exePath, err)
+	}
+	dir := filepath.Dir(target)
+
+	tmp, err := os.CreateTemp(dir, ".maestro-update-*")
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return &NotWritableError{Path: target, Err: err}
+		}
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once renamed
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0755); err != nil {
+		return fmt.Errorf("setting permissions: %w", err)
+	}
+
+	// Windows cannot overwrite a running executable, but it can rename it
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		os.Remove(old)
+		if err := os.Rename(target, old); err != nil {
+			return fmt.Errorf("moving current binary aside: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmpName, target); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return &NotWritableError{Path: target, Err: err}
+		}
+		return fmt.Errorf("replacing binary: %w", err)
+	}
+	return nil
+}
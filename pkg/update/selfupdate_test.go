@@ -0,0 +1,167 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAssetName(t *testing.T) {
+	tests := []struct {
+		ver, goos, goarch, want string
+	}{
+		{"1.2.3", "darwin", "arm64", "maestro_1.2.3_Darwin_arm64.tar.gz"},
+		{"v1.2.3", "linux", "amd64", "maestro_1.2.3_Linux_x86_64.tar.gz"},
+		{"1.2.3", "windows", "amd64", "maestro_1.2.3_Windows_x86_64.zip"},
+	}
+	for _, tt := range tests {
+		if got := AssetName(tt.ver, tt.goos, tt.goarch); got != tt.want {
+			t.Errorf("AssetName(%q, %q, %q) = %q, want %q", tt.ver, tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+}
+
+func TestChangelogExcerpt(t *testing.T) {
+	notes := "## Changes\r\n\r\n- one\n- two\n- three\n"
+	if got, want := ChangelogExcerpt(notes, 3), "## Changes\n- one\n- two\n…"; got != want {
+		t.Errorf("ChangelogExcerpt() = %q, want %q", got, want)
+	}
+	if got, want := ChangelogExcerpt(notes, 10), "## Changes\n- one\n- two\n- three"; got != want {
+		t.Errorf("ChangelogExcerpt() = %q, want %q", got, want)
+	}
+}
+
+func TestIsHomebrewInstall(t *testing.T) {
+	if !IsHomebrewInstall("/opt/homebrew/Cellar/maestro/1.0.0/bin/maestro") {
+		t.Error("expected Cellar path to be detected as Homebrew")
+	}
+	if IsHomebrewInstall("/usr/local/bin/maestro") {
+		t.Error("expected /usr/local/bin to not be detected as Homebrew")
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	data := []byte("abc123  maestro_1.0.0_Linux_x86_64.tar.gz\nDEF456  maestro_1.0.0_Darwin_arm64.tar.gz\n")
+	got, err := parseChecksums(data, "maestro_1.0.0_Darwin_arm64.tar.gz")
+	if err != nil || got != "def456" {
+		t.Errorf("parseChecksums() = %q, %v, want def456", got, err)
+	}
+	if _, err := parseChecksums(data, "maestro_1.0.0_Windows_x86_64.zip"); err == nil {
+		t.Error("expected error for missing asset")
+	}
+}
+
+func makeTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{"README.md", []byte("readme")}, {name, content}} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0755, Size: int64(len(f.data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write(f.data)
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestExtractBinary(t *testing.T) {
+	archive := makeTarGz(t, "maestro", []byte("binary"))
+	got, err := extractBinary(archive, "maestro", false)
+	if err != nil || string(got) != "binary" {
+		t.Errorf("extractBinary(tar.gz) = %q, %v", got, err)
+	}
+	if _, err := extractBinary(archive, "maestro.exe", false); err == nil {
+		t.Error("expected error for missing binary")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("maestro.exe")
+	w.Write([]byte("winbinary"))
+	zw.Close()
+	got, err = extractBinary(buf.Bytes(), "maestro.exe", true)
+	if err != nil || string(got) != "winbinary" {
+		t.Errorf("extractBinary(zip) = %q, %v", got, err)
+	}
+}
+
+func TestDownloadBinary(t *testing.T) {
+	name := AssetName("2.0.0", "linux", "arm64")
+	archive := makeTarGz(t, "maestro", []byte("new binary"))
+	sum := sha256.Sum256(archive)
+	checksums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + name:
+			w.Write(archive)
+		case "/checksums.txt":
+			w.Write([]byte(checksums))
+		case "/bad.txt":
+			w.Write([]byte(strings.Repeat("0", 64) + "  " + name + "\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	release := &Release{
+		Result: &Result{LatestVersion: "2.0.0"},
+		Assets: map[string]string{
+			name:               srv.URL + "/" + name,
+			ChecksumsAssetName: srv.URL + "/checksums.txt",
+		},
+	}
+	got, err := DownloadBinary(release, "linux", "arm64")
+	if err != nil || string(got) != "new binary" {
+		t.Fatalf("DownloadBinary() = %q, %v", got, err)
+	}
+
+	release.Assets[ChecksumsAssetName] = srv.URL + "/bad.txt"
+	if _, err := DownloadBinary(release, "linux", "arm64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+
+	if _, err := DownloadBinary(release, "darwin", "amd64"); err == nil {
+		t.Error("expected error for missing platform asset")
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "maestro")
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReplaceExecutable(exe, []byte("new")); err != nil {
+		t.Fatalf("ReplaceExecutable() error: %v", err)
+	}
+	data, _ := os.ReadFile(exe)
+	if string(data) != "new" {
+		t.Errorf("binary content = %q, want %q", data, "new")
+	}
+	info, _ := os.Stat(exe)
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("binary mode = %v, want 0755", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected temp file to be cleaned up, found %d entries", len(entries))
+	}
+}
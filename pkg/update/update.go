@@ -43,8 +43,24 @@ type cacheFile struct {
 
 // githubRelease is the subset of the GitHub releases API response we need.
 type githubRelease struct {
-	TagName string `json:"tag_name"`
-	HTMLURL string `json:"html_url"`
+	TagName string        `json:"tag_name"`
+	HTMLURL string        `json:"html_url"`
+	Body    string        `json:"body"`
+	Assets  []githubAsset `json:"assets"`
+}
+
+// githubAsset is a downloadable file attached to a release.
+type githubAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Release is a freshly fetched release with the details needed to show its
+// changelog and install it.
+type Release struct {
+	*Result
+	Notes  string            // Release notes (markdown)
+	Assets map[string]string // Asset name -> download URL
 }
 
 // Checker manages periodic update checks with caching.
@@ -142,8 +158,39 @@ func (c *Checker) checkNow() {
 	}
 }
 
+// CheckNow fetches the latest release immediately and refreshes the on-disk
+// cache. Unlike the daemon loop it returns errors, e.g. when offline.
+func (c *Checker) CheckNow() (*Release, error) {
+	release, err := c.fetchRelease()
+	if err != nil {
+		return nil, err
+	}
+	result := c.buildResult(release.TagName, release.HTMLURL, time.Now())
+	c.saveCache(&cacheFile{
+		LatestVersion: result.LatestVersion,
+		ReleaseURL:    result.ReleaseURL,
+		CheckedAt:     result.CheckedAt,
+	})
+
+	assets := make(map[string]string, len(release.Assets))
+	for _, a := range release.Assets {
+		assets[a.Name] = a.BrowserDownloadURL
+	}
+	return &Release{Result: result, Notes: release.Body, Assets: assets}, nil
+}
+
 // fetchLatestRelease queries the GitHub releases API.
 func (c *Checker) fetchLatestRelease() (*Result, error) {
+	release, err := c.fetchRelease()
+	if err != nil {
+		return nil, err
+	}
+	return c.buildResult(release.TagName, release.HTMLURL, time.Now()), nil
+}
+
+// fetchRelease downloads the latest release metadata. The default transport
+// honors HTTPS_PROXY/HTTP_PROXY/NO_PROXY.
+func (c *Checker) fetchRelease() (*githubRelease, error) {
 	client := &http.Client{Timeout: 10 * time.Second}
 
 	req, err := http.NewRequest("GET", c.releasesURL, nil)
@@ -172,7 +219,7 @@ func (c *Checker) fetchLatestRelease() (*Result, error) {
 		return nil, fmt.Errorf("empty tag_name in response")
 	}
 
-	return c.buildResult(release.TagName, release.HTMLURL, time.Now()), nil
+	return &release, nil
 }
 
 // buildResult constructs a Result by comparing the given latest version against
@@ -184,7 +231,7 @@ func (c *Checker) buildResult(latestTag, releaseURL string, checkedAt time.Time)
 	updateAvail := false
 	if !version.IsDevelopment() {
 		currentClean := strings.TrimPrefix(current, "v")
-		updateAvail = compareSemver(currentClean, latest) < 0
+		updateAvail = CompareSemver(currentClean, latest) < 0
 	}
 
 	return &Result{
@@ -282,11 +329,11 @@ func (c *Checker) log(format string, args ...interface{}) {
 	}
 }
 
-// compareSemver compares two semver strings (without "v" prefix).
+// CompareSemver compares two semver strings (without "v" prefix).
 // Returns -1 if a < b, 0 if a == b, 1 if a > b.
 // Pre-release versions (e.g., "1.0.0-rc1") are considered lower than the
 // corresponding plain version ("1.0.0").
-func compareSemver(a, b string) int {
+func CompareSemver(a, b string) int {
 	aHasPre := strings.Contains(a, "-")
 	bHasPre := strings.Contains(b, "-")
 
@@ -338,7 +385,7 @@ func FormatUpdateWarning(result *Result) string {
 
 	return fmt.Sprintf(
 		"\n⚠️  Update available: %s → %s\n"+
-			"   Run: brew upgrade maestro  (or download from GitHub)\n"+
+			"   Run: maestro self-update  (or brew upgrade maestro)\n"+
 			"   %s\n",
 		result.CurrentVersion, result.LatestVersion, result.ReleaseURL,
 	)
@@ -35,9 +35,9 @@ func TestCompareSemver(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := compareSemver(tt.a, tt.b)
+		got := CompareSemver(tt.a, tt.b)
 		if got != tt.want {
-			t.Errorf("compareSemver(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			t.Errorf("CompareSemver(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
 		}
 	}
 }